@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -168,13 +169,26 @@ func (c *Client) Update(args params.ApplicationUpdate) error {
 }
 
 // AddUnits adds a given number of units to an application using the specified
-// placement directives to assign units to machines.
-func (c *Client) AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error) {
+// placement directives to assign units to machines. The IDs of detached storage
+// instances to attach to the new unit may be specified, in which case only one
+// unit may be added.
+func (c *Client) AddUnits(
+	application string,
+	numUnits int,
+	placement []*instance.Placement,
+	attachStorage []string,
+) ([]string, error) {
 	args := params.AddApplicationUnits{
 		ApplicationName: application,
 		NumUnits:        numUnits,
 		Placement:       placement,
 	}
+	for _, id := range attachStorage {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		args.AttachStorage = append(args.AttachStorage, names.NewStorageTag(id).String())
+	}
 	results := new(params.AddApplicationUnitsResults)
 	err := c.facade.FacadeCall("AddUnits", args, results)
 	return results.Units, err
@@ -186,6 +200,17 @@ func (c *Client) DestroyUnits(unitNames ...string) error {
 	return c.facade.FacadeCall("DestroyUnits", params, nil)
 }
 
+// DestroyUnitsDetachingStorage decreases the number of units dedicated
+// to an application, detaching the storage owned by the units rather
+// than destroying it.
+func (c *Client) DestroyUnitsDetachingStorage(unitNames ...string) error {
+	params := params.DestroyApplicationUnits{
+		UnitNames:     unitNames,
+		DetachStorage: true,
+	}
+	return c.facade.FacadeCall("DestroyUnits", params, nil)
+}
+
 // Destroy destroys a given application.
 func (c *Client) Destroy(application string) error {
 	params := params.ApplicationDestroy{
@@ -211,9 +236,14 @@ func (c *Client) SetConstraints(application string, constraints constraints.Valu
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
-func (c *Client) Expose(application string) error {
-	params := params.ApplicationExpose{ApplicationName: application}
+// were also explicitly marked by units as open. The optional exposed
+// endpoints, keyed by endpoint name, restrict the sources which may
+// access those ports; the empty name applies to all endpoints.
+func (c *Client) Expose(application string, exposedEndpoints map[string]params.ExposedEndpoint) error {
+	params := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposedEndpoints,
+	}
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
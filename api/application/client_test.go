@@ -138,6 +138,28 @@ func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestServiceExpose(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		args, ok := a.(params.ApplicationExpose)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "application",
+			ExposedEndpoints: map[string]params.ExposedEndpoint{
+				"website": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+			},
+		})
+		return nil
+	})
+	err := s.client.Expose("application", map[string]params.ExposedEndpoint{
+		"website": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
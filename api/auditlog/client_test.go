@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditLogMockSuite{})
+
+func (s *auditLogMockSuite) TestEntries(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Entries")
+			c.Check(a, jc.DeepEquals, params.AuditLogFilter{
+				UserTag: "user-bob",
+				Limit:   10,
+			})
+
+			c.Assert(result, gc.FitsTypeOf, &params.AuditLogEntries{})
+			*(result.(*params.AuditLogEntries)) = params.AuditLogEntries{
+				Entries: []params.AuditLogEntry{{
+					OriginName: "user-bob",
+					Operation:  "Client:v1 - FullStatus",
+				}},
+			}
+			return nil
+		})
+	client := auditlog.NewClient(apiCaller)
+	entries, err := client.Entries(params.AuditLogFilter{UserTag: "user-bob", Limit: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []params.AuditLogEntry{{
+		OriginName: "user-bob",
+		Operation:  "Client:v1 - FullStatus",
+	}})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *auditLogMockSuite) TestEntriesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Entries(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
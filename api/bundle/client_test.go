@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleMockSuite{})
+
+func (s *bundleMockSuite) TestExportBundle(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ExportBundle")
+			c.Check(a, gc.IsNil)
+
+			c.Assert(result, gc.FitsTypeOf, &params.StringResult{})
+			*(result.(*params.StringResult)) = params.StringResult{
+				Result: "services: {}\n",
+			}
+			return nil
+		})
+	client := bundle.NewClient(apiCaller)
+	result, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, "services: {}\n")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *bundleMockSuite) TestExportBundleError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.StringResult)) = params.StringResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		})
+	client := bundle.NewClient(apiCaller)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
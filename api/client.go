@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -508,6 +509,22 @@ func (c *Client) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSer
 	return resp.ToolsList, nil
 }
 
+// UploadResource sends the content of a resource to a model being
+// imported by a model migration. The resource metadata must already
+// have been imported.
+func (c *Client) UploadResource(application, name string, content io.ReadSeeker) error {
+	query := url.Values{}
+	query.Set("application", application)
+	query.Set("name", name)
+	endpoint := "/migrate/resources?" + query.Encode()
+	contentType := "application/octet-stream"
+	var resp params.ResourceUploadResult
+	if err := c.httpPost(content, endpoint, contentType, &resp); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (c *Client) httpPost(content io.ReadSeeker, endpoint, contentType string, response interface{}) error {
 	req, err := http.NewRequest("POST", endpoint, nil)
 	if err != nil {
@@ -604,6 +621,17 @@ type DebugLogParams struct {
 	// NoTail tells the server to only return the logs it has now, and not
 	// to wait for new logs to arrive.
 	NoTail bool
+	// StartTime, if set, excludes log messages written before this time.
+	StartTime time.Time
+	// EndTime, if set, excludes log messages written after this time.
+	// Setting it implies NoTail.
+	EndTime time.Time
+	// MessageRegex, if set, is a regular expression which log messages
+	// must match to be included in the response.
+	MessageRegex string
+	// Format specifies the format of the log lines sent back: either
+	// "text" (the default) or "json", one record per line.
+	Format string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -628,6 +656,18 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
+	if !args.StartTime.IsZero() {
+		attrs.Set("startTime", args.StartTime.UTC().Format(time.RFC3339Nano))
+	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.UTC().Format(time.RFC3339Nano))
+	}
+	if args.MessageRegex != "" {
+		attrs.Set("messageRegex", args.MessageRegex)
+	}
+	if args.Format != "" {
+		attrs.Set("format", args.Format)
+	}
 	return attrs
 }
 
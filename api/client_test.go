@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
@@ -89,6 +90,31 @@ func (s *clientSuite) TestUploadToolsOtherEnvironment(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *clientSuite) TestUploadResource(c *gc.C) {
+	client := s.APIState.Client()
+	var called bool
+
+	defer fakeAPIEndpoint(c, client, envEndpoint(c, s.APIState, "migrate/resources"), "POST",
+		func(w http.ResponseWriter, r *http.Request) {
+			called = true
+
+			c.Assert(r.URL.Query(), gc.DeepEquals, url.Values{
+				"application": []string{"mysql"},
+				"name":        []string{"spam"},
+			})
+			defer r.Body.Close()
+			obtained, err := ioutil.ReadAll(r.Body)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(string(obtained), gc.Equals, "spamspamspam")
+		},
+	).Close()
+
+	// As with UploadTools, only check that the content is POSTed to
+	// the correct endpoint.
+	client.UploadResource("mysql", "spam", strings.NewReader("spamspamspam"))
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *clientSuite) TestAddLocalCharm(c *gc.C) {
 	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	curl := charm.MustParseURL(
@@ -397,6 +423,10 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		Level:         loggo.ERROR,
 		Replay:        true,
 		NoTail:        true,
+		StartTime:     time.Date(2016, 10, 1, 12, 30, 0, 0, time.UTC),
+		EndTime:       time.Date(2016, 10, 2, 12, 30, 0, 500, time.UTC),
+		MessageRegex:  "^hook failed",
+		Format:        "json",
 	}
 
 	client := s.APIState.Client()
@@ -415,6 +445,10 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"level":         {"ERROR"},
 		"replay":        {"true"},
 		"noTail":        {"true"},
+		"startTime":     {"2016-10-01T12:30:00Z"},
+		"endTime":       {"2016-10-02T12:30:00.0000005Z"},
+		"messageRegex":  {"^hook failed"},
+		"format":        {"json"},
 	})
 }
 
@@ -19,8 +19,10 @@ var facadeVersions = map[string]int{
 	"Annotations":                  2,
 	"Application":                  1,
 	"ApplicationScaler":            1,
+	"AuditLog":                     1,
 	"Backups":                      1,
 	"Block":                        2,
+	"Bundle":                       1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
@@ -72,7 +74,7 @@ var facadeVersions = map[string]int{
 	"Singular":                     1,
 	"Spaces":                       2,
 	"SSHClient":                    1,
-	"StatusHistory":                2,
+	"StatusHistory":                3,
 	"Storage":                      2,
 	"StorageProvisioner":           2,
 	"StringsWatcher":               1,
@@ -108,9 +108,18 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 	return tags, nil
 }
 
-// OpenedPorts returns a map of network.PortRange to unit tag for all opened
-// port ranges on the machine for the subnet matching given subnetTag.
-func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+// PortOwner identifies the unit which opened a port range, and the
+// endpoint of the unit it was opened for. The endpoint is empty for
+// ranges opened for all of the unit's endpoints.
+type PortOwner struct {
+	UnitTag  names.UnitTag
+	Endpoint string
+}
+
+// OpenedPorts returns a map of network.PortRange to the owning unit and
+// endpoint for all opened port ranges on the machine for the subnet
+// matching given subnetTag.
+func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]PortOwner, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +142,16 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]PortOwner)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[ports.PortRange.NetworkPortRange()] = PortOwner{
+			UnitTag:  unitTag,
+			Endpoint: ports.Endpoint,
+		}
 	}
 	return endResult, nil
 }
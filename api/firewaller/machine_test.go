@@ -130,7 +130,17 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, jc.DeepEquals, map[network.PortRange]names.UnitTag{
-		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.PortOwner{
+		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {UnitTag: unitTag},
+	})
+
+	// Ports opened for an endpoint report it.
+	err = s.units[0].OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.PortOwner{
+		network.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}:     {UnitTag: unitTag, Endpoint: "url"},
+		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {UnitTag: unitTag},
 	})
 }
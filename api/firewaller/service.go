@@ -75,3 +75,27 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// EndpointIngressCIDRs returns the source CIDRs from which the ports
+// opened for each endpoint of an exposed service may be accessed, keyed
+// by endpoint name. Ports opened for endpoints without their own sources
+// use those under the empty endpoint name, if any. Unexposed services
+// have none.
+func (s *Application) EndpointIngressCIDRs() (map[string][]string, error) {
+	var results params.EndpointIngressCIDRsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetEndpointIngressCIDRs", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.CIDRs, nil
+}
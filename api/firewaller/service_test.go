@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -91,3 +92,25 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestEndpointIngressCIDRs(c *gc.C) {
+	err := s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":    {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"url": {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.apiApplication.EndpointIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, map[string][]string{
+		"":    {"10.0.0.0/24"},
+		"url": {"192.168.1.0/24"},
+	})
+
+	err = s.application.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiApplication.EndpointIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
@@ -4,6 +4,8 @@
 package migrationmaster
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -29,9 +31,46 @@ type Client interface {
 	// migration.
 	SetPhase(migration.Phase) error
 
+	// SetStatusMessage sets a human readable message about the
+	// progress of the currently active model migration.
+	SetStatusMessage(string) error
+
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() ([]byte, error)
+
+	// ModelLogs returns at most maxCount of the log records for the
+	// model associated with the API connection which follow the
+	// record with the time and document ID given, oldest first. If
+	// afterID is empty, records written at the time given are
+	// included.
+	ModelLogs(after time.Time, afterID string, maxCount int) ([]params.LogStreamRecord, error)
+
+	// WatchMinionReports returns a watcher which reports when a
+	// migration minion has made a report for the current migration
+	// phase.
+	WatchMinionReports() (watcher.NotifyWatcher, error)
+
+	// MinionReports returns details of the reports made by migration
+	// minions to the controller for the current migration phase.
+	MinionReports() (MinionReports, error)
+
+	// ModelInfo returns the details of the model associated with the
+	// API connection which are needed by the target controller's
+	// prechecks.
+	ModelInfo() (migration.ModelInfo, error)
+
+	// Prechecks returns the problems found which would prevent the
+	// model associated with the API connection from being migrated.
+	Prechecks() ([]migration.PrecheckFailure, error)
+
+	// SetPrecheckFailures records the problems found by the
+	// prechecks for the currently active model migration.
+	SetPrecheckFailures([]migration.PrecheckFailure) error
+
+	// Reap removes all documents of the model associated with the
+	// API connection from the source controller.
+	Reap() error
 }
 
 // MigrationStatus returns the details for a migration as needed by
@@ -43,6 +82,34 @@ type MigrationStatus struct {
 	TargetInfo migration.TargetInfo
 }
 
+// MinionReports holds the details of whether a migration minion
+// succeeded or failed for a specific migration phase.
+type MinionReports struct {
+	// MigrationId holds the id of the migration the reports related
+	// to.
+	MigrationId string
+
+	// Phase holds the phase of the migration the reports related
+	// to.
+	Phase migration.Phase
+
+	// SuccessCount holds the number of agents which have
+	// successfully completed a given migration phase.
+	SuccessCount int
+
+	// UnknownCount holds the number of agents still to report for a
+	// given migration phase.
+	UnknownCount int
+
+	// UnknownAgents holds the tags of the agents that are still to
+	// report for a given migration phase.
+	UnknownAgents []names.Tag
+
+	// FailedAgents holds the tags of the agents which have reported
+	// a failure to complete a given migration phase.
+	FailedAgents []names.Tag
+}
+
 // NewClient returns a new Client based on an existing API connection.
 func NewClient(caller base.APICaller) Client {
 	return &client{base.NewFacadeCaller(caller, "MigrationMaster")}
@@ -119,6 +186,14 @@ func (c *client) SetPhase(phase migration.Phase) error {
 	return c.caller.FacadeCall("SetPhase", args, nil)
 }
 
+// SetStatusMessage implements Client.
+func (c *client) SetStatusMessage(message string) error {
+	args := params.SetMigrationStatusMessageArgs{
+		Message: message,
+	}
+	return c.caller.FacadeCall("SetStatusMessage", args, nil)
+}
+
 // Export implements Client.
 func (c *client) Export() ([]byte, error) {
 	var serialized params.SerializedModel
@@ -128,3 +203,136 @@ func (c *client) Export() ([]byte, error) {
 	}
 	return serialized.Bytes, nil
 }
+
+// ModelLogs implements Client.
+func (c *client) ModelLogs(after time.Time, afterID string, maxCount int) ([]params.LogStreamRecord, error) {
+	args := params.ModelLogsArgs{
+		After:    after,
+		AfterID:  afterID,
+		MaxCount: maxCount,
+	}
+	var result params.LogStreamRecords
+	err := c.caller.FacadeCall("ModelLogs", args, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Records, nil
+}
+
+// WatchMinionReports implements Client.
+func (c *client) WatchMinionReports() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := c.caller.FacadeCall("WatchMinionReports", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(c.caller.RawAPICaller(), result)
+	return w, nil
+}
+
+// MinionReports implements Client.
+func (c *client) MinionReports() (MinionReports, error) {
+	var in params.MinionReports
+	var out MinionReports
+
+	err := c.caller.FacadeCall("MinionReports", nil, &in)
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+
+	out.MigrationId = in.MigrationId
+
+	phase, ok := migration.ParsePhase(in.Phase)
+	if !ok {
+		return out, errors.Errorf("invalid phase: %q", in.Phase)
+	}
+	out.Phase = phase
+
+	out.SuccessCount = in.SuccessCount
+	out.UnknownCount = in.UnknownCount
+
+	out.UnknownAgents, err = convertTags(in.Unknown)
+	if err != nil {
+		return out, errors.Annotate(err, "processing unknown agents")
+	}
+
+	out.FailedAgents, err = convertTags(in.Failed)
+	if err != nil {
+		return out, errors.Annotate(err, "processing failed agents")
+	}
+
+	return out, nil
+}
+
+// ModelInfo implements Client.
+func (c *client) ModelInfo() (migration.ModelInfo, error) {
+	var info params.MigrationModelInfo
+	err := c.caller.FacadeCall("ModelInfo", nil, &info)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Trace(err)
+	}
+	owner, err := names.ParseUserTag(info.OwnerTag)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Annotate(err, "parsing owner tag")
+	}
+	return migration.ModelInfo{
+		UUID:            info.UUID,
+		Name:            info.Name,
+		Owner:           owner,
+		AgentVersion:    info.AgentVersion,
+		CloudName:       info.CloudName,
+		CloudRegion:     info.CloudRegion,
+		CloudCredential: info.CloudCredential,
+	}, nil
+}
+
+// Prechecks implements Client.
+func (c *client) Prechecks() ([]migration.PrecheckFailure, error) {
+	var result params.PrecheckResult
+	err := c.caller.FacadeCall("Prechecks", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var failures []migration.PrecheckFailure
+	for _, failure := range result.Failures {
+		failures = append(failures, migration.PrecheckFailure{
+			Code:    failure.Code,
+			Entity:  failure.Entity,
+			Message: failure.Message,
+		})
+	}
+	return failures, nil
+}
+
+// SetPrecheckFailures implements Client.
+func (c *client) SetPrecheckFailures(failures []migration.PrecheckFailure) error {
+	var args params.SetPrecheckFailuresArgs
+	for _, failure := range failures {
+		args.Failures = append(args.Failures, params.PrecheckFailure{
+			Code:    failure.Code,
+			Entity:  failure.Entity,
+			Message: failure.Message,
+		})
+	}
+	return c.caller.FacadeCall("SetPrecheckFailures", args, nil)
+}
+
+// Reap implements Client.
+func (c *client) Reap() error {
+	return c.caller.FacadeCall("Reap", nil, nil)
+}
+
+func convertTags(tagStrs []string) ([]names.Tag, error) {
+	var tags []names.Tag
+	for _, tagStr := range tagStrs {
+		tag, err := names.ParseTag(tagStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
@@ -10,6 +10,7 @@ import (
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -173,3 +174,297 @@ func (s *ClientSuite) TestExportError(c *gc.C) {
 	_, err := client.Export()
 	c.Assert(err, gc.ErrorMatches, "blam")
 }
+
+func (s *ClientSuite) TestSetStatusMessage(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.SetStatusMessage("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationStatusMessageArgs{Message: "foo"}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetStatusMessage", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestModelLogs(c *gc.C) {
+	var stub jujutesting.Stub
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.LogStreamRecords)
+		*out = params.LogStreamRecords{
+			Records: []params.LogStreamRecord{{Timestamp: t0, Message: "hello"}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	records, err := client.ModelLogs(t0.Add(-time.Hour), "some-id", 100)
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.ModelLogsArgs{After: t0.Add(-time.Hour), AfterID: "some-id", MaxCount: 100}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.ModelLogs", []interface{}{"", expectedArg}},
+	})
+	c.Assert(records, jc.DeepEquals, []params.LogStreamRecord{{Timestamp: t0, Message: "hello"}})
+}
+
+func (s *ClientSuite) TestModelLogsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("blam")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.ModelLogs(time.Time{}, "", 100)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *ClientSuite) TestWatchMinionReports(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		switch request {
+		case "WatchMinionReports":
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				NotifyWatcherId: "abc",
+			}
+		case "Next":
+			// The full success case is tested in api/watcher.
+			return errors.New("boom")
+		case "Stop":
+		}
+		return nil
+	})
+
+	client := migrationmaster.NewClient(apiCaller)
+	w, err := client.WatchMinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	errC := make(chan error)
+	go func() {
+		errC <- w.Wait()
+	}()
+
+	select {
+	case err := <-errC:
+		c.Assert(err, gc.ErrorMatches, "boom")
+		expectedCalls := []jujutesting.StubCall{
+			{"MigrationMaster.WatchMinionReports", []interface{}{"", nil}},
+			{"NotifyWatcher.Next", []interface{}{"abc", nil}},
+			{"NotifyWatcher.Stop", []interface{}{"abc", nil}},
+		}
+		// The Stop API call happens in a separate goroutine which
+		// might execute after the worker has exited so wait for the
+		// expected calls to arrive.
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			if len(stub.Calls()) >= len(expectedCalls) {
+				return
+			}
+		}
+		stub.CheckCalls(c, expectedCalls)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for watcher to die")
+	}
+}
+
+func (s *ClientSuite) TestWatchMinionReportsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.WatchMinionReports()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestMinionReports(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			MigrationId:  "id",
+			Phase:        "IMPORT",
+			SuccessCount: 4,
+			UnknownCount: 2,
+			Unknown:      []string{"machine-3", "unit-foo-0"},
+			Failed:       []string{"machine-1", "unit-bar-2"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	out, err := client.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.MinionReports", []interface{}{"", nil}},
+	})
+	c.Assert(out, gc.DeepEquals, migrationmaster.MinionReports{
+		MigrationId:   "id",
+		Phase:         migration.IMPORT,
+		SuccessCount:  4,
+		UnknownCount:  2,
+		UnknownAgents: []names.Tag{names.NewMachineTag("3"), names.NewUnitTag("foo/0")},
+		FailedAgents:  []names.Tag{names.NewMachineTag("1"), names.NewUnitTag("bar/2")},
+	})
+}
+
+func (s *ClientSuite) TestMinionReportsFailedCall(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("blam")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *ClientSuite) TestMinionReportsInvalidPhase(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _ string, _ string, _ interface{}, result interface{}) error {
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			Phase: "BLARGH",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, `invalid phase: "BLARGH"`)
+}
+
+func (s *ClientSuite) TestMinionReportsBadUnknownTag(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _ string, _ string, _ interface{}, result interface{}) error {
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			Phase:   "IMPORT",
+			Unknown: []string{"carl"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, `processing unknown agents: "carl" is not a valid tag`)
+}
+
+func (s *ClientSuite) TestModelInfo(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationModelInfo)) = params.MigrationModelInfo{
+			UUID:            "uuid",
+			Name:            "name",
+			OwnerTag:        names.NewUserTag("owner").String(),
+			AgentVersion:    version.MustParse("1.2.3"),
+			CloudName:       "cloud",
+			CloudRegion:     "region",
+			CloudCredential: "cred",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	model, err := client.ModelInfo()
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.ModelInfo", []interface{}{"", nil}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model, gc.DeepEquals, migration.ModelInfo{
+		UUID:            "uuid",
+		Name:            "name",
+		Owner:           names.NewUserTag("owner"),
+		AgentVersion:    version.MustParse("1.2.3"),
+		CloudName:       "cloud",
+		CloudRegion:     "region",
+		CloudCredential: "cred",
+	})
+}
+
+func (s *ClientSuite) TestModelInfoBadOwner(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		*(result.(*params.MigrationModelInfo)) = params.MigrationModelInfo{
+			OwnerTag: "not-a-tag",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.ModelInfo()
+	c.Assert(err, gc.ErrorMatches, `parsing owner tag: "not-a-tag" is not a valid tag`)
+}
+
+func (s *ClientSuite) TestPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.PrecheckResult)) = params.PrecheckResult{
+			Failures: []params.PrecheckFailure{{
+				Code:    "machine-error",
+				Entity:  "machine-0",
+				Message: "machine 0 is in error state: boom",
+			}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	failures, err := client.Prechecks()
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.Prechecks", []interface{}{"", nil}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.DeepEquals, []migration.PrecheckFailure{{
+		Code:    "machine-error",
+		Entity:  "machine-0",
+		Message: "machine 0 is in error state: boom",
+	}})
+}
+
+func (s *ClientSuite) TestPrechecksError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("blam")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.Prechecks()
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *ClientSuite) TestSetPrecheckFailures(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.SetPrecheckFailures([]migration.PrecheckFailure{{
+		Code:    "cleanup-needed",
+		Message: "cleanup needed",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetPrecheckFailures", []interface{}{"", params.SetPrecheckFailuresArgs{
+			Failures: []params.PrecheckFailure{{
+				Code:    "cleanup-needed",
+				Message: "cleanup needed",
+			}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestReap(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.Reap()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.Reap", []interface{}{"", nil}},
+	})
+}
+
+func (s *ClientSuite) TestReapError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.Reap()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
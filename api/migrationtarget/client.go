@@ -4,16 +4,24 @@
 package migrationtarget
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 )
 
 // Client describes the client side API for the MigrationTarget
 // facade. It is called by the migration master worker to talk to the
 // target controller during a migration.
 type Client interface {
+	// Prechecks checks that the target controller is able to accept
+	// the model described. The problems found, if any, are returned.
+	Prechecks(migration.ModelInfo) ([]migration.PrecheckFailure, error)
+
 	// Import takes a serialized model and imports it into the target
 	// controller.
 	Import([]byte) error
@@ -24,6 +32,15 @@ type Client interface {
 
 	// Activate marks a migrated model as being ready to use.
 	Activate(string) error
+
+	// LatestLogTime returns the timestamp of the most recent log
+	// record transferred for a migrated model. A zero time is
+	// returned if no logs have been transferred yet.
+	LatestLogTime(string) (time.Time, error)
+
+	// AddLogs sends a batch of log records for a migrated model to
+	// the target controller.
+	AddLogs(string, []params.LogStreamRecord) error
 }
 
 // NewClient returns a new Client based on an existing API connection.
@@ -36,6 +53,33 @@ type client struct {
 	caller base.FacadeCaller
 }
 
+// Prechecks implements Client.
+func (c *client) Prechecks(model migration.ModelInfo) ([]migration.PrecheckFailure, error) {
+	args := params.MigrationModelInfo{
+		UUID:            model.UUID,
+		Name:            model.Name,
+		OwnerTag:        model.Owner.String(),
+		AgentVersion:    model.AgentVersion,
+		CloudName:       model.CloudName,
+		CloudRegion:     model.CloudRegion,
+		CloudCredential: model.CloudCredential,
+	}
+	var result params.PrecheckResult
+	err := c.caller.FacadeCall("Prechecks", args, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var failures []migration.PrecheckFailure
+	for _, failure := range result.Failures {
+		failures = append(failures, migration.PrecheckFailure{
+			Code:    failure.Code,
+			Entity:  failure.Entity,
+			Message: failure.Message,
+		})
+	}
+	return failures, nil
+}
+
 // Import implements Client.
 func (c *client) Import(bytes []byte) error {
 	serialized := params.SerializedModel{Bytes: bytes}
@@ -53,3 +97,23 @@ func (c *client) Activate(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
 	return c.caller.FacadeCall("Activate", args, nil)
 }
+
+// LatestLogTime implements Client.
+func (c *client) LatestLogTime(modelUUID string) (time.Time, error) {
+	var result time.Time
+	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
+	err := c.caller.FacadeCall("LatestLogTime", args, &result)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// AddLogs implements Client.
+func (c *client) AddLogs(modelUUID string, records []params.LogStreamRecord) error {
+	args := params.AddModelLogsArgs{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Records:  records,
+	}
+	return c.caller.FacadeCall("AddLogs", args, nil)
+}
@@ -6,12 +6,15 @@ package migrationtarget_test
 import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 )
 
 type ClientSuite struct {
@@ -30,6 +33,45 @@ func (s *ClientSuite) getClientAndStub(c *gc.C) (migrationtarget.Client, *jujute
 	return client, &stub
 }
 
+func (s *ClientSuite) TestPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.PrecheckResult)) = params.PrecheckResult{
+			Failures: []params.PrecheckFailure{{
+				Code:    "model-exists",
+				Entity:  "model-uuid",
+				Message: "model with same UUID already exists (uuid)",
+			}},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	failures, err := client.Prechecks(migration.ModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		Owner:        names.NewUserTag("owner"),
+		AgentVersion: version.MustParse("1.2.3"),
+		CloudName:    "cloud",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Prechecks", []interface{}{"", params.MigrationModelInfo{
+			UUID:         "uuid",
+			Name:         "name",
+			OwnerTag:     "user-owner",
+			AgentVersion: version.MustParse("1.2.3"),
+			CloudName:    "cloud",
+		}}},
+	})
+	c.Assert(failures, gc.DeepEquals, []migration.PrecheckFailure{{
+		Code:    "model-exists",
+		Entity:  "model-uuid",
+		Message: "model with same UUID already exists (uuid)",
+	}})
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -58,6 +100,30 @@ func (s *ClientSuite) TestActivate(c *gc.C) {
 	s.AssertModelCall(c, stub, names.NewModelTag(uuid), "Activate", err)
 }
 
+func (s *ClientSuite) TestLatestLogTime(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	uuid := "fake"
+	_, err := client.LatestLogTime(uuid)
+	s.AssertModelCall(c, stub, names.NewModelTag(uuid), "LatestLogTime", err)
+}
+
+func (s *ClientSuite) TestAddLogs(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	records := []params.LogStreamRecord{{Message: "hello"}}
+	err := client.AddLogs("fake", records)
+
+	expectedArg := params.AddModelLogsArgs{
+		ModelTag: names.NewModelTag("fake").String(),
+		Records:  records,
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.AddLogs", []interface{}{"", expectedArg}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *ClientSuite) AssertModelCall(c *gc.C, stub *jujutesting.Stub, tag names.ModelTag, call string, err error) {
 	expectedArg := params.ModelArgs{ModelTag: tag.String()}
 	stub.CheckCalls(c, []jujutesting.StubCall{
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -14,20 +15,29 @@ const apiName = "StatusHistory"
 
 // Facade allows calls to "StatusHistory" endpoints
 type Facade struct {
+	*common.ModelWatcher
 	facade base.FacadeCaller
 }
 
 // NewFacade returns a status "StatusHistory" Facade.
 func NewFacade(caller base.APICaller) *Facade {
 	facadeCaller := base.NewFacadeCaller(caller, apiName)
-	return &Facade{facadeCaller}
+	return &Facade{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
 }
 
-// Prune calls "StatusHistory.Prune"
-func (s *Facade) Prune(maxHistoryTime time.Duration, maxHistoryMB int) error {
+// Prune calls "StatusHistory.Prune" and returns the number of
+// status history entries removed.
+func (s *Facade) Prune(maxHistoryTime time.Duration, maxHistoryMB int) (int, error) {
 	p := params.StatusHistoryPruneArgs{
 		MaxHistoryTime: maxHistoryTime,
 		MaxHistoryMB:   maxHistoryMB,
 	}
-	return s.facade.FacadeCall("Prune", p, nil)
+	var result params.StatusHistoryPruneResult
+	if err := s.facade.FacadeCall("Prune", p, &result); err != nil {
+		return 0, err
+	}
+	return result.Removed, nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
 )
 
 // Client allows access to the storage API end point.
@@ -147,3 +148,79 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// Detach detaches the specified storage instances from their units,
+// without destroying them. If a unit is not specified for a storage
+// instance, it is detached from the unit that owns it.
+func (c *Client) Detach(ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+	return c.modifyStorageAttachments("Detach", ids)
+}
+
+// Attach attaches the specified detached storage instances to units.
+func (c *Client) Attach(ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+	return c.modifyStorageAttachments("Attach", ids)
+}
+
+func (c *Client) modifyStorageAttachments(method string, ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+	out := params.ErrorResults{}
+	in := params.StorageAttachmentIds{Ids: ids}
+	if err := c.facade.FacadeCall(method, in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(ids), len(out.Results))
+	}
+	return out.Results, nil
+}
+
+// Import imports existing storage into the model. Storage that
+// belongs to another model is only imported if force is true.
+// A "CHANGE" block can block this operation.
+func (c *Client) Import(
+	kind storage.StorageKind,
+	storagePool string,
+	storageProviderId string,
+	storageName string,
+	force bool,
+) (names.StorageTag, error) {
+	var results params.ImportStorageResults
+	args := params.BulkImportStorageParams{
+		[]params.ImportStorageParams{{
+			StorageName: storageName,
+			Kind:        params.StorageKind(kind),
+			Pool:        storagePool,
+			ProviderId:  storageProviderId,
+			Force:       force,
+		}},
+	}
+	if err := c.facade.FacadeCall("Import", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf(
+			"expected 1 result, got %d",
+			len(results.Results),
+		)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
+
+// Resize requests that the specified storage instance be grown to the
+// specified size, in MiB.
+// A "CHANGE" block can block this operation.
+func (c *Client) Resize(storageTag names.StorageTag, size uint64) error {
+	var results params.ErrorResults
+	args := params.BulkResizeStorageParams{
+		[]params.ResizeStorageParams{{
+			StorageTag: storageTag.String(),
+			Size:       size,
+		}},
+	}
+	if err := c.facade.FacadeCall("Resize", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -398,6 +398,43 @@ func (s *provisionerSuite) TestVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}, {"volume-101"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+		*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+			Results: []params.VolumeResizeParamsResult{{
+				Result: &params.VolumeResizeParams{
+					VolumeTag: "volume-100",
+					VolumeId:  "vol-ume",
+					Size:      2048,
+					Provider:  "loop",
+				},
+			}, {}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.VolumeResizeParams([]names.VolumeTag{
+		names.NewVolumeTag("100"), names.NewVolumeTag("101"),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(resizeParams, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: &params.VolumeResizeParams{
+			VolumeTag: "volume-100", VolumeId: "vol-ume", Size: 2048, Provider: "loop",
+		},
+	}, {}})
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -511,6 +548,75 @@ func (s *provisionerSuite) TestFilesystemAttachmentParams(c *gc.C) {
 	c.Assert(filesystemParams, jc.DeepEquals, paramsResults)
 }
 
+func (s *provisionerSuite) TestFilesystemResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "FilesystemResizeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"filesystem-0-0"}, {"filesystem-1"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.FilesystemResizeParamsResults{})
+		*(result.(*params.FilesystemResizeParamsResults)) = params.FilesystemResizeParamsResults{
+			Results: []params.FilesystemResizeParamsResult{{
+				Result: &params.FilesystemResizeParams{
+					FilesystemTag: "filesystem-0-0",
+					FilesystemId:  "fs",
+					VolumeTag:     "volume-0-0",
+					Size:          2048,
+				},
+			}, {}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.FilesystemResizeParams([]names.FilesystemTag{
+		names.NewFilesystemTag("0/0"), names.NewFilesystemTag("1"),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(resizeParams, jc.DeepEquals, []params.FilesystemResizeParamsResult{{
+		Result: &params.FilesystemResizeParams{
+			FilesystemTag: "filesystem-0-0",
+			FilesystemId:  "fs",
+			VolumeTag:     "volume-0-0",
+			Size:          2048,
+		},
+	}, {}})
+}
+
+func (s *provisionerSuite) TestCancelVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "CancelVolumeResizes")
+		c.Check(arg, gc.DeepEquals, params.VolumeResizes{
+			Resizes: []params.VolumeResizeParams{{VolumeTag: "volume-100", Size: 2048}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: nil}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.CancelVolumeResizes([]params.VolumeResizeParams{{
+		VolumeTag: "volume-100", Size: 2048,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.IsNil)
+}
+
 func (s *provisionerSuite) TestSetVolumeInfo(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
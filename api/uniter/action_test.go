@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -42,13 +44,21 @@ func (s *actionSuite) TestAction(c *gc.C) {
 				},
 			},
 		},
+	}, {
+		description: "An Action with a timeout.",
+		action: params.Action{
+			Name:       "fakeaction",
+			Parameters: basicParams,
+			Timeout:    time.Minute,
+		},
 	}}
 
 	for i, actionTest := range actionTests {
 		c.Logf("test %d: %s", i, actionTest.description)
-		a, err := s.uniterSuite.wordpressUnit.AddAction(
+		a, err := s.uniterSuite.wordpressUnit.AddActionWithTimeout(
 			actionTest.action.Name,
-			actionTest.action.Parameters)
+			actionTest.action.Parameters,
+			actionTest.action.Timeout)
 		c.Assert(err, jc.ErrorIsNil)
 
 		ok := names.IsValidAction(a.Id())
@@ -61,6 +71,7 @@ func (s *actionSuite) TestAction(c *gc.C) {
 
 		c.Assert(retrievedAction.Name(), gc.DeepEquals, actionTest.action.Name)
 		c.Assert(retrievedAction.Params(), gc.DeepEquals, actionTest.action.Parameters)
+		c.Assert(retrievedAction.Timeout(), gc.Equals, actionTest.action.Timeout)
 	}
 }
 
@@ -122,3 +133,19 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestLogActionMessage(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err = s.State.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "halfway there")
+}
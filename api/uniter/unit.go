@@ -425,6 +425,13 @@ func (u *Unit) AvailabilityZone() (string, error) {
 // OpenPorts sets the policy of the port range with protocol to be
 // opened.
 func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
+	return u.OpenPortsForEndpoint("", protocol, fromPort, toPort)
+}
+
+// OpenPortsForEndpoint sets the policy of the port range with protocol
+// to be opened for the named endpoint. An empty endpoint opens the
+// range for all of the unit's endpoints.
+func (u *Unit) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -432,6 +439,7 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
 	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
@@ -460,6 +468,100 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 	return result.OneError()
 }
 
+// State returns the key-value state stored by the unit's charm.
+func (u *Unit) State() (map[string]string, error) {
+	var results params.UnitStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("State", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.State, nil
+}
+
+// SetState replaces the key-value state stored by the unit's charm
+// with the supplied values.
+func (u *Unit) SetState(state map[string]string) error {
+	var result params.ErrorResults
+	args := params.SetUnitStateArgs{
+		Args: []params.SetUnitStateArg{
+			{Tag: u.tag.String(), State: state},
+		},
+	}
+	err := u.st.facade.FacadeCall("SetState", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// WorkloadVersion returns the version of the workload reported by
+// the current unit.
+func (u *Unit) WorkloadVersion() (string, error) {
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WorkloadVersion", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// SetWorkloadVersion sets the version of the workload that the unit
+// is running.
+func (u *Unit) SetWorkloadVersion(version string) error {
+	var result params.ErrorResults
+	args := params.EntityWorkloadVersions{
+		Entities: []params.EntityWorkloadVersion{
+			{Tag: u.tag.String(), WorkloadVersion: version},
+		},
+	}
+	err := u.st.facade.FacadeCall("SetWorkloadVersion", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// GoalState returns the units the unit's application is expected to
+// have, and the units expected on each of its relations.
+func (u *Unit) GoalState() (params.GoalState, error) {
+	var results params.GoalStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("GoalStates", args, &results)
+	if err != nil {
+		return params.GoalState{}, err
+	}
+	if len(results.Results) != 1 {
+		return params.GoalState{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.GoalState{}, result.Error
+	}
+	return *result.Result, nil
+}
+
 var ErrNoCharmURLSet = errors.New("unit has no charm url set")
 
 // CharmURL returns the charm URL this unit is currently using.
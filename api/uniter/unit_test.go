@@ -401,6 +401,19 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	err := s.apiUnit.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.OpenPortsForEndpoint("missing", "tcp", 443, 443)
+	c.Assert(err, gc.ErrorMatches, `.*endpoint "missing" not found`)
+
+	ports, err := s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.DeepEquals, []network.PortRange{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80},
+	})
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
@@ -420,6 +433,53 @@ func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	c.Assert(curl.String(), gc.Equals, s.wordpressCharm.String())
 }
 
+func (s *unitSuite) TestGetSetState(c *gc.C) {
+	unitState, err := s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+
+	err = s.apiUnit.SetState(map[string]string{"db.host": "10.0.0.5"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err = s.apiUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"db.host": "10.0.0.5"})
+
+	// Verify the state directly.
+	unitState, err = s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"db.host": "10.0.0.5"})
+}
+
+func (s *unitSuite) TestWorkloadVersion(c *gc.C) {
+	version, err := s.apiUnit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "")
+
+	err = s.apiUnit.SetWorkloadVersion("4.5.3")
+	c.Assert(err, jc.ErrorIsNil)
+
+	version, err = s.apiUnit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "4.5.3")
+
+	// Verify the version directly.
+	version, err = s.wordpressUnit.WorkloadVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, "4.5.3")
+}
+
+func (s *unitSuite) TestGoalState(c *gc.C) {
+	goalState, err := s.apiUnit.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goalState, jc.DeepEquals, params.GoalState{
+		Units: params.UnitsGoalState{
+			"wordpress/0": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{},
+	})
+}
+
 func (s *unitSuite) TestConfigSettings(c *gc.C) {
 	// Make sure ConfigSettings returns an error when
 	// no charm URL is set, as its state counterpart does.
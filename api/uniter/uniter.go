@@ -217,8 +217,9 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Name,
-		params: result.Action.Parameters,
+		name:    result.Action.Name,
+		params:  result.Action.Parameters,
+		timeout: result.Action.Timeout,
 	}, nil
 }
 
@@ -275,6 +276,30 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// LogActionMessage logs a progress message for the specified action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	var outcome params.ErrorResults
+
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: tag.String(), Value: message},
+		},
+	}
+
+	err := st.facade.FacadeCall("LogActionsMessages", args, &outcome)
+	if err != nil {
+		return err
+	}
+	if len(outcome.Results) != 1 {
+		return fmt.Errorf("expected 1 result, got %d", len(outcome.Results))
+	}
+	result := outcome.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
@@ -361,6 +361,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: httpCtxt,
 		},
 	)
+	add("/model/:modeluuid/migrate/resources",
+		&resourcesMigrationUploadHandler{
+			ctxt: httpCtxt,
+		},
+	)
 	add("/model/:modeluuid/backups",
 		&backupHandler{
 			ctxt: strictCtxt,
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -428,7 +429,17 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return svc.SetExposed()
+	}
+	exposed := make(map[string]state.ExposedEndpoint)
+	for name, ep := range args.ExposedEndpoints {
+		exposed[name] = state.ExposedEndpoint{
+			ExposeToSpaces: ep.ExposeToSpaces,
+			ExposeToCIDRs:  ep.ExposeToCIDRs,
+		}
+	}
+	return svc.MergeExposeSettings(exposed)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -453,7 +464,18 @@ func addApplicationUnits(st *state.State, args params.AddApplicationUnits) ([]*s
 	if args.NumUnits < 1 {
 		return nil, errors.New("must add at least one unit")
 	}
-	return jjj.AddUnits(st, application, args.NumUnits, args.Placement)
+	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+		return nil, errors.New("cannot attach existing storage when more than one unit is requested")
+	}
+	attachStorage := make([]names.StorageTag, len(args.AttachStorage))
+	for i, tagString := range args.AttachStorage {
+		tag, err := names.ParseStorageTag(tagString)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachStorage[i] = tag
+	}
+	return jjj.AddUnits(st, application, args.NumUnits, args.Placement, attachStorage)
 }
 
 // AddUnits adds a given number of units to an application.
@@ -486,10 +508,12 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 		case err != nil:
 		case unit.Life() != state.Alive:
 			continue
-		case unit.IsPrincipal():
-			err = unit.Destroy()
-		default:
+		case !unit.IsPrincipal():
 			err = errors.Errorf("unit %q is a subordinate", name)
+		case args.DetachStorage:
+			err = unit.DestroyDetachingStorage()
+		default:
+			err = unit.Destroy()
 		}
 		if err != nil {
 			errs = append(errs, err.Error())
@@ -1856,6 +1856,26 @@ func (s *serviceSuite) TestAddUnitToMachineNotFound(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `adding new machine to host unit "dummy/0": machine 42 not found`)
 }
 
+func (s *serviceSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := s.applicationApi.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "dummy",
+		NumUnits:        2,
+		AttachStorage:   []string{"storage-data-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot attach existing storage when more than one unit is requested")
+}
+
+func (s *serviceSuite) TestAddUnitsAttachStorageInvalidTag(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := s.applicationApi.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "dummy",
+		NumUnits:        1,
+		AttachStorage:   []string{"volume-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+}
+
 func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1870,7 +1890,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1882,6 +1902,24 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeToCIDRs(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	s.AddTestingService(c, "dummy-service", charm)
+	err := s.applicationApi.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	application, err := s.State.Application("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1922,7 +1960,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1937,7 +1975,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationApi.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationApi.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2136,6 +2174,28 @@ func (s *serviceSuite) TestDestroyPrincipalUnits(c *gc.C) {
 	s.assertDestroyPrincipalUnits(c, units)
 }
 
+func (s *serviceSuite) TestDestroyUnitsDetachingStorage(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	sCons := map[string]state.StorageConstraints{
+		"data": {Pool: "", Size: 1024, Count: 1},
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, sCons)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.applicationApi.DestroyUnits(params.DestroyApplicationUnits{
+		UnitNames:     []string{"storage-block/0"},
+		DetachStorage: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, unit, state.Dying)
+	si, err := s.State.StorageInstance(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *serviceSuite) TestDestroySubordinateUnits(c *gc.C) {
 	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	wordpress0, err := wordpress.AddUnit()
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog implements the API endpoint used to read the
+// controller's audit log.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("AuditLog", 1, NewAPI)
+}
+
+// Backend defines the state methods used by the AuditLog facade.
+type Backend interface {
+	IsControllerAdministrator(names.UserTag) (bool, error)
+	AuditEntries(audit.Filter) ([]audit.AuditEntry, error)
+}
+
+// API implements the AuditLog facade.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new AuditLog API facade.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	return newAPI(st, authorizer)
+}
+
+func newAPI(backend Backend, authorizer common.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	// The audit log records requests made on every model, so it
+	// is only available to controller administrators.
+	apiUser, _ := authorizer.GetAuthTag().(names.UserTag)
+	isAdmin, err := backend.IsControllerAdministrator(apiUser)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return &API{backend: backend}, nil
+}
+
+// Entries returns the audit log entries which match the given filter,
+// most recent first.
+func (api *API) Entries(args params.AuditLogFilter) (params.AuditLogEntries, error) {
+	filter := audit.Filter{
+		Operation: args.Operation,
+		Limit:     args.Limit,
+	}
+	if args.UserTag != "" {
+		tag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return params.AuditLogEntries{}, errors.Trace(err)
+		}
+		filter.OriginName = tag.String()
+	}
+	if args.ModelTag != "" {
+		tag, err := names.ParseModelTag(args.ModelTag)
+		if err != nil {
+			return params.AuditLogEntries{}, errors.Trace(err)
+		}
+		filter.ModelUUID = tag.Id()
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	if filter.Limit < 0 {
+		return params.AuditLogEntries{}, errors.NotValidf("negative limit")
+	}
+
+	entries, err := api.backend.AuditEntries(filter)
+	if err != nil {
+		return params.AuditLogEntries{}, errors.Trace(err)
+	}
+	result := params.AuditLogEntries{
+		Entries: make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			JujuServerVersion: entry.JujuServerVersion,
+			ModelTag:          names.NewModelTag(entry.ModelUUID).String(),
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Facade:            entry.Facade,
+			FacadeVersion:     entry.FacadeVersion,
+			Method:            entry.Method,
+			Duration:          entry.Duration,
+			ErrorCode:         entry.ErrorCode,
+			ErrorMessage:      entry.ErrorMessage,
+			Data:              entry.Data,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/auditlog"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	coretesting.BaseSuite
+
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &fakeBackend{admin: true}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPIForTest(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresControllerAdmin(c *gc.C) {
+	s.backend.admin = false
+	_, err := auditlog.NewAPIForTest(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCall(c, 0, "IsControllerAdministrator", names.NewUserTag("admin"))
+}
+
+func (s *auditLogSuite) TestEntries(c *gc.C) {
+	t0 := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.entries = []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.0.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0,
+		RemoteAddress:     "10.0.0.1:1234",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Client:v1 - FullStatus",
+		Facade:            "Client",
+		FacadeVersion:     1,
+		Method:            "FullStatus",
+		Duration:          time.Second,
+		Data:              map[string]interface{}{"request-body": "x"},
+	}}
+	api, err := auditlog.NewAPIForTest(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	from := t0.Add(-time.Hour)
+	result, err := api.Entries(params.AuditLogFilter{
+		UserTag:   "user-bob",
+		ModelTag:  coretesting.ModelTag.String(),
+		Operation: "status",
+		From:      &from,
+		Limit:     5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditLogEntries{
+		Entries: []params.AuditLogEntry{{
+			JujuServerVersion: version.MustParse("2.0.0"),
+			ModelTag:          coretesting.ModelTag.String(),
+			Timestamp:         t0,
+			RemoteAddress:     "10.0.0.1:1234",
+			OriginType:        "API request",
+			OriginName:        "user-bob",
+			Operation:         "Client:v1 - FullStatus",
+			Facade:            "Client",
+			FacadeVersion:     1,
+			Method:            "FullStatus",
+			Duration:          time.Second,
+			Data:              map[string]interface{}{"request-body": "x"},
+		}},
+	})
+	s.backend.CheckCall(c, 1, "AuditEntries", audit.Filter{
+		ModelUUID:  coretesting.ModelTag.Id(),
+		OriginName: names.NewUserTag("bob").String(),
+		Operation:  "status",
+		From:       from,
+		Limit:      5,
+	})
+}
+
+func (s *auditLogSuite) TestEntriesInvalidFilter(c *gc.C) {
+	api, err := auditlog.NewAPIForTest(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Entries(params.AuditLogFilter{UserTag: "machine-0"})
+	c.Check(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	_, err = api.Entries(params.AuditLogFilter{ModelTag: "bob"})
+	c.Check(err, gc.ErrorMatches, `"bob" is not a valid tag`)
+	_, err = api.Entries(params.AuditLogFilter{Limit: -1})
+	c.Check(err, gc.ErrorMatches, "negative limit not valid")
+	s.backend.CheckCallNames(c, "IsControllerAdministrator")
+}
+
+type fakeBackend struct {
+	gitjujutesting.Stub
+	admin   bool
+	entries []audit.AuditEntry
+}
+
+func (b *fakeBackend) IsControllerAdministrator(user names.UserTag) (bool, error) {
+	b.AddCall("IsControllerAdministrator", user)
+	return b.admin, b.NextErr()
+}
+
+func (b *fakeBackend) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	b.AddCall("AuditEntries", filter)
+	return b.entries, b.NextErr()
+}
@@ -0,0 +1,262 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle implements the API endpoint used to describe a
+// running model as a charm bundle.
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("Bundle", 1, NewAPI)
+}
+
+// API implements the Bundle facade.
+type API struct {
+	state      *state.State
+	authorizer common.Authorizer
+}
+
+// NewAPI returns a new Bundle API facade.
+func NewAPI(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		state:      st,
+		authorizer: authorizer,
+	}, nil
+}
+
+// ExportBundle returns the current model as a bundle YAML document
+// that can be deployed with "juju deploy".
+func (api *API) ExportBundle() (params.StringResult, error) {
+	data, err := api.bundleData()
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	bytes, err := goyaml.Marshal(data)
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringResult{Result: string(bytes)}, nil
+}
+
+func (api *API) bundleData() (*charm.BundleData, error) {
+	applications, err := api.state.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+	for _, application := range applications {
+		spec, machineIds, err := api.applicationSpec(application)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", application.Name())
+		}
+		data.Applications[application.Name()] = spec
+		for _, id := range machineIds {
+			if _, found := data.Machines[id]; found {
+				continue
+			}
+			machineSpec, err := api.machineSpec(id)
+			if err != nil {
+				return nil, errors.Annotatef(err, "machine %q", id)
+			}
+			data.Machines[id] = machineSpec
+		}
+	}
+
+	relations, err := api.state.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established automatically.
+			continue
+		}
+		data.Relations = append(data.Relations, []string{
+			endpoints[0].String(),
+			endpoints[1].String(),
+		})
+	}
+	return data, nil
+}
+
+// applicationSpec returns the bundle description of the application,
+// along with the ids of the top level machines its units are placed on.
+func (api *API) applicationSpec(application *state.Application) (*charm.ApplicationSpec, []string, error) {
+	curl, _ := application.CharmURL()
+	spec := &charm.ApplicationSpec{
+		Charm:  bundleCharm(curl),
+		Series: application.Series(),
+		Expose: application.IsExposed(),
+	}
+
+	settings, err := application.ConfigSettings()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(settings) > 0 {
+		spec.Options = settings
+	}
+
+	annotations, err := api.state.Annotations(application)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+
+	bindings, err := application.EndpointBindings()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+
+	storage, err := application.StorageConstraints()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for name, cons := range storage {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = storageDirective(cons)
+	}
+
+	if !application.IsPrincipal() {
+		// Subordinate units follow their principals.
+		return spec, nil, nil
+	}
+
+	cons, err := application.Constraints()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	spec.Constraints = cons.String()
+
+	units, err := application.AllUnits()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	sort.Sort(byUnitNumber(units))
+	spec.NumUnits = len(units)
+
+	// Each unit has a placement directive, so that the directives
+	// line up with the units when deployed; units not yet assigned to
+	// a machine are placed on a new one.
+	var machineIds []string
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			spec.To = append(spec.To, newMachinePlacement)
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		placement, topLevelId := unitPlacement(machineId)
+		spec.To = append(spec.To, placement)
+		machineIds = append(machineIds, topLevelId)
+	}
+	if len(machineIds) == 0 {
+		// Without any assigned units, the placement is left to
+		// the deployment.
+		spec.To = nil
+	}
+	return spec, machineIds, nil
+}
+
+// bundleCharm returns the reference to the charm as written in a
+// bundle. Local charms cannot be deployed by URL, so they are referred
+// to by a path relative to the bundle file, where the charm directory
+// is expected to be found.
+func bundleCharm(curl *charm.URL) string {
+	if curl.Schema == "local" {
+		return "./" + curl.Name
+	}
+	return curl.String()
+}
+
+func (api *API) machineSpec(id string) (*charm.MachineSpec, error) {
+	machine, err := api.state.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := machine.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &charm.MachineSpec{
+		Series:      machine.Series(),
+		Constraints: cons.String(),
+	}, nil
+}
+
+// newMachinePlacement is the bundle placement directive for a unit on
+// a new machine.
+const newMachinePlacement = "new"
+
+// unitPlacement returns the bundle placement directive for a unit on
+// the machine with the given id, along with the id of the top level
+// machine. Units in containers are placed in a new container of the
+// same type on the top level machine.
+func unitPlacement(machineId string) (placement, topLevelId string) {
+	parts := strings.Split(machineId, "/")
+	topLevelId = parts[0]
+	if len(parts) == 1 {
+		return topLevelId, topLevelId
+	}
+	containerType := parts[len(parts)-2]
+	return fmt.Sprintf("%s:%s", containerType, topLevelId), topLevelId
+}
+
+// storageDirective formats the storage constraints as they are
+// written in a bundle.
+func storageDirective(cons state.StorageConstraints) string {
+	return fmt.Sprintf("%s,%d,%dM", cons.Pool, cons.Count, cons.Size)
+}
+
+type byUnitNumber []*state.Unit
+
+func (u byUnitNumber) Len() int      { return len(u) }
+func (u byUnitNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u byUnitNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(name string) int {
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
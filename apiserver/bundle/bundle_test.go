@@ -0,0 +1,178 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/common"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type bundleSuite struct {
+	jujutesting.JujuConnSuite
+
+	api        *bundle.API
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&bundleSuite{})
+
+func (s *bundleSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.api, err = bundle.NewAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *bundleSuite) TestNewAPIRequiresClient(c *gc.C) {
+	anAuthorizer := s.authorizer
+	anAuthorizer.Tag = s.Factory.MakeMachine(c, nil).Tag()
+	_, err := bundle.NewAPI(s.State, nil, anAuthorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *bundleSuite) exportBundle(c *gc.C) *charm.BundleData {
+	result, err := s.api.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *bundleSuite) TestExportBundleEmpty(c *gc.C) {
+	data := s.exportBundle(c)
+	c.Assert(data.Applications, gc.HasLen, 0)
+	c.Assert(data.Machines, gc.HasLen, 0)
+	c.Assert(data.Relations, gc.HasLen, 0)
+}
+
+func (s *bundleSuite) TestExportBundle(c *gc.C) {
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.AddTestingCharm(c, "wordpress"),
+		Settings: map[string]interface{}{
+			"blog-title": "my blog",
+		},
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	err := wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.AddTestingCharm(c, "mysql"),
+	})
+
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("cores=2"),
+	})
+	container := s.Factory.MakeMachineNested(c, machine.Id(), nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: wordpress,
+		Machine:     machine,
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: wordpress,
+		Machine:     container,
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: mysql,
+		Machine:     machine,
+	})
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := s.exportBundle(c)
+	c.Assert(data.Applications, gc.HasLen, 2)
+
+	wp := data.Applications["wordpress"]
+	c.Assert(wp, gc.NotNil)
+	c.Check(wp.Charm, gc.Equals, "./wordpress")
+	c.Check(wp.Series, gc.Equals, "quantal")
+	c.Check(wp.NumUnits, gc.Equals, 2)
+	c.Check(wp.To, jc.DeepEquals, []string{
+		machine.Id(),
+		"lxd:" + machine.Id(),
+	})
+	c.Check(wp.Expose, jc.IsTrue)
+	c.Check(wp.Options, jc.DeepEquals, map[string]interface{}{
+		"blog-title": "my blog",
+	})
+	c.Check(wp.Constraints, gc.Equals, "mem=4096M")
+
+	db := data.Applications["mysql"]
+	c.Assert(db, gc.NotNil)
+	c.Check(db.NumUnits, gc.Equals, 1)
+	c.Check(db.To, jc.DeepEquals, []string{machine.Id()})
+	c.Check(db.Expose, jc.IsFalse)
+
+	c.Assert(data.Machines, gc.HasLen, 1)
+	c.Check(data.Machines[machine.Id()], jc.DeepEquals, &charm.MachineSpec{
+		Series:      "quantal",
+		Constraints: "cores=2",
+	})
+
+	c.Assert(data.Relations, gc.HasLen, 1)
+	c.Check(data.Relations[0], jc.SameContents, []string{"wordpress:db", "mysql:server"})
+}
+
+func (s *bundleSuite) TestExportBundleUnassignedUnits(c *gc.C) {
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.AddTestingCharm(c, "wordpress"),
+	})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.AddTestingCharm(c, "mysql"),
+	})
+	machine := s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: wordpress,
+		Machine:     machine,
+	})
+	_, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := s.exportBundle(c)
+	wp := data.Applications["wordpress"]
+	c.Assert(wp, gc.NotNil)
+	c.Check(wp.NumUnits, gc.Equals, 2)
+	c.Check(wp.To, jc.DeepEquals, []string{machine.Id(), "new"})
+
+	db := data.Applications["mysql"]
+	c.Assert(db, gc.NotNil)
+	c.Check(db.NumUnits, gc.Equals, 1)
+	c.Check(db.To, gc.HasLen, 0)
+}
+
+func (s *bundleSuite) TestExportBundleStorage(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "storage-block",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Pool: "loop", Size: 1024, Count: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := s.exportBundle(c)
+	app := data.Applications["storage-block"]
+	c.Assert(app, gc.NotNil)
+	c.Check(app.Storage["data"], gc.Equals, "loop,1,1024M")
+}
@@ -333,7 +333,7 @@ func opClientServiceSetCharm(c *gc.C, st api.Connection, mst *state.State) (func
 }
 
 func opClientAddServiceUnits(c *gc.C, st api.Connection, mst *state.State) (func(), error) {
-	_, err := application.NewClient(st).AddUnits("nosuch", 1, nil)
+	_, err := application.NewClient(st).AddUnits("nosuch", 1, nil, nil)
 	if params.IsCodeNotFound(err) {
 		err = nil
 	}
@@ -607,6 +607,11 @@ func (context *statusContext) processApplication(service *state.Application) par
 
 		processedStatus.MeterStatuses = context.processUnitMeterStatuses(context.units[service.Name()])
 	}
+	if err := checkIngress(service); err != nil {
+		processedStatus.Status.Status = status.StatusError.String()
+		processedStatus.Status.Info = err.Error()
+		processedStatus.Status.Data = nil
+	}
 
 	versions := make([]string, 0, len(processedStatus.Units))
 	for _, unit := range processedStatus.Units {
@@ -617,6 +622,23 @@ func (context *statusContext) processApplication(service *state.Application) par
 	return processedStatus
 }
 
+// checkIngress returns an error if the application is exposed, but its
+// expose settings resolve to no source CIDRs, so that its ports may not
+// be accessed from anywhere.
+func checkIngress(service *state.Application) error {
+	if !service.IsExposed() {
+		return nil
+	}
+	cidrs, err := service.IngressCIDRs()
+	if err != nil {
+		return errors.Annotate(err, "cannot resolve expose settings")
+	}
+	if len(cidrs) == 0 {
+		return errors.New("exposed to spaces without subnets: ports not accessible from anywhere")
+	}
+	return nil
+}
+
 func isColorStatus(code state.MeterStatusCode) bool {
 	return code == state.MeterGreen || code == state.MeterAmber || code == state.MeterRed
 }
@@ -153,6 +153,25 @@ func (s *statusUnitTestSuite) TestMeterStatus(c *gc.C) {
 	}
 }
 
+func (s *statusUnitTestSuite) TestExposedToSpacesWithoutSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.MakeApplication(c, nil)
+	err = application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"admin"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, found := status.Applications[application.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Check(appStatus.Exposed, jc.IsTrue)
+	c.Check(appStatus.Status.Status, gc.Equals, "error")
+	c.Check(appStatus.Status.Info, gc.Equals, "exposed to spaces without subnets: ports not accessible from anywhere")
+}
+
 func (s *statusUnitTestSuite) checkWorkloadVersionAggregation(
 	c *gc.C, expectedAppVersion string, unitVersions ...string) {
 	application := s.MakeApplication(c, nil)
@@ -37,7 +37,7 @@ func (r *clientAuthRoot) FindMethod(rootName string, version int, methodName str
 	// ReadOnly User
 	if r.user.IsReadOnly() {
 		canCall := isCallAllowableByReadOnlyUser(rootName, methodName) ||
-			isCallReadOnly(rootName, methodName)
+			IsCallReadOnly(rootName, methodName)
 		if !canCall {
 			return nil, errors.Trace(common.ErrPerm)
 		}
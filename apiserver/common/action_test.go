@@ -140,6 +140,31 @@ func (s *actionsSuite) TestBeginActions(c *gc.C) {
 	})
 }
 
+func (s *actionsSuite) TestLogActionsMessages(c *gc.C) {
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: "success", Value: "hello"},
+			{Tag: "fail", Value: "hello"},
+			{Tag: "invalid", Value: "hello"},
+		},
+	}
+	expectErr := errors.New("explosivo")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success": fakeAction{},
+		"fail":    fakeAction{logErr: expectErr},
+	})
+
+	results := common.LogActionsMessages(args, actionFn)
+
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(expectErr)},
+			{common.ServerError(actionNotFoundErr)},
+		},
+	})
+}
+
 func (s *actionsSuite) TestGetActions(c *gc.C) {
 	args := entities("success", "fail", "notPending")
 	actionFn := makeGetActionByTagString(map[string]state.Action{
@@ -333,6 +358,7 @@ type fakeAction struct {
 	name      string
 	beginErr  error
 	finishErr error
+	logErr    error
 	status    state.ActionStatus
 }
 
@@ -360,6 +386,10 @@ func (mock fakeAction) Finish(state.ActionResults) (state.Action, error) {
 	return nil, mock.finishErr
 }
 
+func (mock fakeAction) Log(string) error {
+	return mock.logErr
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{
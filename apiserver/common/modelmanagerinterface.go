@@ -45,6 +45,7 @@ type ModelManagerBackend interface {
 	RemoveModelUser(names.UserTag) error
 	ModelUser(names.UserTag) (*state.ModelUser, error)
 	ModelTag() names.ModelTag
+	GetModelMigration() (state.ModelMigration, error)
 	Close() error
 }
 
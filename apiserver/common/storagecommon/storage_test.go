@@ -129,6 +129,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoMatchingBlockDevic
 	}, {
 		DeviceName: "sdb",
 		BusAddress: s.volumeAttachment.info.BusAddress,
+		Size:       2048,
 	}}
 	info, err := storagecommon.StorageAttachmentInfo(s.st, s.storageAttachment, s.machineTag)
 	c.Assert(err, jc.ErrorIsNil)
@@ -136,6 +137,7 @@ func (s *storageAttachmentInfoSuite) TestStorageAttachmentInfoMatchingBlockDevic
 	c.Assert(info, jc.DeepEquals, &storage.StorageAttachmentInfo{
 		Kind:     storage.StorageKindBlock,
 		Location: filepath.FromSlash("/dev/sdb"),
+		Size:     2048,
 	})
 }
 
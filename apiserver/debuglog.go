@@ -8,8 +8,10 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -67,6 +69,11 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC 3339 timestamp; only send lines logged at or after it
+//   endTime -> string - RFC 3339 timestamp; only send lines logged at or before it
+//      - existing logs are sent back, but the command does not wait for new ones.
+//   messageRegex -> string - only send lines whose message matches this regular expression
+//   format -> string - one of [text, json], if json, each line is a JSON object.
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server := websocket.Server{
 		Handler: func(conn *websocket.Conn) {
@@ -150,6 +157,10 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+	startTime     time.Time
+	endTime       time.Time
+	messageRegex  string
+	jsonFormat    bool
 }
 
 func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
@@ -197,6 +208,37 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.filterLevel = level
 	}
 
+	if value := queryMap.Get("startTime"); value != "" {
+		startTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("startTime value %q is not a valid time", value)
+		}
+		params.startTime = startTime
+	}
+
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("endTime value %q is not a valid time", value)
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("messageRegex"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, errors.Errorf("messageRegex value %q is not a valid regular expression", value)
+		}
+		params.messageRegex = value
+	}
+
+	switch value := queryMap.Get("format"); value {
+	case "", "text":
+	case "json":
+		params.jsonFormat = true
+	default:
+		return nil, errors.Errorf("format value %q is not one of %q, %q", value, "text", "json")
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -4,6 +4,7 @@
 package apiserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -43,8 +44,11 @@ func handleDebugLogDBRequest(
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
 
-			line := formatLogRecord(rec)
-			_, err := socket.Write([]byte(line))
+			line, err := formatLogLine(rec, reqParams.jsonFormat)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			_, err = socket.Write(line)
 			if err != nil {
 				return errors.Annotate(err, "sending failed")
 			}
@@ -66,6 +70,10 @@ func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+		StartTime:     reqParams.startTime,
+		EndTime:       reqParams.endTime,
+		MessageRegex:  reqParams.messageRegex,
+		Limit:         int(reqParams.maxLines),
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -73,6 +81,14 @@ func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 	return params
 }
 
+// formatLogLine returns the line sent to the client for the record.
+func formatLogLine(r *state.LogRecord, jsonFormat bool) ([]byte, error) {
+	if jsonFormat {
+		return formatLogRecordJSON(r)
+	}
+	return []byte(formatLogRecord(r)), nil
+}
+
 func formatLogRecord(r *state.LogRecord) string {
 	return fmt.Sprintf("%s: %s %s %s %s %s\n",
 		r.Entity,
@@ -84,6 +100,35 @@ func formatLogRecord(r *state.LogRecord) string {
 	)
 }
 
+// logRecordJSON is the form of a log record sent when the client
+// asks for JSON output.
+type logRecordJSON struct {
+	ModelUUID string    `json:"model-uuid"`
+	Entity    string    `json:"entity"`
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location"`
+	Message   string    `json:"message"`
+}
+
+// formatLogRecordJSON returns the record as a single line of JSON.
+func formatLogRecordJSON(r *state.LogRecord) ([]byte, error) {
+	data, err := json.Marshal(logRecordJSON{
+		ModelUUID: r.ModelUUID,
+		Entity:    r.Entity.String(),
+		Timestamp: r.Time.UTC(),
+		Level:     r.Level.String(),
+		Module:    r.Module,
+		Location:  r.Location,
+		Message:   r.Message,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "marshalling log record")
+	}
+	return append(data, '\n'), nil
+}
+
 func formatTime(t time.Time) string {
 	return t.In(time.UTC).Format("2006-01-02 15:04:05")
 }
@@ -29,24 +29,31 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
+	startTime := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	endTime := time.Date(2016, 10, 2, 12, 0, 0, 0, time.UTC)
 	reqParams := &debugLogParams{
 		fromTheStart:  false,
 		noTail:        true,
 		backlog:       11,
+		maxLines:      50,
 		filterLevel:   loggo.INFO,
 		includeEntity: []string{"foo"},
 		includeModule: []string{"bar"},
 		excludeEntity: []string{"baz"},
 		excludeModule: []string{"qux"},
+		startTime:     startTime,
+		endTime:       endTime,
+		messageRegex:  "fail(ed|ure)",
 	}
 
 	called := false
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
-		c.Assert(params.StartTime.IsZero(), jc.IsTrue)
+		c.Assert(params.StartTime, gc.Equals, startTime)
+		c.Assert(params.EndTime, gc.Equals, endTime)
+		c.Assert(params.MessageRegex, gc.Equals, "fail(ed|ure)")
+		c.Assert(params.Limit, gc.Equals, 50)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -125,6 +132,34 @@ func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestFullRequestJSON(c *gc.C) {
+	tailer := newFakeLogTailer()
+	tailer.logsCh <- &state.LogRecord{
+		Time:      time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		ModelUUID: "deadbeef",
+		Entity:    names.NewMachineTag("99"),
+		Module:    "some.where",
+		Location:  "code.go:42",
+		Level:     loggo.INFO,
+		Message:   "stuff happened",
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
+		return tailer, nil
+	})
+
+	stop := make(chan struct{})
+	done := s.runRequest(&debugLogParams{jsonFormat: true}, stop)
+
+	s.assertOutput(c, []string{
+		"ok",
+		`{"model-uuid":"deadbeef","entity":"machine-99","timestamp":"2015-06-19T15:34:37Z",` +
+			`"level":"INFO","module":"some.where","location":"code.go:42","message":"stuff happened"}` + "\n",
+	})
+
+	close(stop)
+	s.assertStops(c, done, tailer)
+}
+
 func (s *debugLogDBIntSuite) TestRequestStopsWhenTailerStops(c *gc.C) {
 	tailer := newFakeLogTailer()
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
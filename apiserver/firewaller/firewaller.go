@@ -152,6 +152,23 @@ func (f *FirewallerAPI) watchOneEnvironOpenedPorts(tag names.Tag) (string, []str
 	return "", nil, watcher.EnsureErr(watch)
 }
 
+// WatchSubnets returns a NotifyWatcher that notifies when the subnets
+// of the model, and so the subnets of its spaces, change.
+func (f *FirewallerAPI) WatchSubnets() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := f.st.WatchSubnets()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = f.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
 // them.
@@ -189,6 +206,7 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 		}
 		if ports != nil {
 			portRangeMap := ports.AllPortRanges()
+			endpointMap := ports.AllPortRangeEndpoints()
 			var portRanges []network.PortRange
 			for portRange := range portRangeMap {
 				portRanges = append(portRanges, portRange)
@@ -201,6 +219,7 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 					params.MachinePortRange{
 						UnitTag:   unitTag,
 						PortRange: params.FromNetworkPortRange(portRange),
+						Endpoint:  endpointMap[portRange],
 					})
 			}
 		}
@@ -279,6 +298,34 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetEndpointIngressCIDRs returns the source CIDRs from which the
+// ports opened for each endpoint of each given service may be accessed,
+// keyed by endpoint name. Ports opened for endpoints without their own
+// sources use those under the empty endpoint name, if any. Unexposed
+// services have none.
+func (f *FirewallerAPI) GetEndpointIngressCIDRs(args params.Entities) (params.EndpointIngressCIDRsResults, error) {
+	result := params.EndpointIngressCIDRsResults{
+		Results: make([]params.EndpointIngressCIDRsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.EndpointIngressCIDRsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].CIDRs, err = service.EndpointIngressCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -365,6 +365,52 @@ func (s *firewallerBaseSuite) testGetExposed(
 	})
 }
 
+func (s *firewallerBaseSuite) testGetEndpointIngressCIDRs(
+	c *gc.C,
+	facade interface {
+		GetEndpointIngressCIDRs(args params.Entities) (params.EndpointIngressCIDRsResults, error)
+	},
+) {
+	err := s.service.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"":    {ExposeToCIDRs: []string{"192.168.1.0/24", "10.0.0.0/24"}},
+		"url": {ExposeToCIDRs: []string{"172.16.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}})
+	result, err := facade.GetEndpointIngressCIDRs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EndpointIngressCIDRsResults{
+		Results: []params.EndpointIngressCIDRsResult{
+			{CIDRs: map[string][]string{
+				"":    {"10.0.0.0/24", "192.168.1.0/24"},
+				"url": {"172.16.0.0/16"},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Unexposing the service discards the sources.
+	err = s.service.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args = params.Entities{Entities: []params.Entity{
+		{Tag: s.service.Tag().String()},
+	}}
+	result, err = facade.GetEndpointIngressCIDRs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EndpointIngressCIDRsResults{
+		Results: []params.EndpointIngressCIDRsResult{{}},
+	})
+}
+
 func (s *firewallerBaseSuite) testGetAssignedMachine(
 	c *gc.C,
 	facade interface {
@@ -77,6 +77,10 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetEndpointIngressCIDRs(c *gc.C) {
+	s.testGetEndpointIngressCIDRs(c, s.firewaller)
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
@@ -87,7 +91,7 @@ func (s *firewallerSuite) openPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.units[0].OpenPort("tcp", 4321)
 	c.Assert(err, jc.ErrorIsNil)
-	err = s.units[2].OpenPorts("udp", 1111, 2222)
+	err = s.units[2].OpenPortsForEndpoint("url", "udp", 1111, 2222)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -138,6 +142,28 @@ func (s *firewallerSuite) TestWatchOpenedPorts(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.firewaller.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.31.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 	s.openPorts(c)
 
@@ -168,7 +194,7 @@ func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 	expectPortsMachine2 := []params.MachinePortRange{
 		{UnitTag: unit2Tag, PortRange: params.PortRange{
 			FromPort: 1111, ToPort: 2222, Protocol: "udp",
-		}},
+		}, Endpoint: "url"},
 	}
 	result, err := s.firewaller.GetMachinePorts(args)
 	c.Assert(err, jc.ErrorIsNil)
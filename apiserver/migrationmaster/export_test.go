@@ -4,6 +4,8 @@
 package migrationmaster
 
 import (
+	"time"
+
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -14,10 +16,20 @@ func PatchState(p Patcher, st Backend) {
 	})
 }
 
+func PatchPrecheckBackend(p Patcher, backend migration.PrecheckBackend) {
+	p.PatchValue(&getPrecheckBackend, func(*state.State) migration.PrecheckBackend {
+		return backend
+	})
+}
+
 func PatchExportModel(p Patcher, f func(migration.StateExporter) ([]byte, error)) {
 	p.PatchValue(&exportModel, f)
 }
 
+func PatchModelLogsAfter(p Patcher, f func(state.ModelSessioner, time.Time, string, int) ([]*state.LogRecord, error)) {
+	p.PatchValue(&modelLogsAfter, f)
+}
+
 type Patcher interface {
 	PatchValue(ptr, value interface{})
 }
@@ -22,9 +22,10 @@ func init() {
 // API implements the API required for the model migration
 // master worker.
 type API struct {
-	backend    Backend
-	authorizer common.Authorizer
-	resources  *common.Resources
+	backend         Backend
+	precheckBackend migration.PrecheckBackend
+	authorizer      common.Authorizer
+	resources       *common.Resources
 }
 
 // NewAPI creates a new API server endpoint for the model migration
@@ -38,9 +39,10 @@ func NewAPI(
 		return nil, common.ErrPerm
 	}
 	return &API{
-		backend:    getBackend(st),
-		authorizer: authorizer,
-		resources:  resources,
+		backend:         getBackend(st),
+		precheckBackend: getPrecheckBackend(st),
+		authorizer:      authorizer,
+		resources:       resources,
 	}, nil
 }
 
@@ -118,6 +120,170 @@ func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
 	return errors.Annotate(err, "failed to set phase")
 }
 
+// SetStatusMessage sets a human readable status message for the
+// active model migration.
+func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) error {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SetStatusMessage(args.Message)
+	return errors.Annotate(err, "failed to set status message")
+}
+
+// WatchMinionReports sets up a watcher which reports when a report
+// for a migration minion has arrived.
+func (api *API) WatchMinionReports() params.NotifyWatchResult {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return params.NotifyWatchResult{Error: common.ServerError(err)}
+	}
+
+	watch, err := mig.WatchMinionReports()
+	if err != nil {
+		return params.NotifyWatchResult{Error: common.ServerError(err)}
+	}
+
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}
+}
+
+// MinionReports returns details of the reports made by migration
+// minions to the controller for the current migration phase.
+func (api *API) MinionReports() (params.MinionReports, error) {
+	var out params.MinionReports
+
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+
+	reports, err := mig.MinionReports()
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+
+	out.MigrationId = mig.Id()
+	phase, err := mig.Phase()
+	if err != nil {
+		return out, errors.Trace(err)
+	}
+	out.Phase = phase.String()
+
+	out.SuccessCount = len(reports.Succeeded)
+	out.UnknownCount = len(reports.Unknown)
+	out.Unknown = tagsToStrings(reports.Unknown)
+	out.Failed = tagsToStrings(reports.Failed)
+	return out, nil
+}
+
+func tagsToStrings(tags []names.Tag) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = tag.String()
+	}
+	return out
+}
+
+// ModelInfo returns the details of the model being migrated which
+// the target controller requires to check that the model can be
+// imported.
+func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
+	info, err := api.precheckBackend.ModelInfo()
+	if err != nil {
+		return params.MigrationModelInfo{}, errors.Trace(err)
+	}
+	return params.MigrationModelInfo{
+		UUID:            info.UUID,
+		Name:            info.Name,
+		OwnerTag:        info.Owner.String(),
+		AgentVersion:    info.AgentVersion,
+		CloudName:       info.CloudName,
+		CloudRegion:     info.CloudRegion,
+		CloudCredential: info.CloudCredential,
+	}, nil
+}
+
+// Prechecks checks that the model associated with the API connection
+// and the source controller are in a suitable state for the model to
+// be migrated. The problems found, if any, are returned.
+func (api *API) Prechecks() (params.PrecheckResult, error) {
+	failures, err := migration.SourcePrecheck(api.precheckBackend)
+	if err != nil {
+		return params.PrecheckResult{}, errors.Trace(err)
+	}
+	return params.PrecheckResult{
+		Failures: precheckFailuresToParams(failures),
+	}, nil
+}
+
+// SetPrecheckFailures records the problems found by the source and
+// target controllers while checking the active model migration.
+func (api *API) SetPrecheckFailures(args params.SetPrecheckFailuresArgs) error {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SetPrecheckFailures(precheckFailuresFromParams(args.Failures))
+	return errors.Annotate(err, "failed to set precheck failures")
+}
+
+// precheckFailuresToParams converts precheck failures for
+// transmission over the API.
+func precheckFailuresToParams(failures []coremigration.PrecheckFailure) []params.PrecheckFailure {
+	var out []params.PrecheckFailure
+	for _, failure := range failures {
+		out = append(out, params.PrecheckFailure{
+			Code:    failure.Code,
+			Entity:  failure.Entity,
+			Message: failure.Message,
+		})
+	}
+	return out
+}
+
+// precheckFailuresFromParams converts precheck failures received
+// over the API.
+func precheckFailuresFromParams(failures []params.PrecheckFailure) []coremigration.PrecheckFailure {
+	var out []coremigration.PrecheckFailure
+	for _, failure := range failures {
+		out = append(out, coremigration.PrecheckFailure{
+			Code:    failure.Code,
+			Entity:  failure.Entity,
+			Message: failure.Message,
+		})
+	}
+	return out
+}
+
+// Reap removes all documents for the model associated with the API
+// connection. It should only be called once the model has been
+// successfully migrated to the target controller.
+func (api *API) Reap() error {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if phase != coremigration.REAP {
+		return errors.Errorf("migration is in %s phase, not REAP", phase)
+	}
+	err = api.backend.RemoveExportingModelDocs()
+	return errors.Annotate(err, "failed to remove model from source controller")
+}
+
 var exportModel = migration.ExportModel
 
 // Export serializes the model associated with the API connection.
@@ -132,3 +298,33 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Bytes = bytes
 	return serialized, nil
 }
+
+var modelLogsAfter = state.ModelLogsAfter
+
+// ModelLogs returns a batch of the log records for the model
+// associated with the API connection which follow the record with
+// the time and document ID given. An empty batch indicates that
+// there are no more records to transfer.
+func (api *API) ModelLogs(args params.ModelLogsArgs) (params.LogStreamRecords, error) {
+	var result params.LogStreamRecords
+	records, err := modelLogsAfter(api.backend, args.After, args.AfterID, args.MaxCount)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Records = make([]params.LogStreamRecord, len(records))
+	for i, rec := range records {
+		result.Records[i] = params.LogStreamRecord{
+			ID:        rec.ID,
+			ModelUUID: rec.ModelUUID,
+			Entity:    rec.Entity.String(),
+			Version:   rec.Version.String(),
+			Timestamp: rec.Time,
+			Module:    rec.Module,
+			Location:  rec.Location,
+			Level:     rec.Level.String(),
+			Message:   rec.Message,
+			DocID:     rec.DocID,
+		}
+	}
+	return result, nil
+}
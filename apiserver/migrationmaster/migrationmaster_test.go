@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -28,9 +30,10 @@ var _ migrationmaster.Backend = (*state.State)(nil)
 type Suite struct {
 	testing.BaseSuite
 
-	backend    *stubBackend
-	resources  *common.Resources
-	authorizer apiservertesting.FakeAuthorizer
+	backend         *stubBackend
+	precheckBackend *stubPrecheckBackend
+	resources       *common.Resources
+	authorizer      apiservertesting.FakeAuthorizer
 }
 
 var _ = gc.Suite(&Suite{})
@@ -39,9 +42,11 @@ func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	s.backend = &stubBackend{
-		migration: new(stubMigration),
+		migration: &stubMigration{phase: coremigration.READONLY},
 	}
 	migrationmaster.PatchState(s, s.backend)
+	s.precheckBackend = &stubPrecheckBackend{}
+	migrationmaster.PatchPrecheckBackend(s, s.precheckBackend)
 
 	s.resources = common.NewResources()
 	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
@@ -144,6 +149,237 @@ func (s *Suite) TestExport(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestSetStatusMessage(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.migration.messageSet, gc.Equals, "foo")
+}
+
+func (s *Suite) TestSetStatusMessageNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Check(err, gc.ErrorMatches, "could not get migration: boom")
+}
+
+func (s *Suite) TestSetStatusMessageError(c *gc.C) {
+	s.backend.migration.setMessageErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
+}
+
+func (s *Suite) TestModelLogs(c *gc.C) {
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	var afterArg time.Time
+	var afterIDArg string
+	var maxCountArg int
+	modelLogsAfter := func(_ state.ModelSessioner, after time.Time, afterID string, maxCount int) ([]*state.LogRecord, error) {
+		afterArg = after
+		afterIDArg = afterID
+		maxCountArg = maxCount
+		return []*state.LogRecord{{
+			ID:        t0.UnixNano(),
+			Time:      t0,
+			DocID:     "57a5e8a3a3e6c1e2b8a1c2d4",
+			ModelUUID: modelUUID,
+			Entity:    names.NewMachineTag("42"),
+			Version:   version.MustParse("2.0.1"),
+			Level:     loggo.INFO,
+			Module:    "foo",
+			Location:  "bar.go:42",
+			Message:   "hello",
+		}}, nil
+	}
+	migrationmaster.PatchModelLogsAfter(s, modelLogsAfter)
+	api := s.mustMakeAPI(c)
+
+	after := t0.Add(-time.Hour)
+	result, err := api.ModelLogs(params.ModelLogsArgs{
+		After:    after,
+		AfterID:  "57a5e8a3a3e6c1e2b8a1c2d3",
+		MaxCount: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(afterArg, gc.Equals, after)
+	c.Check(afterIDArg, gc.Equals, "57a5e8a3a3e6c1e2b8a1c2d3")
+	c.Check(maxCountArg, gc.Equals, 10)
+	c.Check(result, gc.DeepEquals, params.LogStreamRecords{
+		Records: []params.LogStreamRecord{{
+			ID:        t0.UnixNano(),
+			ModelUUID: modelUUID,
+			Entity:    "machine-42",
+			Version:   "2.0.1",
+			Timestamp: t0,
+			Module:    "foo",
+			Location:  "bar.go:42",
+			Level:     "INFO",
+			Message:   "hello",
+			DocID:     "57a5e8a3a3e6c1e2b8a1c2d4",
+		}},
+	})
+}
+
+func (s *Suite) TestModelLogsError(c *gc.C) {
+	modelLogsAfter := func(state.ModelSessioner, time.Time, string, int) ([]*state.LogRecord, error) {
+		return nil, errors.New("nope")
+	}
+	migrationmaster.PatchModelLogsAfter(s, modelLogsAfter)
+	api := s.mustMakeAPI(c)
+
+	_, err := api.ModelLogs(params.ModelLogsArgs{})
+	c.Check(err, gc.ErrorMatches, "nope")
+}
+
+func (s *Suite) TestWatchMinionReports(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	result := api.WatchMinionReports()
+	c.Assert(result.Error, gc.IsNil)
+
+	resource := s.resources.Get(result.NotifyWatcherId)
+	watcher, _ := resource.(state.NotifyWatcher)
+	c.Assert(watcher, gc.NotNil)
+
+	select {
+	case <-watcher.Changes():
+		c.Fatalf("initial event not consumed")
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+func (s *Suite) TestWatchMinionReportsNoMigration(c *gc.C) {
+	s.backend.getErr = errors.NotFoundf("migration")
+	api := s.mustMakeAPI(c)
+
+	result := api.WatchMinionReports()
+	c.Assert(result.Error, gc.ErrorMatches, "migration not found")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *Suite) TestMinionReports(c *gc.C) {
+	s.backend.migration.minionReports = &state.MinionReports{
+		Succeeded: []names.Tag{
+			names.NewMachineTag("2"),
+			names.NewMachineTag("1"),
+		},
+		Failed: []names.Tag{
+			names.NewMachineTag("0"),
+			names.NewUnitTag("foo/1"),
+		},
+		Unknown: []names.Tag{
+			names.NewMachineTag("3"),
+			names.NewUnitTag("foo/2"),
+		},
+	}
+
+	api := s.mustMakeAPI(c)
+	reports, err := api.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports, gc.DeepEquals, params.MinionReports{
+		MigrationId:  "id",
+		Phase:        "READONLY",
+		SuccessCount: 2,
+		UnknownCount: 2,
+		Unknown:      []string{"machine-3", "unit-foo-2"},
+		Failed:       []string{"machine-0", "unit-foo-1"},
+	})
+}
+
+func (s *Suite) TestMinionReportsError(c *gc.C) {
+	s.backend.migration.minionReportsErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	_, err := api.MinionReports()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestModelInfo(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	info, err := api.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, gc.DeepEquals, params.MigrationModelInfo{
+		UUID:            modelUUID,
+		Name:            "model-name",
+		OwnerTag:        "user-owner",
+		AgentVersion:    version.MustParse("2.0.0"),
+		CloudName:       "cloud",
+		CloudRegion:     "region",
+		CloudCredential: "cred",
+	})
+}
+
+func (s *Suite) TestPrechecks(c *gc.C) {
+	// The stub backend reports that a cleanup is needed.
+	api := s.mustMakeAPI(c)
+	result, err := api.Prechecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.PrecheckResult{
+		Failures: []params.PrecheckFailure{{
+			Code:    "cleanup-needed",
+			Message: "cleanup needed",
+		}},
+	})
+}
+
+func (s *Suite) TestPrechecksError(c *gc.C) {
+	s.precheckBackend.err = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	_, err := api.Prechecks()
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
+func (s *Suite) TestSetPrecheckFailures(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.SetPrecheckFailures(params.SetPrecheckFailuresArgs{
+		Failures: []params.PrecheckFailure{{
+			Code:    "machine-error",
+			Entity:  "machine-0",
+			Message: "machine 0 is in error state",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.migration.precheckFailuresSet, jc.DeepEquals, []coremigration.PrecheckFailure{{
+		Code:    "machine-error",
+		Entity:  "machine-0",
+		Message: "machine 0 is in error state",
+	}})
+}
+
+func (s *Suite) TestSetPrecheckFailuresNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	err := api.SetPrecheckFailures(params.SetPrecheckFailuresArgs{})
+	c.Assert(err, gc.ErrorMatches, "could not get migration: boom")
+}
+
+func (s *Suite) TestReap(c *gc.C) {
+	s.backend.migration.phase = coremigration.REAP
+	api := s.mustMakeAPI(c)
+	err := api.Reap()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.removeCalled, jc.IsTrue)
+}
+
+func (s *Suite) TestReapWrongPhase(c *gc.C) {
+	s.backend.migration.phase = coremigration.LOGTRANSFER
+	api := s.mustMakeAPI(c)
+	err := api.Reap()
+	c.Assert(err, gc.ErrorMatches, "migration is in LOGTRANSFER phase, not REAP")
+	c.Assert(s.backend.removeCalled, jc.IsFalse)
+}
+
+func (s *Suite) TestReapError(c *gc.C) {
+	s.backend.migration.phase = coremigration.REAP
+	s.backend.removeErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	err := api.Reap()
+	c.Assert(err, gc.ErrorMatches, "failed to remove model from source controller: boom")
+}
+
 func (s *Suite) makeAPI() (*migrationmaster.API, error) {
 	return migrationmaster.NewAPI(nil, s.resources, s.authorizer)
 }
@@ -159,6 +395,14 @@ type stubBackend struct {
 
 	getErr    error
 	migration *stubMigration
+
+	removeErr    error
+	removeCalled bool
+}
+
+func (b *stubBackend) RemoveExportingModelDocs() error {
+	b.removeCalled = true
+	return b.removeErr
 }
 
 func (b *stubBackend) WatchForModelMigration() state.NotifyWatcher {
@@ -174,12 +418,29 @@ func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
 
 type stubMigration struct {
 	state.ModelMigration
-	setPhaseErr error
-	phaseSet    coremigration.Phase
+	phase         coremigration.Phase
+	setPhaseErr   error
+	phaseSet      coremigration.Phase
+	setMessageErr error
+	messageSet    string
+
+	minionReports    *state.MinionReports
+	minionReportsErr error
+
+	precheckFailuresSet []coremigration.PrecheckFailure
+}
+
+func (m *stubMigration) SetPrecheckFailures(failures []coremigration.PrecheckFailure) error {
+	m.precheckFailuresSet = failures
+	return nil
+}
+
+func (m *stubMigration) Id() string {
+	return "id"
 }
 
 func (m *stubMigration) Phase() (coremigration.Phase, error) {
-	return coremigration.READONLY, nil
+	return m.phase, nil
 }
 
 func (m *stubMigration) Attempt() (int, error) {
@@ -208,6 +469,67 @@ func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	return nil
 }
 
+func (m *stubMigration) SetStatusMessage(message string) error {
+	if m.setMessageErr != nil {
+		return m.setMessageErr
+	}
+	m.messageSet = message
+	return nil
+}
+
+func (m *stubMigration) WatchMinionReports() (state.NotifyWatcher, error) {
+	return apiservertesting.NewFakeNotifyWatcher(), nil
+}
+
+func (m *stubMigration) MinionReports() (*state.MinionReports, error) {
+	if m.minionReportsErr != nil {
+		return nil, m.minionReportsErr
+	}
+	if m.minionReports == nil {
+		return new(state.MinionReports), nil
+	}
+	return m.minionReports, nil
+}
+
+// stubPrecheckBackend reports that a cleanup is required, but is
+// otherwise empty.
+type stubPrecheckBackend struct {
+	migration.PrecheckBackend
+	err error
+}
+
+func (b *stubPrecheckBackend) ModelInfo() (coremigration.ModelInfo, error) {
+	return coremigration.ModelInfo{
+		UUID:            modelUUID,
+		Name:            "model-name",
+		Owner:           names.NewUserTag("owner"),
+		AgentVersion:    version.MustParse("2.0.0"),
+		CloudName:       "cloud",
+		CloudRegion:     "region",
+		CloudCredential: "cred",
+	}, nil
+}
+
+func (b *stubPrecheckBackend) NeedsCleanup() (bool, error) {
+	return true, b.err
+}
+
+func (b *stubPrecheckBackend) IsUpgrading() (bool, error) {
+	return false, nil
+}
+
+func (b *stubPrecheckBackend) AgentVersion() (version.Number, error) {
+	return version.MustParse("2.0.0"), nil
+}
+
+func (b *stubPrecheckBackend) AllMachines() ([]migration.PrecheckMachine, error) {
+	return nil, nil
+}
+
+func (b *stubPrecheckBackend) AllApplications() ([]migration.PrecheckApplication, error) {
+	return nil, nil
+}
+
 var modelUUID string
 var controllerUUID string
 
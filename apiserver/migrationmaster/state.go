@@ -12,11 +12,15 @@ import (
 // migrationmaster facade.
 type Backend interface {
 	migration.StateExporter
+	state.ModelSessioner
 
 	WatchForModelMigration() state.NotifyWatcher
 	GetModelMigration() (state.ModelMigration, error)
+	RemoveExportingModelDocs() error
 }
 
 var getBackend = func(st *state.State) Backend {
 	return st
 }
+
+var getPrecheckBackend = migration.PrecheckShim
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -56,3 +57,20 @@ func (api *API) Watch() (params.NotifyWatchResult, error) {
 		NotifyWatcherId: api.resources.Register(w),
 	}, nil
 }
+
+// Report allows a migration minion to submit whether it succeeded or
+// failed for a specific migration phase.
+func (api *API) Report(info params.MinionReport) error {
+	phase, ok := migration.ParsePhase(info.Phase)
+	if !ok {
+		return errors.New("unable to parse phase")
+	}
+
+	mig, err := api.backend.Migration(info.MigrationId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = mig.SubmitMinionReport(api.authorizer.GetAuthTag(), phase, info.Success)
+	return errors.Trace(err)
+}
@@ -5,13 +5,16 @@ package migrationminion_test
 
 import (
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/migrationminion"
+	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -74,6 +77,53 @@ func (s *Suite) TestWatch(c *gc.C) {
 	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
 }
 
+func (s *Suite) TestReport(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "IMPORT",
+		Success:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Migration", []interface{}{"id"}},
+		{"Report", []interface{}{s.authorizer.Tag, migration.IMPORT, true}},
+	})
+}
+
+func (s *Suite) TestReportInvalidPhase(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "WTF",
+		Success:     true,
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to parse phase")
+}
+
+func (s *Suite) TestReportNoSuchMigration(c *gc.C) {
+	failure := errors.NotFoundf("model")
+	s.backend.modelLookupErr = failure
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "QUIESCE",
+		Success:     false,
+	})
+	c.Assert(errors.Cause(err), gc.Equals, failure)
+}
+
+func (s *Suite) TestReportFailure(c *gc.C) {
+	s.backend.reportErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "QUIESCE",
+		Success:     true,
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *Suite) makeAPI() (*migrationminion.API, error) {
 	return migrationminion.NewAPI(nil, s.resources, s.authorizer)
 }
@@ -86,7 +136,10 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationminion.API {
 
 type stubBackend struct {
 	migrationminion.Backend
-	watchError error
+	stub           jujutesting.Stub
+	watchError     error
+	modelLookupErr error
+	reportErr      error
 }
 
 func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
@@ -95,3 +148,21 @@ func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
 	}
 	return apiservertesting.NewFakeNotifyWatcher(), nil
 }
+
+func (b *stubBackend) Migration(id string) (state.ModelMigration, error) {
+	b.stub.AddCall("Migration", id)
+	if b.modelLookupErr != nil {
+		return nil, b.modelLookupErr
+	}
+	return &stubModelMigration{backend: b}, nil
+}
+
+type stubModelMigration struct {
+	state.ModelMigration
+	backend *stubBackend
+}
+
+func (m *stubModelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
+	m.backend.stub.AddCall("Report", tag, phase, success)
+	return m.backend.reportErr
+}
@@ -4,11 +4,16 @@
 package migrationtarget
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -57,6 +62,39 @@ func checkAuth(authorizer common.Authorizer, st *state.State) error {
 	return nil
 }
 
+// Prechecks checks that the target controller is able to accept the
+// model described. The problems found, if any, are returned.
+func (api *API) Prechecks(model params.MigrationModelInfo) (params.PrecheckResult, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return params.PrecheckResult{}, errors.Trace(err)
+	}
+	failures, err := migration.TargetPrecheck(
+		migration.TargetPrecheckShim(api.state),
+		coremigration.ModelInfo{
+			UUID:            model.UUID,
+			Name:            model.Name,
+			Owner:           ownerTag,
+			AgentVersion:    model.AgentVersion,
+			CloudName:       model.CloudName,
+			CloudRegion:     model.CloudRegion,
+			CloudCredential: model.CloudCredential,
+		},
+	)
+	if err != nil {
+		return params.PrecheckResult{}, errors.Trace(err)
+	}
+	var result params.PrecheckResult
+	for _, failure := range failures {
+		result.Failures = append(result.Failures, params.PrecheckFailure{
+			Code:    failure.Code,
+			Entity:  failure.Entity,
+			Message: failure.Message,
+		})
+	}
+	return result, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
@@ -70,11 +108,7 @@ func (api *API) Import(serialized params.SerializedModel) error {
 }
 
 func (api *API) getModel(args params.ModelArgs) (*state.Model, error) {
-	tag, err := names.ParseModelTag(args.ModelTag)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	model, err := api.state.GetModel(tag)
+	model, err := api.getMigratedModel(args)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -111,3 +145,107 @@ func (api *API) Activate(args params.ModelArgs) error {
 
 	return model.SetMigrationMode(state.MigrationModeActive)
 }
+
+// logTransferSink identifies the LastSentLogTracker used to record
+// the progress of log transfer for a migrated model.
+const logTransferSink = "migration-logtransfer"
+
+// LatestLogTime returns the timestamp of the most recent log record
+// received for the specified model during log transfer. A zero time
+// is returned if no log records have been received yet.
+func (api *API) LatestLogTime(args params.ModelArgs) (time.Time, error) {
+	model, err := api.getMigratedModel(args)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	st, err := api.state.ForModel(model.ModelTag())
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	defer st.Close()
+
+	tracker := state.NewLastSentLogTracker(st, logTransferSink)
+	recID, err := tracker.Get()
+	if errors.Cause(err) == state.ErrNeverForwarded {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return time.Unix(0, recID).UTC(), nil
+}
+
+// AddLogs stores log records transferred from the source controller
+// for a migrated model and records the timestamp of the latest one
+// so that an interrupted transfer can be resumed.
+func (api *API) AddLogs(args params.AddModelLogsArgs) error {
+	model, err := api.getMigratedModel(params.ModelArgs{ModelTag: args.ModelTag})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(args.Records) == 0 {
+		return nil
+	}
+	records := make([]*state.LogRecord, len(args.Records))
+	for i, apiRec := range args.Records {
+		rec, err := recFromAPI(apiRec)
+		if err != nil {
+			return errors.Annotatef(err, "log record %d", i)
+		}
+		records[i] = rec
+	}
+
+	st, err := api.state.ForModel(model.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+
+	if err := state.ImportModelLogs(st, records); err != nil {
+		return errors.Trace(err)
+	}
+	tracker := state.NewLastSentLogTracker(st, logTransferSink)
+	last := records[len(records)-1]
+	return errors.Trace(tracker.Set(last.Time.UnixNano()))
+}
+
+// getMigratedModel returns the specified model regardless of its
+// migration mode. Log transfer happens after the model has been
+// activated.
+func (api *API) getMigratedModel(args params.ModelArgs) (*state.Model, error) {
+	tag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := api.state.GetModel(tag)
+	return model, errors.Trace(err)
+}
+
+func recFromAPI(apiRec params.LogStreamRecord) (*state.LogRecord, error) {
+	entity, err := names.ParseTag(apiRec.Entity)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ver version.Number
+	if apiRec.Version != "" {
+		ver, err = version.Parse(apiRec.Version)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	level, ok := loggo.ParseLevel(apiRec.Level)
+	if !ok {
+		return nil, errors.Errorf("unrecognized log level %q", apiRec.Level)
+	}
+	return &state.LogRecord{
+		ID:        apiRec.ID,
+		Time:      apiRec.Timestamp,
+		DocID:     apiRec.DocID,
+		ModelUUID: apiRec.ModelUUID,
+		Entity:    entity,
+		Version:   ver,
+		Level:     level,
+		Module:    apiRec.Module,
+		Location:  apiRec.Location,
+		Message:   apiRec.Message,
+	}, nil
+}
@@ -4,7 +4,10 @@
 package migrationtarget_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -81,6 +84,32 @@ func (s *Suite) TestNotControllerAdmin(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
 }
 
+func (s *Suite) TestPrechecks(c *gc.C) {
+	api := s.mustNewAPI(c)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Attempting to migrate a model which already exists fails.
+	result, err := api.Prechecks(params.MigrationModelInfo{
+		UUID:      model.UUID(),
+		Name:      model.Name(),
+		OwnerTag:  model.Owner().String(),
+		CloudName: info.CloudName,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Failures, gc.HasLen, 1)
+	c.Check(result.Failures[0].Code, gc.Equals, "model-exists")
+	c.Check(result.Failures[0].Entity, gc.Equals, model.ModelTag().String())
+}
+
+func (s *Suite) TestPrechecksBadOwner(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.Prechecks(params.MigrationModelInfo{OwnerTag: "not-a-tag"})
+	c.Assert(err, gc.ErrorMatches, `"not-a-tag" is not a valid tag`)
+}
+
 func (s *Suite) importModel(c *gc.C, api *migrationtarget.API) names.ModelTag {
 	uuid, bytes := s.makeExportedModel(c)
 	err := api.Import(params.SerializedModel{Bytes: bytes})
@@ -171,6 +200,87 @@ func (s *Suite) TestActivateNotImportingEnv(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
 }
 
+func (s *Suite) TestLatestLogTimeNeverSet(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	latest, err := api.LatestLogTime(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, gc.Equals, time.Time{})
+}
+
+func (s *Suite) TestAddLogs(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	args := params.AddModelLogsArgs{
+		ModelTag: tag.String(),
+		Records: []params.LogStreamRecord{{
+			Entity:    "machine-0",
+			Version:   "2.0.1",
+			Timestamp: t0,
+			Module:    "foo",
+			Location:  "foo.go:1",
+			Level:     "INFO",
+			Message:   "first",
+			DocID:     "57a5e8a3a3e6c1e2b8a1c2d3",
+		}, {
+			Entity:    "unit-foo-0",
+			Timestamp: t1,
+			Module:    "bar",
+			Location:  "bar.go:2",
+			Level:     "ERROR",
+			Message:   "second",
+			DocID:     "57a5e8a3a3e6c1e2b8a1c2d4",
+		}},
+	}
+	err := api.AddLogs(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Sending the same records again, as happens when a transfer
+	// is retried, does not duplicate them.
+	err = api.AddLogs(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	latest, err := api.LatestLogTime(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, gc.Equals, t1)
+
+	st, err := s.State.ForModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	records, err := state.ModelLogsAfter(st, time.Time{}, "", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 2)
+	c.Check(records[0].Message, gc.Equals, "first")
+	c.Check(records[0].Entity, gc.Equals, names.NewMachineTag("0"))
+	c.Check(records[1].Message, gc.Equals, "second")
+	c.Check(records[1].Level, gc.Equals, loggo.ERROR)
+}
+
+func (s *Suite) TestAddLogsBadLevel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	err := api.AddLogs(params.AddModelLogsArgs{
+		ModelTag: tag.String(),
+		Records: []params.LogStreamRecord{{
+			Entity: "machine-0",
+			Level:  "LOUD",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `log record 0: unrecognized log level "LOUD"`)
+}
+
+func (s *Suite) TestAddLogsMissingModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	newUUID := utils.MustNewUUID().String()
+	err := api.AddLogs(params.AddModelLogsArgs{ModelTag: names.NewModelTag(newUUID).String()})
+	c.Assert(err, gc.ErrorMatches, `model not found`)
+}
+
 func (s *Suite) newAPI() (*migrationtarget.API, error) {
 	return migrationtarget.NewAPI(s.State, s.resources, s.authorizer)
 }
@@ -20,6 +20,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -123,6 +124,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		{"ForModel", []interface{}{names.NewModelTag(s.st.model.cfg.UUID())}},
 		{"Model", nil},
 		{"ControllerConfig", nil},
+		{"GetModelMigration", nil},
 		{"Close", nil},
 	})
 	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
@@ -136,6 +138,63 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *modelInfoSuite) TestModelInfoWithMigration(c *gc.C) {
+	start := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	s.st.migration = &mockMigration{
+		phase:   migration.LOGTRANSFER,
+		message: "transferring logs: 10 records sent",
+		start:   start,
+	}
+	info := s.getModelInfo(c)
+	c.Assert(info.Migration, jc.DeepEquals, &params.ModelMigrationStatus{
+		Status: "transferring logs: 10 records sent",
+		Start:  &start,
+	})
+}
+
+func (s *modelInfoSuite) TestModelInfoWithFinishedMigration(c *gc.C) {
+	start := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	s.st.migration = &mockMigration{
+		phase: migration.ABORTDONE,
+		start: start,
+		end:   end,
+	}
+	info := s.getModelInfo(c)
+	c.Assert(info.Migration, jc.DeepEquals, &params.ModelMigrationStatus{
+		Status: "ABORTDONE",
+		Start:  &start,
+		End:    &end,
+	})
+}
+
+func (s *modelInfoSuite) TestModelInfoWithPrecheckFailures(c *gc.C) {
+	start := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	s.st.migration = &mockMigration{
+		phase:   migration.ABORTDONE,
+		message: "precheck failed",
+		start:   start,
+		end:     end,
+		failures: []migration.PrecheckFailure{{
+			Code:    migration.PrecheckUnitError,
+			Entity:  "unit-foo-0",
+			Message: "unit foo/0 is in error state: hook failed",
+		}},
+	}
+	info := s.getModelInfo(c)
+	c.Assert(info.Migration, jc.DeepEquals, &params.ModelMigrationStatus{
+		Status: "precheck failed",
+		Start:  &start,
+		End:    &end,
+		PrecheckFailures: []params.PrecheckFailure{{
+			Code:    "unit-error",
+			Entity:  "unit-foo-0",
+			Message: "unit foo/0 is in error state: hook failed",
+		}},
+	})
+}
+
 func (s *modelInfoSuite) TestModelInfoOwner(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("bob@local"))
 	info := s.getModelInfo(c)
@@ -216,6 +275,7 @@ type mockState struct {
 	controllerModel *mockModel
 	users           []*state.ModelUser
 	creds           map[string]cloud.Credential
+	migration       *mockMigration
 }
 
 func (st *mockState) ModelUUID() string {
@@ -302,6 +362,17 @@ func (st *mockState) CloudCredentials(user names.UserTag) (map[string]cloud.Cred
 	return st.creds, st.NextErr()
 }
 
+func (st *mockState) GetModelMigration() (state.ModelMigration, error) {
+	st.MethodCall(st, "GetModelMigration")
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	if st.migration == nil {
+		return nil, errors.NotFoundf("migration")
+	}
+	return st.migration, nil
+}
+
 func (st *mockState) Close() error {
 	st.MethodCall(st, "Close")
 	return st.NextErr()
@@ -394,6 +465,36 @@ func (m *mockModel) DestroyIncludingHosted() error {
 	return m.NextErr()
 }
 
+type mockMigration struct {
+	state.ModelMigration
+
+	phase    migration.Phase
+	message  string
+	start    time.Time
+	end      time.Time
+	failures []migration.PrecheckFailure
+}
+
+func (m *mockMigration) PrecheckFailures() []migration.PrecheckFailure {
+	return m.failures
+}
+
+func (m *mockMigration) Phase() (migration.Phase, error) {
+	return m.phase, nil
+}
+
+func (m *mockMigration) StatusMessage() string {
+	return m.message
+}
+
+func (m *mockMigration) StartTime() time.Time {
+	return m.start
+}
+
+func (m *mockMigration) EndTime() time.Time {
+	return m.end
+}
+
 type mockModelUser struct {
 	gitjujutesting.Stub
 	userName       string
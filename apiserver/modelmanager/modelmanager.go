@@ -347,6 +347,36 @@ func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResul
 	return results, nil
 }
 
+func makeModelMigrationStatus(migration state.ModelMigration) *params.ModelMigrationStatus {
+	status := migration.StatusMessage()
+	if status == "" {
+		if phase, err := migration.Phase(); err == nil {
+			status = phase.String()
+		}
+	}
+	var failures []params.PrecheckFailure
+	for _, failure := range migration.PrecheckFailures() {
+		failures = append(failures, params.PrecheckFailure{
+			Code:    failure.Code,
+			Entity:  failure.Entity,
+			Message: failure.Message,
+		})
+	}
+	return &params.ModelMigrationStatus{
+		Status:           status,
+		Start:            timePtr(migration.StartTime()),
+		End:              timePtr(migration.EndTime()),
+		PrecheckFailures: failures,
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
 func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, error) {
 	st, err := m.state.ForModel(tag)
 	if errors.IsNotFound(err) {
@@ -394,6 +424,13 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		CloudCredential: model.CloudCredential(),
 	}
 
+	migration, err := st.GetModelMigration()
+	if err == nil {
+		info.Migration = makeModelMigrationStatus(migration)
+	} else if !errors.IsNotFound(err) {
+		return params.ModelInfo{}, errors.Annotate(err, "getting migration status")
+	}
+
 	authorizedOwner := m.authCheck(owner) == nil
 	for _, user := range users {
 		if !authorizedOwner && m.authCheck(user.UserTag()) != nil {
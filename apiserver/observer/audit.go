@@ -3,17 +3,29 @@
 package observer
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/rpc"
 )
 
+// RedactedValue replaces the values of secret attributes in the
+// arguments recorded in audit entries.
+const RedactedValue = "<redacted>"
+
+// alwaysSecretKeys holds the names of attributes which are redacted
+// from recorded arguments whatever the AuditContext specifies.
+var alwaysSecretKeys = set.NewStrings("password", "credentials", "macaroons")
+
 // Context defines things an Audit observer need know about to operate
 // correctly.
 type AuditContext struct {
@@ -21,31 +33,61 @@ type AuditContext struct {
 	// JujuServerVersion is the version of jujud.
 	JujuServerVersion version.Number
 
-	// ModelUUID is the UUID of the model the audit observer is
-	// currently running on.
+	// ModelUUID is the UUID of the controller model. It's recorded
+	// against requests made on connections which aren't to a
+	// specific model.
 	ModelUUID string
+
+	// Clock is used to timestamp entries and to time API calls. If
+	// it's nil, the wall clock is used.
+	Clock clock.Clock
+
+	// SecretKeys holds the names of attributes, such as those marked
+	// secret in the configuration schemas, whose values are redacted
+	// from the recorded arguments of API calls.
+	SecretKeys set.Strings
+
+	// ExcludeMethod, if set, reports whether calls to the given
+	// facade method should be left out of the audit log.
+	ExcludeMethod func(facade, method string) bool
 }
 
 type ErrorHandler func(error)
 
 // NewAudit creates a new Audit with the information provided via the Context.
 func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorHandler ErrorHandler) *Audit {
+	auditClock := ctx.Clock
+	if auditClock == nil {
+		auditClock = clock.WallClock
+	}
+	secretKeys := alwaysSecretKeys.Union(ctx.SecretKeys)
 	return &Audit{
 		jujuServerVersion: ctx.JujuServerVersion,
 		modelUUID:         ctx.ModelUUID,
+		clock:             auditClock,
+		secretKeys:        secretKeys,
+		excludeMethod:     ctx.ExcludeMethod,
 		errorHandler:      errorHandler,
 		handleAuditEntry:  handleAuditEntry,
 	}
 }
 
 // Audit is an observer which will log APIServer requests using the
-// function provided.
+// function provided. Each request is recorded when its reply is
+// sent, so that entries include the outcome of the call.
 type Audit struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	clock             clock.Clock
+	secretKeys        set.Strings
+	excludeMethod     func(facade, method string) bool
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 
+	// mu guards state, which is updated by requests and replies
+	// being handled concurrently.
+	mu sync.Mutex
+
 	// state represents information that's built up as methods on this
 	// type are called. We segregate this to ensure it's clear what
 	// information is transient in case we want to extract it
@@ -54,53 +96,122 @@ type Audit struct {
 	state struct {
 		remoteAddress    string
 		authenticatedTag string
+		modelUUID        string
+		pending          map[uint64]pendingRequest
 	}
 }
 
+// pendingRequest holds the details of a request which has not yet
+// been replied to.
+type pendingRequest struct {
+	entry   audit.AuditEntry
+	started time.Time
+}
+
 // Login implements Observer.
 func (a *Audit) Login(tag string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.state.authenticatedTag = tag
 }
 
 // ServerRequest implements Observer.
 func (a *Audit) ServerRequest(hdr *rpc.Header, body interface{}) {
+	req := hdr.Request
+	if a.excludeMethod != nil && a.excludeMethod(req.Type, req.Action) {
+		return
+	}
+	// The arguments are redacted now, rather than when the reply is
+	// sent, as the server method may modify them.
+	args := redactArgs(body, a.secretKeys)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	auditEntry := a.boilerplateAuditEntry()
-	//auditEntry.OriginIP =
 	auditEntry.OriginType = "API request"
-	auditEntry.OriginName = a.state.authenticatedTag
-	auditEntry.Operation = rpcRequestToOperation(hdr.Request)
-	auditEntry.Data = map[string]interface{}{"request-body": body}
-	err := a.handleAuditEntry(auditEntry)
-	if err != nil {
-		a.errorHandler(errors.Trace(err))
+	auditEntry.Operation = rpcRequestToOperation(req)
+	auditEntry.Facade = req.Type
+	auditEntry.FacadeVersion = req.Version
+	auditEntry.Method = req.Action
+	auditEntry.Data = map[string]interface{}{"request-body": args}
+	if a.state.pending == nil {
+		a.state.pending = make(map[uint64]pendingRequest)
+	}
+	a.state.pending[hdr.RequestId] = pendingRequest{
+		entry:   auditEntry,
+		started: a.clock.Now(),
 	}
 }
 
+// ServerReply implements Observer.
+func (a *Audit) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	a.mu.Lock()
+	pending, ok := a.state.pending[hdr.RequestId]
+	delete(a.state.pending, hdr.RequestId)
+	a.mu.Unlock()
+	if !ok {
+		return
+	}
+	auditEntry := pending.entry
+	auditEntry.Duration = a.clock.Now().Sub(pending.started)
+	auditEntry.ErrorCode = hdr.ErrorCode
+	auditEntry.ErrorMessage = hdr.Error
+	a.writeEntry(auditEntry)
+}
+
 // Join implements Observer.
 func (a *Audit) Join(req *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.state.remoteAddress = req.RemoteAddr
+	if req.URL != nil {
+		a.state.modelUUID = req.URL.Query().Get(":modeluuid")
+	}
 }
 
 // Leave implements Observer.
 func (a *Audit) Leave() {
+	a.mu.Lock()
+	pending := a.state.pending
+	a.state.pending = nil
 	a.state.remoteAddress = ""
 	a.state.authenticatedTag = ""
+	a.state.modelUUID = ""
+	a.mu.Unlock()
+
+	// Requests which were never replied to are still recorded, so
+	// that the audit log shows they were attempted.
+	now := a.clock.Now()
+	for _, p := range pending {
+		auditEntry := p.entry
+		auditEntry.Duration = now.Sub(p.started)
+		auditEntry.ErrorMessage = "connection closed before reply"
+		a.writeEntry(auditEntry)
+	}
 }
 
 // ClientRequest implements Observer.
 func (a *Audit) ClientRequest(hdr *rpc.Header, body interface{}) {}
 
-// ServerReply implements Observer.
-func (a *Audit) ServerReply(rpc.Request, *rpc.Header, interface{}) {}
-
 // ClientReply implements Observer.
 func (a *Audit) ClientReply(req rpc.Request, hdr *rpc.Header, body interface{}) {}
 
+func (a *Audit) writeEntry(auditEntry audit.AuditEntry) {
+	if err := a.handleAuditEntry(auditEntry); err != nil {
+		a.errorHandler(errors.Trace(err))
+	}
+}
+
+// boilerplateAuditEntry must be called with a.mu held.
 func (a *Audit) boilerplateAuditEntry() audit.AuditEntry {
+	modelUUID := a.state.modelUUID
+	if modelUUID == "" {
+		modelUUID = a.modelUUID
+	}
 	return audit.AuditEntry{
 		JujuServerVersion: a.jujuServerVersion,
-		ModelUUID:         a.modelUUID,
-		Timestamp:         time.Now().UTC(),
+		ModelUUID:         modelUUID,
+		Timestamp:         a.clock.Now().UTC(),
 		RemoteAddress:     a.state.remoteAddress,
 		OriginName:        a.state.authenticatedTag,
 	}
@@ -109,3 +220,39 @@ func (a *Audit) boilerplateAuditEntry() audit.AuditEntry {
 func rpcRequestToOperation(req rpc.Request) string {
 	return fmt.Sprintf("%s:v%d - %s", req.Type, req.Version, req.Action)
 }
+
+// redactArgs returns the JSON form of the given API call arguments,
+// with the values of any attributes named in secretKeys replaced by
+// RedactedValue, however deeply they are nested.
+func redactArgs(args interface{}, secretKeys set.Strings) interface{} {
+	if args == nil {
+		return nil
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Sprintf("cannot record arguments: %v", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Sprintf("cannot record arguments: %v", err)
+	}
+	return redactValue(value, secretKeys)
+}
+
+func redactValue(value interface{}, secretKeys set.Strings) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if secretKeys.Contains(k) {
+				value[k] = RedactedValue
+			} else {
+				value[k] = redactValue(v, secretKeys)
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redactValue(v, secretKeys)
+		}
+	}
+	return value
+}
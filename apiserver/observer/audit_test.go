@@ -0,0 +1,206 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const (
+	controllerModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	hostedModelUUID     = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+)
+
+type auditSuite struct {
+	testing.IsolationSuite
+
+	clock   *coretesting.Clock
+	entries []audit.AuditEntry
+	ctx     observer.AuditContext
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC))
+	s.entries = nil
+	s.ctx = observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.0.0"),
+		ModelUUID:         controllerModelUUID,
+		Clock:             s.clock,
+	}
+}
+
+func (s *auditSuite) newAudit(c *gc.C, path string) *observer.Audit {
+	a := observer.NewAudit(&s.ctx, func(entry audit.AuditEntry) error {
+		s.entries = append(s.entries, entry)
+		return nil
+	}, func(err error) {
+		c.Errorf("unexpected error: %v", err)
+	})
+	u := &url.URL{Path: path}
+	if path != "/api" {
+		u.RawQuery = url.Values{":modeluuid": {hostedModelUUID}}.Encode()
+	}
+	a.Join(&http.Request{RemoteAddr: "10.0.0.1:1234", URL: u})
+	a.Login("user-bob")
+	return a
+}
+
+func request(id uint64, facade string, version int, method string) *rpc.Header {
+	return &rpc.Header{
+		RequestId: id,
+		Request: rpc.Request{
+			Type:    facade,
+			Version: version,
+			Action:  method,
+		},
+	}
+}
+
+func (s *auditSuite) TestRecordsEntryOnReply(c *gc.C) {
+	a := s.newAudit(c, "/model/"+hostedModelUUID+"/api")
+	hdr := request(1, "ModelManager", 2, "DestroyModel")
+	a.ServerRequest(hdr, params.Entities{Entities: []params.Entity{{Tag: "model-x"}}})
+	c.Assert(s.entries, gc.HasLen, 0)
+
+	s.clock.Advance(1500 * time.Millisecond)
+	a.ServerReply(hdr.Request, &rpc.Header{RequestId: 1}, struct{}{})
+	c.Assert(s.entries, gc.HasLen, 1)
+	entry := s.entries[0]
+	c.Check(entry.Validate(), jc.ErrorIsNil)
+	c.Check(entry, jc.DeepEquals, audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.0.0"),
+		ModelUUID:         hostedModelUUID,
+		Timestamp:         time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC),
+		RemoteAddress:     "10.0.0.1:1234",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "ModelManager:v2 - DestroyModel",
+		Facade:            "ModelManager",
+		FacadeVersion:     2,
+		Method:            "DestroyModel",
+		Duration:          1500 * time.Millisecond,
+		Data: map[string]interface{}{
+			"request-body": map[string]interface{}{
+				"entities": []interface{}{
+					map[string]interface{}{"tag": "model-x"},
+				},
+			},
+		},
+	})
+	c.Check(entry.Outcome(), gc.Equals, "ok")
+}
+
+func (s *auditSuite) TestRecordsError(c *gc.C) {
+	a := s.newAudit(c, "/model/"+hostedModelUUID+"/api")
+	hdr := request(7, "ModelManager", 2, "DestroyModel")
+	a.ServerRequest(hdr, struct{}{})
+	a.ServerReply(hdr.Request, &rpc.Header{
+		RequestId: 7,
+		Error:     "permission denied",
+		ErrorCode: "unauthorized access",
+	}, struct{}{})
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].ErrorCode, gc.Equals, "unauthorized access")
+	c.Check(s.entries[0].ErrorMessage, gc.Equals, "permission denied")
+	c.Check(s.entries[0].Outcome(), gc.Equals, "unauthorized access")
+}
+
+func (s *auditSuite) TestConcurrentRequests(c *gc.C) {
+	a := s.newAudit(c, "/model/"+hostedModelUUID+"/api")
+	first := request(1, "Client", 1, "FullStatus")
+	second := request(2, "Application", 1, "Deploy")
+	a.ServerRequest(first, nil)
+	s.clock.Advance(time.Second)
+	a.ServerRequest(second, nil)
+	s.clock.Advance(time.Second)
+	a.ServerReply(second.Request, &rpc.Header{RequestId: 2}, nil)
+	a.ServerReply(first.Request, &rpc.Header{RequestId: 1}, nil)
+
+	c.Assert(s.entries, gc.HasLen, 2)
+	c.Check(s.entries[0].Method, gc.Equals, "Deploy")
+	c.Check(s.entries[0].Duration, gc.Equals, time.Second)
+	c.Check(s.entries[1].Method, gc.Equals, "FullStatus")
+	c.Check(s.entries[1].Duration, gc.Equals, 2*time.Second)
+}
+
+func (s *auditSuite) TestLeaveRecordsUnansweredRequests(c *gc.C) {
+	a := s.newAudit(c, "/model/"+hostedModelUUID+"/api")
+	a.ServerRequest(request(1, "Client", 1, "FullStatus"), nil)
+	s.clock.Advance(time.Second)
+	a.Leave()
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].ErrorMessage, gc.Equals, "connection closed before reply")
+	c.Check(s.entries[0].Duration, gc.Equals, time.Second)
+}
+
+func (s *auditSuite) TestControllerConnectionUsesControllerModel(c *gc.C) {
+	a := s.newAudit(c, "/api")
+	hdr := request(1, "Controller", 3, "AllModels")
+	a.ServerRequest(hdr, nil)
+	a.ServerReply(hdr.Request, &rpc.Header{RequestId: 1}, nil)
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].ModelUUID, gc.Equals, controllerModelUUID)
+}
+
+func (s *auditSuite) TestRedactsSecrets(c *gc.C) {
+	s.ctx.SecretKeys = set.NewStrings("admin-secret", "secret-key")
+	a := s.newAudit(c, "/api")
+	hdr := request(1, "Cloud", 1, "UpdateCredentials")
+	a.ServerRequest(hdr, map[string]interface{}{
+		"config": map[string]interface{}{
+			"admin-secret": "sekrit",
+			"name":         "foo",
+		},
+		"credentials": []interface{}{"x"},
+		"users": []interface{}{
+			map[string]interface{}{"user": "bob", "password": "hunter2"},
+		},
+		"attrs": map[string]interface{}{"secret-key": 42},
+	})
+	a.ServerReply(hdr.Request, &rpc.Header{RequestId: 1}, nil)
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Data["request-body"], jc.DeepEquals, map[string]interface{}{
+		"config": map[string]interface{}{
+			"admin-secret": observer.RedactedValue,
+			"name":         "foo",
+		},
+		"credentials": observer.RedactedValue,
+		"users": []interface{}{
+			map[string]interface{}{"user": "bob", "password": observer.RedactedValue},
+		},
+		"attrs": map[string]interface{}{"secret-key": observer.RedactedValue},
+	})
+}
+
+func (s *auditSuite) TestExcludedMethods(c *gc.C) {
+	s.ctx.ExcludeMethod = func(facade, method string) bool {
+		return facade == "Client" && method == "FullStatus"
+	}
+	a := s.newAudit(c, "/api")
+	excluded := request(1, "Client", 1, "FullStatus")
+	included := request(2, "Client", 1, "AddMachines")
+	a.ServerRequest(excluded, nil)
+	a.ServerRequest(included, nil)
+	a.ServerReply(excluded.Request, &rpc.Header{RequestId: 1}, nil)
+	a.ServerReply(included.Request, &rpc.Header{RequestId: 2}, nil)
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Method, gc.Equals, "AddMachines")
+}
@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
+// AuditLogFilter holds the criteria used to select entries from the
+// controller's audit log. Empty fields match every entry.
+type AuditLogFilter struct {
+	// UserTag restricts entries to those made by this user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// ModelTag restricts entries to those made on this model.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// Operation restricts entries to those whose operation contains
+	// this text, ignoring case.
+	Operation string `json:"operation,omitempty"`
+
+	// From, if set, restricts entries to those made at or after
+	// this time.
+	From *time.Time `json:"from,omitempty"`
+
+	// To, if set, restricts entries to those made at or before
+	// this time.
+	To *time.Time `json:"to,omitempty"`
+
+	// Limit is the maximum number of entries to return, starting
+	// from the most recent.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry describes a single request recorded in the
+// controller's audit log.
+type AuditLogEntry struct {
+	JujuServerVersion version.Number         `json:"juju-server-version"`
+	ModelTag          string                 `json:"model-tag"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Facade            string                 `json:"facade,omitempty"`
+	FacadeVersion     int                    `json:"facade-version,omitempty"`
+	Method            string                 `json:"method,omitempty"`
+	Duration          time.Duration          `json:"duration,omitempty"`
+	ErrorCode         string                 `json:"error-code,omitempty"`
+	ErrorMessage      string                 `json:"error-message,omitempty"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditLogEntries holds the entries read from the controller's audit
+// log, most recent first.
+type AuditLogEntries struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
@@ -317,6 +317,60 @@ type EntityWorkloadVersions struct {
 	Entities []EntityWorkloadVersion `json:"entities"`
 }
 
+// UnitStateResult holds the key-value state stored by a unit's charm,
+// or an error.
+type UnitStateResult struct {
+	State map[string]string `json:"state,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// UnitStateResults holds the results of a UnitState call.
+type UnitStateResults struct {
+	Results []UnitStateResult `json:"results"`
+}
+
+// SetUnitStateArg holds the key-value state to store for a unit,
+// replacing any state previously stored.
+type SetUnitStateArg struct {
+	Tag   string            `json:"tag"`
+	State map[string]string `json:"state"`
+}
+
+// SetUnitStateArgs holds the parameters for setting the state of a
+// set of units.
+type SetUnitStateArgs struct {
+	Args []SetUnitStateArg `json:"args"`
+}
+
+// GoalStateStatus holds the status a unit is expected to reach, as
+// reported by the goal-state hook tool.
+type GoalStateStatus struct {
+	Status string `json:"status"`
+}
+
+// UnitsGoalState holds the goal state status of a set of units, keyed
+// by unit name.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState holds the units a unit's application is expected to have,
+// and the units expected on each of its relations, keyed by the local
+// endpoint name.
+type GoalState struct {
+	Units     UnitsGoalState            `json:"units"`
+	Relations map[string]UnitsGoalState `json:"relations"`
+}
+
+// GoalStateResult holds the goal state for a unit, or an error.
+type GoalStateResult struct {
+	Result *GoalState `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// GoalStateResults holds the results of a GoalStates call.
+type GoalStateResults struct {
+	Results []GoalStateResult `json:"results"`
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
@@ -19,6 +19,7 @@ type LogStreamRecord struct {
 	Location  string    `json:"lo"`
 	Level     string    `json:"lv"`
 	Message   string    `json:"msg"`
+	DocID     string    `json:"doc-id,omitempty"`
 }
 
 // LogStreamConfig holds all the information necessary to open a
@@ -3,6 +3,12 @@
 
 package params
 
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
 // InitiateModelMigrationArgs holds the details required to start one
 // or more model migrations.
 type InitiateModelMigrationArgs struct {
@@ -46,6 +52,12 @@ type SetMigrationPhaseArgs struct {
 	Phase string `json:"phase"`
 }
 
+// SetMigrationStatusMessageArgs provides a migration status message
+// to the migrationmaster.SetStatusMessage API method.
+type SetMigrationStatusMessageArgs struct {
+	Message string `json:"message"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model.
 type SerializedModel struct {
 	Bytes []byte `json:"bytes"`
@@ -56,10 +68,31 @@ type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
 }
 
+// ModelLogsArgs holds the parameters for retrieving a batch of a
+// model's log records for transfer to a migration target controller.
+type ModelLogsArgs struct {
+	After    time.Time `json:"after"`
+	AfterID  string    `json:"after-id,omitempty"`
+	MaxCount int       `json:"max-count"`
+}
+
+// LogStreamRecords holds a batch of log records.
+type LogStreamRecords struct {
+	Records []LogStreamRecord `json:"records"`
+}
+
+// AddModelLogsArgs holds a batch of log records to be added to a
+// migrated model on the target controller.
+type AddModelLogsArgs struct {
+	ModelTag string            `json:"model-tag"`
+	Records  []LogStreamRecord `json:"records"`
+}
+
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
-	Attempt int    `json:"attempt"`
-	Phase   string `json:"phase"`
+	MigrationId string `json:"migration-id"`
+	Attempt     int    `json:"attempt"`
+	Phase       string `json:"phase"`
 
 	// TODO(mjs): I'm not convinced these Source fields will get used.
 	SourceAPIAddrs []string `json:"source-api-addrs"`
@@ -78,6 +111,50 @@ type FullMigrationStatus struct {
 	Phase   string             `json:"phase"`
 }
 
+// MinionReport holds the details of whether a migration minion
+// succeeded or failed for a specific migration phase.
+type MinionReport struct {
+	// MigrationId holds the id of the migration the agent is
+	// reporting about.
+	MigrationId string `json:"migration-id"`
+
+	// Phase holds the phase of the migration the agent is
+	// reporting about.
+	Phase string `json:"phase"`
+
+	// Success is true if the agent successfully completed its
+	// actions for the migration phase, false otherwise.
+	Success bool `json:"success"`
+}
+
+// MinionReports holds the details of whether a migration minion
+// succeeded or failed for a specific migration phase.
+type MinionReports struct {
+	// MigrationId holds the id of the migration the reports related
+	// to.
+	MigrationId string `json:"migration-id"`
+
+	// Phase holds the phase of the migration the reports related
+	// to.
+	Phase string `json:"phase"`
+
+	// SuccessCount holds the number of agents which have
+	// successfully completed a given migration phase.
+	SuccessCount int `json:"success-count"`
+
+	// UnknownCount holds the number of agents still to report for a
+	// given migration phase.
+	UnknownCount int `json:"unknown-count"`
+
+	// Unknown holds the tags of all the agents that are still to
+	// report for a given migration phase.
+	Unknown []string `json:"unknown"`
+
+	// Failed holds the tags of all agents which have reported that
+	// they failed to complete a given migration phase.
+	Failed []string `json:"failed"`
+}
+
 type PhaseResult struct {
 	Phase string `json:"phase"`
 	Error *Error `json:"error,omitempty"`
@@ -86,3 +163,46 @@ type PhaseResult struct {
 type PhaseResults struct {
 	Results []PhaseResult `json:"results"`
 }
+
+// MigrationModelInfo holds the details of a model which the target
+// controller needs in order to check whether the model can be
+// migrated to it.
+type MigrationModelInfo struct {
+	UUID            string         `json:"uuid"`
+	Name            string         `json:"name"`
+	OwnerTag        string         `json:"owner-tag"`
+	AgentVersion    version.Number `json:"agent-version"`
+	CloudName       string         `json:"cloud-name"`
+	CloudRegion     string         `json:"cloud-region,omitempty"`
+	CloudCredential string         `json:"cloud-credential,omitempty"`
+}
+
+// PrecheckFailure describes a single problem which prevents a model
+// from being migrated.
+type PrecheckFailure struct {
+	Code    string `json:"code"`
+	Entity  string `json:"entity,omitempty"`
+	Message string `json:"message"`
+}
+
+// PrecheckResult holds the problems found when checking whether a
+// model can be migrated. An empty list of failures indicates that
+// the checks passed.
+type PrecheckResult struct {
+	Failures []PrecheckFailure `json:"failures,omitempty"`
+}
+
+// SetPrecheckFailuresArgs holds the problems found by the source and
+// target controllers during the PRECHECK phase of a model migration.
+type SetPrecheckFailuresArgs struct {
+	Failures []PrecheckFailure `json:"failures"`
+}
+
+// ResourceUploadResult is returned when the content of a resource is
+// uploaded to a model being imported by a migration.
+type ResourceUploadResult struct {
+	Error       *Error `json:"error,omitempty"`
+	ID          string `json:"id"`
+	Fingerprint string `json:"fingerprint"`
+	Size        int64  `json:"size"`
+}
@@ -67,6 +67,19 @@ type ModelInfo struct {
 	// to the model. Owners and administrators can see all users
 	// that have access; other users can only see their own details.
 	Users []ModelUserInfo `json:"users"`
+
+	// Migration contains information about the latest migration
+	// attempt for the model, if there has been one.
+	Migration *ModelMigrationStatus `json:"migration,omitempty"`
+}
+
+// ModelMigrationStatus holds information about the progress of a
+// (possibly failed) model migration.
+type ModelMigrationStatus struct {
+	Status           string            `json:"status"`
+	Start            *time.Time        `json:"start"`
+	End              *time.Time        `json:"end,omitempty"`
+	PrecheckFailures []PrecheckFailure `json:"precheck-failures,omitempty"`
 }
 
 // ModelInfoResult holds the result of a ModelInfo call.
@@ -248,6 +248,32 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints optionally restricts the sources which may
+	// access the application's endpoints, keyed by endpoint name.
+	// The empty endpoint name applies to all endpoints.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint holds the spaces and CIDRs which may access an
+// exposed application endpoint.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
+}
+
+// EndpointIngressCIDRsResult holds the source CIDRs from which the
+// ports opened for each of an application's endpoints may be accessed,
+// keyed by endpoint name, or an error.
+type EndpointIngressCIDRsResult struct {
+	Error *Error              `json:"error,omitempty"`
+	CIDRs map[string][]string `json:"cidrs,omitempty"`
+}
+
+// EndpointIngressCIDRsResults holds the results of a
+// GetEndpointIngressCIDRs call.
+type EndpointIngressCIDRsResults struct {
+	Results []EndpointIngressCIDRsResult `json:"results"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -349,11 +375,16 @@ type AddApplicationUnits struct {
 	ApplicationName string                `json:"application"`
 	NumUnits        int                   `json:"num-units"`
 	Placement       []*instance.Placement `json:"placement"`
+	AttachStorage   []string              `json:"attach-storage,omitempty"`
 }
 
 // DestroyApplicationUnits holds parameters for the DestroyUnits call.
 type DestroyApplicationUnits struct {
 	UnitNames []string `json:"unit-names"`
+
+	// DetachStorage, if true, causes the storage owned by the units
+	// to be detached and kept rather than destroyed with them.
+	DetachStorage bool `json:"detach-storage,omitempty"`
 }
 
 // ApplicationDestroy holds the parameters for making the application Destroy call.
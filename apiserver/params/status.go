@@ -167,6 +167,12 @@ type StatusHistoryPruneArgs struct {
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
 
+// StatusHistoryPruneResult holds the result of a status history
+// pruning run.
+type StatusHistoryPruneResult struct {
+	Removed int `json:"removed"`
+}
+
 // StatusResult holds an entity status, extra information, or an
 // error.
 type StatusResult struct {
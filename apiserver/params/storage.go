@@ -93,6 +93,7 @@ type StorageAttachment struct {
 
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
+	Size     uint64      `json:"size,omitempty"`
 	Life     Life        `json:"life"`
 }
 
@@ -270,6 +271,53 @@ type VolumeParamsResults struct {
 	Results []VolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for resizing a volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volume-tag"`
+	VolumeId  string `json:"volume-id"`
+	Size      uint64 `json:"size"`
+	Provider  string `json:"provider"`
+}
+
+// VolumeResizeParamsResult holds the parameters for resizing a volume,
+// or nil if no resize is pending for the volume.
+type VolumeResizeParamsResult struct {
+	Result *VolumeResizeParams `json:"result,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resizing parameters for multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeResizes holds the parameters of multiple volume resizes.
+type VolumeResizes struct {
+	Resizes []VolumeResizeParams `json:"resizes"`
+}
+
+// FilesystemResizeParams holds the parameters for growing a
+// volume-backed filesystem to fill its resized volume.
+type FilesystemResizeParams struct {
+	FilesystemTag string `json:"filesystem-tag"`
+	FilesystemId  string `json:"filesystem-id"`
+	VolumeTag     string `json:"volume-tag"`
+	Size          uint64 `json:"size"`
+}
+
+// FilesystemResizeParamsResult holds the parameters for resizing a
+// filesystem, or nil if no resize is pending for the filesystem.
+type FilesystemResizeParamsResult struct {
+	Result *FilesystemResizeParams `json:"result,omitempty"`
+	Error  *Error                  `json:"error,omitempty"`
+}
+
+// FilesystemResizeParamsResults holds resizing parameters for multiple
+// filesystems.
+type FilesystemResizeParamsResults struct {
+	Results []FilesystemResizeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -668,3 +716,65 @@ type StorageAddParams struct {
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// BulkImportStorageParams contains the parameters for importing a collection
+// of storage entities.
+type BulkImportStorageParams struct {
+	Storage []ImportStorageParams `json:"storage"`
+}
+
+// ImportStorageParams contains the parameters for importing a storage entity.
+type ImportStorageParams struct {
+	// Kind is the kind of the storage entity to import.
+	Kind StorageKind `json:"kind"`
+
+	// Pool is the name of the storage pool into which the storage
+	// entity will be imported.
+	Pool string `json:"pool"`
+
+	// ProviderId is the storage provider's unique ID for the storage
+	// entity, e.g. the EBS volume ID.
+	ProviderId string `json:"provider-id"`
+
+	// StorageName is the name of the storage to assign to the entity.
+	StorageName string `json:"storage-name"`
+
+	// Force indicates whether the storage entity should be imported
+	// even if it belongs to another model.
+	Force bool `json:"force,omitempty"`
+}
+
+// ImportStorageResults contains the results of importing a collection of
+// storage entities.
+type ImportStorageResults struct {
+	Results []ImportStorageResult `json:"results"`
+}
+
+// ImportStorageResult contains the result of importing a storage entity.
+type ImportStorageResult struct {
+	Result *ImportStorageDetails `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ImportStorageDetails contains the details of an imported storage entity.
+type ImportStorageDetails struct {
+	// StorageTag contains the string representation of the storage tag
+	// assigned to the imported storage entity.
+	StorageTag string `json:"storage-tag"`
+}
+
+// BulkResizeStorageParams contains the parameters for resizing a
+// collection of storage entities.
+type BulkResizeStorageParams struct {
+	Storage []ResizeStorageParams `json:"storage"`
+}
+
+// ResizeStorageParams contains the parameters for resizing a storage
+// entity.
+type ResizeStorageParams struct {
+	// StorageTag is the tag of the storage entity to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage, in MiB.
+	Size uint64 `json:"size"`
+}
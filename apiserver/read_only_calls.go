@@ -22,7 +22,9 @@ var readOnlyCalls = set.NewStrings(
 	"Application.GetConstraints",
 	"Application.CharmRelations",
 	"Application.Get",
+	"AuditLog.Entries",
 	"Block.List",
+	"Bundle.ExportBundle",
 	"Charms.CharmInfo",
 	"Charms.IsMetered",
 	"Charms.List",
@@ -61,9 +63,9 @@ var readOnlyCalls = set.NewStrings(
 	"UserManager.UserInfo",
 )
 
-// isCallReadOnly returns whether or not the method on the facade
+// IsCallReadOnly returns whether or not the method on the facade
 // is known to not alter the database.
-func isCallReadOnly(facade, method string) bool {
+func IsCallReadOnly(facade, method string) bool {
 	key := facade + "." + method
 	// NOTE: maybe useful in the future to be able to specify entire facades
 	// as read only, in which case specifying something like "Facade.*" would
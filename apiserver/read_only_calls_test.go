@@ -54,7 +54,7 @@ func (*readOnlyCallsSuite) TestReadOnlyCall(c *gc.C) {
 		{"Storage", "ListStorageDetails"},
 	} {
 		c.Logf("check %s.%s", test.facade, test.method)
-		c.Check(isCallReadOnly(test.facade, test.method), jc.IsTrue)
+		c.Check(IsCallReadOnly(test.facade, test.method), jc.IsTrue)
 	}
 }
 
@@ -68,6 +68,6 @@ func (*readOnlyCallsSuite) TestWritableCalls(c *gc.C) {
 		{"UnknownFacade", "List"},
 	} {
 		c.Logf("check %s.%s", test.facade, test.method)
-		c.Check(isCallReadOnly(test.facade, test.method), jc.IsFalse)
+		c.Check(IsCallReadOnly(test.facade, test.method), jc.IsFalse)
 	}
 }
@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// resourcesMigrationUploadHandler handles the upload of resource data
+// for a model that is being imported as part of a model migration.
+type resourcesMigrationUploadHandler struct {
+	ctxt httpContext
+}
+
+func (h *resourcesMigrationUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Validate before authenticate because the authentication is dependent
+	// on the state connection that is determined during the validation.
+	st, _, err := h.ctxt.stateForRequestAuthenticatedUser(r)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	switch r.Method {
+	case "POST":
+		result, err := h.processPost(r, st)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+		sendStatusAndJSON(w, http.StatusOK, result)
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method))
+	}
+}
+
+// processPost handles resource upload POST request after
+// authentication.
+func (h *resourcesMigrationUploadHandler) processPost(r *http.Request, st *state.State) (*params.ResourceUploadResult, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.MigrationMode() != state.MigrationModeImporting {
+		return nil, errors.BadRequestf("model not importing")
+	}
+
+	query := r.URL.Query()
+	application := query.Get("application")
+	if application == "" {
+		return nil, errors.BadRequestf("missing application")
+	}
+	name := query.Get("name")
+	if name == "" {
+		return nil, errors.BadRequestf("missing resource name")
+	}
+
+	resources, err := st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res, err := resources.StoreResourceData(application, name, r.Body)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot store resource")
+	}
+	return &params.ResourceUploadResult{
+		ID:          res.ID,
+		Fingerprint: res.Fingerprint.String(),
+		Size:        res.Size,
+	}, nil
+}
@@ -29,6 +29,7 @@ func newRestrictedRoot(finder rpc.MethodFinder) *restrictedRoot {
 // boundaries.
 var restrictedRootNames = set.NewStrings(
 	"AllModelWatcher",
+	"AuditLog",
 	"Controller",
 	"Cloud",
 	"MigrationTarget",
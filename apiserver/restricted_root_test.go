@@ -39,6 +39,8 @@ func (r *restrictedRootSuite) TestFindAllowedMethod(c *gc.C) {
 	r.assertMethodAllowed(c, "ModelManager", 2, "CreateModel")
 	r.assertMethodAllowed(c, "ModelManager", 2, "ListModels")
 
+	r.assertMethodAllowed(c, "AuditLog", 1, "Entries")
+
 	r.assertMethodAllowed(c, "UserManager", 1, "AddUser")
 	r.assertMethodAllowed(c, "UserManager", 1, "SetPassword")
 	r.assertMethodAllowed(c, "UserManager", 1, "UserInfo")
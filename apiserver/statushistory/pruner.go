@@ -4,35 +4,46 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 )
 
 func init() {
-	common.RegisterStandardFacade("StatusHistory", 2, NewAPI)
+	common.RegisterStandardFacade("StatusHistory", 3, NewAPI)
 }
 
 // API is the concrete implementation of the Pruner endpoint..
 type API struct {
+	*common.ModelWatcher
 	st         *state.State
 	authorizer common.Authorizer
 }
 
 // NewAPI returns an API Instance.
-func NewAPI(st *state.State, _ *common.Resources, auth common.Authorizer) (*API, error) {
+func NewAPI(st *state.State, resources *common.Resources, auth common.Authorizer) (*API, error) {
+	if !auth.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
 	return &API{
-		st:         st,
-		authorizer: auth,
+		ModelWatcher: common.NewModelWatcher(st, resources, auth),
+		st:           st,
+		authorizer:   auth,
 	}, nil
 }
 
-// Prune endpoint removes status history entries until
+// Prune endpoint removes status history entries of the model until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
-func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
+// the model's history is smaller than p.MaxHistoryMB.
+func (api *API) Prune(p params.StatusHistoryPruneArgs) (params.StatusHistoryPruneResult, error) {
 	if !api.authorizer.AuthModelManager() {
-		return common.ErrPerm
+		return params.StatusHistoryPruneResult{}, common.ErrPerm
+	}
+	removed, err := state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	if err != nil {
+		return params.StatusHistoryPruneResult{}, errors.Trace(err)
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	return params.StatusHistoryPruneResult{Removed: removed}, nil
 }
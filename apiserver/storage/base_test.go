@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/storage"
 	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	jujustorage "github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
@@ -84,6 +85,12 @@ const (
 	addStorageForUnitCall                   = "addStorageForUnit"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
+	detachStorageCall                       = "detachStorage"
+	attachStorageCall                       = "attachStorage"
+	resizeStorageCall                       = "resizeStorage"
+	addExistingVolumeCall                   = "addExistingVolume"
+	validateExistingVolumeCall              = "validateExistingVolume"
+	modelConfigCall                         = "modelConfig"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -227,6 +234,32 @@ func (s *baseStorageSuite) constructState() *mockState {
 			val, found := s.blocks[t]
 			return val, found, nil
 		},
+		detachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, detachStorageCall)
+			return nil
+		},
+		attachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, attachStorageCall)
+			return nil
+		},
+		resizeStorage: func(storage names.StorageTag, size uint64) error {
+			s.calls = append(s.calls, resizeStorageCall)
+			return nil
+		},
+		validateExistingVolume: func(poolName, volumeId, storageName string) error {
+			s.calls = append(s.calls, validateExistingVolumeCall)
+			return nil
+		},
+		addExistingVolume: func(info state.VolumeInfo, storageName string) (names.StorageTag, error) {
+			s.calls = append(s.calls, addExistingVolumeCall)
+			return names.NewStorageTag(storageName + "/0"), nil
+		},
+		modelConfig: func() (*config.Config, error) {
+			s.calls = append(s.calls, modelConfigCall)
+			return config.New(config.UseDefaults, coretesting.FakeConfig())
+		},
+		modelTag:       coretesting.ModelTag,
+		controllerUUID: coretesting.ModelTag.Id(),
 	}
 }
 
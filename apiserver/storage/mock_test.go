@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	jujustorage "github.com/juju/juju/storage"
@@ -46,6 +47,7 @@ type mockState struct {
 	storageInstanceFilesystem           func(names.StorageTag) (state.Filesystem, error)
 	storageInstanceFilesystemAttachment func(m names.MachineTag, f names.FilesystemTag) (state.FilesystemAttachment, error)
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	watchFilesystem                     func(names.FilesystemTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices                   func(names.MachineTag) state.NotifyWatcher
@@ -61,6 +63,14 @@ type mockState struct {
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
+	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	resizeStorage                       func(names.StorageTag, uint64) error
+	validateExistingVolume              func(string, string, string) error
+	addExistingVolume                   func(state.VolumeInfo, string) (names.StorageTag, error)
+	modelConfig                         func() (*config.Config, error)
+	modelTag                            names.ModelTag
+	controllerUUID                      string
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -99,6 +109,10 @@ func (st *mockState) WatchStorageAttachment(s names.StorageTag, u names.UnitTag)
 	return st.watchStorageAttachment(s, u)
 }
 
+func (st *mockState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return st.watchFilesystem(f)
+}
+
 func (st *mockState) WatchFilesystemAttachment(mtag names.MachineTag, f names.FilesystemTag) state.NotifyWatcher {
 	return st.watchFilesystemAttachment(mtag, f)
 }
@@ -151,6 +165,38 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) DetachStorage(s names.StorageTag, u names.UnitTag) error {
+	return st.detachStorage(s, u)
+}
+
+func (st *mockState) AttachStorage(s names.StorageTag, u names.UnitTag) error {
+	return st.attachStorage(s, u)
+}
+
+func (st *mockState) ResizeStorage(s names.StorageTag, size uint64) error {
+	return st.resizeStorage(s, size)
+}
+
+func (st *mockState) ValidateExistingVolume(poolName, volumeId, storageName string) error {
+	return st.validateExistingVolume(poolName, volumeId, storageName)
+}
+
+func (st *mockState) AddExistingVolume(info state.VolumeInfo, storageName string) (names.StorageTag, error) {
+	return st.addExistingVolume(info, storageName)
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return st.modelConfig()
+}
+
+func (st *mockState) ModelTag() names.ModelTag {
+	return st.modelTag
+}
+
+func (st *mockState) ControllerUUID() string {
+	return st.controllerUUID
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -277,8 +323,8 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
-func (m *mockStorageInstance) Owner() names.Tag {
-	return m.owner
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Tag() names.Tag {
@@ -303,7 +349,7 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
-	return m.storage.Owner().(names.UnitTag)
+	return m.storage.owner.(names.UnitTag)
 }
 
 type mockVolumeAttachment struct {
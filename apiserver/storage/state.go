@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -38,6 +39,9 @@ type storageAccess interface {
 	// WatchStorageAttachment is required for storage functionality.
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) state.NotifyWatcher
 
+	// WatchFilesystem is required for storage functionality.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// WatchFilesystemAttachment is required for storage functionality.
 	WatchFilesystemAttachment(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 
@@ -80,6 +84,30 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(names.StorageTag, names.UnitTag) error
+
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(names.StorageTag, names.UnitTag) error
+
+	// ResizeStorage is required for storage resize functionality.
+	ResizeStorage(names.StorageTag, uint64) error
+
+	// ValidateExistingVolume is required for storage import functionality.
+	ValidateExistingVolume(string, string, string) error
+
+	// AddExistingVolume is required for storage import functionality.
+	AddExistingVolume(state.VolumeInfo, string) (names.StorageTag, error)
+
+	// ModelConfig is required for storage import functionality.
+	ModelConfig() (*config.Config, error)
+
+	// ModelTag is required for storage import functionality.
+	ModelTag() names.ModelTag
+
+	// ControllerUUID is required for storage import functionality.
+	ControllerUUID() string
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
@@ -180,9 +181,14 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		}
 	}
 
+	var ownerTag string
+	if owner, ok := si.Owner(); ok {
+		ownerTag = owner.String()
+	}
+
 	return &params.StorageDetails{
 		StorageTag:  si.Tag().String(),
-		OwnerTag:    si.Owner().String(),
+		OwnerTag:    ownerTag,
 		Kind:        params.StorageKind(si.Kind()),
 		Status:      common.EntityStatusFromState(status),
 		Persistent:  persistent,
@@ -693,3 +699,176 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// Detach detaches the specified storage instances from their units,
+// without destroying the storage instances. If no unit is specified for
+// a storage instance, it is detached from the unit that owns it.
+// Detached storage may later be attached to another unit with Attach.
+// A "CHANGE" block can block this operation.
+func (a *API) Detach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	return a.modifyStorageAttachments(args, true, a.storage.DetachStorage)
+}
+
+// Attach attaches the specified detached storage instances to units.
+// A "CHANGE" block can block this operation.
+func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	return a.modifyStorageAttachments(args, false, a.storage.AttachStorage)
+}
+
+func (a *API) modifyStorageAttachments(
+	args params.StorageAttachmentIds,
+	defaultToOwner bool,
+	modify func(names.StorageTag, names.UnitTag) error,
+) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	one := func(id params.StorageAttachmentId) error {
+		storageTag, err := names.ParseStorageTag(id.StorageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var unitTag names.UnitTag
+		if id.UnitTag == "" && defaultToOwner {
+			unitTag, err = a.storageOwnerUnit(storageTag)
+		} else {
+			unitTag, err = names.ParseUnitTag(id.UnitTag)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return modify(storageTag, unitTag)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		if err := one(id); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// storageOwnerUnit returns the tag of the unit that owns the storage
+// instance with the specified tag.
+func (a *API) storageOwnerUnit(tag names.StorageTag) (names.UnitTag, error) {
+	si, err := a.storage.StorageInstance(tag)
+	if err != nil {
+		return names.UnitTag{}, errors.Trace(err)
+	}
+	owner, ok := si.Owner()
+	if !ok {
+		return names.UnitTag{}, errors.Errorf("storage %s is not attached", tag.Id())
+	}
+	unitTag, ok := owner.(names.UnitTag)
+	if !ok {
+		return names.UnitTag{}, errors.NotSupportedf("detaching storage owned by %s", names.ReadableString(owner))
+	}
+	return unitTag, nil
+}
+
+// Resize requests that the specified storage instances be grown to the
+// specified sizes. The resize is carried out asynchronously by the
+// storage provisioner.
+// A "CHANGE" block can block this operation.
+func (a *API) Resize(args params.BulkResizeStorageParams) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err == nil {
+			err = a.storage.ResizeStorage(storageTag, arg.Size)
+		}
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// Import imports existing storage into the model.
+// A "CHANGE" block can block this operation.
+func (a *API) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ImportStorageResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		details, err := a.importStorage(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+func (a *API) importStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
+	if arg.Kind != params.StorageKindBlock {
+		return nil, errors.NotSupportedf("importing storage of kind %q", arg.Kind.String())
+	}
+	if arg.ProviderId == "" {
+		return nil, errors.NotValidf("empty provider ID")
+	}
+	providerType, cfg, err := storagecommon.StoragePoolConfig(arg.Pool, a.poolManager)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return nil, errors.NotSupportedf("importing volumes with %q provider", providerType)
+	}
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeSource, err := provider.VolumeSource(modelConfig, cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeImporter, ok := volumeSource.(storage.VolumeImporter)
+	if !ok {
+		return nil, errors.NotSupportedf("importing volumes with %q provider", providerType)
+	}
+
+	// Check that the model will accept the volume before touching
+	// it in the cloud.
+	if err := a.storage.ValidateExistingVolume(arg.Pool, arg.ProviderId, arg.StorageName); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	resourceTags := tags.ResourceTags(
+		a.storage.ModelTag(),
+		names.NewModelTag(a.storage.ControllerUUID()),
+		modelConfig,
+	)
+	volumeInfo, err := volumeImporter.ImportVolume(arg.ProviderId, resourceTags, arg.Force)
+	if err != nil {
+		return nil, errors.Annotate(err, "importing volume")
+	}
+	storageTag, err := a.storage.AddExistingVolume(state.VolumeInfo{
+		HardwareId: volumeInfo.HardwareId,
+		Size:       volumeInfo.Size,
+		Pool:       arg.Pool,
+		VolumeId:   volumeInfo.VolumeId,
+		Persistent: volumeInfo.Persistent,
+	}, arg.StorageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
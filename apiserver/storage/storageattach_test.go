@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageAttachSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageAttachSuite{})
+
+func (s *storageAttachSuite) attachmentIds() params.StorageAttachmentIds {
+	return params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+		UnitTag:    s.unitTag.String(),
+	}}}
+}
+
+func (s *storageAttachSuite) TestDetach(c *gc.C) {
+	var detached []string
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		detached = append(detached, storage.Id()+":"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Detach(s.attachmentIds())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	c.Assert(detached, jc.DeepEquals, []string{"data/0:mysql/0"})
+	s.assertCalls(c, []string{getBlockForTypeCall, detachStorageCall})
+}
+
+func (s *storageAttachSuite) TestDetachFromOwner(c *gc.C) {
+	var detached []string
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		detached = append(detached, storage.Id()+":"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: s.storageTag.String(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	c.Assert(detached, jc.DeepEquals, []string{"data/0:mysql/0"})
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, detachStorageCall})
+}
+
+func (s *storageAttachSuite) TestDetachError(c *gc.C) {
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		return errors.New("boom")
+	}
+	results, err := s.api.Detach(s.attachmentIds())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *storageAttachSuite) TestDetachInvalidTags(c *gc.C) {
+	results, err := s.api.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: "volume-0",
+		UnitTag:    s.unitTag.String(),
+	}, {
+		StorageTag: s.storageTag.String(),
+		UnitTag:    "machine-0",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
+
+func (s *storageAttachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(s.attachmentIds())
+	s.assertBlocked(c, err, "TestDetachBlocked")
+}
+
+func (s *storageAttachSuite) TestAttach(c *gc.C) {
+	var attached []string
+	s.state.attachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, attachStorageCall)
+		attached = append(attached, storage.Id()+":"+unit.Id())
+		return nil
+	}
+	results, err := s.api.Attach(s.attachmentIds())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	c.Assert(attached, jc.DeepEquals, []string{"data/0:mysql/0"})
+	s.assertCalls(c, []string{getBlockForTypeCall, attachStorageCall})
+}
+
+func (s *storageAttachSuite) TestAttachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAttachBlocked")
+	_, err := s.api.Attach(s.attachmentIds())
+	s.assertBlocked(c, err, "TestAttachBlocked")
+}
@@ -0,0 +1,186 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	"github.com/juju/juju/storage/provider/registry"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type storageImportSuite struct {
+	baseStorageSuite
+
+	volumeSource *dummy.VolumeSource
+}
+
+var _ = gc.Suite(&storageImportSuite{})
+
+func (s *storageImportSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+
+	s.volumeSource = &dummy.VolumeSource{
+		ImportVolumeFunc: func(volumeId string, resourceTags map[string]string, force bool) (jujustorage.VolumeInfo, error) {
+			return jujustorage.VolumeInfo{
+				VolumeId:   volumeId,
+				HardwareId: "hw",
+				Size:       1024,
+				Persistent: true,
+			}, nil
+		},
+	}
+	registry.RegisterProvider("importer", &dummy.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*config.Config, *jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	})
+	registry.RegisterProvider("filesystems-only", &dummy.StorageProvider{
+		SupportsFunc: func(kind jujustorage.StorageKind) bool {
+			return kind == jujustorage.StorageKindFilesystem
+		},
+	})
+	s.AddCleanup(func(*gc.C) {
+		registry.RegisterProvider("importer", nil)
+		registry.RegisterProvider("filesystems-only", nil)
+	})
+
+	pool, err := jujustorage.NewConfig("pool", "importer", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["pool"] = pool
+}
+
+func (s *storageImportSuite) TestImport(c *gc.C) {
+	var added []state.VolumeInfo
+	s.state.addExistingVolume = func(info state.VolumeInfo, storageName string) (names.StorageTag, error) {
+		s.calls = append(s.calls, addExistingVolumeCall)
+		c.Assert(storageName, gc.Equals, "data")
+		added = append(added, info)
+		return names.NewStorageTag("data/1"), nil
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "pool",
+		ProviderId:  "vol-ume",
+		StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ImportStorageResults{[]params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{StorageTag: "storage-data-1"},
+	}}})
+	c.Assert(added, jc.DeepEquals, []state.VolumeInfo{{
+		HardwareId: "hw",
+		Size:       1024,
+		Pool:       "pool",
+		VolumeId:   "vol-ume",
+		Persistent: true,
+	}})
+	s.assertCalls(c, []string{getBlockForTypeCall, modelConfigCall, validateExistingVolumeCall, addExistingVolumeCall})
+	s.volumeSource.CheckCalls(c, []jujutesting.StubCall{{
+		"ImportVolume", []interface{}{"vol-ume", map[string]string{
+			"juju-model-uuid":      coretesting.ModelTag.Id(),
+			"juju-controller-uuid": coretesting.ModelTag.Id(),
+		}, false},
+	}})
+}
+
+func (s *storageImportSuite) TestImportForce(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "pool",
+		ProviderId:  "vol-ume",
+		StorageName: "data",
+		Force:       true,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.volumeSource.CheckCall(c, 0, "ImportVolume", "vol-ume", map[string]string{
+		"juju-model-uuid":      coretesting.ModelTag.Id(),
+		"juju-controller-uuid": coretesting.ModelTag.Id(),
+	}, true)
+}
+
+func (s *storageImportSuite) TestImportValidatesBeforeImportingVolume(c *gc.C) {
+	s.state.validateExistingVolume = func(poolName, volumeId, storageName string) error {
+		s.calls = append(s.calls, validateExistingVolumeCall)
+		c.Assert(poolName, gc.Equals, "pool")
+		c.Assert(volumeId, gc.Equals, "vol-ume")
+		c.Assert(storageName, gc.Equals, "data")
+		return errors.AlreadyExistsf("volume with provider ID %q", volumeId)
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "pool",
+		ProviderId:  "vol-ume",
+		StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `volume with provider ID "vol-ume" already exists`)
+	s.assertCalls(c, []string{getBlockForTypeCall, modelConfigCall, validateExistingVolumeCall})
+	s.volumeSource.CheckNoCalls(c)
+}
+
+func (s *storageImportSuite) TestImportFilesystemNotSupported(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindFilesystem,
+		Pool:        "pool",
+		ProviderId:  "fs-id",
+		StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `importing storage of kind "filesystem" not supported`)
+}
+
+func (s *storageImportSuite) TestImportProviderNotSupported(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "filesystems-only",
+		ProviderId:  "vol-ume",
+		StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `importing volumes with "filesystems-only" provider not supported`)
+}
+
+func (s *storageImportSuite) TestImportVolumeError(c *gc.C) {
+	s.volumeSource.ImportVolumeFunc = func(string, map[string]string, bool) (jujustorage.VolumeInfo, error) {
+		return jujustorage.VolumeInfo{}, errors.New("no such volume")
+	}
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "pool",
+		ProviderId:  "vol-ume",
+		StorageName: "data",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "importing volume: no such volume")
+	s.assertCalls(c, []string{getBlockForTypeCall, modelConfigCall, validateExistingVolumeCall})
+}
+
+func (s *storageImportSuite) TestImportBlocked(c *gc.C) {
+	s.blockAllChanges(c, "import")
+	_, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "pool",
+		ProviderId:  "vol-ume",
+		StorageName: "data",
+	}}})
+	s.assertBlocked(c, err, "import")
+}
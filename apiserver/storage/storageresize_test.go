@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) TestResize(c *gc.C) {
+	var resized []names.StorageTag
+	var sizes []uint64
+	s.state.resizeStorage = func(storage names.StorageTag, size uint64) error {
+		s.calls = append(s.calls, resizeStorageCall)
+		resized = append(resized, storage)
+		sizes = append(sizes, size)
+		return nil
+	}
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{}}})
+	c.Assert(resized, jc.DeepEquals, []names.StorageTag{s.storageTag})
+	c.Assert(sizes, jc.DeepEquals, []uint64{2048})
+	s.assertCalls(c, []string{getBlockForTypeCall, resizeStorageCall})
+}
+
+func (s *storageResizeSuite) TestResizeErrors(c *gc.C) {
+	s.state.resizeStorage = func(storage names.StorageTag, size uint64) error {
+		s.calls = append(s.calls, resizeStorageCall)
+		return errors.New("foo")
+	}
+	results, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: "volume-0",
+		Size:       2048,
+	}, {
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "foo")
+	s.assertCalls(c, []string{getBlockForTypeCall, resizeStorageCall})
+}
+
+func (s *storageResizeSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeBlocked")
+	_, err := s.api.Resize(params.BulkResizeStorageParams{[]params.ResizeStorageParams{{
+		StorageTag: s.storageTag.String(),
+		Size:       2048,
+	}}})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
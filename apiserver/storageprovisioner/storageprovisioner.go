@@ -70,6 +70,18 @@ func NewStorageProvisionerAPI(st *state.State, resources *common.Resources, auth
 		// machine as a parent are accessible by it.
 		return names.NewMachineTag(parentId) == authEntityTag
 	}
+	// isAttachedToAuthMachine reports whether the filesystem is attached
+	// to the authenticated machine. A volume-backed filesystem keeps the
+	// scope of the machine it was created on when its storage is moved
+	// to another machine.
+	isAttachedToAuthMachine := func(tag names.FilesystemTag) bool {
+		machineTag, ok := authorizer.GetAuthTag().(names.MachineTag)
+		if !ok {
+			return false
+		}
+		_, err := st.FilesystemAttachment(machineTag, tag)
+		return err == nil
+	}
 	getScopeAuthFunc := func() (common.AuthFunc, error) {
 		return func(tag names.Tag) bool {
 			switch tag := tag.(type) {
@@ -96,7 +108,8 @@ func NewStorageProvisionerAPI(st *state.State, resources *common.Resources, auth
 		case names.FilesystemTag:
 			machineTag, ok := names.FilesystemMachine(tag)
 			if ok {
-				return canAccessStorageMachine(machineTag, false)
+				return canAccessStorageMachine(machineTag, false) ||
+					isAttachedToAuthMachine(tag)
 			}
 			return authorizer.AuthModelManager()
 		case names.MachineTag:
@@ -261,6 +274,22 @@ func (s *StorageProvisionerAPI) WatchVolumes(args params.Entities) (params.Strin
 	return s.watchStorageEntities(args, s.st.WatchModelVolumes, s.st.WatchMachineVolumes)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, that may require the volumes
+// to be resized. Unlike WatchVolumes, all changes to the volumes are
+// reported, not just lifecycle changes.
+func (s *StorageProvisionerAPI) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the tag passed to NewState, that may require the
+// filesystems to be resized. Unlike WatchFilesystems, all changes to
+// the filesystems are reported, not just lifecycle changes.
+func (s *StorageProvisionerAPI) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelFilesystemResizes, s.st.WatchMachineFilesystemResizes)
+}
+
 // WatchFilesystems watches for changes to filesystems scoped
 // to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.StringsWatchResults, error) {
@@ -628,6 +657,128 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 	return results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags. If a volume has no pending resize, then
+// its result will be nil.
+func (s *StorageProvisionerAPI) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(arg params.Entity) (*params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return nil, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return nil, common.ErrPerm
+		} else if err != nil {
+			return nil, err
+		}
+		if volume.Life() != state.Alive {
+			return nil, nil
+		}
+		info, err := volume.Info()
+		if errors.IsNotProvisioned(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		size := volume.PendingSize()
+		if size <= info.Size {
+			return nil, nil
+		}
+		providerType, _, err := storagecommon.StoragePoolConfig(info.Pool, poolManager)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  info.VolumeId,
+			Size:      size,
+			Provider:  string(providerType),
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// FilesystemResizeParams returns the parameters for growing the
+// filesystems with the specified tags to fill their backing volumes.
+// If a filesystem has no pending resize, then its result will be nil.
+func (s *StorageProvisionerAPI) FilesystemResizeParams(args params.Entities) (params.FilesystemResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.FilesystemResizeParamsResults{}, err
+	}
+	results := params.FilesystemResizeParamsResults{
+		Results: make([]params.FilesystemResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (*params.FilesystemResizeParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return nil, common.ErrPerm
+		}
+		filesystem, err := s.st.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return nil, common.ErrPerm
+		} else if err != nil {
+			return nil, err
+		}
+		if filesystem.Life() != state.Alive {
+			return nil, nil
+		}
+		info, err := filesystem.Info()
+		if errors.IsNotProvisioned(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		size := filesystem.PendingSize()
+		if size <= info.Size {
+			return nil, nil
+		}
+		volumeTag, err := filesystem.Volume()
+		if errors.Cause(err) == state.ErrNoBackingVolume {
+			// Only volume-backed filesystems are resized.
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &params.FilesystemResizeParams{
+			FilesystemTag: tag.String(),
+			FilesystemId:  info.FilesystemId,
+			VolumeTag:     volumeTag.String(),
+			Size:          size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.FilesystemResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -951,6 +1102,20 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		// The storage provisioner does not know the volume's pool.
+		// If the volume has already been provisioned, e.g. it is
+		// being resized, then the existing pool is retained.
+		volume, err := s.st.Volume(volumeTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if info, err := volume.Info(); err == nil {
+			volumeInfo.Pool = info.Pool
+		} else if !errors.IsNotProvisioned(err) {
+			return errors.Trace(err)
+		}
 		err = s.st.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -980,6 +1145,19 @@ func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (para
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		// As for volumes, the existing pool of an already
+		// provisioned filesystem is retained.
+		filesystem, err := s.st.Filesystem(filesystemTag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if info, err := filesystem.Info(); err == nil {
+			filesystemInfo.Pool = info.Pool
+		} else if !errors.IsNotProvisioned(err) {
+			return errors.Trace(err)
+		}
 		err = s.st.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -993,6 +1171,32 @@ func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (para
 	return results, nil
 }
 
+// CancelVolumeResizes cancels the specified pending volume resizes,
+// along with the pending resizes of the filesystems backed by the
+// volumes. The storage provisioner cancels resizes that the volumes'
+// storage providers cannot carry out.
+func (s *StorageProvisionerAPI) CancelVolumeResizes(args params.VolumeResizes) (params.ErrorResults, error) {
+	canAccessVolume, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Resizes)),
+	}
+	one := func(arg params.VolumeResizeParams) error {
+		volumeTag, err := names.ParseVolumeTag(arg.VolumeTag)
+		if err != nil || !canAccessVolume(volumeTag) {
+			return common.ErrPerm
+		}
+		return errors.Trace(s.st.CancelVolumeResize(volumeTag, arg.Size))
+	}
+	for i, arg := range args.Resizes {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // SetVolumeAttachmentInfo records the details of newly provisioned volume
 // attachments.
 func (s *StorageProvisionerAPI) SetVolumeAttachmentInfo(
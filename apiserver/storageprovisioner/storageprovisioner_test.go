@@ -44,9 +44,11 @@ func (s *provisionerSuite) SetUpSuite(c *gc.C) {
 
 	registry.RegisterProvider("environscoped", &dummy.StorageProvider{
 		StorageScope: storage.ScopeEnviron,
+		IsResizable:  true,
 	})
 	registry.RegisterProvider("machinescoped", &dummy.StorageProvider{
 		StorageScope: storage.ScopeMachine,
+		IsResizable:  true,
 	})
 	registry.RegisterEnvironStorageProviders(
 		"dummy", "environscoped", "machinescoped",
@@ -420,6 +422,106 @@ func (s *provisionerSuite) TestVolumeParamsEmptyArgs(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 0)
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-0-0"},
+			{"volume-1"},
+			{"volume-2"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{},
+			{},
+			{Result: &params.VolumeResizeParams{
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Size:      8192,
+				Provider:  "environscoped",
+			}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+// setupVolumeBackedFilesystem adds a unit with provisioned, volume-backed
+// filesystem storage, and requests that the storage be resized.
+func (s *provisionerSuite) setupVolumeBackedFilesystem(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	service := s.AddTestingServiceWithStorage(c, "storage-filesystem", ch, map[string]state.StorageConstraints{
+		"data": {Pool: "loop", Size: 1024, Count: 1},
+	})
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machineTag := names.NewMachineTag("0")
+	volumeTag := names.NewVolumeTag("0/0")
+	filesystemTag := names.NewFilesystemTag("0/0")
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volumeTag, state.VolumeAttachmentInfo{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{FilesystemId: "fs", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeStorage(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *provisionerSuite) TestFilesystemResizeParams(c *gc.C) {
+	s.setupVolumeBackedFilesystem(c)
+	results, err := s.api.FilesystemResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"filesystem-0-0"},
+			{"volume-0-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemResizeParamsResults{
+		Results: []params.FilesystemResizeParamsResult{
+			{Result: &params.FilesystemResizeParams{
+				FilesystemTag: "filesystem-0-0",
+				FilesystemId:  "fs",
+				VolumeTag:     "volume-0-0",
+				Size:          2048,
+			}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestCancelVolumeResizes(c *gc.C) {
+	s.setupVolumeBackedFilesystem(c)
+	results, err := s.api.CancelVolumeResizes(params.VolumeResizes{
+		Resizes: []params.VolumeResizeParams{
+			{VolumeTag: "volume-0-0", Size: 2048},
+			{VolumeTag: "filesystem-0-0", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volume.PendingSize(), gc.Equals, uint64(0))
+	filesystem, err := s.State.Filesystem(names.NewFilesystemTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystem.PendingSize(), gc.Equals, uint64(0))
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -586,6 +688,39 @@ func (s *provisionerSuite) TestFilesystemAttachmentParams(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestSetVolumeInfoResized(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetVolumeInfo(params.Volumes{
+		Volumes: []params.Volume{{
+			VolumeTag: "volume-2",
+			Info: params.VolumeInfo{
+				VolumeId:   "def",
+				HardwareId: "456",
+				Size:       8192,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volume.PendingSize(), gc.Equals, uint64(0))
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeInfo{
+		VolumeId:   "def",
+		HardwareId: "456",
+		Size:       8192,
+		Pool:       "environscoped",
+	})
+}
+
 func (s *provisionerSuite) TestSetVolumeAttachmentInfo(c *gc.C) {
 	s.setupVolumes(c)
 
@@ -722,6 +857,41 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+	defer statetesting.AssertStop(c, s.resources.Get("1"))
+
+	// Requesting a resize of an environ-scoped volume triggers
+	// the environ-scoped watcher.
+	wc := statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	err = s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("2")
+	wc.AssertNoChange()
+}
+
 func (s *provisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
@@ -0,0 +1,199 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// The statuses reported for units in a goal state.
+const (
+	// goalStateWaiting means the unit has not yet been deployed.
+	goalStateWaiting = "waiting"
+
+	// goalStateJoining means the unit is running but has not yet
+	// entered the scope of the relation.
+	goalStateJoining = "joining"
+
+	// goalStateActive means the unit is running and, for related
+	// units, has entered the scope of the relation.
+	goalStateActive = "active"
+
+	// goalStateDying means the unit is going away.
+	goalStateDying = "dying"
+)
+
+// GoalStates returns, for each given unit, the units its application
+// is expected to have and the units expected on each of its relations,
+// so that charms can tell whether more units are still to come.
+func (u *UniterAPIV3) GoalStates(args params.Entities) (params.GoalStateResults, error) {
+	result := params.GoalStateResults{
+		Results: make([]params.GoalStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.GoalStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		goalState, err := u.goalState(unit)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Result = goalState
+	}
+	return result, nil
+}
+
+// goalState returns the goal state of the topology around the unit.
+func (u *UniterAPIV3) goalState(unit *state.Unit) (*params.GoalState, error) {
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.GoalState{
+		Units:     make(params.UnitsGoalState),
+		Relations: make(map[string]params.UnitsGoalState),
+	}
+	for _, other := range units {
+		if other.Life() == state.Dead {
+			continue
+		}
+		unitStatus, err := unitGoalStatus(other, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Units[other.Name()] = params.GoalStateStatus{Status: unitStatus}
+	}
+
+	relations, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		if rel.Life() == state.Dead {
+			continue
+		}
+		local, err := rel.Endpoint(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relUnits, ok := result.Relations[local.Name]
+		if !ok {
+			relUnits = make(params.UnitsGoalState)
+			result.Relations[local.Name] = relUnits
+		}
+		if err := u.addRelatedGoalStates(relUnits, rel, local, unit); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+// addRelatedGoalStates records the goal state status of each unit
+// expected on the other side of the relation from unit. Units of
+// container-scoped relations are only expected when they share the
+// unit's container.
+func (u *UniterAPIV3) addRelatedGoalStates(
+	relUnits params.UnitsGoalState,
+	rel *state.Relation,
+	local state.Endpoint,
+	unit *state.Unit,
+) error {
+	related, err := rel.RelatedEndpoints(local.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	container := unitContainer(unit)
+	for _, ep := range related {
+		relatedApp, err := u.st.Application(ep.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		relatedUnits, err := relatedApp.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, other := range relatedUnits {
+			if other.Life() == state.Dead {
+				continue
+			}
+			if local.Scope == charm.ScopeContainer && unitContainer(other) != container {
+				continue
+			}
+			ru, err := rel.Unit(other)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			unitStatus, err := unitGoalStatus(other, ru)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			relUnits[other.Name()] = params.GoalStateStatus{Status: unitStatus}
+		}
+	}
+	return nil
+}
+
+// unitGoalStatus returns the goal state status of the unit. If ru is
+// not nil, the status reflects whether the unit has joined the
+// relation.
+func unitGoalStatus(unit *state.Unit, ru *state.RelationUnit) (string, error) {
+	if unit.Life() != state.Alive {
+		return goalStateDying, nil
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if agentStatus.Status == status.StatusAllocating {
+		return goalStateWaiting, nil
+	}
+	if ru == nil {
+		return goalStateActive, nil
+	}
+	inScope, err := ru.InScope()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !inScope {
+		return goalStateJoining, nil
+	}
+	return goalStateActive, nil
+}
+
+// unitContainer returns the name of the principal unit whose container
+// the unit runs in.
+func unitContainer(unit *state.Unit) string {
+	if principal, ok := unit.PrincipalName(); ok {
+		return principal
+	}
+	return unit.Name()
+}
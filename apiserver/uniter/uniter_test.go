@@ -835,6 +835,104 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestState(c *gc.C) {
+	err := s.wordpressUnit.SetState(map[string]string{"db-host": "10.0.0.5"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.State(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UnitStateResults{
+		Results: []params.UnitStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{State: map[string]string{"db-host": "10.0.0.5"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetState(c *gc.C) {
+	args := params.SetUnitStateArgs{Args: []params.SetUnitStateArg{
+		{Tag: "unit-mysql-0", State: map[string]string{"a": "1"}},
+		{Tag: "unit-wordpress-0", State: map[string]string{"b": "2"}},
+		{Tag: "unit-foo-42", State: map[string]string{"c": "3"}},
+	}}
+	result, err := s.uniter.SetState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	unitState, err := s.wordpressUnit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"b": "2"})
+}
+
+func (s *uniterSuite) TestGoalStates(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	now := time.Now()
+	err := s.mysqlUnit.SetAgentStatus(status.StatusInfo{
+		Status: status.StatusIdle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.GoalStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.GoalStateResults{
+		Results: []params.GoalStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: &params.GoalState{
+				Units: params.UnitsGoalState{
+					"wordpress/0": {Status: "waiting"},
+				},
+				Relations: map[string]params.UnitsGoalState{
+					"db": {"mysql/0": {Status: "joining"}},
+				},
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+
+	// Once the related unit enters scope it is reported as active,
+	// and it is reported as dying once it starts going away.
+	relUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertGoalStateRelation(c, params.UnitsGoalState{"mysql/0": {Status: "active"}})
+
+	err = s.mysqlUnit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertGoalStateRelation(c, params.UnitsGoalState{"mysql/0": {Status: "dying"}})
+}
+
+func (s *uniterSuite) assertGoalStateRelation(c *gc.C, expected params.UnitsGoalState) {
+	args := params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}}
+	result, err := s.uniter.GoalStates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.Relations["db"], jc.DeepEquals, expected)
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -882,6 +980,28 @@ func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	args := params.EntitiesPortRanges{Entities: []params.EntityPortRange{
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80, Endpoint: "url"},
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 443, ToPort: 443, Endpoint: "missing"},
+	}}
+	result, err := s.uniter.OpenPorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `.*endpoint "missing" not found`)
+
+	machineId, err := s.wordpressUnit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{80, 80, "tcp"}: "url",
+	})
+}
+
 func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	// Open port udp:4321 in advance on wordpressUnit.
 	err := s.wordpressUnit.OpenPorts("udp", 4321, 5000)
@@ -1311,6 +1431,37 @@ func (s *uniterSuite) TestFinishActionsFailure(c *gc.C) {
 	c.Assert(results[0].Name(), gc.Equals, testName)
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	good, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: good.ActionTag().String(), Value: "halfway there"},
+			{Tag: bad.ActionTag().String(), Value: "halfway there"},
+			{Tag: pending.ActionTag().String(), Value: "halfway there"},
+		},
+	}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(res.Results[2].Error, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+
+	good, err = s.State.Action(good.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := good.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "halfway there")
+}
+
 func (s *uniterSuite) TestFinishActionsAuthAccess(c *gc.C) {
 	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -468,6 +468,7 @@ func (w *srvMigrationStatusWatcher) Next() (params.MigrationStatus, error) {
 	}
 
 	return params.MigrationStatus{
+		MigrationId:    mig.Id(),
 		Attempt:        attempt,
 		Phase:          phase.String(),
 		SourceAPIAddrs: sourceAddrs,
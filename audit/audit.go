@@ -39,10 +39,60 @@ type AuditEntry struct {
 	// Operation is the operation that was performed that triggered
 	// the audit event.
 	Operation string
+	// Facade, FacadeVersion and Method identify the API call which
+	// triggered the audit event, if any.
+	Facade        string
+	FacadeVersion int
+	Method        string
+	// Duration is how long the operation took to complete.
+	Duration time.Duration
+	// ErrorCode is the code of the error the operation failed with,
+	// if any.
+	ErrorCode string
+	// ErrorMessage is the message of the error the operation failed
+	// with, if any.
+	ErrorMessage string
 	// Data is a catch-all for storing random data.
 	Data map[string]interface{}
 }
 
+// Outcome returns "ok" if the audited operation succeeded; otherwise
+// it returns the code of the error it failed with, or "error" if the
+// error had no code.
+func (e AuditEntry) Outcome() string {
+	switch {
+	case e.ErrorMessage == "" && e.ErrorCode == "":
+		return "ok"
+	case e.ErrorCode != "":
+		return e.ErrorCode
+	default:
+		return "error"
+	}
+}
+
+// Filter selects which audit entries are read back from a store.
+// Zero-valued fields match every entry.
+type Filter struct {
+	// ModelUUID restricts entries to those written on the model
+	// with this ID.
+	ModelUUID string
+	// OriginName restricts entries to those triggered by this
+	// origin, e.g. a user tag.
+	OriginName string
+	// Operation restricts entries to those whose operation
+	// contains this text, ignoring case.
+	Operation string
+	// From restricts entries to those generated at or after this
+	// time.
+	From time.Time
+	// To restricts entries to those generated at or before this
+	// time.
+	To time.Time
+	// Limit is the maximum number of entries to return, starting
+	// from the most recent.
+	Limit int
+}
+
 // Validate ensures that the entry considers itself to be in a
 // complete and valid state.
 func (e AuditEntry) Validate() error {
@@ -103,6 +103,15 @@ func (s *auditSuite) TestValidate_EmptyJujuServerVersionErrors(c *gc.C) {
 	c.Check(validationErr, gc.ErrorMatches, "JujuServerVersion not assigned")
 }
 
+func (s *auditSuite) TestOutcome(c *gc.C) {
+	entry := validEntry()
+	c.Check(entry.Outcome(), gc.Equals, "ok")
+	entry.ErrorMessage = "boom"
+	c.Check(entry.Outcome(), gc.Equals, "error")
+	entry.ErrorCode = "not found"
+	c.Check(entry.Outcome(), gc.Equals, "not found")
+}
+
 func validEntry() audit.AuditEntry {
 	return audit.AuditEntry{
 		JujuServerVersion: version.MustParse("1.0.0"),
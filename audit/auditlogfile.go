@@ -65,6 +65,8 @@ func (a *auditLogFileSink) handle(entry AuditEntry) error {
 		entry.OriginName,
 		entry.OriginType,
 		entry.Operation,
+		entry.Outcome(),
+		entry.Duration.String(),
 		fmt.Sprintf("%v", entry.Data),
 	}, ",") + "\n"))
 	return err
@@ -49,6 +49,9 @@ func (s *auditLogFileSuite) TestLogging(c *gc.C) {
 		OriginType:    "API",
 		OriginName:    "user-admin",
 		Operation:     "status",
+		Duration:      1500 * time.Millisecond,
+		ErrorCode:     "unauthorized access",
+		ErrorMessage:  "permission denied",
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -56,8 +59,8 @@ func (s *auditLogFileSuite) TestLogging(c *gc.C) {
 	logPath := filepath.Join(dir, "audit.log")
 	logContents, err := ioutil.ReadFile(logPath)
 	c.Assert(err, jc.ErrorIsNil)
-	line0 := "2015-06-01 23:02:01," + modelUUID + ",10.0.0.1,user-admin,API,deploy,map[foo:bar]\n"
-	line1 := "2015-06-01 23:02:02," + modelUUID + ",10.0.0.2,user-admin,API,status,map[]\n"
+	line0 := "2015-06-01 23:02:01," + modelUUID + ",10.0.0.1,user-admin,API,deploy,ok,0s,map[foo:bar]\n"
+	line1 := "2015-06-01 23:02:02," + modelUUID + ",10.0.0.2,user-admin,API,status,unauthorized access,1.5s,map[]\n"
 	c.Assert(string(logContents), gc.Equals, line0+line1)
 
 	// Check the file mode is as expected. This doesn't work on
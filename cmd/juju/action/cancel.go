@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewCancelCommand() cmd.Command {
+	return modelcmd.Wrap(&cancelCommand{})
+}
+
+// cancelCommand cancels queued Actions by ID.
+type cancelCommand struct {
+	ActionCommandBase
+	out          cmd.Output
+	requestedIds []string
+}
+
+const cancelDoc = `
+Cancel the actions with the given IDs, so that they are never run. Partial
+IDs may also be used. Only actions which are still queued can be cancelled;
+actions which are already running or have finished are left as they are.
+
+Examples:
+
+$ juju cancel-action 1d3f
+actions:
+- id: 1d3fcb80-f89c-4f09-8aa6-a2b5e3e0a6de
+  status: cancelled
+  unit: mysql/3
+`
+
+// SetFlags offers an option for YAML output.
+func (c *cancelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+func (c *cancelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "cancel-action",
+		Args:    "<action ID> [<action ID>...]",
+		Purpose: "Cancel queued actions by ID.",
+		Doc:     cancelDoc,
+	}
+}
+
+// Init checks that at least one action ID was given.
+func (c *cancelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action ID specified")
+	}
+	c.requestedIds = args
+	return nil
+}
+
+func (c *cancelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	var tags []names.ActionTag
+	entities := []params.Entity{}
+	for _, id := range c.requestedIds {
+		tag, err := getActionTagByPrefix(api, id)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, tag)
+		entities = append(entities, params.Entity{tag.String()})
+	}
+
+	results, err := api.Cancel(params.Entities{Entities: entities})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(tags) {
+		return errors.Errorf("expected %d results, got %d", len(tags), len(results.Results))
+	}
+
+	failed := false
+	items := []map[string]interface{}{}
+	for i, result := range results.Results {
+		item := resultToMap(result)
+		if result.Error != nil {
+			// Failed results carry no action, so identify them
+			// with the requested tag instead.
+			item["id"] = tags[i].Id()
+			delete(item, "status")
+			failed = true
+		}
+		items = append(items, item)
+	}
+	if err := c.out.Write(ctx, map[string]interface{}{"actions": items}); err != nil {
+		return err
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) runCancel(c *gc.C, client *fakeAPIClient, args ...string) (*cmd.Context, error) {
+	restore := s.patchAPIClient(client)
+	defer restore()
+	args = append([]string{"-m", "admin"}, args...)
+	return testing.RunCommand(c, action.NewCancelCommandForTest(s.store), args...)
+}
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(action.NewCancelCommandForTest(s.store), []string{"-m", "admin"})
+	c.Assert(err, gc.ErrorMatches, "no action ID specified")
+}
+
+func (s *CancelSuite) TestCancel(c *gc.C) {
+	client := &fakeAPIClient{
+		actionTagMatches: tagsForIdPrefix("f47ac", validActionTagString),
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: "unit-mysql-0",
+			},
+			Status: params.ActionCancelled,
+		}},
+	}
+	ctx, err := s.runCancel(c, client, "f47ac")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.cancelledActions, jc.DeepEquals, params.Entities{
+		Entities: []params.Entity{{Tag: validActionTagString}},
+	})
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+actions:
+- id: `+validActionId+`
+  status: cancelled
+  unit: mysql/0
+`[1:])
+}
+
+func (s *CancelSuite) TestCancelFailure(c *gc.C) {
+	client := &fakeAPIClient{
+		actionTagMatches: tagsForIdPrefix("f47ac", validActionTagString),
+		actionResults: []params.ActionResult{{
+			Error: &params.Error{Message: "cannot cancel action: action is running"},
+		}},
+	}
+	ctx, err := s.runCancel(c, client, "f47ac")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+actions:
+- error: 'cannot cancel action: action is running'
+  id: `+validActionId+`
+`[1:])
+}
+
+func (s *CancelSuite) TestCancelUnknownAction(c *gc.C) {
+	_, err := s.runCancel(c, &fakeAPIClient{}, "f47ac")
+	c.Assert(err, gc.ErrorMatches, `actions for identifier "f47ac" not found`)
+}
+
+func (s *CancelSuite) TestCancelAPIError(c *gc.C) {
+	client := &fakeAPIClient{
+		actionTagMatches: tagsForIdPrefix("f47ac", validActionTagString),
+	}
+	client.apiErr = errors.New("boom")
+	_, err := s.runCancel(c, client, "f47ac")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"gopkg.in/juju/names.v2"
 
@@ -15,6 +17,7 @@ import (
 var (
 	NewActionAPIClient = &newAPIClient
 	AddValueToMap      = addValueToMap
+	NewMessagePrinter  = newMessagePrinter
 )
 
 type ShowOutputCommand struct {
@@ -49,6 +52,14 @@ func (c *RunCommand) Args() [][]string {
 	return c.args
 }
 
+func (c *RunCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *RunCommand) Wait() bool {
+	return c.wait
+}
+
 type ListCommand struct {
 	*listCommand
 }
@@ -67,6 +78,12 @@ func NewShowOutputCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Sh
 	return modelcmd.Wrap(c), &ShowOutputCommand{c}
 }
 
+func NewCancelCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &cancelCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.ModelSkipDefault)
+}
+
 func NewStatusCommandForTest(store jujuclient.ClientStore) (cmd.Command, *StatusCommand) {
 	c := &statusCommand{}
 	c.SetClientStore(store)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -33,6 +34,8 @@ type runCommand struct {
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
+	timeout      time.Duration
+	wait         bool
 	out          cmd.Output
 	args         [][]string
 }
@@ -54,6 +57,12 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+If --timeout is passed, the action is killed and marked as failed if it is
+still running once the given duration has passed since it started.
+
+If --wait is passed, the command blocks until the action has finished and
+then shows its results, as 'juju show-action-output <ID>' would.
+
 Examples:
 
 $ juju run-action mysql/3 backup 
@@ -104,6 +113,13 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/3 backup --timeout 1h --wait
+id: <ID>
+results:
+  ...
+status: completed
+...
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -114,6 +130,8 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "Kill the action if it runs for longer than this")
+	f.BoolVar(&c.wait, "wait", false, "Wait for the action to finish and show its results")
 }
 
 func (c *runCommand) Info() *cmd.Info {
@@ -127,6 +145,9 @@ func (c *runCommand) Info() *cmd.Info {
 
 // Init gets the unit tag, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no unit specified")
@@ -233,6 +254,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -259,6 +281,20 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 
+	if c.wait {
+		// A timer that has already fired and been drained never
+		// fires again, so the results are waited for indefinitely.
+		wait := time.NewTimer(0)
+		<-wait.C
+		result, err := GetActionResult(api, tag.Id(), wait)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		output := FormatActionResult(result)
+		output["id"] = tag.Id()
+		return c.out.Write(ctx, output)
+	}
+
 	output := map[string]string{"Action queued with id": tag.Id()}
 	return c.out.Write(ctx, output)
 }
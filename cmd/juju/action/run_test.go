@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	jc "github.com/juju/testing/checkers"
@@ -399,3 +400,44 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestInitTimeoutAndWait(c *gc.C) {
+	wrappedCommand, command := action.NewRunCommandForTest(s.store)
+	err := testing.InitCommand(wrappedCommand, []string{"-m", "admin", "--timeout", "90s", "--wait", validUnitId, "backup"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(command.Timeout(), gc.Equals, 90*time.Second)
+	c.Check(command.Wait(), jc.IsTrue)
+
+	wrappedCommand, _ = action.NewRunCommandForTest(s.store)
+	err = testing.InitCommand(wrappedCommand, []string{"-m", "admin", "--timeout", "-1s", validUnitId, "backup"})
+	c.Check(err, gc.ErrorMatches, "timeout must not be negative")
+}
+
+func (s *RunSuite) TestRunWithTimeoutAndWait(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		delay:   time.NewTimer(0),
+		timeout: time.NewTimer(testing.LongWait),
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{"outfile": "backup.tar.bz2"},
+		}},
+		actionTagMatches: tagsForIdPrefix(validActionId, validActionTagString),
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, wrappedCommand, "-m", "admin", "--timeout", "1h", "--wait", validUnitId, "backup")
+	c.Assert(err, jc.ErrorIsNil)
+
+	enqueued := fakeClient.EnqueuedActions()
+	c.Assert(enqueued.Actions, gc.HasLen, 1)
+	c.Check(enqueued.Actions[0].Timeout, gc.Equals, time.Hour)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+id: `+validActionId+`
+results:
+  outfile: backup.tar.bz2
+status: completed
+`[1:])
+}
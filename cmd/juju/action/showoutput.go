@@ -4,6 +4,8 @@
 package action
 
 import (
+	"fmt"
+	"io"
 	"regexp"
 	"time"
 
@@ -26,6 +28,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -37,12 +40,19 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+Progress messages logged by the action with action-log are shown in the
+results.  To follow them while the action runs, use the --watch flag: each
+message is printed as soon as it is logged, and the results are shown once
+the action has finished.  Unless --wait is also given, --watch waits
+indefinitely.
 `
 
 // Set up the output.
 func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Print progress messages as they are logged")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -81,6 +91,11 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.watch && waitDur < 0 {
+		// Watching without a wait time follows the action until
+		// it finishes.
+		waitDur = 0
+	}
 
 	api, err := c.NewActionAPIClient()
 	if err != nil {
@@ -102,14 +117,59 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
-	result, err := GetActionResult(api, c.requestedId, wait)
+	if !c.watch {
+		result, err := GetActionResult(api, c.requestedId, wait)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, FormatActionResult(result))
+	}
+
+	tick := time.NewTimer(2 * time.Second)
+	result, err := timerLoop(api, c.requestedId, wait, tick, newMessagePrinter(ctx.Stdout))
 	if err != nil {
 		return errors.Trace(err)
 	}
-
+	// The progress messages have already been printed.
+	result.Log = nil
 	return c.out.Write(ctx, FormatActionResult(result))
 }
 
+// newMessagePrinter returns a function which writes to w any progress
+// messages in the given result that it has not already written.
+func newMessagePrinter(w io.Writer) func(params.ActionResult) {
+	// Only the most recent messages are kept in the log, so those
+	// already written are recorded by the time of the last of them,
+	// and the number written which were logged at that time.
+	var last time.Time
+	var printedAtLast int
+	return func(result params.ActionResult) {
+		seenAtLast := 0
+		for _, message := range result.Log {
+			switch {
+			case message.Timestamp.Before(last):
+				continue
+			case message.Timestamp.Equal(last):
+				seenAtLast++
+				if seenAtLast <= printedAtLast {
+					continue
+				}
+			default:
+				last = message.Timestamp
+				printedAtLast, seenAtLast = 0, 1
+			}
+			fmt.Fprintln(w, formatActionMessage(message))
+			printedAtLast++
+		}
+	}
+}
+
+// formatActionMessage returns the progress message prefixed with the
+// time it was logged.
+func formatActionMessage(message params.ActionMessage) string {
+	return fmt.Sprintf("%s %s", message.Timestamp.String(), message.Message)
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -119,13 +179,13 @@ func GetActionResult(api APIClient, requestedId string, wait *time.Timer) (param
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	tick := time.NewTimer(2 * time.Second)
 
-	return timerLoop(api, requestedId, wait, tick)
+	return timerLoop(api, requestedId, wait, tick, nil)
 }
 
 // timerLoop loops indefinitely to query the given API, until "wait" times
-// out, using the "tick" timer to delay the API queries.  It writes the
-// result to the given output.
-func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (params.ActionResult, error) {
+// out, using the "tick" timer to delay the API queries.  If onResult is
+// not nil, it is called with each result fetched.
+func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer, onResult func(params.ActionResult)) (params.ActionResult, error) {
 	var (
 		result params.ActionResult
 		err    error
@@ -138,6 +198,9 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 		if err != nil {
 			return result, err
 		}
+		if onResult != nil {
+			onResult(result)
+		}
 
 		// Whether or not we're waiting for a result, if a completed
 		// result arrives, we're done.
@@ -202,6 +265,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		messages := make([]string, len(result.Log))
+		for i, message := range result.Log {
+			messages[i] = formatActionMessage(message)
+		}
+		response["log"] = messages
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
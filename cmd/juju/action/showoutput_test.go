@@ -167,6 +167,32 @@ timing:
   completed: 2015-02-14 08:15:30 +0000 UTC
   enqueued: 2015-02-14 08:13:00 +0000 UTC
   started: 2015-02-14 08:15:00 +0000 UTC
+`[1:],
+	}, {
+		should:            "pretty-print action progress messages",
+		withClientQueryID: validActionId,
+		withAPITimeout:    10 * time.Second,
+		withTags:          tagsForIdPrefix(validActionId, validActionTagString),
+		withAPIResponse: []params.ActionResult{{
+			Status: "running",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "dumping database",
+			}, {
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+				Message:   "compressing dump",
+			}},
+			Enqueued: time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:  time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		}},
+		expectedOutput: `
+log:
+- 2015-02-14 08:15:10 +0000 UTC dumping database
+- 2015-02-14 08:15:20 +0000 UTC compressing dump
+status: running
+timing:
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  started: 2015-02-14 08:15:00 +0000 UTC
 `[1:],
 	}, {
 		should:            "pretty-print action output with no completed time",
@@ -290,6 +316,81 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestWatch(c *gc.C) {
+	client := makeFakeClient(
+		0,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "completed",
+			Output: map[string]interface{}{"size": "10G"},
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "dumping database",
+			}, {
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+				Message:   "compressing dump",
+			}},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:   time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+2015-02-14 08:15:10 +0000 UTC dumping database
+2015-02-14 08:15:20 +0000 UTC compressing dump
+results:
+  size: 10G
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  started: 2015-02-14 08:15:00 +0000 UTC
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestWatchPrintsMessagesBeyondLogLimit(c *gc.C) {
+	at := func(second int, message string) params.ActionMessage {
+		return params.ActionMessage{
+			Timestamp: time.Date(2015, time.February, 14, 8, 15, second, 0, time.UTC),
+			Message:   message,
+		}
+	}
+	var buf bytes.Buffer
+	printMessages := action.NewMessagePrinter(&buf)
+
+	// The log is at its limit of three messages, so each new message
+	// pushes out the oldest one.
+	printMessages(params.ActionResult{Log: []params.ActionMessage{
+		at(1, "one"), at(1, "two"), at(2, "three"),
+	}})
+	printMessages(params.ActionResult{Log: []params.ActionMessage{
+		at(1, "two"), at(2, "three"), at(2, "four"),
+	}})
+	printMessages(params.ActionResult{Log: []params.ActionMessage{
+		at(2, "four"), at(3, "five"), at(3, "six"),
+	}})
+	printMessages(params.ActionResult{Log: []params.ActionMessage{
+		at(2, "four"), at(3, "five"), at(3, "six"),
+	}})
+	c.Check(buf.String(), gc.Equals, `
+2015-02-14 08:15:01 +0000 UTC one
+2015-02-14 08:15:01 +0000 UTC two
+2015-02-14 08:15:02 +0000 UTC three
+2015-02-14 08:15:02 +0000 UTC four
+2015-02-14 08:15:03 +0000 UTC five
+2015-02-14 08:15:03 +0000 UTC six
+`[1:])
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
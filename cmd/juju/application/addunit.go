@@ -50,8 +50,14 @@ Add a unit of mariadb to LXD container number 3 on machine 24:
 
     juju add-unit mariadb --to 24/lxd/3
 
+Add a unit of postgresql, attaching the storage instance pgdata/0, which
+was previously detached from another unit:
+
+    juju add-unit postgresql --attach-storage pgdata/0
+
 See also: 
-    remove-unit`[1:]
+    remove-unit
+    detach-storage`[1:]
 
 // UnitCommandBase provides support for commands which deploy units. It handles the parsing
 // and validation of --to and --num-units arguments.
@@ -114,6 +120,7 @@ type addUnitCommand struct {
 	modelcmd.ModelCommandBase
 	UnitCommandBase
 	ApplicationName string
+	AttachStorage   []string
 	api             serviceAddUnitAPI
 }
 
@@ -130,6 +137,7 @@ func (c *addUnitCommand) Info() *cmd.Info {
 func (c *addUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.UnitCommandBase.SetFlags(f)
 	f.IntVar(&c.NumUnits, "n", 1, "Number of units to add")
+	f.Var(cmd.NewStringsValue(nil, &c.AttachStorage), "attach-storage", "Existing storage to attach to the deployed unit")
 }
 
 func (c *addUnitCommand) Init(args []string) error {
@@ -142,6 +150,14 @@ func (c *addUnitCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args[1:]); err != nil {
 		return err
 	}
+	if len(c.AttachStorage) > 0 && c.NumUnits != 1 {
+		return errors.New("--attach-storage cannot be used with -n")
+	}
+	for _, id := range c.AttachStorage {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
 	return c.UnitCommandBase.Init(args)
 }
 
@@ -150,7 +166,7 @@ func (c *addUnitCommand) Init(args []string) error {
 type serviceAddUnitAPI interface {
 	Close() error
 	ModelUUID() string
-	AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error)
+	AddUnits(application string, numUnits int, placement []*instance.Placement, attachStorage []string) ([]string, error)
 }
 
 func (c *addUnitCommand) getAPI() (serviceAddUnitAPI, error) {
@@ -179,7 +195,7 @@ func (c *addUnitCommand) Run(_ *cmd.Context) error {
 		}
 		c.Placement[i] = p
 	}
-	_, err = apiclient.AddUnits(c.ApplicationName, c.NumUnits, c.Placement)
+	_, err = apiclient.AddUnits(c.ApplicationName, c.NumUnits, c.Placement, c.AttachStorage)
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
 
@@ -23,11 +23,12 @@ type AddUnitSuite struct {
 }
 
 type fakeServiceAddUnitAPI struct {
-	envType     string
-	application string
-	numUnits    int
-	placement   []*instance.Placement
-	err         error
+	envType       string
+	application   string
+	numUnits      int
+	placement     []*instance.Placement
+	attachStorage []string
+	err           error
 }
 
 func (f *fakeServiceAddUnitAPI) Close() error {
@@ -38,7 +39,7 @@ func (f *fakeServiceAddUnitAPI) ModelUUID() string {
 	return "fake-uuid"
 }
 
-func (f *fakeServiceAddUnitAPI) AddUnits(application string, numUnits int, placement []*instance.Placement) ([]string, error) {
+func (f *fakeServiceAddUnitAPI) AddUnits(application string, numUnits int, placement []*instance.Placement, attachStorage []string) ([]string, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
@@ -48,6 +49,7 @@ func (f *fakeServiceAddUnitAPI) AddUnits(application string, numUnits int, place
 
 	f.numUnits += numUnits
 	f.placement = placement
+	f.attachStorage = attachStorage
 	return nil, nil
 }
 
@@ -83,6 +85,12 @@ var initAddUnitErrorTests = []struct {
 	}, {
 		args: []string{"some-application-name", "--to", "1,#:foo"},
 		err:  `invalid --to parameter "#:foo"`,
+	}, {
+		args: []string{"some-application-name", "-n", "2", "--attach-storage", "data/0"},
+		err:  `--attach-storage cannot be used with -n`,
+	}, {
+		args: []string{"some-application-name", "--attach-storage", "foo"},
+		err:  `storage ID "foo" not valid`,
 	},
 }
 
@@ -125,6 +133,13 @@ func (s *AddUnitSuite) TestAddUnitWithPlacement(c *gc.C) {
 	})
 }
 
+func (s *AddUnitSuite) TestAddUnitAttachStorage(c *gc.C) {
+	err := s.runAddUnit(c, "some-application-name", "--attach-storage", "foo/0,bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.numUnits, gc.Equals, 2)
+	c.Assert(s.fake.attachStorage, jc.DeepEquals, []string{"foo/0", "bar/1"})
+}
+
 func (s *AddUnitSuite) TestBlockAddUnit(c *gc.C) {
 	// Block operation
 	s.fake.err = common.OperationBlockedError("TestBlockAddUnit")
@@ -62,29 +62,8 @@ func deployBundle(
 	log deploymentLogger,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
-	verifyConstraints := func(s string) error {
-		_, err := constraints.Parse(s)
-		return err
-	}
-	verifyStorage := func(s string) error {
-		_, err := storage.ParseConstraints(s)
-		return err
-	}
-	var verifyError error
-	if bundleFilePath == "" {
-		verifyError = data.Verify(verifyConstraints, verifyStorage)
-	} else {
-		verifyError = data.VerifyLocal(bundleFilePath, verifyConstraints, verifyStorage)
-	}
-	if verifyError != nil {
-		if verr, ok := verifyError.(*charm.VerificationError); ok {
-			errs := make([]string, len(verr.Errors))
-			for i, err := range verr.Errors {
-				errs[i] = err.Error()
-			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
-		}
-		return nil, errors.Annotate(verifyError, "cannot deploy bundle")
+	if err := verifyBundle(data, bundleFilePath); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	// Retrieve bundle changes.
@@ -188,6 +167,36 @@ func deployBundle(
 	return csMacs, nil
 }
 
+// verifyBundle checks that the given bundle data is valid. If bundleFilePath
+// is not empty, local charm paths are also checked relative to it.
+func verifyBundle(data *charm.BundleData, bundleFilePath string) error {
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	}
+	var verifyError error
+	if bundleFilePath == "" {
+		verifyError = data.Verify(verifyConstraints, verifyStorage)
+	} else {
+		verifyError = data.VerifyLocal(bundleFilePath, verifyConstraints, verifyStorage)
+	}
+	if verifyError != nil {
+		if verr, ok := verifyError.(*charm.VerificationError); ok {
+			errs := make([]string, len(verr.Errors))
+			for i, err := range verr.Errors {
+				errs[i] = err.Error()
+			}
+			return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+		}
+		return errors.Annotate(verifyError, "cannot deploy bundle")
+	}
+	return nil
+}
+
 // bundleHandler provides helpers and the state required to deploy a bundle.
 type bundleHandler struct {
 	// bundleDir is the path where the bundle file is located for local bundles.
@@ -574,7 +583,7 @@ func (h *bundleHandler) addUnit(id string, p bundlechanges.AddUnitParams) error
 		}
 		placementArg = append(placementArg, placement)
 	}
-	r, err := h.serviceClient.AddUnits(application, 1, placementArg)
+	r, err := h.serviceClient.AddUnits(application, 1, placementArg, nil)
 	if err != nil {
 		return errors.Annotatef(err, "cannot add unit for application %q", application)
 	}
@@ -599,7 +608,7 @@ func (h *bundleHandler) addUnit(id string, p bundlechanges.AddUnitParams) error
 // exposeService exposes an application.
 func (h *bundleHandler) exposeService(id string, p bundlechanges.ExposeParams) error {
 	application := resolve(p.Application, h.results)
-	if err := h.serviceClient.Expose(application); err != nil {
+	if err := h.serviceClient.Expose(application, nil); err != nil {
 		return errors.Annotatef(err, "cannot expose application %s", application)
 	}
 	h.log.Infof("application %s exposed", application)
@@ -83,6 +83,42 @@ deployment of bundle "cs:bundle/wordpress-simple-1" completed`
 	})
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleDryRun(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	ctx, err := coretesting.RunCommand(c, NewDeployCommand(), "bundle/wordpress-simple", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	expectedOutput := `
+ID             CHANGE        DESCRIPTION
+addCharm-0     add-charm     add charm cs:xenial/mysql-42
+deploy-1       deploy        deploy application mysql using cs:xenial/mysql-42
+addCharm-2     add-charm     add charm cs:xenial/wordpress-47
+deploy-3       deploy        deploy application wordpress using cs:xenial/wordpress-47
+addRelation-4  add-relation  add relation wordpress:db - mysql:server
+addUnit-5      add-unit      add unit mysql/0 to new machine
+addUnit-6      add-unit      add unit wordpress/0 to new machine
+`[1:]
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, expectedOutput)
+	s.assertCharmsUploaded(c)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{})
+
+	// Once the bundle is deployed, there is nothing left to do.
+	_, err = runDeployCommand(c, "bundle/wordpress-simple")
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err = coretesting.RunCommand(c, NewDeployCommand(), "bundle/wordpress-simple", "--dry-run", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "changes: []\n")
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployDryRunInvalidFlags(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	_, err := runDeployCommand(c, "xenial/wordpress", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "Flags provided but not supported when deploying a charm: --dry-run.")
+	_, err = runDeployCommand(c, "xenial/wordpress", "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, "--format can only be used with --dry-run")
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleWithTermsSuccess(c *gc.C) {
 	testcharms.UploadCharm(c, s.client, "xenial/terms1-17", "terms1")
 	testcharms.UploadCharm(c, s.client, "xenial/terms2-42", "terms2")
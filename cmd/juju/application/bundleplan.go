@@ -0,0 +1,447 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+// bundlePlan holds the changes that deploying a bundle would apply to the
+// current model.
+type bundlePlan struct {
+	Changes []bundlePlanChange `yaml:"changes" json:"changes"`
+}
+
+// bundlePlanChange describes a single change included in a bundle plan.
+type bundlePlanChange struct {
+	Id          string `yaml:"id" json:"id"`
+	Kind        string `yaml:"kind" json:"kind"`
+	Description string `yaml:"description" json:"description"`
+}
+
+// applicationGetter retrieves the current charm, config and constraints of
+// an application already present in the model.
+type applicationGetter func(application string) (*params.ApplicationGetResults, error)
+
+// charmResolver resolves a charm store URL to the fully qualified URL of the
+// charm that would be deployed.
+type charmResolver func(url *charm.URL) (*charm.URL, error)
+
+// bundlePlanner computes the changes required to deploy a bundle without
+// applying any of them. It reuses the bundle handler logic for choosing
+// machines so that the plan matches what an actual deployment would do.
+type bundlePlanner struct {
+	h *bundleHandler
+
+	// status holds the current model status.
+	status *params.FullStatus
+
+	// getApplication is used to retrieve the current config of existing
+	// applications.
+	getApplication applicationGetter
+
+	// newMachines records the placeholders of machines that would be
+	// created by the deployment.
+	newMachines map[string]bool
+
+	// nextUnit maps application names to the number of the next unit that
+	// would be added.
+	nextUnit map[string]int
+
+	changes []bundlePlanChange
+}
+
+// planBundle returns the changes required to deploy the given bundle data
+// to a model with the given status. Changes that would be no-ops, such as
+// adding units or relations that already exist, are not included.
+func planBundle(
+	bundleFilePath string,
+	data *charm.BundleData,
+	status *params.FullStatus,
+	getApplication applicationGetter,
+	resolveCharm charmResolver,
+) (*bundlePlan, error) {
+	changes := bundlechanges.FromData(data)
+	unitStatus := make(map[string]string)
+	nextUnit := make(map[string]int)
+	for appName, appData := range status.Applications {
+		for unit, unitData := range appData.Units {
+			unitStatus[unit] = unitData.Machine
+			if num, err := unitNumber(unit); err == nil && num >= nextUnit[appName] {
+				nextUnit[appName] = num + 1
+			}
+		}
+	}
+	p := &bundlePlanner{
+		h: &bundleHandler{
+			bundleDir:       bundleFilePath,
+			changes:         changes,
+			results:         make(map[string]string, len(changes)),
+			data:            data,
+			unitStatus:      unitStatus,
+			ignoredMachines: make(map[string]bool, len(data.Applications)),
+			ignoredUnits:    make(map[string]bool, len(data.Applications)),
+		},
+		status:         status,
+		getApplication: getApplication,
+		newMachines:    make(map[string]bool),
+		nextUnit:       nextUnit,
+	}
+
+	// Charms only need to be added when they are used by applications
+	// which are deployed or upgraded.
+	charms := make(map[string]string)
+	for _, change := range changes {
+		change, ok := change.(*bundlechanges.AddCharmChange)
+		if !ok {
+			continue
+		}
+		ch := change.Params.Charm
+		if !isLocalCharmPath(ch) {
+			url, err := charm.ParseURL(ch)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if url, err = resolveCharm(url); err != nil {
+				return nil, errors.Annotatef(err, "cannot resolve URL %q", ch)
+			}
+			ch = url.String()
+		}
+		charms[change.Id()] = ch
+	}
+	requiredCharms := make(map[string]bool)
+	for _, change := range changes {
+		change, ok := change.(*bundlechanges.AddApplicationChange)
+		if !ok {
+			continue
+		}
+		id := strings.TrimPrefix(change.Params.Charm, "$")
+		existing, ok := status.Applications[change.Params.Application]
+		if !ok || !p.sameCharm(charms[id], change.Params.Series, existing.Charm) {
+			requiredCharms[id] = true
+		}
+	}
+
+	for _, change := range changes {
+		var err error
+		switch change := change.(type) {
+		case *bundlechanges.AddCharmChange:
+			p.h.results[change.Id()] = charms[change.Id()]
+			if requiredCharms[change.Id()] {
+				p.add(change.Id(), "add-charm", "add charm %s", charms[change.Id()])
+			}
+		case *bundlechanges.AddMachineChange:
+			err = p.addMachine(change.Id(), change.Params)
+		case *bundlechanges.AddRelationChange:
+			p.addRelation(change.Id(), change.Params)
+		case *bundlechanges.AddApplicationChange:
+			err = p.addApplication(change.Id(), change.Params)
+		case *bundlechanges.AddUnitChange:
+			p.addUnit(change.Id(), change.Params)
+		case *bundlechanges.ExposeChange:
+			p.expose(change.Id(), change.Params)
+		case *bundlechanges.SetAnnotationsChange:
+			p.setAnnotations(change.Id(), change.Params)
+		default:
+			return nil, errors.Errorf("unknown change type: %T", change)
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot plan bundle deployment")
+		}
+	}
+	return &bundlePlan{Changes: p.changes}, nil
+}
+
+// add appends a change to the plan.
+func (p *bundlePlanner) add(id, kind, format string, args ...interface{}) {
+	p.changes = append(p.changes, bundlePlanChange{
+		Id:          id,
+		Kind:        kind,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// sameCharm reports whether the charm declared in the bundle refers to the
+// existing charm, given as a charm URL string.
+func (p *bundlePlanner) sameCharm(bundleCharm, series, existing string) bool {
+	existingURL, err := charm.ParseURL(existing)
+	if err != nil {
+		return false
+	}
+	if isLocalCharmPath(bundleCharm) {
+		charmPath := bundleCharm
+		if !filepath.IsAbs(charmPath) {
+			charmPath = filepath.Join(p.h.bundleDir, charmPath)
+		}
+		if series == "" {
+			series = p.h.data.Series
+		}
+		_, curl, err := charmrepo.NewCharmAtPath(charmPath, series)
+		if err != nil {
+			return false
+		}
+		return existingURL.Schema == curl.Schema && existingURL.Name == curl.Name
+	}
+	curl, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return false
+	}
+	if curl.Schema != existingURL.Schema || curl.User != existingURL.User || curl.Name != existingURL.Name {
+		return false
+	}
+	if curl.Series != "" && curl.Series != existingURL.Series {
+		return false
+	}
+	return curl.Revision == -1 || curl.Revision == existingURL.Revision
+}
+
+// isLocalCharmPath reports whether the given bundle charm reference is a
+// path to a local charm.
+func isLocalCharmPath(ch string) bool {
+	return strings.HasPrefix(ch, ".") || filepath.IsAbs(ch)
+}
+
+// addApplication plans the deployment of a new application, or the charm,
+// config and constraints updates of an existing one.
+func (p *bundlePlanner) addApplication(id string, args bundlechanges.AddApplicationParams) error {
+	p.h.results[id] = args.Application
+	ch := resolve(args.Charm, p.h.results)
+	existing, ok := p.status.Applications[args.Application]
+	if !ok {
+		msg := fmt.Sprintf("deploy application %s using %s", args.Application, ch)
+		if args.Series != "" {
+			msg += fmt.Sprintf(" on series %s", args.Series)
+		}
+		p.add(id, "deploy", "%s", msg)
+		return nil
+	}
+	if !p.sameCharm(ch, args.Series, existing.Charm) {
+		p.add(id, "upgrade-charm", "upgrade application %s from %s to %s", args.Application, existing.Charm, ch)
+	}
+	if len(args.Options) == 0 && args.Constraints == "" {
+		return nil
+	}
+	current, err := p.getApplication(args.Application)
+	if err != nil {
+		return errors.Annotatef(err, "cannot retrieve info for application %q", args.Application)
+	}
+	if diff := configChanges(current.Config, args.Options); len(diff) > 0 {
+		p.add(id, "set-config", "set config for application %s: %s", args.Application, strings.Join(diff, ", "))
+	}
+	if args.Constraints != "" {
+		cons, err := constraints.Parse(args.Constraints)
+		if err != nil {
+			// This should never happen, as the bundle is already verified.
+			return errors.Annotate(err, "invalid constraints for application")
+		}
+		if cons.String() != current.Constraints.String() {
+			p.add(id, "set-constraints", "set constraints for application %s to %q (was %q)", args.Application, cons, current.Constraints)
+		}
+	}
+	return nil
+}
+
+// configChanges returns a sorted description of the options whose value
+// differs from the current application config.
+func configChanges(current map[string]interface{}, options map[string]interface{}) []string {
+	var diff []string
+	for name, value := range options {
+		old, ok := currentConfigValue(current, name)
+		if ok && fmt.Sprint(old) == fmt.Sprint(value) {
+			continue
+		}
+		if ok {
+			diff = append(diff, fmt.Sprintf("%s=%v (was %v)", name, value, old))
+		} else {
+			diff = append(diff, fmt.Sprintf("%s=%v", name, value))
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// currentConfigValue returns the value of the named option in the given
+// application config, as returned by the application Get API call.
+func currentConfigValue(config map[string]interface{}, name string) (interface{}, bool) {
+	info, ok := config[name].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	value, ok := info["value"]
+	return value, ok
+}
+
+// addMachine plans the creation of a machine, unless the required units are
+// already placed.
+func (p *bundlePlanner) addMachine(id string, args bundlechanges.AddMachineParams) error {
+	services := p.h.servicesForMachineChange(id)
+	msg := services[0] + " unit"
+	if svcLen := len(services); svcLen != 1 {
+		msg = strings.Join(services[:svcLen-1], ", ") + " and " + services[svcLen-1] + " units"
+	}
+	if machine := p.h.chooseMachine(services...); machine != "" {
+		p.h.results[id] = machine
+		return nil
+	}
+	p.h.results[id] = id
+	p.newMachines[id] = true
+	if args.ContainerType == "" {
+		p.add(id, "add-machine", "add new machine for holding %s", msg)
+		return nil
+	}
+	ct := args.ContainerType
+	if ct == "lxc" {
+		ct = "lxd"
+	}
+	if args.ParentId == "" {
+		p.add(id, "add-machine", "add %s container in new machine for holding %s", ct, msg)
+		return nil
+	}
+	parent := p.describeMachine(resolve(args.ParentId, p.h.results))
+	p.add(id, "add-machine", "add %s container in %s for holding %s", ct, parent, msg)
+	return nil
+}
+
+// addUnit plans the addition of a unit, unless the application already has
+// the required number of units.
+func (p *bundlePlanner) addUnit(id string, args bundlechanges.AddUnitParams) {
+	application := resolve(args.Application, p.h.results)
+	if machine := p.h.chooseMachine(application); machine != "" {
+		p.h.results[id] = machine
+		return
+	}
+	unit := fmt.Sprintf("%s/%d", application, p.nextUnit[application])
+	p.nextUnit[application]++
+	if args.To == "" {
+		p.add(id, "add-unit", "add unit %s to new machine", unit)
+		p.h.results[id] = unit
+		p.h.unitStatus[unit] = ""
+		return
+	}
+	machine := resolve(args.To, p.h.results)
+	if names.IsValidUnit(machine) {
+		p.add(id, "add-unit", "add unit %s to the machine holding %s", unit, machine)
+		machine = p.h.unitStatus[machine]
+	} else {
+		p.add(id, "add-unit", "add unit %s to %s", unit, p.describeMachine(machine))
+	}
+	p.h.results[id] = machine
+	p.h.unitStatus[unit] = machine
+}
+
+// describeMachine returns a description of the given machine, which can be
+// either an existing machine id or the placeholder of a new machine.
+func (p *bundlePlanner) describeMachine(machine string) string {
+	if p.newMachines[machine] {
+		return "new machine " + machine
+	}
+	return "machine " + machine
+}
+
+// addRelation plans the addition of a relation, unless the relation
+// already exists.
+func (p *bundlePlanner) addRelation(id string, args bundlechanges.AddRelationParams) {
+	ep1 := resolveRelation(args.Endpoint1, p.h.results)
+	ep2 := resolveRelation(args.Endpoint2, p.h.results)
+	for _, rel := range p.status.Relations {
+		if len(rel.Endpoints) != 2 {
+			continue
+		}
+		e1, e2 := rel.Endpoints[0], rel.Endpoints[1]
+		if endpointMatches(ep1, e1) && endpointMatches(ep2, e2) ||
+			endpointMatches(ep1, e2) && endpointMatches(ep2, e1) {
+			return
+		}
+	}
+	p.add(id, "add-relation", "add relation %s - %s", ep1, ep2)
+}
+
+// endpointMatches reports whether the given bundle endpoint, in the
+// "application[:relation]" form, refers to the given relation endpoint.
+func endpointMatches(endpoint string, status params.EndpointStatus) bool {
+	parts := strings.SplitN(endpoint, ":", 2)
+	if parts[0] != status.ApplicationName {
+		return false
+	}
+	return len(parts) == 1 || parts[1] == status.Name
+}
+
+// expose plans exposing an application, unless it is already exposed.
+func (p *bundlePlanner) expose(id string, args bundlechanges.ExposeParams) {
+	application := resolve(args.Application, p.h.results)
+	if existing, ok := p.status.Applications[application]; ok && existing.Exposed {
+		return
+	}
+	p.add(id, "expose", "expose application %s", application)
+}
+
+// setAnnotations plans setting annotations on an application or a machine.
+func (p *bundlePlanner) setAnnotations(id string, args bundlechanges.SetAnnotationsParams) {
+	eid := resolve(args.Id, p.h.results)
+	if args.EntityType == bundlechanges.MachineType {
+		eid = p.describeMachine(eid)
+	} else {
+		eid = fmt.Sprintf("%s %s", args.EntityType, eid)
+	}
+	keys := make([]string, 0, len(args.Annotations))
+	for key := range args.Annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	p.add(id, "set-annotations", "set annotations for %s: %s", eid, strings.Join(keys, ", "))
+}
+
+// unitNumber returns the number of the given unit.
+func unitNumber(unit string) (int, error) {
+	parts := strings.Split(unit, "/")
+	if len(parts) != 2 {
+		return 0, errors.NotValidf("unit name %q", unit)
+	}
+	return strconv.Atoi(parts[1])
+}
+
+// formatBundlePlanTabular returns a tabular summary of a bundle plan.
+func formatBundlePlanTabular(value interface{}) ([]byte, error) {
+	plan, ok := value.(*bundlePlan)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", plan, value)
+	}
+	if len(plan.Changes) == 0 {
+		return []byte("No changes to apply."), nil
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("ID", "CHANGE", "DESCRIPTION")
+	for _, change := range plan.Changes {
+		print(change.Id, change.Kind, change.Description)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
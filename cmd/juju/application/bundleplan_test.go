@@ -0,0 +1,181 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+type BundlePlanSuite struct{}
+
+var _ = gc.Suite(&BundlePlanSuite{})
+
+const wordpressBundle = `
+applications:
+    mysql:
+        charm: mysql
+        num_units: 2
+        options:
+            dataset-size: 80%
+    wordpress:
+        charm: wordpress
+        num_units: 1
+        expose: true
+relations:
+    - ["wordpress:db", "mysql:server"]
+`
+
+var resolvedCharms = map[string]string{
+	"cs:mysql":     "cs:xenial/mysql-42",
+	"cs:wordpress": "cs:xenial/wordpress-47",
+}
+
+func resolveTestCharm(url *charm.URL) (*charm.URL, error) {
+	resolved, ok := resolvedCharms[url.String()]
+	if !ok {
+		return nil, errors.NotFoundf("charm %q", url)
+	}
+	return charm.MustParseURL(resolved), nil
+}
+
+func noApplications(application string) (*params.ApplicationGetResults, error) {
+	return nil, errors.NotFoundf("application %q", application)
+}
+
+func (s *BundlePlanSuite) planBundle(c *gc.C, status *params.FullStatus, getApplication applicationGetter) *bundlePlan {
+	data, err := charm.ReadBundleData(strings.NewReader(wordpressBundle))
+	c.Assert(err, jc.ErrorIsNil)
+	plan, err := planBundle("", data, status, getApplication, resolveTestCharm)
+	c.Assert(err, jc.ErrorIsNil)
+	return plan
+}
+
+func (s *BundlePlanSuite) TestPlanEmptyModel(c *gc.C) {
+	plan := s.planBundle(c, &params.FullStatus{}, noApplications)
+	c.Assert(plan.Changes, jc.DeepEquals, []bundlePlanChange{
+		{"addCharm-0", "add-charm", "add charm cs:xenial/mysql-42"},
+		{"deploy-1", "deploy", "deploy application mysql using cs:xenial/mysql-42"},
+		{"addCharm-2", "add-charm", "add charm cs:xenial/wordpress-47"},
+		{"deploy-3", "deploy", "deploy application wordpress using cs:xenial/wordpress-47"},
+		{"expose-4", "expose", "expose application wordpress"},
+		{"addRelation-5", "add-relation", "add relation wordpress:db - mysql:server"},
+		{"addUnit-6", "add-unit", "add unit mysql/0 to new machine"},
+		{"addUnit-7", "add-unit", "add unit mysql/1 to new machine"},
+		{"addUnit-8", "add-unit", "add unit wordpress/0 to new machine"},
+	})
+}
+
+func (s *BundlePlanSuite) TestPlanExistingApplication(c *gc.C) {
+	status := &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm: "cs:xenial/mysql-41",
+				Units: map[string]params.UnitStatus{
+					"mysql/2": {Machine: "0"},
+				},
+			},
+		},
+	}
+	var called []string
+	getApplication := func(application string) (*params.ApplicationGetResults, error) {
+		called = append(called, application)
+		return &params.ApplicationGetResults{
+			Application: application,
+			Config: map[string]interface{}{
+				"dataset-size": map[string]interface{}{"value": "50%"},
+			},
+		}, nil
+	}
+	plan := s.planBundle(c, status, getApplication)
+	c.Assert(called, jc.DeepEquals, []string{"mysql"})
+	c.Assert(plan.Changes, jc.DeepEquals, []bundlePlanChange{
+		{"addCharm-0", "add-charm", "add charm cs:xenial/mysql-42"},
+		{"deploy-1", "upgrade-charm", "upgrade application mysql from cs:xenial/mysql-41 to cs:xenial/mysql-42"},
+		{"deploy-1", "set-config", "set config for application mysql: dataset-size=80% (was 50%)"},
+		{"addCharm-2", "add-charm", "add charm cs:xenial/wordpress-47"},
+		{"deploy-3", "deploy", "deploy application wordpress using cs:xenial/wordpress-47"},
+		{"expose-4", "expose", "expose application wordpress"},
+		{"addRelation-5", "add-relation", "add relation wordpress:db - mysql:server"},
+		{"addUnit-6", "add-unit", "add unit mysql/3 to new machine"},
+		{"addUnit-8", "add-unit", "add unit wordpress/0 to new machine"},
+	})
+}
+
+func (s *BundlePlanSuite) TestPlanAlreadyDeployed(c *gc.C) {
+	status := &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm: "cs:xenial/mysql-42",
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {Machine: "0"},
+					"mysql/1": {Machine: "1"},
+				},
+			},
+			"wordpress": {
+				Charm:   "cs:xenial/wordpress-47",
+				Exposed: true,
+				Units: map[string]params.UnitStatus{
+					"wordpress/0": {Machine: "2"},
+				},
+			},
+		},
+		Relations: []params.RelationStatus{{
+			Endpoints: []params.EndpointStatus{
+				{ApplicationName: "mysql", Name: "server", Role: "provider"},
+				{ApplicationName: "wordpress", Name: "db", Role: "requirer"},
+			},
+		}},
+	}
+	getApplication := func(application string) (*params.ApplicationGetResults, error) {
+		return &params.ApplicationGetResults{
+			Application: application,
+			Config: map[string]interface{}{
+				"dataset-size": map[string]interface{}{"value": "80%"},
+			},
+			Constraints: constraints.Value{},
+		}, nil
+	}
+	plan := s.planBundle(c, status, getApplication)
+	c.Assert(plan.Changes, gc.HasLen, 0)
+}
+
+func (s *BundlePlanSuite) TestPlanResolveError(c *gc.C) {
+	data, err := charm.ReadBundleData(strings.NewReader(`
+applications:
+    django:
+        charm: django
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = planBundle("", data, &params.FullStatus{}, noApplications, resolveTestCharm)
+	c.Assert(err, gc.ErrorMatches, `cannot resolve URL "django": charm "cs:django" not found`)
+}
+
+func (s *BundlePlanSuite) TestFormatBundlePlanTabular(c *gc.C) {
+	out, err := formatBundlePlanTabular(&bundlePlan{
+		Changes: []bundlePlanChange{
+			{"addCharm-0", "add-charm", "add charm cs:xenial/mysql-42"},
+			{"addRelation-5", "add-relation", "add relation wordpress:db - mysql:server"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, ""+
+		"ID             CHANGE        DESCRIPTION\n"+
+		"addCharm-0     add-charm     add charm cs:xenial/mysql-42\n"+
+		"addRelation-5  add-relation  add relation wordpress:db - mysql:server\n",
+	)
+}
+
+func (s *BundlePlanSuite) TestFormatBundlePlanTabularNoChanges(c *gc.C) {
+	out, err := formatBundlePlanTabular(&bundlePlan{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, "No changes to apply.")
+}
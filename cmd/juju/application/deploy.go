@@ -87,7 +87,12 @@ type DeployCommand struct {
 	Bindings map[string]string
 	Steps    []DeployStep
 
+	// DryRun indicates that the changes required to deploy a bundle
+	// should be displayed rather than applied.
+	DryRun bool
+
 	flagSet *gnuflag.FlagSet
+	out     cmd.Output
 }
 
 const deployDoc = `
@@ -128,6 +133,13 @@ For example:
 
   juju deploy /path/to/bundle/openstack/bundle.yaml
 
+The changes required to deploy a bundle can be previewed with the --dry-run
+flag. The current model is compared with the bundle, and only new
+applications, units, machines and relations, upgraded charms and changed
+configuration and constraints are listed. Nothing is deployed.
+
+  juju deploy /path/to/bundle/openstack/bundle.yaml --dry-run
+
 <application name>, if omitted, will be derived from <charm name>.
 
 Constraints can be specified when using deploy by specifying the --constraints
@@ -212,7 +224,7 @@ var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
 	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
-	bundleOnlyFlags = []string{}
+	bundleOnlyFlags = []string{"dry-run", "format", "o"}
 )
 
 func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -228,6 +240,12 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show the changes required to deploy a bundle without applying them")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatBundlePlanTabular,
+	})
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -253,6 +271,11 @@ func (c *DeployCommand) Init(args []string) error {
 	default:
 		return cmd.CheckEmpty(args[2:])
 	}
+	if !c.DryRun && c.flagSet != nil {
+		if flags := getFlags(c.flagSet, []string{"format", "o"}); len(flags) > 0 {
+			return errors.Errorf("%s can only be used with --dry-run", strings.Join(flags, ", "))
+		}
+	}
 	err := c.parseBind()
 	if err != nil {
 		return err
@@ -321,6 +344,9 @@ func (c *DeployCommand) deployCharmOrBundle(ctx *cmd.Context, client *api.Client
 		// Charm may have been supplied via a path reference.
 		ch, curl, charmErr := charmrepo.NewCharmAtPathForceSeries(c.CharmOrBundle, c.Series, c.Force)
 		if charmErr == nil {
+			if flags := getFlags(c.flagSet, bundleOnlyFlags); len(flags) > 0 {
+				return errors.Errorf("Flags provided but not supported when deploying a charm: %s.", strings.Join(flags, ", "))
+			}
 			if curl, charmErr = client.AddLocalCharm(curl, ch); charmErr != nil {
 				return charmErr
 			}
@@ -409,6 +435,9 @@ func (c *DeployCommand) deployCharmOrBundle(ctx *cmd.Context, client *api.Client
 		if flags := getFlags(c.flagSet, charmOnlyFlags); len(flags) > 0 {
 			return errors.Errorf("Flags provided but not supported when deploying a bundle: %s.", strings.Join(flags, ", "))
 		}
+		if c.DryRun {
+			return c.planBundle(ctx, client, &deployer, resolver, bundleFilePath, bundleData)
+		}
 		// TODO(ericsnow) Do something with the CS macaroons that were returned?
 		if _, err := deployBundle(
 			bundleFilePath, bundleData, c.Channel, client, &deployer, resolver, ctx, c.BundleStorage,
@@ -463,6 +492,45 @@ func (c *DeployCommand) deployCharmOrBundle(ctx *cmd.Context, client *api.Client
 	})
 }
 
+// planBundle writes the changes required to deploy the given bundle to the
+// current model, without applying them.
+func (c *DeployCommand) planBundle(
+	ctx *cmd.Context,
+	client *api.Client,
+	deployer *applicationDeployer,
+	resolver *charmURLResolver,
+	bundleFilePath string,
+	data *charm.BundleData,
+) error {
+	if err := verifyBundle(data, bundleFilePath); err != nil {
+		return errors.Trace(err)
+	}
+	status, err := client.Status(nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot get model status")
+	}
+	serviceClient, err := deployer.newApplicationAPIClient()
+	if err != nil {
+		return errors.Annotate(err, "cannot get application client")
+	}
+	defer serviceClient.Close()
+	resolveCharm := func(url *charm.URL) (*charm.URL, error) {
+		resolved, _, _, _, err := resolver.resolve(url)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if resolved.Series == "bundle" {
+			return nil, errors.Errorf("expected charm URL, got bundle URL %q", url)
+		}
+		return resolved, nil
+	}
+	plan, err := planBundle(bundleFilePath, data, status, serviceClient.Get, resolveCharm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, plan)
+}
+
 type deployCharmArgs struct {
 	id       charmstore.CharmID
 	csMac    *macaroon.Macaroon
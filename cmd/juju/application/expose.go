@@ -4,10 +4,15 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +24,20 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default the application's open ports may be accessed from anywhere.
+Access can be restricted to a set of source CIDRs with --to-cidrs, and
+to the subnets of a set of spaces with --to-spaces. The restrictions
+apply to all of the application's endpoints, unless --endpoints is used
+to name the endpoints they apply to. The restrictions of an endpoint
+apply to the ports the charm opened for it with "open-port --endpoint";
+other ports use the restrictions for all endpoints, and are not opened
+if only named endpoints were exposed. Exposing an already exposed
+application updates the restrictions of the given endpoints only.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/24,192.168.1.0/24
+    juju expose mysql --endpoints db-admin --to-spaces admin
 
 See also: 
     unexpose`[1:]
@@ -34,6 +51,14 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	endpoints string
+	toSpaces  string
+	toCIDRs   string
+
+	// ExposedEndpoints holds the expose settings parsed from the
+	// flags, keyed by endpoint name.
+	ExposedEndpoints map[string]params.ExposedEndpoint
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +70,62 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.endpoints, "endpoints", "", "Comma-separated list of endpoints the restrictions apply to")
+	f.StringVar(&c.toSpaces, "to-spaces", "", "Comma-separated list of spaces whose subnets may access the application")
+	f.StringVar(&c.toCIDRs, "to-cidrs", "", "Comma-separated list of CIDRs which may access the application")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
-	return cmd.CheckEmpty(args[1:])
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	return c.parseExposedEndpoints()
+}
+
+func (c *exposeCommand) parseExposedEndpoints() error {
+	if c.endpoints == "" && c.toSpaces == "" && c.toCIDRs == "" {
+		return nil
+	}
+	exposed := params.ExposedEndpoint{
+		ExposeToSpaces: splitCommaList(c.toSpaces),
+		ExposeToCIDRs:  splitCommaList(c.toCIDRs),
+	}
+	for _, cidr := range exposed.ExposeToCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	endpoints := splitCommaList(c.endpoints)
+	if len(endpoints) == 0 {
+		endpoints = []string{""}
+	}
+	c.ExposedEndpoints = make(map[string]params.ExposedEndpoint)
+	for _, name := range endpoints {
+		c.ExposedEndpoints[name] = exposed
+	}
+	return nil
+}
+
+// splitCommaList returns the non-empty, whitespace trimmed elements of
+// the given comma-separated list.
+func splitCommaList(list string) []string {
+	var result []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
 }
 
 type serviceExposeAPI interface {
 	Close() error
-	Expose(serviceName string) error
+	Expose(serviceName string, exposedEndpoints map[string]params.ExposedEndpoint) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +145,5 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	return block.ProcessBlockedError(client.Expose(c.ApplicationName, c.ExposedEndpoints), block.BlockChange)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
@@ -23,7 +24,8 @@ func NewRemoveUnitCommand() cmd.Command {
 // removeUnitCommand is responsible for destroying application units.
 type removeUnitCommand struct {
 	modelcmd.ModelCommandBase
-	UnitNames []string
+	UnitNames     []string
+	DetachStorage bool
 }
 
 const removeUnitDoc = `
@@ -40,9 +42,15 @@ on that machine (including units in containers).
 Removing all units of a service is not equivalent to removing the service
 itself; for that, the ` + "`juju remove-service`" + ` command is used.
 
+Storage owned by a removed unit is destroyed along with it. To keep the
+storage, so that it can later be attached to another unit with
+` + "`juju add-unit --attach-storage`" + `, use --detach-storage. Storage that
+is confined to the unit's machine cannot be detached.
+
 Examples:
 
     juju remove-unit wordpress/2 wordpress/3 wordpress/4
+    juju remove-unit --detach-storage postgresql/1
 
 See also: remove-service
 `
@@ -57,6 +65,10 @@ func (c *removeUnitCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.DetachStorage, "detach-storage", false, "Detach the units' storage rather than destroying it")
+}
+
 func (c *removeUnitCommand) Init(args []string) error {
 	c.UnitNames = args
 	if len(c.UnitNames) == 0 {
@@ -86,5 +98,10 @@ func (c *removeUnitCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.DestroyUnits(c.UnitNames...), block.BlockRemove)
+	if c.DetachStorage {
+		err = client.DestroyUnitsDetachingStorage(c.UnitNames...)
+	} else {
+		err = client.DestroyUnits(c.UnitNames...)
+	}
+	return block.ProcessBlockedError(err, block.BlockRemove)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/juju/common"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -57,6 +58,24 @@ func (s *RemoveUnitSuite) TestRemoveUnit(c *gc.C) {
 		c.Assert(u.Life(), gc.Equals, state.Dying)
 	}
 }
+
+func (s *RemoveUnitSuite) TestRemoveUnitDetachStorage(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "storage-block")
+	err := runDeploy(c, ch, "storage-block", "--series", "trusty", "--storage", "data=1G")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runRemoveUnit(c, "--detach-storage", "storage-block/0")
+	c.Assert(err, jc.ErrorIsNil)
+	u, err := s.State.Unit("storage-block/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Life(), gc.Equals, state.Dying)
+	si, err := s.State.StorageInstance(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *RemoveUnitSuite) TestBlockRemoveUnit(c *gc.C) {
 	svc := s.setupUnitForRemove(c)
 
@@ -6,12 +6,16 @@ package commands
 import (
 	"fmt"
 	"io"
+	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -43,13 +47,26 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--since' and '--until' options restrict the messages shown to those
+logged within a time range. Each takes an RFC3339 timestamp
+("2016-10-01T12:00:00Z"), a date ("2016-10-01") or a duration ("90m")
+meaning that long ago. '--since' implies '--replay', and '--until' implies
+'--no-tail'.
+
+The '--match' option only shows messages whose text matches the given
+regular expression. The match is performed by the controller.
+
+The '--format json' option emits each log record as a single line of JSON,
+suitable for processing with other tools.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --since, --until and --match selections are logically ANDed to form the
+  complete filter.
 
 Examples:
 
@@ -88,6 +105,15 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show at most 20 messages from the last two hours which mention a failed
+hook, as JSON:
+
+    juju debug-log --since 2h --match "hook failed" --limit 20 --format json
+
+Show all messages logged on a given day, and then exit:
+
+    juju debug-log --since 2016-10-01 --until 2016-10-02
+
 See also: 
     status
     ssh`
@@ -108,6 +134,9 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	since  string
+	until  string
+	format string
 	params api.DebugLogParams
 }
 
@@ -124,10 +153,14 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	f.UintVar(&c.params.Backlog, "n", defaultLineCount, "Show this many of the most recent (possibly filtered) lines, and continue to append")
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
-	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
+	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
 	f.BoolVar(&c.params.NoTail, "T", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.params.NoTail, "no-tail", false, "")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time; implies --replay")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time; implies --no-tail")
+	f.StringVar(&c.params.MessageRegex, "match", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.format, "format", "text", "Output format, one of [text, json]")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -139,6 +172,39 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	now := time.Now()
+	if c.since != "" {
+		since, err := common.ParseTimeArg(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since")
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := common.ParseTimeArg(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until")
+		}
+		c.params.EndTime = until
+		c.params.NoTail = true
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() && c.params.EndTime.Before(c.params.StartTime) {
+		return errors.New("--until must not be before --since")
+	}
+	if c.params.MessageRegex != "" {
+		if _, err := regexp.Compile(c.params.MessageRegex); err != nil {
+			return errors.Annotate(err, "invalid --match")
+		}
+	}
+	switch c.format {
+	case "text":
+		// The server's default format.
+	case "json":
+		c.params.Format = c.format
+	default:
+		return fmt.Errorf("format value %q is not one of %q, %q", c.format, "text", "json")
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -91,6 +92,44 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--since", "2016-10-01T12:00:00Z"},
+			expected: api.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC),
+			},
+		}, {
+			args: []string{"--until", "2016-10-02"},
+			expected: api.DebugLogParams{
+				Backlog: 10,
+				NoTail:  true,
+				EndTime: time.Date(2016, 10, 2, 0, 0, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "2016-10-02", "--until", "2016-10-01"},
+			errMatch: `--until must not be before --since`,
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since: .*`,
+		}, {
+			args: []string{"--match", "hook (failed|error)"},
+			expected: api.DebugLogParams{
+				Backlog:      10,
+				MessageRegex: "hook (failed|error)",
+			},
+		}, {
+			args:     []string{"--match", "hook ("},
+			errMatch: `invalid --match: .*`,
+		}, {
+			args: []string{"--format", "json"},
+			expected: api.DebugLogParams{
+				Backlog: 10,
+				Format:  "json",
+			},
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
 		},
 	} {
 		c.Logf("test %v", i)
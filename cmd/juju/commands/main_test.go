@@ -370,6 +370,7 @@ var commandNames = []string{
 	"agree",
 	"agreements",
 	"allocate",
+	"attach-storage",
 	"autoload-credentials",
 	"backups",
 	"block",
@@ -377,6 +378,7 @@ var commandNames = []string{
 	"bootstrap",
 	"budgets",
 	"cached-images",
+	"cancel-action",
 	"change-user-password",
 	"charm",
 	"clouds",
@@ -395,10 +397,13 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-application",
 	"destroy-unit",
+	"detach-storage",
+	"diff-bundle",
 	"disable-user",
 	"download-backup",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
 	"get-config",
 	"get-configs",
@@ -412,6 +417,7 @@ var commandNames = []string{
 	"help-tool",
 	"import-ssh-key",
 	"import-ssh-keys",
+	"import-volume",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -456,6 +462,7 @@ var commandNames = []string{
 	"remove-ssh-key",
 	"remove-ssh-keys",
 	"remove-unit", // alias for destroy-unit
+	"resize-storage",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
@@ -478,6 +485,7 @@ var commandNames = []string{
 	"shares",
 	"show-action-output",
 	"show-action-status",
+	"show-audit-log",
 	"show-backup",
 	"show-budget",
 	"show-cloud",
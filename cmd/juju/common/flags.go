@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -76,3 +77,23 @@ func (f *ConfigFlag) String() string {
 	}
 	return strings.Join(strs, " ")
 }
+
+// ParseTimeArg parses a point in time given on the command line. It
+// accepts an RFC3339 timestamp ("2016-10-01T12:00:00Z"), a date
+// ("2016-10-01", taken as midnight UTC), or a duration ("90m") which
+// is taken to mean that long before now.
+func ParseTimeArg(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d).UTC(), nil
+	}
+	return time.Time{}, errors.Errorf(
+		"%q is not a valid time; expected a timestamp (2016-10-01T12:00:00Z), a date (2016-10-01) or a duration (90m)",
+		value,
+	)
+}
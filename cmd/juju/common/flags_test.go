@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -96,3 +97,36 @@ func assertConfigFlagReadAttrs(c *gc.C, f ConfigFlag, expect map[string]interfac
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(attrs, jc.DeepEquals, expect)
 }
+
+func (*FlagsSuite) TestParseTimeArg(c *gc.C) {
+	now := time.Date(2016, 10, 17, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		value  string
+		expect time.Time
+	}{{
+		value:  "2016-10-01T08:30:00Z",
+		expect: time.Date(2016, 10, 1, 8, 30, 0, 0, time.UTC),
+	}, {
+		value:  "2016-10-01T08:30:00+02:00",
+		expect: time.Date(2016, 10, 1, 6, 30, 0, 0, time.UTC),
+	}, {
+		value:  "2016-10-01",
+		expect: time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		value:  "90m",
+		expect: time.Date(2016, 10, 17, 10, 30, 0, 0, time.UTC),
+	}} {
+		c.Logf("parsing %q", test.value)
+		t, err := ParseTimeArg(test.value, now)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(t, gc.Equals, test.expect)
+	}
+}
+
+func (*FlagsSuite) TestParseTimeArgErrors(c *gc.C) {
+	now := time.Date(2016, 10, 17, 12, 0, 0, 0, time.UTC)
+	for _, value := range []string{"", "yesterday", "-1h", "2016-13-01"} {
+		_, err := ParseTimeArg(value, now)
+		c.Check(err, gc.ErrorMatches, `".*" is not a valid time; expected .*`)
+	}
+}
@@ -27,9 +27,14 @@ type ModelInfo struct {
 
 // ModelStatus contains the current status of a model.
 type ModelStatus struct {
-	Current status.Status `json:"current" yaml:"current"`
-	Message string        `json:"message,omitempty" yaml:"message,omitempty"`
-	Since   string        `json:"since,omitempty" yaml:"since,omitempty"`
+	Current        status.Status `json:"current" yaml:"current"`
+	Message        string        `json:"message,omitempty" yaml:"message,omitempty"`
+	Since          string        `json:"since,omitempty" yaml:"since,omitempty"`
+	Migration      string        `json:"migration,omitempty" yaml:"migration,omitempty"`
+	MigrationStart string        `json:"migration-start,omitempty" yaml:"migration-start,omitempty"`
+	MigrationEnd   string        `json:"migration-end,omitempty" yaml:"migration-end,omitempty"`
+
+	MigrationPrecheckFailures []string `json:"migration-precheck-failures,omitempty" yaml:"migration-precheck-failures,omitempty"`
 }
 
 // ModelUserInfo defines the serialization behaviour of the model user
@@ -53,6 +58,19 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 	if info.Status.Since != nil {
 		status.Since = UserFriendlyDuration(*info.Status.Since, now)
 	}
+	if info.Migration != nil {
+		status.Migration = info.Migration.Status
+		if info.Migration.Start != nil {
+			status.MigrationStart = UserFriendlyDuration(*info.Migration.Start, now)
+		}
+		if info.Migration.End != nil {
+			status.MigrationEnd = UserFriendlyDuration(*info.Migration.End, now)
+		}
+		for _, failure := range info.Migration.PrecheckFailures {
+			status.MigrationPrecheckFailures = append(status.MigrationPrecheckFailures,
+				failure.Code+": "+failure.Message)
+		}
+	}
 	return ModelInfo{
 		Name:           info.Name,
 		UUID:           info.UUID,
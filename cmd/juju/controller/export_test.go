@@ -136,6 +136,14 @@ func NewListBlocksCommandForTest(api listBlocksAPI, apierr error, store jujuclie
 	return modelcmd.WrapController(c)
 }
 
+// NewShowAuditLogCommandForTest returns a showAuditLogCommand with
+// the api provided as specified.
+func NewShowAuditLogCommandForTest(api auditLogAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &showAuditLogCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewGetConfigCommandCommandForTest returns a GetConfigCommandCommand with
 // the api provided as specified.
 func NewGetConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore) cmd.Command {
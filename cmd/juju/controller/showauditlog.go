@@ -0,0 +1,260 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// defaultAuditLogLimit is the number of entries shown when --limit is
+// not specified.
+const defaultAuditLogLimit = 50
+
+// NewShowAuditLogCommand returns a command to show the controller's
+// audit log.
+func NewShowAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&showAuditLogCommand{})
+}
+
+// showAuditLogCommand shows the requests recorded in the controller's
+// audit log.
+type showAuditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api auditLogAPI
+
+	user      string
+	model     string
+	operation string
+	from      string
+	to        string
+	limit     int
+	utc       bool
+
+	filter params.AuditLogFilter
+}
+
+var showAuditLogDoc = `
+Shows the requests made by users to the controller's API servers, most
+recent first. Entries can be filtered by the user who made the request,
+the model it was made on, its operation and when it was made. Each
+entry shows whether the request succeeded and how long it took; the
+yaml and json formats also show the request's arguments, with secret
+values redacted.
+
+Read-only requests can be left out of the audit log by setting the
+audit-log-exclude-methods controller config to ReadOnlyMethods.
+
+The --from and --to options take a timestamp (2016-10-01T12:00:00Z), a
+date (2016-10-01) or a duration, which is taken to mean that long ago.
+
+Only controller administrators may see the audit log.
+
+Examples:
+
+    juju show-audit-log
+    juju show-audit-log --user bob --model default --from 2h
+    juju show-audit-log --operation Application --from 2016-10-01 --to 2016-10-02
+    juju show-audit-log --limit 500 --format yaml
+
+See also:
+    debug-log
+`
+
+// auditLogAPI defines the methods on the audit log API endpoint
+// that the show-audit-log command calls.
+type auditLogAPI interface {
+	Close() error
+	Entries(params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *showAuditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-audit-log",
+		Purpose: "Shows requests recorded in the controller's audit log.",
+		Doc:     showAuditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showAuditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.model, "model", "", "Only show requests made on this model")
+	f.StringVar(&c.operation, "operation", "", "Only show requests whose operation contains this text")
+	f.StringVar(&c.from, "from", "", "Only show requests made at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show requests made at or before this time")
+	f.IntVar(&c.limit, "limit", defaultAuditLogLimit, "The maximum number of entries to show")
+	f.BoolVar(&c.utc, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showAuditLogCommand) Init(args []string) error {
+	if c.limit <= 0 {
+		return errors.New("limit must be positive")
+	}
+	c.filter = params.AuditLogFilter{
+		Operation: c.operation,
+		Limit:     c.limit,
+	}
+	if c.user != "" {
+		if !names.IsValidUser(c.user) {
+			return errors.NotValidf("user name %q", c.user)
+		}
+		c.filter.UserTag = names.NewUserTag(c.user).String()
+	}
+	now := time.Now()
+	if c.from != "" {
+		from, err := common.ParseTimeArg(c.from, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --from")
+		}
+		c.filter.From = &from
+	}
+	if c.to != "" {
+		to, err := common.ParseTimeArg(c.to, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --to")
+		}
+		c.filter.To = &to
+	}
+	if c.filter.From != nil && c.filter.To != nil && c.filter.To.Before(*c.filter.From) {
+		return errors.New("--to must not be before --from")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showAuditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showAuditLogCommand) Run(ctx *cmd.Context) error {
+	filter := c.filter
+	if c.model != "" {
+		uuids, err := c.ModelUUIDs([]string{c.model})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filter.ModelTag = names.NewModelTag(uuids[0]).String()
+	}
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	entries, err := api.Entries(filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = c.formatEntry(entry)
+	}
+	return c.out.Write(ctx, result)
+}
+
+// auditLogEntry defines the serialization behaviour of an audit log
+// entry.
+type auditLogEntry struct {
+	Timestamp     string                 `yaml:"timestamp" json:"timestamp"`
+	User          string                 `yaml:"user" json:"user"`
+	Model         string                 `yaml:"model" json:"model"`
+	RemoteAddress string                 `yaml:"remote-address" json:"remote-address"`
+	OriginType    string                 `yaml:"origin-type" json:"origin-type"`
+	Operation     string                 `yaml:"operation" json:"operation"`
+	Result        string                 `yaml:"result" json:"result"`
+	Error         string                 `yaml:"error,omitempty" json:"error,omitempty"`
+	Duration      string                 `yaml:"duration,omitempty" json:"duration,omitempty"`
+	Data          map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+	ServerVersion string                 `yaml:"server-version" json:"server-version"`
+}
+
+func (c *showAuditLogCommand) formatEntry(entry params.AuditLogEntry) auditLogEntry {
+	user := entry.OriginName
+	if tag, err := names.ParseUserTag(user); err == nil {
+		user = tag.Canonical()
+	}
+	model := entry.ModelTag
+	if tag, err := names.ParseModelTag(model); err == nil {
+		model = tag.Id()
+	}
+	result := "ok"
+	if entry.ErrorCode != "" {
+		result = entry.ErrorCode
+	} else if entry.ErrorMessage != "" {
+		result = "error"
+	}
+	var duration string
+	if entry.Duration > 0 {
+		duration = entry.Duration.String()
+	}
+	return auditLogEntry{
+		Timestamp:     common.FormatTime(&entry.Timestamp, c.utc),
+		User:          user,
+		Model:         model,
+		RemoteAddress: entry.RemoteAddress,
+		OriginType:    entry.OriginType,
+		Operation:     entry.Operation,
+		Result:        result,
+		Error:         entry.ErrorMessage,
+		Duration:      duration,
+		Data:          entry.Data,
+		ServerVersion: entry.JujuServerVersion.String(),
+	}
+}
+
+func formatAuditLogTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	if len(entries) == 0 {
+		return []byte("No audit log entries."), nil
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tUSER\tMODEL\tADDRESS\tOPERATION\tRESULT\tDURATION\n")
+	for _, entry := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp, entry.User, entry.Model, entry.RemoteAddress, entry.Operation, entry.Result, entry.Duration)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/testing"
+)
+
+type ShowAuditLogSuite struct {
+	baseControllerSuite
+	api *fakeAuditLogAPI
+}
+
+var _ = gc.Suite(&ShowAuditLogSuite{})
+
+func (s *ShowAuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			JujuServerVersion: version.MustParse("2.0.0"),
+			ModelTag:          "model-def",
+			Timestamp:         time.Date(2016, 10, 1, 12, 30, 0, 0, time.UTC),
+			RemoteAddress:     "10.0.0.1:1234",
+			OriginType:        "API request",
+			OriginName:        "user-bob@local",
+			Operation:         "Application:v1 - Deploy",
+			Duration:          1200 * time.Millisecond,
+			Data: map[string]interface{}{
+				"request-body": map[string]interface{}{"application": "mysql"},
+			},
+		}, {
+			JujuServerVersion: version.MustParse("2.0.0"),
+			ModelTag:          "model-abc",
+			Timestamp:         time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC),
+			RemoteAddress:     "10.0.0.2:4321",
+			OriginType:        "API request",
+			OriginName:        "user-admin@local",
+			Operation:         "Client:v1 - FullStatus",
+			Duration:          30 * time.Millisecond,
+			ErrorCode:         "unauthorized access",
+			ErrorMessage:      "permission denied",
+		}},
+	}
+}
+
+func (s *ShowAuditLogSuite) run(c *gc.C, args ...string) (string, error) {
+	command := controller.NewShowAuditLogCommandForTest(s.api, s.store)
+	ctx, err := testing.RunCommand(c, command, args...)
+	if ctx == nil {
+		return "", err
+	}
+	return testing.Stdout(ctx), err
+}
+
+func (s *ShowAuditLogSuite) TestShowTabular(c *gc.C) {
+	out, err := s.run(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"TIME                  USER         MODEL  ADDRESS        OPERATION                RESULT               DURATION\n"+
+		"2016-10-01 12:30:00Z  bob@local    def    10.0.0.1:1234  Application:v1 - Deploy  ok                   1.2s\n"+
+		"2016-10-01 12:00:00Z  admin@local  abc    10.0.0.2:4321  Client:v1 - FullStatus   unauthorized access  30ms\n",
+	)
+	s.api.CheckCallNames(c, "Entries", "Close")
+	s.api.CheckCall(c, 0, "Entries", params.AuditLogFilter{Limit: 50})
+}
+
+func (s *ShowAuditLogSuite) TestShowYAML(c *gc.C) {
+	s.api.entries = s.api.entries[:1]
+	out, err := s.run(c, "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	var entries []map[string]interface{}
+	err = goyaml.Unmarshal([]byte(out), &entries)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []map[string]interface{}{{
+		"timestamp":      "2016-10-01 12:30:00Z",
+		"user":           "bob@local",
+		"model":          "def",
+		"remote-address": "10.0.0.1:1234",
+		"origin-type":    "API request",
+		"operation":      "Application:v1 - Deploy",
+		"result":         "ok",
+		"duration":       "1.2s",
+		"data": map[interface{}]interface{}{
+			"request-body": map[interface{}]interface{}{"application": "mysql"},
+		},
+		"server-version": "2.0.0",
+	}})
+}
+
+func (s *ShowAuditLogSuite) TestShowNoEntries(c *gc.C) {
+	s.api.entries = nil
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "No audit log entries.\n")
+}
+
+func (s *ShowAuditLogSuite) TestFilters(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "my-model",
+		"--operation", "deploy",
+		"--from", "2016-10-01",
+		"--to", "2016-10-02T00:00:00Z",
+		"--limit", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2016, 10, 2, 0, 0, 0, 0, time.UTC)
+	s.api.CheckCall(c, 0, "Entries", params.AuditLogFilter{
+		UserTag:   "user-bob",
+		ModelTag:  "model-def",
+		Operation: "deploy",
+		From:      &from,
+		To:        &to,
+		Limit:     10,
+	})
+}
+
+func (s *ShowAuditLogSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--limit", "0"},
+		err:  "limit must be positive",
+	}, {
+		args: []string{"--user", "not/valid"},
+		err:  `user name "not/valid" not valid`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: "yesterday" is not a valid time; .*`,
+	}, {
+		args: []string{"--to", "later"},
+		err:  `invalid --to: "later" is not a valid time; .*`,
+	}, {
+		args: []string{"--from", "2016-10-02", "--to", "2016-10-01"},
+		err:  "--to must not be before --from",
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("args: %v", test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ShowAuditLogSuite) TestAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	gitjujutesting.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeAuditLogAPI) Entries(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.AddCall("Entries", filter)
+	return f.entries, f.NextErr()
+}
@@ -0,0 +1,543 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+)
+
+const diffBundleCommandDoc = `
+Compares a local bundle with the current model and prints the
+differences.
+
+The applications, charms, series, configuration, constraints, number
+of units, unit placement, exposure and relations are compared. Values
+not specified in the bundle, such as a charm revision or an
+application series, are not compared. Machines are only compared when
+the bundle declares them, using the machine ids in the bundle.
+
+Overlays are bundle files applied on top of the bundle before the
+comparison, in the order given. Applications in an overlay are merged
+into those of the bundle, and relations are added to them.
+
+The command exits with a non-zero status when differences are found,
+so that it can be used to detect models drifting from their bundle.
+
+Examples:
+
+    juju diff-bundle ./bundle.yaml
+    juju diff-bundle ./bundle.yaml --overlay ./production.yaml
+    juju diff-bundle ./bundle.yaml --format json
+
+See also:
+    export-bundle
+    deploy
+`
+
+// NewDiffBundleCommand returns a command to compare a bundle with the
+// current model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand compares a local bundle with the current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	api        DiffBundleAPI
+	out        cmd.Output
+	bundleFile string
+	overlays   []string
+}
+
+// DiffBundleAPI defines the methods on the bundle API that the
+// diff-bundle command calls.
+type DiffBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+// Info implements Command.Info.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file>",
+		Purpose: "Compares a bundle with the current model.",
+		Doc:     diffBundleCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(cmd.NewAppendStringsValue(&c.overlays), "overlay", "Bundle file to apply on top of the bundle (may be repeated)")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.Init.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.bundleFile = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *diffBundleCommand) getAPI() (DiffBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	data, err := readBundle(ctx.AbsPath(c.bundleFile))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, overlay := range c.overlays {
+		overlayData, err := readBundle(ctx.AbsPath(overlay))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		mergeBundle(data, overlayData)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	exported, err := client.ExportBundle()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelData, err := charm.ReadBundleData(strings.NewReader(exported))
+	if err != nil {
+		return errors.Annotate(err, "cannot read model bundle")
+	}
+
+	diff := diffBundle(data, modelData)
+	if err := c.out.Write(ctx, diff); err != nil {
+		return errors.Trace(err)
+	}
+	if !diff.empty() {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// readBundle reads the bundle data from the bundle file or directory at
+// the given path.
+func readBundle(path string) (*charm.BundleData, error) {
+	data, err := charmrepo.ReadBundleFile(path)
+	if err == nil {
+		return data, nil
+	}
+	b, pathErr := charmrepo.NewBundleAtPath(path)
+	if pathErr != nil {
+		return nil, errors.Annotatef(err, "cannot read bundle %q", path)
+	}
+	return b.Data(), nil
+}
+
+// mergeBundle applies the given overlay on top of the bundle data.
+func mergeBundle(data, overlay *charm.BundleData) {
+	if overlay.Series != "" {
+		data.Series = overlay.Series
+	}
+	if data.Applications == nil {
+		data.Applications = make(map[string]*charm.ApplicationSpec)
+	}
+	for name, spec := range overlay.Applications {
+		existing, ok := data.Applications[name]
+		if !ok || existing == nil {
+			data.Applications[name] = spec
+			continue
+		}
+		if spec == nil {
+			continue
+		}
+		if spec.Charm != "" {
+			existing.Charm = spec.Charm
+		}
+		if spec.Series != "" {
+			existing.Series = spec.Series
+		}
+		if spec.NumUnits != 0 {
+			existing.NumUnits = spec.NumUnits
+		}
+		if len(spec.To) > 0 {
+			existing.To = spec.To
+		}
+		if spec.Expose {
+			existing.Expose = true
+		}
+		if spec.Constraints != "" {
+			existing.Constraints = spec.Constraints
+		}
+		existing.Options = mergeOptions(existing.Options, spec.Options)
+		existing.Annotations = mergeStrings(existing.Annotations, spec.Annotations)
+		existing.Storage = mergeStrings(existing.Storage, spec.Storage)
+		existing.EndpointBindings = mergeStrings(existing.EndpointBindings, spec.EndpointBindings)
+	}
+	if len(overlay.Machines) > 0 && data.Machines == nil {
+		data.Machines = make(map[string]*charm.MachineSpec)
+	}
+	for id, spec := range overlay.Machines {
+		data.Machines[id] = spec
+	}
+	data.Relations = append(data.Relations, overlay.Relations...)
+}
+
+func mergeOptions(base, overlay map[string]interface{}) map[string]interface{} {
+	if len(overlay) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]interface{})
+	}
+	for k, v := range overlay {
+		base[k] = v
+	}
+	return base
+}
+
+func mergeStrings(base, overlay map[string]string) map[string]string {
+	if len(overlay) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]string)
+	}
+	for k, v := range overlay {
+		base[k] = v
+	}
+	return base
+}
+
+// bundleDiff holds the differences between a bundle and a model.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Machines     map[string]*machineDiff     `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// empty reports whether no differences were found.
+func (d *bundleDiff) empty() bool {
+	return len(d.Applications) == 0 && len(d.Machines) == 0 && d.Relations == nil
+}
+
+// applicationDiff holds the differences for a single application. Missing
+// is set to "bundle" or "model" when the application only exists in the
+// other one.
+type applicationDiff struct {
+	Missing     string                 `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *stringDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series      *stringDiff            `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints *stringDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Options     map[string]*optionDiff `yaml:"options,omitempty" json:"options,omitempty"`
+	Expose      *boolDiff              `yaml:"expose,omitempty" json:"expose,omitempty"`
+	NumUnits    *intDiff               `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Placement   *listDiff              `yaml:"placement,omitempty" json:"placement,omitempty"`
+}
+
+// machineDiff holds the differences for a single machine.
+type machineDiff struct {
+	Missing     string      `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Series      *stringDiff `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints *stringDiff `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+}
+
+// relationsDiff holds the relations which only exist in the bundle or
+// only exist in the model.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+type stringDiff struct {
+	Bundle string `yaml:"bundle" json:"bundle"`
+	Model  string `yaml:"model" json:"model"`
+}
+
+type optionDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+type boolDiff struct {
+	Bundle bool `yaml:"bundle" json:"bundle"`
+	Model  bool `yaml:"model" json:"model"`
+}
+
+type intDiff struct {
+	Bundle int `yaml:"bundle" json:"bundle"`
+	Model  int `yaml:"model" json:"model"`
+}
+
+type listDiff struct {
+	Bundle []string `yaml:"bundle" json:"bundle"`
+	Model  []string `yaml:"model" json:"model"`
+}
+
+// diffBundle compares the bundle data with the bundle exported from the
+// model.
+func diffBundle(data, model *charm.BundleData) *bundleDiff {
+	diff := &bundleDiff{
+		Applications: make(map[string]*applicationDiff),
+		Machines:     make(map[string]*machineDiff),
+	}
+	for name, spec := range data.Applications {
+		modelSpec, ok := model.Applications[name]
+		if !ok {
+			diff.Applications[name] = &applicationDiff{Missing: "model"}
+			continue
+		}
+		if appDiff := diffApplication(data.Series, spec, modelSpec); appDiff != nil {
+			diff.Applications[name] = appDiff
+		}
+	}
+	for name := range model.Applications {
+		if _, ok := data.Applications[name]; !ok {
+			diff.Applications[name] = &applicationDiff{Missing: "bundle"}
+		}
+	}
+
+	// Machines are only compared when the bundle declares them, as
+	// otherwise the bundle relies on machines being created implicitly.
+	if len(data.Machines) > 0 {
+		for id, spec := range data.Machines {
+			modelSpec, ok := model.Machines[id]
+			if !ok {
+				diff.Machines[id] = &machineDiff{Missing: "model"}
+				continue
+			}
+			if machDiff := diffMachine(data.Series, spec, modelSpec); machDiff != nil {
+				diff.Machines[id] = machDiff
+			}
+		}
+		for id := range model.Machines {
+			if _, ok := data.Machines[id]; !ok {
+				diff.Machines[id] = &machineDiff{Missing: "bundle"}
+			}
+		}
+	}
+
+	diff.Relations = diffRelations(data.Relations, model.Relations)
+	return diff
+}
+
+// diffApplication returns the differences between the application spec in
+// the bundle and the one exported from the model, or nil if they match.
+func diffApplication(defaultSeries string, spec, model *charm.ApplicationSpec) *applicationDiff {
+	if spec == nil {
+		spec = &charm.ApplicationSpec{}
+	}
+	var diff applicationDiff
+	changed := false
+	if !charmMatches(spec.Charm, model.Charm) {
+		diff.Charm = &stringDiff{Bundle: spec.Charm, Model: model.Charm}
+		changed = true
+	}
+	series := spec.Series
+	if series == "" {
+		series = defaultSeries
+	}
+	if series != "" && series != model.Series {
+		diff.Series = &stringDiff{Bundle: series, Model: model.Series}
+		changed = true
+	}
+	if cons, modelCons := normalizeConstraints(spec.Constraints), normalizeConstraints(model.Constraints); cons != modelCons {
+		diff.Constraints = &stringDiff{Bundle: cons, Model: modelCons}
+		changed = true
+	}
+	for name, value := range spec.Options {
+		modelValue, ok := model.Options[name]
+		if ok && fmt.Sprint(value) == fmt.Sprint(modelValue) {
+			continue
+		}
+		if diff.Options == nil {
+			diff.Options = make(map[string]*optionDiff)
+		}
+		diff.Options[name] = &optionDiff{Bundle: value, Model: modelValue}
+		changed = true
+	}
+	for name, modelValue := range model.Options {
+		if _, ok := spec.Options[name]; ok {
+			continue
+		}
+		if diff.Options == nil {
+			diff.Options = make(map[string]*optionDiff)
+		}
+		diff.Options[name] = &optionDiff{Model: modelValue}
+		changed = true
+	}
+	if spec.Expose != model.Expose {
+		diff.Expose = &boolDiff{Bundle: spec.Expose, Model: model.Expose}
+		changed = true
+	}
+	if spec.NumUnits != model.NumUnits {
+		diff.NumUnits = &intDiff{Bundle: spec.NumUnits, Model: model.NumUnits}
+		changed = true
+	}
+	if len(spec.To) > 0 && !reflect.DeepEqual(spec.To, model.To) {
+		diff.Placement = &listDiff{Bundle: spec.To, Model: model.To}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return &diff
+}
+
+// diffMachine returns the differences between the machine spec in the
+// bundle and the one exported from the model, or nil if they match.
+func diffMachine(defaultSeries string, spec, model *charm.MachineSpec) *machineDiff {
+	if spec == nil {
+		spec = &charm.MachineSpec{}
+	}
+	if model == nil {
+		model = &charm.MachineSpec{}
+	}
+	var diff machineDiff
+	changed := false
+	series := spec.Series
+	if series == "" {
+		series = defaultSeries
+	}
+	if series != "" && series != model.Series {
+		diff.Series = &stringDiff{Bundle: series, Model: model.Series}
+		changed = true
+	}
+	if cons, modelCons := normalizeConstraints(spec.Constraints), normalizeConstraints(model.Constraints); cons != modelCons {
+		diff.Constraints = &stringDiff{Bundle: cons, Model: modelCons}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return &diff
+}
+
+// diffRelations returns the relations which only exist in the bundle or in
+// the model, or nil if the relations match.
+func diffRelations(relations, modelRelations [][]string) *relationsDiff {
+	matched := make([]bool, len(modelRelations))
+	var diff relationsDiff
+outer:
+	for _, relation := range relations {
+		for i, modelRelation := range modelRelations {
+			if !matched[i] && relationMatches(relation, modelRelation) {
+				matched[i] = true
+				continue outer
+			}
+		}
+		diff.BundleAdditions = append(diff.BundleAdditions, relation)
+	}
+	for i, modelRelation := range modelRelations {
+		if !matched[i] {
+			diff.ModelAdditions = append(diff.ModelAdditions, modelRelation)
+		}
+	}
+	if len(diff.BundleAdditions) == 0 && len(diff.ModelAdditions) == 0 {
+		return nil
+	}
+	sort.Sort(relationsByEndpoints(diff.BundleAdditions))
+	sort.Sort(relationsByEndpoints(diff.ModelAdditions))
+	return &diff
+}
+
+// relationMatches reports whether the bundle relation refers to the model
+// relation. Bundle endpoints may omit the relation name.
+func relationMatches(relation, modelRelation []string) bool {
+	if len(relation) != 2 || len(modelRelation) != 2 {
+		return false
+	}
+	return endpointMatches(relation[0], modelRelation[0]) && endpointMatches(relation[1], modelRelation[1]) ||
+		endpointMatches(relation[0], modelRelation[1]) && endpointMatches(relation[1], modelRelation[0])
+}
+
+func endpointMatches(endpoint, modelEndpoint string) bool {
+	parts := strings.SplitN(endpoint, ":", 2)
+	modelParts := strings.SplitN(modelEndpoint, ":", 2)
+	if parts[0] != modelParts[0] {
+		return false
+	}
+	return len(parts) == 1 || len(modelParts) == 2 && parts[1] == modelParts[1]
+}
+
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
+
+// charmMatches reports whether the charm in the bundle refers to the charm
+// used in the model. Parts of the charm URL not specified in the bundle,
+// such as the series or revision, are not compared. Local charms, which
+// the model exports as paths relative to the bundle, are compared by
+// charm name.
+func charmMatches(bundleCharm, modelCharm string) bool {
+	if isLocalCharmPath(modelCharm) {
+		return isLocalCharmPath(bundleCharm) && filepath.Base(bundleCharm) == filepath.Base(modelCharm)
+	}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return bundleCharm == modelCharm
+	}
+	if isLocalCharmPath(bundleCharm) {
+		return modelURL.Schema == "local" && modelURL.Name == filepath.Base(bundleCharm)
+	}
+	url, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return false
+	}
+	if url.Schema != modelURL.Schema || url.User != modelURL.User || url.Name != modelURL.Name {
+		return false
+	}
+	if url.Series != "" && url.Series != modelURL.Series {
+		return false
+	}
+	return url.Revision == -1 || url.Revision == modelURL.Revision
+}
+
+// isLocalCharmPath reports whether the given bundle charm reference is a
+// path to a local charm.
+func isLocalCharmPath(ch string) bool {
+	return strings.HasPrefix(ch, ".") || filepath.IsAbs(ch)
+}
+
+// normalizeConstraints returns the canonical form of the given constraints,
+// so that equivalent constraints compare equal.
+func normalizeConstraints(s string) string {
+	cons, err := constraints.Parse(s)
+	if err != nil {
+		return s
+	}
+	return cons.String()
+}
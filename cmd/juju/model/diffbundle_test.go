@@ -0,0 +1,296 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type DiffBundleCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeExportBundleClient
+	store *jujuclienttesting.MemStore
+	dir   string
+}
+
+var _ = gc.Suite(&DiffBundleCommandSuite{})
+
+const modelBundle = `services:
+  mysql:
+    charm: cs:xenial/mysql-42
+    series: xenial
+    num_units: 1
+    to: ["0"]
+    options:
+      dataset-size: 50%
+  wordpress:
+    charm: cs:xenial/wordpress-47
+    series: xenial
+    num_units: 2
+    to: ["1", "2"]
+    expose: true
+machines:
+  "0":
+    series: xenial
+  "1":
+    series: xenial
+  "2":
+    series: xenial
+relations:
+- ["wordpress:db", "mysql:server"]
+`
+
+func (s *DiffBundleCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportBundleClient{bundle: modelBundle}
+	s.dir = c.MkDir()
+
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = &jujuclient.ControllerAccounts{
+		CurrentAccount: "admin@local",
+	}
+	err := s.store.UpdateModel("testing", "admin@local", "mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].AccountModels["admin@local"].CurrentModel = "mymodel"
+}
+
+func (s *DiffBundleCommandSuite) writeFile(c *gc.C, name, content string) string {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *DiffBundleCommandSuite) runDiff(c *gc.C, args ...string) (string, error) {
+	ctx, err := testing.RunCommand(c, model.NewDiffBundleCommandForTest(s.fake, s.store), args...)
+	if ctx == nil {
+		return "", err
+	}
+	return testing.Stdout(ctx), err
+}
+
+func (s *DiffBundleCommandSuite) TestNoDifferences(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", `
+applications:
+    mysql:
+        charm: mysql
+        num_units: 1
+        options:
+            dataset-size: 50%
+    wordpress:
+        charm: cs:xenial/wordpress
+        num_units: 2
+        expose: true
+relations:
+    - ["wordpress:db", "mysql"]
+`)
+	out, err := s.runDiff(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+}
+
+func (s *DiffBundleCommandSuite) TestDifferences(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", `
+series: xenial
+applications:
+    haproxy:
+        charm: haproxy
+    mysql:
+        charm: mysql
+        num_units: 1
+        constraints: mem=4G
+        options:
+            dataset-size: 80%
+    wordpress:
+        charm: cs:xenial/wordpress-46
+        num_units: 3
+        to: ["1", "2", "3"]
+relations:
+    - ["wordpress:db", "mysql:server"]
+    - ["haproxy:reverseproxy", "wordpress:website"]
+`)
+	out, err := s.runDiff(c, path)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(out, gc.Equals, `
+applications:
+  haproxy:
+    missing: model
+  mysql:
+    constraints:
+      bundle: mem=4096M
+      model: ""
+    options:
+      dataset-size:
+        bundle: 80%
+        model: 50%
+  wordpress:
+    charm:
+      bundle: cs:xenial/wordpress-46
+      model: cs:xenial/wordpress-47
+    expose:
+      bundle: false
+      model: true
+    num_units:
+      bundle: 3
+      model: 2
+    placement:
+      bundle:
+      - "1"
+      - "2"
+      - "3"
+      model:
+      - "1"
+      - "2"
+relations:
+  bundle-additions:
+  - - haproxy:reverseproxy
+    - wordpress:website
+`[1:])
+}
+
+func (s *DiffBundleCommandSuite) TestMachines(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", `
+applications:
+    mysql:
+        charm: cs:xenial/mysql-42
+        num_units: 1
+        to: ["0"]
+        options:
+            dataset-size: 50%
+    wordpress:
+        charm: cs:xenial/wordpress-47
+        num_units: 2
+        to: ["0", "3"]
+        expose: true
+machines:
+    "0":
+        series: trusty
+    "3":
+relations:
+    - ["wordpress:db", "mysql:server"]
+`)
+	out, err := s.runDiff(c, path, "--format", "json")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(out, gc.Equals, `{"applications":{"wordpress":{"placement":{"bundle":["0","3"],"model":["1","2"]}}},`+
+		`"machines":{"0":{"series":{"bundle":"trusty","model":"xenial"}},"1":{"missing":"bundle"},`+
+		`"2":{"missing":"bundle"},"3":{"missing":"model"}}}`+"\n")
+}
+
+func (s *DiffBundleCommandSuite) TestLocalCharms(c *gc.C) {
+	// Local charms are exported as paths relative to the bundle.
+	s.fake.bundle = `applications:
+  mysql:
+    charm: ./mysql
+    series: xenial
+    num_units: 1
+  wordpress:
+    charm: ./wordpress
+    series: xenial
+    num_units: 1
+`
+	path := s.writeFile(c, "bundle.yaml", `
+applications:
+    mysql:
+        charm: ./charms/mysql
+        num_units: 1
+    wordpress:
+        charm: `+filepath.Join(s.dir, "wordpress")+`
+        num_units: 1
+`)
+	out, err := s.runDiff(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+
+	path = s.writeFile(c, "bundle.yaml", `
+applications:
+    mysql:
+        charm: ./charms/mariadb
+        num_units: 1
+    wordpress:
+        charm: cs:wordpress
+        num_units: 1
+`)
+	out, err = s.runDiff(c, path)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(out, gc.Equals, `
+applications:
+  mysql:
+    charm:
+      bundle: ./charms/mariadb
+      model: ./mysql
+  wordpress:
+    charm:
+      bundle: cs:wordpress
+      model: ./wordpress
+`[1:])
+}
+
+func (s *DiffBundleCommandSuite) TestOverlays(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", `
+applications:
+    mysql:
+        charm: mysql
+        num_units: 1
+        options:
+            dataset-size: 80%
+    wordpress:
+        charm: wordpress
+        num_units: 1
+relations:
+    - ["wordpress:db", "mysql:server"]
+`)
+	overlay1 := s.writeFile(c, "overlay1.yaml", `
+applications:
+    mysql:
+        options:
+            dataset-size: 50%
+    wordpress:
+        num_units: 3
+`)
+	overlay2 := s.writeFile(c, "overlay2.yaml", `
+applications:
+    wordpress:
+        num_units: 2
+        expose: true
+`)
+	out, err := s.runDiff(c, path, "--overlay", overlay1, "--overlay", overlay2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+}
+
+func (s *DiffBundleCommandSuite) TestBundleNotFound(c *gc.C) {
+	_, err := s.runDiff(c, filepath.Join(s.dir, "missing.yaml"))
+	c.Assert(err, gc.ErrorMatches, `cannot read bundle ".*missing.yaml": .*`)
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *DiffBundleCommandSuite) TestExportError(c *gc.C) {
+	path := s.writeFile(c, "bundle.yaml", "applications: {}\n")
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.runDiff(c, path)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *DiffBundleCommandSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runDiff(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+	_, err = s.runDiff(c, "bundle.yaml", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
@@ -94,3 +94,19 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the
+// api provided as specified.
+func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewDiffBundleCommandForTest returns a diffBundleCommand with the
+// api provided as specified.
+func NewDiffBundleCommandForTest(api DiffBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &diffBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const exportBundleCommandDoc = `
+Exports the current model as a bundle that can be deployed with
+"juju deploy".
+
+The bundle describes the applications in the model along with their
+charms, configuration, constraints, endpoint bindings, storage
+directives, number of units and relations. Units are placed on
+machines matching the model's current machines.
+
+Applications deployed from local charms are exported with the path
+"./<charm name>", relative to the bundle file. The charm directory
+needs to be placed there before the bundle can be deployed.
+
+Examples:
+
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+
+See also:
+    deploy
+`
+
+// NewExportBundleCommand returns a command to export the current model
+// as a bundle.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+// exportBundleCommand writes the current model out as a bundle.
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	api      ExportBundleAPI
+	Filename string
+}
+
+// ExportBundleAPI defines the methods on the bundle API that the
+// export-bundle command calls.
+type ExportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+// Info implements Command.Info.
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: "Exports the current model as a bundle.",
+		Doc:     exportBundleCommandDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Filename, "filename", "", "Write the bundle to this file instead of stdout")
+}
+
+// Init implements Command.Init.
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *exportBundleCommand) getAPI() (ExportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ExportBundle()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.Filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, result)
+		return err
+	}
+	filename := ctx.AbsPath(c.Filename)
+	if err := ioutil.WriteFile(filename, []byte(result), 0644); err != nil {
+		return errors.Annotate(err, "cannot write bundle")
+	}
+	fmt.Fprintf(ctx.Stderr, "Bundle successfully exported to %s\n", filename)
+	return nil
+}
@@ -146,6 +146,59 @@ func (s *ShowCommandSuite) TestShowFormatJson(c *gc.C) {
 	c.Assert(testing.Stdout(ctx), jc.JSONEquals, s.expectedOutput)
 }
 
+func (s *ShowCommandSuite) TestShowWithMigration(c *gc.C) {
+	migrationStart := time.Date(2016, 4, 5, 0, 10, 0, 0, time.UTC)
+	s.fake.info.Migration = &params.ModelMigrationStatus{
+		Status: "transferring logs: 1000 records sent",
+		Start:  &migrationStart,
+	}
+	modelOut := s.expectedOutput["mymodel"].(attrs)
+	modelOut["status"] = attrs{
+		"current":         "active",
+		"since":           "2016-04-05",
+		"migration":       "transferring logs: 1000 records sent",
+		"migration-start": "2016-04-05",
+	}
+
+	ctx, err := testing.RunCommand(c, model.NewShowCommandForTest(&s.fake, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
+}
+
+func (s *ShowCommandSuite) TestShowWithPrecheckFailures(c *gc.C) {
+	migrationStart := time.Date(2016, 4, 5, 0, 10, 0, 0, time.UTC)
+	s.fake.info.Migration = &params.ModelMigrationStatus{
+		Status: "precheck failed",
+		Start:  &migrationStart,
+		End:    &migrationStart,
+		PrecheckFailures: []params.PrecheckFailure{{
+			Code:    "machine-error",
+			Entity:  "machine-0",
+			Message: "machine 0 is in error state: boom",
+		}, {
+			Code:    "model-name-taken",
+			Entity:  "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Message: `model named "mymodel" already exists for admin@local`,
+		}},
+	}
+	modelOut := s.expectedOutput["mymodel"].(attrs)
+	modelOut["status"] = attrs{
+		"current":         "active",
+		"since":           "2016-04-05",
+		"migration":       "precheck failed",
+		"migration-start": "2016-04-05",
+		"migration-end":   "2016-04-05",
+		"migration-precheck-failures": []interface{}{
+			"machine-error: machine 0 is in error state: boom",
+			`model-name-taken: model named "mymodel" already exists for admin@local`,
+		},
+	}
+
+	ctx, err := testing.RunCommand(c, model.NewShowCommandForTest(&s.fake, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
+}
+
 func (s *ShowCommandSuite) TestUnrecognizedArg(c *gc.C) {
 	_, err := testing.RunCommand(c, model.NewShowCommandForTest(&s.fake, s.store), "-m", "admin", "whoops")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
@@ -36,6 +36,7 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	watch    bool
 	api      statusAPI
 }
 
@@ -67,10 +68,15 @@ Explanation of the different formats:
 - yaml: Displays information on machines, applications, and units in yaml format.
 Note: AZ above is the cloud region's availability zone.
 
+With --watch, the status is written again whenever the model changes, until
+the command is interrupted. On a terminal, the status is redrawn in place with
+the lines that changed since the previous redraw highlighted.
+
 Examples:
     juju status
     juju status mysql
     juju status nova-*
+    juju status --watch mysql
 `
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -83,20 +89,25 @@ func (c *statusCommand) Info() *cmd.Info {
 	}
 }
 
+// statusFormatters holds the formatters for the status output
+// formats, keyed by format name.
+var statusFormatters = map[string]cmd.Formatter{
+	"yaml":    cmd.FormatYaml,
+	"json":    cmd.FormatJson,
+	"short":   FormatOneline,
+	"oneline": FormatOneline,
+	"line":    FormatOneline,
+	"tabular": FormatTabular,
+	"summary": FormatSummary,
+}
+
 func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.watch, "watch", false, "Redraw the status whenever the model changes")
 
 	defaultFormat := "tabular"
 
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
-		"yaml":    cmd.FormatYaml,
-		"json":    cmd.FormatJson,
-		"short":   FormatOneline,
-		"oneline": FormatOneline,
-		"line":    FormatOneline,
-		"tabular": FormatTabular,
-		"summary": FormatSummary,
-	})
+	c.out.AddFlags(f, defaultFormat, statusFormatters)
 }
 
 func (c *statusCommand) Init(args []string) error {
@@ -126,22 +137,44 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.watch {
+		return c.runWatch(ctx, apiclient)
+	}
+	status, err := c.requestStatus(ctx, apiclient)
+	if err != nil {
+		return err
+	}
+	formatted, err := c.formatStatus(status)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// requestStatus requests the status of the model, reporting any
+// error that still allows some status to be returned.
+func (c *statusCommand) requestStatus(ctx *cmd.Context, apiclient statusAPI) (*params.FullStatus, error) {
 	status, err := apiclient.Status(c.patterns)
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
-			return err
+			return nil, err
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return nil, errors.Errorf("unable to obtain the current status")
 	}
+	return status, nil
+}
 
+// formatStatus returns the status ready to be written in the selected
+// format.
+func (c *statusCommand) formatStatus(status *params.FullStatus) (interface{}, error) {
 	clientStore := c.ClientStore()
 	controllerDetails, err := clientStore.ControllerByName(c.ControllerName())
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	model := modelStatus{
@@ -150,6 +183,5 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		Cloud:      controllerDetails.Cloud,
 	}
 	formatter := newStatusFormatter(status, model, c.isoTime)
-	formatted := formatter.format()
-	return c.out.Write(ctx, formatted)
+	return formatter.format(), nil
 }
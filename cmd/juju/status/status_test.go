@@ -3330,6 +3330,7 @@ func (s *StatusSuite) prepareTabularData(c *gc.C) *context {
 		setAgentStatus{"wordpress/0", status.StatusIdle, "", nil},
 		setUnitStatus{"wordpress/0", status.StatusActive, "", nil},
 		setUnitTools{"wordpress/0", version.MustParseBinary("1.2.3-trusty-ppc")},
+		setUnitWorkloadVersion{"wordpress/0", "4.5.3"},
 		addService{name: "mysql", charm: "mysql"},
 		setServiceExposed{"mysql", true},
 		addMachine{machineId: "2", job: state.JobHostUnits},
@@ -3343,6 +3344,7 @@ func (s *StatusSuite) prepareTabularData(c *gc.C) *context {
 			status.StatusMaintenance,
 			"installing all the things", nil},
 		setUnitTools{"mysql/0", version.MustParseBinary("1.2.3-trusty-ppc")},
+		setUnitWorkloadVersion{"mysql/0", "5.7.13"},
 		addService{name: "logging", charm: "logging"},
 		setServiceExposed{"logging", true},
 		relateServices{"wordpress", "mysql"},
@@ -3375,10 +3377,10 @@ func (s *StatusSuite) testStatusWithFormatTabular(c *gc.C, useFeatureFlag bool)
 MODEL       CONTROLLER  CLOUD  VERSION  UPGRADE-AVAILABLE
 controller  kontroll    dummy  1.2.3    1.2.4
 
-APP        STATUS       EXPOSED  ORIGIN      CHARM      REV  OS
-logging                 true     jujucharms  logging    1    ubuntu
-mysql      maintenance  true     jujucharms  mysql      1    ubuntu
-wordpress  active       true     jujucharms  wordpress  3    ubuntu
+APP        VERSION  STATUS       EXPOSED  ORIGIN      CHARM      REV  OS
+logging                          true     jujucharms  logging    1    ubuntu
+mysql      5.7.13   maintenance  true     jujucharms  mysql      1    ubuntu
+wordpress  4.5.3    active       true     jujucharms  wordpress  3    ubuntu
 
 RELATION           PROVIDES   CONSUMES   TYPE
 juju-info          logging    mysql      regular
@@ -3441,8 +3443,8 @@ func (s *StatusSuite) TestFormatTabularHookActionName(c *gc.C) {
 MODEL  CONTROLLER  CLOUD  VERSION
                           
 
-APP  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS
-foo          false                   0    
+APP  VERSION  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS
+foo                   false                   0    
 
 UNIT   WORKLOAD     AGENT      MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE
 foo/0  maintenance  executing                                  (config-changed) doing some work
@@ -3507,8 +3509,8 @@ func (s *StatusSuite) TestFormatTabularMetering(c *gc.C) {
 MODEL  CONTROLLER  CLOUD  VERSION
                           
 
-APP  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS
-foo          false                   0    
+APP  VERSION  STATUS  EXPOSED  ORIGIN  CHARM  REV  OS
+foo                   false                   0    
 
 UNIT   WORKLOAD  AGENT  MACHINE  PORTS  PUBLIC-ADDRESS  MESSAGE
 foo/0                                                   
@@ -0,0 +1,371 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const (
+	// clearScreen moves the cursor to the top left corner of the
+	// terminal and clears it.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// highlightStart and highlightEnd surround the lines of output
+	// that changed since the previous redraw.
+	highlightStart = "\x1b[1m"
+	highlightEnd   = "\x1b[0m"
+)
+
+// allWatcher is the subset of api.AllWatcher used to drive the
+// redrawing of the status.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var newAllWatcherForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status with %T", apiclient)
+	}
+	w, err := client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// isTerminal reports whether the status is being written to a
+// terminal, which can be redrawn in place.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
+
+// runWatch writes the status every time the model changes, until the
+// command is interrupted. On a terminal, the status is redrawn in place
+// with the changed lines highlighted.
+func (c *statusCommand) runWatch(ctx *cmd.Context, apiclient statusAPI) error {
+	formatter := statusFormatters[c.out.Name()]
+	redraw := isTerminal(ctx.Stdout)
+	w, err := newAllWatcherForStatus(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+
+	var stopOnce sync.Once
+	stopWatcher := func() {
+		stopOnce.Do(func() { w.Stop() })
+	}
+	defer stopWatcher()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	done := make(chan struct{})
+	defer close(done)
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-interrupted:
+			close(stopped)
+			// Stopping the watcher unblocks any pending Next call.
+			stopWatcher()
+		case <-done:
+		}
+	}()
+	next := func() ([]multiwatcher.Delta, error) {
+		deltas, err := w.Next()
+		if err != nil {
+			select {
+			case <-stopped:
+				return nil, nil
+			default:
+			}
+			return nil, errors.Annotate(err, "watching model")
+		}
+		return deltas, nil
+	}
+
+	// The first deltas describe the whole model. They are only
+	// recorded, since the status requested after them includes
+	// everything they report.
+	deltas, err := next()
+	if deltas == nil {
+		return errors.Trace(err)
+	}
+	sw := newStatusWatch()
+	sw.apply(deltas)
+
+	var previous []byte
+	for refresh := true; ; refresh = sw.apply(deltas) {
+		if refresh {
+			if sw.status, err = c.requestStatus(ctx, apiclient); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		formatted, err := c.formatStatus(sw.status)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		current, err := formatter(formatted)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if previous == nil || !bytes.Equal(previous, current) {
+			if redraw {
+				fmt.Fprint(ctx.Stdout, clearScreen)
+				ctx.Stdout.Write(highlightChanges(previous, current))
+			} else {
+				if previous != nil {
+					fmt.Fprintln(ctx.Stdout)
+				}
+				ctx.Stdout.Write(current)
+			}
+			previous = current
+		}
+		if deltas, err = next(); deltas == nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// statusWatch keeps the status of the model up to date by applying the
+// changes reported by the all watcher to the status last requested.
+// The status is only requested again when entities shown in it are
+// added or removed, since which entities are shown, and how they are
+// related, is decided by the API server.
+type statusWatch struct {
+	status *params.FullStatus
+
+	// known records the entities reported by the all watcher, so
+	// that new entities can be told from those not shown because
+	// they do not match the filter patterns.
+	known map[multiwatcher.EntityId]bool
+}
+
+func newStatusWatch() *statusWatch {
+	return &statusWatch{known: make(map[multiwatcher.EntityId]bool)}
+}
+
+// apply applies the deltas to the status, and reports whether the
+// status must be requested again because entities that may be shown
+// in it were added or removed.
+func (sw *statusWatch) apply(deltas []multiwatcher.Delta) bool {
+	refresh := false
+	for _, delta := range deltas {
+		id := delta.Entity.EntityId()
+		if delta.Removed {
+			delete(sw.known, id)
+			if sw.shows(delta.Entity) {
+				refresh = true
+			}
+			continue
+		}
+		if !sw.known[id] {
+			sw.known[id] = true
+			switch delta.Entity.(type) {
+			case *multiwatcher.MachineInfo, *multiwatcher.ApplicationInfo,
+				*multiwatcher.UnitInfo, *multiwatcher.RelationInfo:
+				refresh = true
+			}
+			continue
+		}
+		if sw.status == nil {
+			continue
+		}
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.MachineInfo:
+			updateMachine(sw.status.Machines, info)
+		case *multiwatcher.ApplicationInfo:
+			sw.updateApplication(info)
+		case *multiwatcher.UnitInfo:
+			sw.updateUnit(info)
+		}
+	}
+	return refresh
+}
+
+// shows reports whether the entity is shown in the status.
+func (sw *statusWatch) shows(entity multiwatcher.EntityInfo) bool {
+	if sw.status == nil {
+		return false
+	}
+	switch info := entity.(type) {
+	case *multiwatcher.MachineInfo:
+		return findMachine(sw.status.Machines, info.Id) != nil
+	case *multiwatcher.ApplicationInfo:
+		_, ok := sw.status.Applications[info.Name]
+		return ok
+	case *multiwatcher.UnitInfo:
+		return sw.findUnit(info.Application, info.Name) != nil
+	case *multiwatcher.RelationInfo:
+		for _, relation := range sw.status.Relations {
+			if relation.Id == info.Id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findMachine returns the machines map holding the machine or container
+// with the given id, or nil if it is not shown.
+func findMachine(machines map[string]params.MachineStatus, id string) map[string]params.MachineStatus {
+	if _, ok := machines[id]; ok {
+		return machines
+	}
+	for _, machine := range machines {
+		if found := findMachine(machine.Containers, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func updateMachine(machines map[string]params.MachineStatus, info *multiwatcher.MachineInfo) {
+	machines = findMachine(machines, info.Id)
+	if machines == nil {
+		return
+	}
+	machine := machines[info.Id]
+	updateDetailedStatus(&machine.AgentStatus, info.AgentStatus)
+	machine.AgentStatus.Life = lifeString(info.Life)
+	updateDetailedStatus(&machine.InstanceStatus, info.InstanceStatus)
+	if info.InstanceId != "" {
+		machine.InstanceId = instance.Id(info.InstanceId)
+	}
+	machine.Series = info.Series
+	machine.HasVote = info.HasVote
+	machine.WantsVote = info.WantsVote
+	machines[info.Id] = machine
+}
+
+func (sw *statusWatch) updateApplication(info *multiwatcher.ApplicationInfo) {
+	application, ok := sw.status.Applications[info.Name]
+	if !ok {
+		return
+	}
+	application.Charm = info.CharmURL
+	application.Exposed = info.Exposed
+	application.Life = lifeString(info.Life)
+	updateDetailedStatus(&application.Status, info.Status)
+	sw.status.Applications[info.Name] = application
+}
+
+// findUnit returns the units map holding the named unit, or nil if it
+// is not shown. Subordinate units are held by their principals.
+func (sw *statusWatch) findUnit(applicationName, unitName string) map[string]params.UnitStatus {
+	if application, ok := sw.status.Applications[applicationName]; ok {
+		if _, ok := application.Units[unitName]; ok {
+			return application.Units
+		}
+	}
+	for _, application := range sw.status.Applications {
+		for _, unit := range application.Units {
+			if _, ok := unit.Subordinates[unitName]; ok {
+				return unit.Subordinates
+			}
+		}
+	}
+	return nil
+}
+
+func (sw *statusWatch) updateUnit(info *multiwatcher.UnitInfo) {
+	units := sw.findUnit(info.Application, info.Name)
+	if units == nil {
+		return
+	}
+	unit := units[info.Name]
+	updateDetailedStatus(&unit.AgentStatus, info.AgentStatus)
+	updateDetailedStatus(&unit.WorkloadStatus, info.WorkloadStatus)
+	unit.PublicAddress = info.PublicAddress
+	if !info.Subordinate {
+		unit.Machine = info.MachineId
+	}
+	unit.OpenedPorts = nil
+	for _, r := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, portRangeString(r))
+	}
+	// As with the API server, the charm is only shown when it
+	// differs from the application's.
+	unit.Charm = ""
+	if charm := sw.status.Applications[info.Application].Charm; charm != "" && info.CharmURL != charm {
+		unit.Charm = info.CharmURL
+	}
+	units[info.Name] = unit
+}
+
+func updateDetailedStatus(out *params.DetailedStatus, info multiwatcher.StatusInfo) {
+	out.Status = string(info.Current)
+	out.Info = info.Message
+	out.Since = info.Since
+	out.Err = info.Err
+	// Only the relation id is shown of the status data.
+	out.Data = make(map[string]interface{})
+	if id, ok := info.Data["relation-id"]; ok {
+		out.Data["relation-id"] = id
+	}
+	if info.Version != "" {
+		out.Version = info.Version
+	}
+}
+
+// lifeString returns the life as shown in the status, in which alive
+// is the usual state and so omitted.
+func lifeString(life multiwatcher.Life) string {
+	if life == multiwatcher.Life("alive") {
+		return ""
+	}
+	return string(life)
+}
+
+func portRangeString(r multiwatcher.PortRange) string {
+	if r.FromPort == r.ToPort {
+		return fmt.Sprintf("%d/%s", r.FromPort, r.Protocol)
+	}
+	return fmt.Sprintf("%d-%d/%s", r.FromPort, r.ToPort, r.Protocol)
+}
+
+// highlightChanges returns the current output with the lines that
+// were not present in the previous output highlighted. Nothing is
+// highlighted on the first redraw.
+func highlightChanges(previous, current []byte) []byte {
+	if previous == nil {
+		return current
+	}
+	seen := make(map[string]bool)
+	for _, line := range bytes.Split(previous, []byte("\n")) {
+		seen[string(line)] = true
+	}
+	var buf bytes.Buffer
+	lines := bytes.Split(current, []byte("\n"))
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) > 0 && !seen[string(line)] {
+			buf.WriteString(highlightStart)
+			buf.Write(line)
+			buf.WriteString(highlightEnd)
+		} else {
+			buf.Write(line)
+		}
+		if i < len(lines)-1 {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+type fakeWatchApiClient struct {
+	statuses []*params.FullStatus
+	patterns [][]string
+}
+
+func (a *fakeWatchApiClient) Status(patterns []string) (*params.FullStatus, error) {
+	a.patterns = append(a.patterns, patterns)
+	status := a.statuses[0]
+	if len(a.statuses) > 1 {
+		a.statuses = a.statuses[1:]
+	}
+	return status, nil
+}
+
+func (a *fakeWatchApiClient) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, errors.New("boom")
+	}
+	deltas := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+func exposedStatus(exposed bool) *params.FullStatus {
+	return &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {Exposed: exposed},
+		},
+	}
+}
+
+func applicationDelta(exposed bool) multiwatcher.Delta {
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.ApplicationInfo{Name: "foo", Exposed: exposed},
+	}
+}
+
+func (s *StatusSuite) patchWatch(c *gc.C, client *fakeWatchApiClient, watcher *fakeAllWatcher, terminal bool) {
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&newAllWatcherForStatus, func(apiclient statusAPI) (allWatcher, error) {
+		c.Assert(apiclient, gc.Equals, client)
+		return watcher, nil
+	})
+	s.PatchValue(&isTerminal, func(io.Writer) bool {
+		return terminal
+	})
+}
+
+func (s *StatusSuite) TestWatchAppliesChanges(c *gc.C) {
+	client := &fakeWatchApiClient{
+		statuses: []*params.FullStatus{exposedStatus(false)},
+	}
+	watcher := &fakeAllWatcher{deltas: [][]multiwatcher.Delta{
+		{applicationDelta(false)},
+		{applicationDelta(false)},
+		{applicationDelta(true)},
+	}}
+	s.patchWatch(c, client, watcher, true)
+
+	code, stdout, stderr := runStatus(c, "--watch", "--format", "yaml", "foo")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "error: watching model: boom\n")
+	// The status is only requested once; changes to the entities
+	// already shown are applied to it.
+	c.Check(client.patterns, jc.DeepEquals, [][]string{{"foo"}})
+	c.Check(watcher.stopped, jc.IsTrue)
+
+	// The unchanged status is not redrawn.
+	redraws := strings.Split(string(stdout), clearScreen)
+	c.Assert(redraws, gc.HasLen, 3)
+	c.Check(redraws[1], gc.Not(jc.Contains), highlightStart)
+	c.Check(redraws[2], jc.Contains, highlightStart)
+	c.Check(redraws[2], jc.Contains, "exposed: true")
+}
+
+func (s *StatusSuite) TestWatchRequestsStatusForNewEntities(c *gc.C) {
+	client := &fakeWatchApiClient{
+		statuses: []*params.FullStatus{exposedStatus(false), exposedStatus(true)},
+	}
+	watcher := &fakeAllWatcher{deltas: [][]multiwatcher.Delta{
+		{applicationDelta(false)},
+		{{Entity: &multiwatcher.UnitInfo{Name: "foo/0", Application: "foo"}}},
+	}}
+	s.patchWatch(c, client, watcher, true)
+
+	code, stdout, _ := runStatus(c, "--watch", "--format", "yaml", "foo")
+	c.Check(code, gc.Equals, 1)
+	c.Check(client.patterns, jc.DeepEquals, [][]string{{"foo"}, {"foo"}})
+	c.Check(strings.Split(string(stdout), clearScreen), gc.HasLen, 3)
+}
+
+func (s *StatusSuite) TestWatchNotOnTerminal(c *gc.C) {
+	client := &fakeWatchApiClient{
+		statuses: []*params.FullStatus{exposedStatus(false)},
+	}
+	watcher := &fakeAllWatcher{deltas: [][]multiwatcher.Delta{
+		{applicationDelta(false)},
+		{applicationDelta(true)},
+	}}
+	s.patchWatch(c, client, watcher, false)
+
+	code, stdout, _ := runStatus(c, "--watch", "--format", "yaml", "foo")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stdout), gc.Not(jc.Contains), "\x1b")
+	c.Check(string(stdout), jc.Contains, "exposed: false")
+	c.Check(string(stdout), jc.Contains, "exposed: true")
+}
+
+func (s *StatusSuite) TestHighlightChanges(c *gc.C) {
+	out := highlightChanges([]byte("a\nb\n\n"), []byte("a\nc\n\n"))
+	c.Assert(string(out), gc.Equals, "a\n"+highlightStart+"c"+highlightEnd+"\n\n")
+
+	out = highlightChanges(nil, []byte("a\nb\n"))
+	c.Assert(string(out), gc.Equals, "a\nb\n")
+}
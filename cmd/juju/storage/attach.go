@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAttachStorageCommand returns a command used to attach existing,
+// detached storage to a unit.
+func NewAttachStorageCommand() cmd.Command {
+	cmd := &attachStorageCommand{}
+	cmd.newAPIFunc = func() (StorageAttachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	attachStorageCommandDoc = `
+Attaches existing, detached storage instances to a unit. The storage
+must not be attached to any other unit, and must be specified by the
+unit's charm with a matching kind.
+
+Examples:
+    juju attach-storage postgresql/1 pgdata/0
+`
+	attachStorageCommandArgs = `<unit name> <storage ID> [<storage ID> ...]`
+)
+
+// attachStorageCommand attaches existing storage instances to a unit.
+type attachStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageAttachAPI, error)
+	unitId     string
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *attachStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("attach-storage requires a unit and at least one storage ID")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.NotValidf("unit name %q", args[0])
+	}
+	for _, id := range args[1:] {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.unitId = args[0]
+	c.storageIds = args[1:]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *attachStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "attach-storage",
+		Purpose: "Attaches existing storage to a unit.",
+		Doc:     attachStorageCommandDoc,
+		Args:    attachStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *attachStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	unitTag := names.NewUnitTag(c.unitId).String()
+	ids := make([]params.StorageAttachmentId, len(c.storageIds))
+	for i, id := range c.storageIds {
+		ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(id).String(),
+			UnitTag:    unitTag,
+		}
+	}
+	results, err := api.Attach(ids)
+	if err != nil {
+		return err
+	}
+	return reportStorageAttachmentResults(ctx, c.storageIds, results, "attaching")
+}
+
+// StorageAttachAPI defines the API methods that the attach-storage
+// command uses.
+type StorageAttachAPI interface {
+	Close() error
+	Attach([]params.StorageAttachmentId) ([]params.ErrorResult, error)
+}
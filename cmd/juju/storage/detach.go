@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewDetachStorageCommand returns a command used to detach storage
+// from the units that they are attached to.
+func NewDetachStorageCommand() cmd.Command {
+	cmd := &detachStorageCommand{}
+	cmd.newAPIFunc = func() (StorageDetachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	detachStorageCommandDoc = `
+Detaches storage instances from the units that they are attached to.
+Detached storage is not destroyed, and may later be attached to another
+unit of the same application with "juju attach-storage", or to a new unit
+with "juju add-unit --attach-storage".
+
+Storage that is required by the unit's charm, and storage that is bound
+to the lifetime of the unit's machine, cannot be detached.
+
+Examples:
+    juju detach-storage pgdata/0
+`
+	detachStorageCommandArgs = `<storage ID> [<storage ID> ...]`
+)
+
+// detachStorageCommand detaches storage instances from their units.
+type detachStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageDetachAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *detachStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("detach-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *detachStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detach-storage",
+		Purpose: "Detaches storage from units.",
+		Doc:     detachStorageCommandDoc,
+		Args:    detachStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *detachStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	ids := make([]params.StorageAttachmentId, len(c.storageIds))
+	for i, id := range c.storageIds {
+		ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(id).String(),
+		}
+	}
+	results, err := api.Detach(ids)
+	if err != nil {
+		return err
+	}
+	return reportStorageAttachmentResults(ctx, c.storageIds, results, "detaching")
+}
+
+// StorageDetachAPI defines the API methods that the detach-storage
+// command uses.
+type StorageDetachAPI interface {
+	Close() error
+	Detach([]params.StorageAttachmentId) ([]params.ErrorResult, error)
+}
+
+// reportStorageAttachmentResults writes the outcome of modifying the
+// attachments of the given storage instances to the context, returning
+// cmd.ErrSilent if any of the operations failed.
+func reportStorageAttachmentResults(ctx *cmd.Context, storageIds []string, results []params.ErrorResult, action string) error {
+	var done []string
+	var failures []string
+	for i, result := range results {
+		if result.Error != nil {
+			failures = append(failures, fmt.Sprintf("failed %s %s: %v", action, storageIds[i], result.Error))
+			continue
+		}
+		done = append(done, fmt.Sprintf("%s %s", action, storageIds[i]))
+	}
+	if len(done) > 0 {
+		fmt.Fprintln(ctx.Stdout, strings.Join(done, newline))
+	}
+	if len(failures) > 0 {
+		fmt.Fprintln(ctx.Stderr, strings.Join(failures, newline))
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type DetachStorageSuite struct {
+	SubStorageSuite
+	fake fakeStorageAttachmentAPI
+}
+
+var _ = gc.Suite(&DetachStorageSuite{})
+
+func (s *DetachStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.fake = fakeStorageAttachmentAPI{}
+}
+
+func (s *DetachStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewDetachStorageCommandForTest(&s.fake, s.store), args...)
+}
+
+func (s *DetachStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "detach-storage requires at least one storage ID")
+	_, err = s.run(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *DetachStorageSuite) TestDetach(c *gc.C) {
+	ctx, err := s.run(c, "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.ids, jc.DeepEquals, []params.StorageAttachmentId{
+		{StorageTag: "storage-foo-0"},
+		{StorageTag: "storage-bar-1"},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, "detaching foo/0\ndetaching bar/1\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "")
+}
+
+func (s *DetachStorageSuite) TestDetachFailure(c *gc.C) {
+	s.fake.results = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "storage is required by the charm"}},
+	}
+	ctx, err := s.run(c, "foo/0", "bar/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "detaching foo/0\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "failed detaching bar/1: storage is required by the charm\n")
+}
+
+func (s *DetachStorageSuite) TestDetachAPIError(c *gc.C) {
+	s.fake.err = errors.New("boom")
+	_, err := s.run(c, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeStorageAttachmentAPI struct {
+	ids     []params.StorageAttachmentId
+	results []params.ErrorResult
+	err     error
+}
+
+func (f *fakeStorageAttachmentAPI) Close() error {
+	return nil
+}
+
+func (f *fakeStorageAttachmentAPI) Detach(ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+	return f.modify(ids)
+}
+
+func (f *fakeStorageAttachmentAPI) Attach(ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+	return f.modify(ids)
+}
+
+func (f *fakeStorageAttachmentAPI) modify(ids []params.StorageAttachmentId) ([]params.ErrorResult, error) {
+	f.ids = ids
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.results != nil {
+		return f.results, nil
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
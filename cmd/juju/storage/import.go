@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/storage"
+)
+
+// NewImportVolumeCommand returns a command used to import an existing
+// volume into the model.
+func NewImportVolumeCommand() cmd.Command {
+	cmd := &importVolumeCommand{}
+	cmd.newAPIFunc = func() (StorageImporter, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	importVolumeCommandDoc = `
+Imports an existing volume, managed by the cloud provider, into the
+model as detached storage. The volume must be specified by its provider
+ID, and must not be in use. The volume will be associated with the
+specified storage pool, and will be tagged as belonging to the model.
+
+The imported storage is assigned the specified storage name, and may
+then be attached to a unit whose charm specifies block storage with the
+same name, using the attach-storage command.
+
+A volume that is tagged as belonging to another model is not imported
+unless --force is specified. Importing such a volume takes it over
+from the other model, which must no longer be using it.
+
+Examples:
+    juju import-volume ebs vol-123456 pgdata
+    juju import-volume --force ebs vol-123456 pgdata
+`
+	importVolumeCommandArgs = `<storage pool> <provider ID> <storage name>`
+)
+
+// importVolumeCommand imports an existing volume into the model.
+type importVolumeCommand struct {
+	StorageCommandBase
+	newAPIFunc  func() (StorageImporter, error)
+	storagePool string
+	providerId  string
+	storageName string
+	force       bool
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importVolumeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Import the volume even if it belongs to another model")
+}
+
+// Init implements Command.Init.
+func (c *importVolumeCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.New("import-volume requires a storage pool, provider ID, and storage name")
+	}
+	if err := cmd.CheckEmpty(args[3:]); err != nil {
+		return err
+	}
+	if !names.IsValidStorageName(args[2]) {
+		return errors.NotValidf("storage name %q", args[2])
+	}
+	c.storagePool = args[0]
+	c.providerId = args[1]
+	c.storageName = args[2]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *importVolumeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-volume",
+		Purpose: "Imports an existing volume into the model.",
+		Doc:     importVolumeCommandDoc,
+		Args:    importVolumeCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *importVolumeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	ctx.Infof("importing %q from storage pool %q as storage %q", c.providerId, c.storagePool, c.storageName)
+	storageTag, err := api.Import(storage.StorageKindBlock, c.storagePool, c.providerId, c.storageName, c.force)
+	if err != nil {
+		return err
+	}
+	ctx.Infof("imported storage %s", storageTag.Id())
+	return nil
+}
+
+// StorageImporter defines the API methods that the import-volume
+// command uses.
+type StorageImporter interface {
+	Close() error
+	Import(
+		kind storage.StorageKind,
+		storagePool, storageProviderId, storageName string,
+		force bool,
+	) (names.StorageTag, error)
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ImportVolumeSuite struct {
+	SubStorageSuite
+	importer mockStorageImporter
+}
+
+var _ = gc.Suite(&ImportVolumeSuite{})
+
+func (s *ImportVolumeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.importer = mockStorageImporter{}
+}
+
+func (s *ImportVolumeSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewImportVolumeCommandForTest(&s.importer, s.store), args...)
+}
+
+func (s *ImportVolumeSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "ebs", "vol-123")
+	c.Assert(err, gc.ErrorMatches, "import-volume requires a storage pool, provider ID, and storage name")
+	_, err = s.run(c, "ebs", "vol-123", "0data")
+	c.Assert(err, gc.ErrorMatches, `storage name "0data" not valid`)
+	_, err = s.run(c, "ebs", "vol-123", "data", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ImportVolumeSuite) TestImportVolume(c *gc.C) {
+	ctx, err := s.run(c, "ebs", "vol-123", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	s.importer.CheckCalls(c, []jujutesting.StubCall{
+		{"Import", []interface{}{jujustorage.StorageKindBlock, "ebs", "vol-123", "data", false}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+importing "vol-123" from storage pool "ebs" as storage "data"
+imported storage data/0
+`[1:])
+}
+
+func (s *ImportVolumeSuite) TestImportVolumeForce(c *gc.C) {
+	_, err := s.run(c, "--force", "ebs", "vol-123", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	s.importer.CheckCall(c, 0, "Import", jujustorage.StorageKindBlock, "ebs", "vol-123", "data", true)
+}
+
+func (s *ImportVolumeSuite) TestImportVolumeError(c *gc.C) {
+	s.importer.SetErrors(errors.New("nope"))
+	_, err := s.run(c, "ebs", "vol-123", "data")
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
+
+type mockStorageImporter struct {
+	jujutesting.Stub
+}
+
+func (m *mockStorageImporter) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockStorageImporter) Import(
+	kind jujustorage.StorageKind,
+	storagePool, storageProviderId, storageName string,
+	force bool,
+) (names.StorageTag, error) {
+	m.MethodCall(m, "Import", kind, storagePool, storageProviderId, storageName, force)
+	return names.NewStorageTag(storageName + "/0"), m.NextErr()
+}
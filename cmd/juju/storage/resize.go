@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommand returns a command used to grow storage
+// instances.
+func NewResizeStorageCommand() cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newAPIFunc = func() (StorageResizer, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grows a storage instance to the specified size. Block storage, and
+filesystem storage backed by a volume, may be resized. Storage may not
+be shrunk. The request is rejected if the storage provider does not
+support resizing volumes.
+
+The resize is carried out asynchronously. For filesystem storage, the
+filesystem that Juju created on the volume is grown to fill it. Once
+the storage has been resized, the "storage-attached" hook will be run
+again for the unit the storage is attached to, so that the charm may
+make use of the additional space. If the volume cannot be resized, the
+resize is abandoned and the volume's status is set to "error".
+
+Examples:
+    juju resize-storage pgdata/0 20G
+`
+	resizeStorageCommandArgs = `<storage ID> <size>`
+)
+
+// resizeStorageCommand grows a storage instance.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageResizer, error)
+	storageId  string
+	size       uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("resize-storage requires a storage ID and size")
+	}
+	if err := cmd.CheckEmpty(args[2:]); err != nil {
+		return err
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.NotValidf("size 0")
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows a storage instance.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.Resize(names.NewStorageTag(c.storageId), c.size); err != nil {
+		return err
+	}
+	ctx.Infof("resizing storage %s to %dM", c.storageId, c.size)
+	return nil
+}
+
+// StorageResizer defines the API methods that the resize-storage
+// command uses.
+type StorageResizer interface {
+	Close() error
+	Resize(names.StorageTag, uint64) error
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type ResizeStorageSuite struct {
+	SubStorageSuite
+	resizer mockStorageResizer
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.resizer = mockStorageResizer{}
+}
+
+func (s *ResizeStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewResizeStorageCommandForTest(&s.resizer, s.store), args...)
+}
+
+func (s *ResizeStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, "resize-storage requires a storage ID and size")
+	_, err = s.run(c, "data", "10G")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+	_, err = s.run(c, "data/0", "big")
+	c.Assert(err, gc.ErrorMatches, "cannot parse size: .*")
+	_, err = s.run(c, "data/0", "0")
+	c.Assert(err, gc.ErrorMatches, "size 0 not valid")
+	_, err = s.run(c, "data/0", "10G", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ResizeStorageSuite) TestResizeStorage(c *gc.C) {
+	ctx, err := s.run(c, "data/0", "10G")
+	c.Assert(err, jc.ErrorIsNil)
+	s.resizer.CheckCalls(c, []jujutesting.StubCall{
+		{"Resize", []interface{}{names.NewStorageTag("data/0"), uint64(10240)}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "resizing storage data/0 to 10240M\n")
+}
+
+func (s *ResizeStorageSuite) TestResizeStorageError(c *gc.C) {
+	s.resizer.SetErrors(errors.New("nope"))
+	_, err := s.run(c, "data/0", "10G")
+	c.Assert(err, gc.ErrorMatches, "nope")
+}
+
+type mockStorageResizer struct {
+	jujutesting.Stub
+}
+
+func (m *mockStorageResizer) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockStorageResizer) Resize(storageTag names.StorageTag, size uint64) error {
+	m.MethodCall(m, "Resize", storageTag, size)
+	return m.NextErr()
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/utils/voyeur"
 	"github.com/juju/version"
 	"gopkg.in/juju/charmrepo.v2-unstable"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -52,7 +53,9 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
 	jujunames "github.com/juju/juju/juju/names"
@@ -70,12 +73,12 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
-	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/imagemetadataworker"
 	"github.com/juju/juju/worker/logsender"
+	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
 	"github.com/juju/juju/worker/peergrouper"
@@ -100,6 +103,10 @@ var (
 	newMetadataUpdater    = imagemetadataworker.NewWorker
 	newUpgradeMongoWorker = mongoupgrader.New
 	reportOpenedState     = func(*state.State) {}
+
+	// migrationMinionReportTimeout is how long the migration master
+	// waits for the agents in a model to report back for each phase.
+	migrationMinionReportTimeout = migrationmaster.DefaultMinionReportTimeout
 )
 
 // Variable to override in tests, default is true
@@ -454,6 +461,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			StartAPIWorkers:      a.startAPIWorkers,
 			PreUpgradeSteps:      upgrades.PreUpgradeSteps,
 			LogSource:            a.bufferedLogs,
+			LogDir:               a.CurrentConfig().LogDir(),
 			NewDeployContext:     newDeployContext,
 			Clock:                clock.WallClock,
 		})
@@ -948,10 +956,6 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 				return newCertificateUpdater(m, agentConfig, st, st, stateServingSetter), nil
 			})
 
-			a.startWorkerAfterUpgrade(singularRunner, "dblogpruner", func() (worker.Worker, error) {
-				return dblogpruner.New(st, dblogpruner.NewLogPruneParams()), nil
-			})
-
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
@@ -982,19 +986,15 @@ func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
 	}
 
 	manifolds := modelManifolds(model.ManifoldsConfig{
-		Agent:                       modelAgent,
-		AgentConfigChanged:          a.configChangedVal,
-		Clock:                       clock.WallClock,
-		RunFlagDuration:             time.Minute,
-		CharmRevisionUpdateInterval: 24 * time.Hour,
-		InstPollerAggregationDelay:  3 * time.Second,
-		// TODO(perrito666) the status history pruning numbers need
-		// to be adjusting, after collecting user data from large install
-		// bases, to numbers allowing a rich and useful back history.
-		StatusHistoryPrunerMaxHistoryTime: 336 * time.Hour, // 2 weeks
-		StatusHistoryPrunerMaxHistoryMB:   5120,            // 5G
-		StatusHistoryPrunerInterval:       5 * time.Minute,
-		SpacesImportedGate:                a.discoverSpacesComplete,
+		Agent:                        modelAgent,
+		AgentConfigChanged:           a.configChangedVal,
+		Clock:                        clock.WallClock,
+		RunFlagDuration:              time.Minute,
+		CharmRevisionUpdateInterval:  24 * time.Hour,
+		InstPollerAggregationDelay:   3 * time.Second,
+		StatusHistoryPrunerInterval:  5 * time.Minute,
+		SpacesImportedGate:           a.discoverSpacesComplete,
+		MigrationMinionReportTimeout: migrationMinionReportTimeout,
 	})
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
@@ -1044,6 +1044,19 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 	dataDir := agentConfig.DataDir()
 	logDir := agentConfig.LogDir()
 
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read controller config")
+	}
+	auditEntrySink := newAuditEntrySink(st, logDir, controllerConfig.AuditLogFile())
+	auditContext := observer.AuditContext{
+		JujuServerVersion: jujuversion.Current,
+		ModelUUID:         agentConfig.Model().Id(),
+		Clock:             clock.WallClock,
+		SecretKeys:        auditSecretKeys(),
+		ExcludeMethod:     auditExcludeMethodFn(controllerConfig.AuditLogExcludeMethods()),
+	}
+
 	endpoint := net.JoinHostPort("", strconv.Itoa(info.APIPort))
 	listener, err := net.Listen("tcp", endpoint)
 	if err != nil {
@@ -1067,9 +1080,8 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 		CertChanged: certChanged,
 		NewObserver: newObserverFn(
 			clock.WallClock,
-			jujuversion.Current,
-			agentConfig.Model().Id(),
-			newAuditEntrySink(st, logDir),
+			auditContext,
+			auditEntrySink,
 			auditErrorHandler,
 		),
 	})
@@ -1080,9 +1092,15 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 	return server, nil
 }
 
-func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
+// newAuditEntrySink returns a sink which records user requests in the
+// controller's audit log collection and, if withFile is true, in an
+// audit.log file in logDir.
+func newAuditEntrySink(st *state.State, logDir string, withFile bool) audit.AuditEntrySinkFn {
 	persistFn := st.PutAuditEntryFn()
-	fileSinkFn := audit.NewLogFileSink(logDir)
+	var fileSinkFn audit.AuditEntrySinkFn
+	if withFile {
+		fileSinkFn = audit.NewLogFileSink(logDir)
+	}
 	return func(entry audit.AuditEntry) error {
 		// We don't care about auditing anything but user actions.
 		if _, err := names.ParseUserTag(entry.OriginName); err != nil {
@@ -1093,6 +1111,9 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 			return nil
 		}
 		persistErr := persistFn(entry)
+		if fileSinkFn == nil {
+			return errors.Annotate(persistErr, "cannot save audit record to database")
+		}
 		sinkErr := fileSinkFn(entry)
 		if persistErr == nil {
 			return errors.Annotate(sinkErr, "cannot save audit record to file")
@@ -1104,10 +1125,58 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 	}
 }
 
+// auditSecretKeys returns the names of the configuration and
+// credential attributes whose values are redacted from the arguments
+// recorded in audit entries.
+func auditSecretKeys() set.Strings {
+	keys := set.NewStrings()
+	addSecrets := func(fields environschema.Fields) {
+		for name, attr := range fields {
+			if attr.Secret {
+				keys.Add(name)
+			}
+		}
+	}
+	if fields, err := config.Schema(nil); err == nil {
+		addSecrets(fields)
+	} else {
+		logger.Warningf("cannot read config schema for audit redaction: %v", err)
+	}
+	for _, providerType := range environs.RegisteredProviders() {
+		provider, err := environs.Provider(providerType)
+		if err != nil {
+			continue
+		}
+		if schemaProvider, ok := provider.(environs.ProviderSchema); ok {
+			addSecrets(schemaProvider.Schema())
+		}
+		for _, schema := range provider.CredentialSchemas() {
+			for _, attr := range schema {
+				if attr.Hidden {
+					keys.Add(attr.Name)
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// auditExcludeMethodFn returns a function reporting whether calls to
+// a facade method should be left out of the audit log, given the
+// methods excluded in controller config.
+func auditExcludeMethodFn(excluded set.Strings) func(facade, method string) bool {
+	readOnly := excluded.Contains(controller.ReadOnlyMethodsWildcard)
+	return func(facade, method string) bool {
+		if excluded.Contains(facade + "." + method) {
+			return true
+		}
+		return readOnly && apiserver.IsCallReadOnly(facade, method)
+	}
+}
+
 func newObserverFn(
 	clock clock.Clock,
-	jujuServerVersion version.Number,
-	modelUUID string,
+	auditContext observer.AuditContext,
 	persistAuditEntry audit.AuditEntrySinkFn,
 	auditErrorHandler observer.ErrorHandler,
 ) observer.ObserverFactory {
@@ -1122,12 +1191,9 @@ func newObserverFn(
 			return observer.NewRequestNotifier(ctx, atomic.AddInt64(&connectionID, 1))
 		},
 		func() observer.Observer {
-			ctx := &observer.AuditContext{
-				JujuServerVersion: jujuServerVersion,
-				ModelUUID:         modelUUID,
-			}
+			ctx := auditContext
 			// TODO(katco): Pass in an error channel
-			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
+			return observer.NewAudit(&ctx, persistAuditEntry, auditErrorHandler)
 		},
 	)
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
@@ -104,6 +105,10 @@ type ManifoldsConfig struct {
 	// structs within the machine agent.
 	LogSource logsender.LogRecordCh
 
+	// LogDir is the agent's log directory. Log records forwarded to
+	// a local file are written beneath it.
+	LogDir string
+
 	// newDeployContext gives the tests the opportunity to create a deployer.Context
 	// that can be used for testing so as to avoid (1) deploying units to the system
 	// running the tests and (2) get access to the *State used internally, so that
@@ -401,11 +406,23 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+		// The db log pruner removes old log entries, within the
+		// retention limits of each model. It runs on every controller
+		// machine, but only prunes on the MongoDB master.
+		dbLogPrunerName: ifFullyUpgraded(dblogpruner.Manifold(dblogpruner.ManifoldConfig{
+			AgentName:   agentName,
+			StateName:   stateName,
+			PruneParams: dblogpruner.NewLogPruneParams(),
+		})),
+
 		logForwarderName: ifFullyUpgraded(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			StateName:     stateName,
 			APICallerName: apiCallerName,
 			SinkOpeners: []logforwarder.LogSinkFn{
 				sinks.OpenSyslog,
+				sinks.OpenHTTP,
+				sinks.OpenGELF,
+				sinks.NewFileOpener(config.LogDir),
 			},
 		})),
 	}
@@ -455,4 +472,5 @@ const (
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
 	logForwarderName         = "log-forwarder"
+	dbLogPrunerName          = "db-log-pruner"
 )
@@ -46,6 +46,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-address-updater",
 		"api-caller",
 		"api-config-watcher",
+		"db-log-pruner",
 		"disk-manager",
 		"host-key-reporter",
 		"log-forwarder",
@@ -332,7 +332,7 @@ func (s *MachineSuite) TestManageModel(c *gc.C) {
 	svc := s.AddTestingService(c, "test-service", charm)
 	err := svc.SetExposed()
 	c.Assert(err, jc.ErrorIsNil)
-	units, err := juju.AddUnits(s.State, svc, 1, nil)
+	units, err := juju.AddUnits(s.State, svc, 1, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// It should be allocated to a machine, which should then be provisioned.
@@ -398,7 +398,7 @@ func (s *MachineSuite) TestManageModelRunsInstancePoller(c *gc.C) {
 	// Add one unit to a service;
 	charm := s.AddTestingCharm(c, "dummy")
 	svc := s.AddTestingService(c, "test-service", charm)
-	units, err := juju.AddUnits(s.State, svc, 1, nil)
+	units, err := juju.AddUnits(s.State, svc, 1, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	m, instId := s.waitProvisioned(c, units[0])
@@ -442,16 +442,6 @@ func (s *MachineSuite) TestManageModelRunsPeergrouper(c *gc.C) {
 	started.assertTriggered(c, "peergrouperworker to start")
 }
 
-func (s *MachineSuite) TestManageModelRunsDbLogPrunerIfFeatureFlagEnabled(c *gc.C) {
-	m, _, _ := s.primeAgent(c, state.JobManageModel)
-	a := s.newAgent(c, m)
-	defer func() { c.Check(a.Stop(), jc.ErrorIsNil) }()
-	go func() { c.Check(a.Run(nil), jc.ErrorIsNil) }()
-
-	runner := s.singularRecord.nextRunner(c)
-	runner.waitForWorker(c, "dblogpruner")
-}
-
 func (s *MachineSuite) TestManageModelCallsUseMultipleCPUs(c *gc.C) {
 	// If it has been enabled, the JobManageModel agent should call utils.UseMultipleCPUs
 	usefulVersion := version.Binary{
@@ -1384,3 +1374,29 @@ func (s *MachineSuite) TestReplicasetInitForNewController(c *gc.C) {
 	c.Assert(s.fakeEnsureMongo.EnsureCount, gc.Equals, 1)
 	c.Assert(s.fakeEnsureMongo.InitiateCount, gc.Equals, 0)
 }
+
+type machineAuditSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&machineAuditSuite{})
+
+func (s *machineAuditSuite) TestAuditExcludeMethod(c *gc.C) {
+	exclude := auditExcludeMethodFn(set.NewStrings("Application.Deploy"))
+	c.Check(exclude("Application", "Deploy"), jc.IsTrue)
+	c.Check(exclude("Application", "Destroy"), jc.IsFalse)
+	c.Check(exclude("Client", "FullStatus"), jc.IsFalse)
+
+	exclude = auditExcludeMethodFn(set.NewStrings("ReadOnlyMethods"))
+	c.Check(exclude("Client", "FullStatus"), jc.IsTrue)
+	c.Check(exclude("ModelManager", "DestroyModel"), jc.IsFalse)
+
+	exclude = auditExcludeMethodFn(set.NewStrings())
+	c.Check(exclude("Client", "FullStatus"), jc.IsFalse)
+}
+
+func (s *machineAuditSuite) TestAuditSecretKeys(c *gc.C) {
+	keys := auditSecretKeys()
+	c.Check(keys.Contains("admin-secret"), jc.IsTrue)
+	c.Check(keys.Contains("name"), jc.IsFalse)
+}
@@ -74,15 +74,18 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
-	// StatusHistoryPruner* values control status-history pruning
-	// behaviour.
-	StatusHistoryPrunerMaxHistoryTime time.Duration
-	StatusHistoryPrunerMaxHistoryMB   uint
-	StatusHistoryPrunerInterval       time.Duration
+	// StatusHistoryPrunerInterval determines how often the status
+	// history is pruned; the retention limits come from model config.
+	StatusHistoryPrunerInterval time.Duration
 
 	// SpacesImportedGate will be unlocked when spaces are known to
 	// have been imported.
 	SpacesImportedGate gate.Lock
+
+	// MigrationMinionReportTimeout is how long the migration master
+	// will wait for the agents in a model to report back for each
+	// migration phase before aborting the migration.
+	MigrationMinionReportTimeout time.Duration
 }
 
 // Manifolds returns a set of interdependent dependency manifolds that will
@@ -148,6 +151,9 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		migrationMasterName: ifNotDead(migrationmaster.Manifold(migrationmaster.ManifoldConfig{
 			APICallerName: apiCallerName,
 			FortressName:  migrationFortressName,
+			ClockName:     clockName,
+
+			MinionReportTimeout: config.MigrationMinionReportTimeout,
 
 			NewFacade: migrationmaster.NewFacade,
 			NewWorker: migrationmaster.NewWorker,
@@ -239,10 +245,8 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 		statusHistoryPrunerName: ifNotDead(statushistorypruner.Manifold(statushistorypruner.ManifoldConfig{
-			APICallerName:  apiCallerName,
-			MaxHistoryTime: config.StatusHistoryPrunerMaxHistoryTime,
-			MaxHistoryMB:   config.StatusHistoryPrunerMaxHistoryMB,
-			PruneInterval:  config.StatusHistoryPrunerInterval,
+			APICallerName: apiCallerName,
+			PruneInterval: config.StatusHistoryPrunerInterval,
 			// TODO(fwereade): 2016-03-17 lp:1558657
 			NewTimer: worker.NewTimer,
 		})),
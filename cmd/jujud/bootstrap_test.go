@@ -888,7 +888,7 @@ func (s *BootstrapSuite) TestDefaultStoragePools(c *gc.C) {
 
 	settings := state.NewStateSettings(st)
 	pm := poolmanager.New(settings)
-	for _, p := range []string{"ebs-ssd"} {
+	for _, p := range []string{"ebs-ssd", "lxd-zfs", "lxd-btrfs"} {
 		_, err = pm.Get(p)
 		c.Assert(err, jc.ErrorIsNil)
 	}
@@ -10,10 +10,12 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
@@ -48,12 +50,30 @@ const (
 	// NumaControlPolicyKey stores the value for this setting
 	SetNumaControlPolicyKey = "set-numa-control-policy"
 
+	// AuditLogFileKey stores whether audit entries are also written
+	// to an audit.log file on each controller machine.
+	AuditLogFileKey = "audit-log-file"
+
+	// AuditLogExcludeMethodsKey stores a comma-separated list of API
+	// methods, as "Facade.Method", which are not recorded in the
+	// audit log.
+	AuditLogExcludeMethodsKey = "audit-log-exclude-methods"
+
+	// ReadOnlyMethodsWildcard may be given in the audit log exclusion
+	// list to stand for every API method known not to change the
+	// model.
+	ReadOnlyMethodsWildcard = "ReadOnlyMethods"
+
 	// Attribute Defaults
 
 	// DefaultNumaControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNumaControlPolicy = false
 
+	// DefaultAuditLogFile keeps writing audit entries to the log
+	// file on controller machines, as well as to the database.
+	DefaultAuditLogFile = true
+
 	// DefaultStatePort is the default port the controller is listening on.
 	DefaultStatePort int = 37017
 
@@ -72,6 +92,8 @@ var ControllerOnlyConfigAttributes = []string{
 	IdentityURL,
 	IdentityPublicKey,
 	SetNumaControlPolicyKey,
+	AuditLogFileKey,
+	AuditLogExcludeMethodsKey,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -193,6 +215,28 @@ func (c Config) NumaCtlPreference() bool {
 	return DefaultNumaControlPolicy
 }
 
+// AuditLogFile returns whether audit entries should also be written
+// to a log file on each controller machine.
+func (c Config) AuditLogFile() bool {
+	if value, ok := c[AuditLogFileKey].(bool); ok {
+		return value
+	}
+	return DefaultAuditLogFile
+}
+
+// AuditLogExcludeMethods returns the API methods, as "Facade.Method",
+// which should not be recorded in the audit log. The set may include
+// ReadOnlyMethodsWildcard.
+func (c Config) AuditLogExcludeMethods() set.Strings {
+	methods := set.NewStrings()
+	for _, method := range strings.Split(c.asString(AuditLogExcludeMethodsKey), ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			methods.Add(method)
+		}
+	}
+	return methods
+}
+
 // maybeReadAttrFromFile sets defined[attr] to:
 //
 // 1) The content of the file defined[attr+"-path"], if that's set
@@ -281,6 +325,15 @@ func Validate(c Config) error {
 		}
 	}
 
+	for _, method := range c.AuditLogExcludeMethods().Values() {
+		if method == ReadOnlyMethodsWildcard {
+			continue
+		}
+		if parts := strings.Split(method, "."); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Errorf("invalid audit log exclusion %q: expected Facade.Method or %s", method, ReadOnlyMethodsWildcard)
+		}
+	}
+
 	if uuid, ok := c[ControllerUUIDKey].(string); ok && !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -348,6 +401,16 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	AuditLogFileKey: {
+		Description: "Also write audit entries to a log file on each controller machine (default true)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	AuditLogExcludeMethodsKey: {
+		Description: "Comma-separated list of API methods, as Facade.Method, which are not recorded in the audit log; " + ReadOnlyMethodsWildcard + " excludes all read-only methods",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	IdentityURL: {
 		Description: "IdentityURL specifies the URL of the identity manager",
 		Type:        environschema.Tstring,
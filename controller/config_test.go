@@ -72,3 +72,30 @@ func (s *ConfigSuite) TestGenerateControllerCertAndKey(c *gc.C) {
 		c.Assert(sanIPs, jc.SameContents, test.sanValues)
 	}
 }
+
+func (s *ConfigSuite) TestAuditLogFile(c *gc.C) {
+	c.Assert(controller.Config{}.AuditLogFile(), jc.IsTrue)
+	cfg := controller.Config{controller.AuditLogFileKey: false}
+	c.Assert(cfg.AuditLogFile(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestAuditLogExcludeMethods(c *gc.C) {
+	c.Assert(controller.Config{}.AuditLogExcludeMethods().Values(), gc.HasLen, 0)
+	cfg := controller.Config{
+		controller.AuditLogExcludeMethodsKey: "ReadOnlyMethods, Client.FullStatus,,",
+	}
+	c.Assert(cfg.AuditLogExcludeMethods().SortedValues(), jc.DeepEquals, []string{
+		"Client.FullStatus", "ReadOnlyMethods",
+	})
+}
+
+func (s *ConfigSuite) TestValidateAuditLogExcludeMethods(c *gc.C) {
+	err := controller.Validate(controller.Config{
+		controller.AuditLogExcludeMethodsKey: "ReadOnlyMethods,Client.FullStatus",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = controller.Validate(controller.Config{
+		controller.AuditLogExcludeMethodsKey: "Client.FullStatus,Pinger",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid audit log exclusion "Pinger": expected Facade.Method or ReadOnlyMethods`)
+}
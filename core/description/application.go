@@ -32,6 +32,8 @@ type application struct {
 	Exposed_    bool `yaml:"exposed,omitempty"`
 	MinUnits_   int  `yaml:"min-units,omitempty"`
 
+	ExposedEndpoints_ map[string]*exposedEndpoint `yaml:"exposed-endpoints,omitempty"`
+
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
 
@@ -46,6 +48,8 @@ type application struct {
 	// unit count will be assumed by the number of units associated.
 	Units_ units `yaml:"units"`
 
+	Resources_ resources `yaml:"resources"`
+
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
@@ -63,6 +67,7 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedEndpoints     map[string]ExposedEndpointArgs
 	MinUnits             int
 	Settings             map[string]interface{}
 	SettingsRefCount     int
@@ -90,7 +95,17 @@ func newApplication(args ApplicationArgs) *application {
 		MetricsCredentials_:   creds,
 		StatusHistory_:        newStatusHistory(),
 	}
+	if len(args.ExposedEndpoints) > 0 {
+		svc.ExposedEndpoints_ = make(map[string]*exposedEndpoint)
+		for name, ep := range args.ExposedEndpoints {
+			svc.ExposedEndpoints_[name] = &exposedEndpoint{
+				ExposeToSpaces_: ep.ExposeToSpaces,
+				ExposeToCIDRs_:  ep.ExposeToCIDRs,
+			}
+		}
+	}
 	svc.setUnits(nil)
+	svc.setResources(nil)
 	return svc
 }
 
@@ -139,6 +154,18 @@ func (s *application) Exposed() bool {
 	return s.Exposed_
 }
 
+// ExposedEndpoints implements Application.
+func (s *application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(s.ExposedEndpoints_) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint)
+	for name, ep := range s.ExposedEndpoints_ {
+		result[name] = ep
+	}
+	return result
+}
+
 // MinUnits implements Application.
 func (s *application) MinUnits() int {
 	return s.MinUnits_
@@ -219,6 +246,30 @@ func (s *application) setUnits(unitList []*unit) {
 	}
 }
 
+// Resources implements Application.
+func (s *application) Resources() []Resource {
+	rs := s.Resources_.Resources_
+	result := make([]Resource, len(rs))
+	for i, r := range rs {
+		result[i] = r
+	}
+	return result
+}
+
+// AddResource implements Application.
+func (s *application) AddResource(args ResourceArgs) Resource {
+	r := newResource(args)
+	s.Resources_.Resources_ = append(s.Resources_.Resources_, r)
+	return r
+}
+
+func (s *application) setResources(resourceList []*resource) {
+	s.Resources_ = resources{
+		Version:    1,
+		Resources_: resourceList,
+	}
+}
+
 // Constraints implements HasConstraints.
 func (s *application) Constraints() Constraints {
 	if s.Constraints_ == nil {
@@ -255,6 +306,11 @@ func (s *application) Validate() error {
 	if s.Leader_ != "" && !leaderFound {
 		return errors.NotValidf("missing unit for leader %q", s.Leader_)
 	}
+	for _, r := range s.Resources_.Resources_ {
+		if err := r.Validate(); err != nil {
+			return errors.Annotatef(err, "application %q", s.Name_)
+		}
+	}
 	return nil
 }
 
@@ -307,6 +363,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-endpoints":   schema.StringMap(schema.StringMap(schema.Any())),
 		"min-units":           schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
@@ -315,15 +372,18 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"leadership-settings": schema.StringMap(schema.Any()),
 		"metrics-creds":       schema.String(),
 		"units":               schema.StringMap(schema.Any()),
+		"resources":           schema.StringMap(schema.Any()),
 	}
 
 	defaults := schema.Defaults{
-		"subordinate":   false,
-		"force-charm":   false,
-		"exposed":       false,
-		"min-units":     int64(0),
-		"leader":        "",
-		"metrics-creds": "",
+		"subordinate":       false,
+		"force-charm":       false,
+		"exposed":           false,
+		"exposed-endpoints": schema.Omit,
+		"min-units":         int64(0),
+		"leader":            "",
+		"metrics-creds":     "",
+		"resources":         schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -366,6 +426,14 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		result.Constraints_ = constraints
 	}
 
+	if exposedMap, ok := valid["exposed-endpoints"]; ok {
+		exposed, err := importExposedEndpoints(exposedMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.ExposedEndpoints_ = exposed
+	}
+
 	encodedCreds := valid["metrics-creds"].(string)
 	// The model stores the creds encoded, but we want to make sure that
 	// we are storing something that can be decoded.
@@ -386,5 +454,62 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 	}
 	result.setUnits(units)
 
+	result.setResources(nil)
+	if resourcesMap, ok := valid["resources"]; ok {
+		resources, err := importResources(resourcesMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.setResources(resources)
+	}
+
+	return result, nil
+}
+
+// ExposedEndpointArgs is an argument struct used to specify the expose
+// settings of an application endpoint.
+type ExposedEndpointArgs struct {
+	ExposeToSpaces []string
+	ExposeToCIDRs  []string
+}
+
+type exposedEndpoint struct {
+	ExposeToSpaces_ []string `yaml:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs_  []string `yaml:"expose-to-cidrs,omitempty"`
+}
+
+// ExposeToSpaces implements ExposedEndpoint.
+func (e *exposedEndpoint) ExposeToSpaces() []string {
+	return e.ExposeToSpaces_
+}
+
+// ExposeToCIDRs implements ExposedEndpoint.
+func (e *exposedEndpoint) ExposeToCIDRs() []string {
+	return e.ExposeToCIDRs_
+}
+
+func importExposedEndpoints(source map[string]interface{}) (map[string]*exposedEndpoint, error) {
+	fields := schema.Fields{
+		"expose-to-spaces": schema.List(schema.String()),
+		"expose-to-cidrs":  schema.List(schema.String()),
+	}
+	defaults := schema.Defaults{
+		"expose-to-spaces": schema.Omit,
+		"expose-to-cidrs":  schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	result := make(map[string]*exposedEndpoint)
+	for name, value := range source {
+		coerced, err := checker.Coerce(value, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "exposed endpoint %q schema check failed", name)
+		}
+		valid := coerced.(map[string]interface{})
+		result[name] = &exposedEndpoint{
+			ExposeToSpaces_: convertToStringSlice(valid["expose-to-spaces"]),
+			ExposeToCIDRs_:  convertToStringSlice(valid["expose-to-cidrs"]),
+		}
+	}
 	return result, nil
 }
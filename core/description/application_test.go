@@ -4,6 +4,8 @@
 package description
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -59,6 +61,10 @@ func minimalApplicationMap() map[interface{}]interface{} {
 				minimalUnitMap(),
 			},
 		},
+		"resources": map[interface{}]interface{}{
+			"version":   1,
+			"resources": []interface{}{},
+		},
 	}
 }
 
@@ -205,6 +211,26 @@ func (s *ApplicationSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(application.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *ApplicationSerializationSuite) TestExposedEndpoints(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.Exposed = true
+	args.ExposedEndpoints = map[string]ExposedEndpointArgs{
+		"": {ExposeToSpaces: []string{"admin"}},
+		"website": {
+			ExposeToCIDRs: []string{"10.0.0.0/24", "192.168.1.0/24"},
+		},
+	}
+	initial := newApplication(args)
+
+	application := s.exportImport(c, initial)
+	exposed := application.ExposedEndpoints()
+	c.Assert(exposed, gc.HasLen, 2)
+	c.Assert(exposed[""].ExposeToSpaces(), jc.DeepEquals, []string{"admin"})
+	c.Assert(exposed[""].ExposeToCIDRs(), gc.HasLen, 0)
+	c.Assert(exposed["website"].ExposeToSpaces(), gc.HasLen, 0)
+	c.Assert(exposed["website"].ExposeToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.0/24"})
+}
+
 func (s *ApplicationSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalApplicationArgs()
 	args.Leader = "ubuntu/1"
@@ -214,3 +240,40 @@ func (s *ApplicationSerializationSuite) TestLeaderValid(c *gc.C) {
 	err := application.Validate()
 	c.Assert(err, gc.ErrorMatches, `missing unit for leader "ubuntu/1" not valid`)
 }
+
+func (s *ApplicationSerializationSuite) TestResources(c *gc.C) {
+	initial := minimalApplication()
+	rFoo := initial.AddResource(ResourceArgs{Name: "foo"})
+	rFoo.SetApplicationRevision(ResourceRevisionArgs{
+		Revision:       3,
+		Type:           "file",
+		Path:           "foo.tgz",
+		Description:    "description",
+		Origin:         "upload",
+		FingerprintHex: "aaa",
+		Size:           111,
+		Timestamp:      time.Date(2016, 10, 18, 2, 3, 4, 0, time.UTC),
+		Username:       "user",
+	})
+	rFoo.SetCharmStoreRevision(ResourceRevisionArgs{
+		Revision:       4,
+		Type:           "file",
+		Path:           "foo.tgz",
+		Description:    "description",
+		Origin:         "store",
+		FingerprintHex: "bbb",
+		Size:           222,
+	})
+	initial.AddResource(ResourceArgs{Name: "bar"}).SetApplicationRevision(minimalResourceRevisionArgs())
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.Resources(), jc.DeepEquals, initial.Resources())
+}
+
+func (s *ApplicationSerializationSuite) TestResourcesValidated(c *gc.C) {
+	application := minimalApplication()
+	application.AddResource(ResourceArgs{Name: "foo"})
+
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu": resource "foo" missing application revision not valid`)
+}
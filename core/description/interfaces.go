@@ -62,6 +62,18 @@ type Model interface {
 	Relations() []Relation
 	AddRelation(RelationArgs) Relation
 
+	Storages() []Storage
+	AddStorage(StorageArgs) Storage
+
+	Volumes() []Volume
+	AddVolume(VolumeArgs) Volume
+
+	Filesystems() []Filesystem
+	AddFilesystem(FilesystemArgs) Filesystem
+
+	StoragePools() []StoragePool
+	AddStoragePool(StoragePoolArgs) StoragePool
+
 	Sequences() map[string]int
 	SetSequence(name string, value int)
 
@@ -166,6 +178,7 @@ type PortRange interface {
 	FromPort() int
 	ToPort() int
 	Protocol() string
+	Endpoint() string
 }
 
 // CloudInstance holds information particular to a machine
@@ -220,6 +233,7 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedEndpoints() map[string]ExposedEndpoint
 	MinUnits() int
 
 	Settings() map[string]interface{}
@@ -236,9 +250,19 @@ type Application interface {
 	Units() []Unit
 	AddUnit(UnitArgs) Unit
 
+	Resources() []Resource
+	AddResource(ResourceArgs) Resource
+
 	Validate() error
 }
 
+// ExposedEndpoint represents the expose settings of an application
+// endpoint.
+type ExposedEndpoint interface {
+	ExposeToSpaces() []string
+	ExposeToCIDRs() []string
+}
+
 // Unit represents an instance of an application in a model.
 type Unit interface {
 	HasAnnotations
@@ -256,6 +280,8 @@ type Unit interface {
 	MeterStatusCode() string
 	MeterStatusInfo() string
 
+	State() map[string]string
+
 	// TODO: storage
 
 	Tools() AgentTools
@@ -278,6 +304,72 @@ type Unit interface {
 	AgentStatusHistory() []Status
 	SetAgentStatusHistory([]StatusArgs)
 
+	Resources() []UnitResource
+	AddResource(UnitResourceArgs) UnitResource
+
+	Payloads() []Payload
+	AddPayload(PayloadArgs) Payload
+
+	Validate() error
+}
+
+// Resource represents an application resource.
+type Resource interface {
+	// Name returns the name of the resource.
+	Name() string
+
+	// ApplicationRevision returns the revision of the resource as
+	// set on the application. May return nil if SetApplicationRevision
+	// hasn't been called yet.
+	ApplicationRevision() ResourceRevision
+
+	// SetApplicationRevision sets the application revision of the
+	// resource.
+	SetApplicationRevision(ResourceRevisionArgs) ResourceRevision
+
+	// CharmStoreRevision returns the revision the charmstore has, as
+	// seen at the last poll. May return nil if SetCharmStoreRevision
+	// hasn't been called yet.
+	CharmStoreRevision() ResourceRevision
+
+	// SetCharmStoreRevision sets the charm store revision of the
+	// resource.
+	SetCharmStoreRevision(ResourceRevisionArgs) ResourceRevision
+
+	Validate() error
+}
+
+// ResourceRevision represents a revision of an application resource.
+type ResourceRevision interface {
+	Revision() int
+	Type() string
+	Path() string
+	Description() string
+	Origin() string
+	FingerprintHex() string
+	Size() int64
+	Timestamp() time.Time
+	Username() string
+}
+
+// UnitResource represents the revision of a resource used by a unit.
+type UnitResource interface {
+	// Name returns the name of the resource.
+	Name() string
+
+	// Revision returns the revision of the resource as used by a
+	// particular unit.
+	Revision() ResourceRevision
+}
+
+// Payload represents a charm payload for a unit.
+type Payload interface {
+	Name() string
+	Type() string
+	RawID() string
+	State() string
+	Labels() []string
+
 	Validate() error
 }
 
@@ -313,3 +405,98 @@ type Endpoint interface {
 	Settings(unitName string) map[string]interface{}
 	SetUnitSettings(unitName string, settings map[string]interface{})
 }
+
+// Storage represents the state of a unit or application-wide storage instance
+// in the model.
+type Storage interface {
+	Tag() names.StorageTag
+	Kind() string
+	// Owner returns the tag of the application or unit that owns this storage
+	// instance, or nil if the storage has been detached from its unit.
+	Owner() (names.Tag, error)
+	Name() string
+
+	Attachments() []names.UnitTag
+
+	Validate() error
+}
+
+// Volume represents a volume (disk, logical volume, etc.) in the model.
+type Volume interface {
+	HasStatusHistory
+
+	Tag() names.VolumeTag
+	Storage() names.StorageTag
+	// Binding returns the tag of the entity that the lifecycle of the
+	// volume is bound to.
+	Binding() (names.Tag, error)
+
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	HardwareID() string
+	VolumeID() string
+	Persistent() bool
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []VolumeAttachment
+	AddAttachment(VolumeAttachmentArgs) VolumeAttachment
+
+	Validate() error
+}
+
+// VolumeAttachment represents a volume attached to a machine.
+type VolumeAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	ReadOnly() bool
+	DeviceName() string
+	DeviceLink() string
+	BusAddress() string
+}
+
+// Filesystem represents a filesystem in the model.
+type Filesystem interface {
+	HasStatusHistory
+
+	Tag() names.FilesystemTag
+	Storage() names.StorageTag
+	Volume() names.VolumeTag
+	// Binding returns the tag of the entity that the lifecycle of the
+	// filesystem is bound to.
+	Binding() (names.Tag, error)
+
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	FilesystemID() string
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []FilesystemAttachment
+	AddAttachment(FilesystemAttachmentArgs) FilesystemAttachment
+
+	Validate() error
+}
+
+// FilesystemAttachment represents a filesystem attached to a machine.
+type FilesystemAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	MountPoint() string
+	ReadOnly() bool
+}
+
+// StoragePool represents a named storage pool and its settings.
+type StoragePool interface {
+	Name() string
+	Provider() string
+	Attributes() map[string]interface{}
+}
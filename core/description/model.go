@@ -41,6 +41,10 @@ func NewModel(args ModelArgs) Model {
 	m.setMachines(nil)
 	m.setApplications(nil)
 	m.setRelations(nil)
+	m.setStorages(nil)
+	m.setVolumes(nil)
+	m.setFilesystems(nil)
+	m.setStoragePools(nil)
 	return m
 }
 
@@ -80,6 +84,10 @@ type model struct {
 	Machines_     machines     `yaml:"machines"`
 	Applications_ applications `yaml:"applications"`
 	Relations_    relations    `yaml:"relations"`
+	Storages_     storages     `yaml:"storages"`
+	Volumes_      volumes      `yaml:"volumes"`
+	Filesystems_  filesystems  `yaml:"filesystems"`
+	StoragePools_ storagepools `yaml:"storage-pools"`
 
 	Sequences_ map[string]int `yaml:"sequences"`
 
@@ -92,7 +100,6 @@ type model struct {
 
 	// TODO:
 	// Spaces
-	// Storage
 }
 
 func (m *model) Tag() names.ModelTag {
@@ -241,6 +248,98 @@ func (m *model) setRelations(relationList []*relation) {
 	}
 }
 
+// Storages implements Model.
+func (m *model) Storages() []Storage {
+	var result []Storage
+	for _, storage := range m.Storages_.Storages_ {
+		result = append(result, storage)
+	}
+	return result
+}
+
+// AddStorage implements Model.
+func (m *model) AddStorage(args StorageArgs) Storage {
+	storage := newStorage(args)
+	m.Storages_.Storages_ = append(m.Storages_.Storages_, storage)
+	return storage
+}
+
+func (m *model) setStorages(storageList []*storage) {
+	m.Storages_ = storages{
+		Version:   1,
+		Storages_: storageList,
+	}
+}
+
+// Volumes implements Model.
+func (m *model) Volumes() []Volume {
+	var result []Volume
+	for _, volume := range m.Volumes_.Volumes_ {
+		result = append(result, volume)
+	}
+	return result
+}
+
+// AddVolume implements Model.
+func (m *model) AddVolume(args VolumeArgs) Volume {
+	volume := newVolume(args)
+	m.Volumes_.Volumes_ = append(m.Volumes_.Volumes_, volume)
+	return volume
+}
+
+func (m *model) setVolumes(volumeList []*volume) {
+	m.Volumes_ = volumes{
+		Version:  1,
+		Volumes_: volumeList,
+	}
+}
+
+// Filesystems implements Model.
+func (m *model) Filesystems() []Filesystem {
+	var result []Filesystem
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		result = append(result, filesystem)
+	}
+	return result
+}
+
+// AddFilesystem implements Model.
+func (m *model) AddFilesystem(args FilesystemArgs) Filesystem {
+	filesystem := newFilesystem(args)
+	m.Filesystems_.Filesystems_ = append(m.Filesystems_.Filesystems_, filesystem)
+	return filesystem
+}
+
+func (m *model) setFilesystems(filesystemList []*filesystem) {
+	m.Filesystems_ = filesystems{
+		Version:      1,
+		Filesystems_: filesystemList,
+	}
+}
+
+// StoragePools implements Model.
+func (m *model) StoragePools() []StoragePool {
+	var result []StoragePool
+	for _, pool := range m.StoragePools_.Pools_ {
+		result = append(result, pool)
+	}
+	return result
+}
+
+// AddStoragePool implements Model.
+func (m *model) AddStoragePool(args StoragePoolArgs) StoragePool {
+	pool := newStoragePool(args)
+	m.StoragePools_.Pools_ = append(m.StoragePools_.Pools_, pool)
+	return pool
+}
+
+func (m *model) setStoragePools(poolList []*storagepool) {
+	m.StoragePools_ = storagepools{
+		Version: 1,
+		Pools_:  poolList,
+	}
+}
+
 // Sequences implements Model.
 func (m *model) Sequences() map[string]int {
 	return m.Sequences_
@@ -306,9 +405,73 @@ func (m *model) Validate() error {
 		return errors.Errorf("unknown unit names in open ports: %s", unknownUnitsWithPorts.SortedValues())
 	}
 
+	if err := m.validateStorage(allUnits); err != nil {
+		return errors.Trace(err)
+	}
+
 	return m.validateRelations()
 }
 
+// validateStorage makes sure that the storage instances, volumes and
+// filesystems are valid, and that they only refer to known units,
+// machines and storage instances.
+func (m *model) validateStorage(allUnits set.Strings) error {
+	allMachines := set.NewStrings()
+	var addMachines func([]*machine)
+	addMachines = func(machines []*machine) {
+		for _, machine := range machines {
+			allMachines.Add(machine.Id())
+			addMachines(machine.Containers_)
+		}
+	}
+	addMachines(m.Machines_.Machines_)
+
+	allStorage := set.NewStrings()
+	for _, storage := range m.Storages_.Storages_ {
+		if err := storage.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allStorage.Add(storage.Tag().Id())
+		for _, unit := range storage.Attachments() {
+			if !allUnits.Contains(unit.Id()) {
+				return errors.NotValidf("storage %q attachment referencing unknown unit %q", storage.Tag().Id(), unit.Id())
+			}
+		}
+	}
+	allVolumes := set.NewStrings()
+	for _, volume := range m.Volumes_.Volumes_ {
+		if err := volume.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allVolumes.Add(volume.Tag().Id())
+		if storageID := volume.StorageID_; storageID != "" && !allStorage.Contains(storageID) {
+			return errors.NotValidf("volume %q referencing unknown storage %q", volume.Tag().Id(), storageID)
+		}
+		for _, attachment := range volume.Attachments_.Attachments_ {
+			if !allMachines.Contains(attachment.MachineID_) {
+				return errors.NotValidf("volume %q attachment referencing unknown machine %q", volume.Tag().Id(), attachment.MachineID_)
+			}
+		}
+	}
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		if err := filesystem.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if storageID := filesystem.StorageID_; storageID != "" && !allStorage.Contains(storageID) {
+			return errors.NotValidf("filesystem %q referencing unknown storage %q", filesystem.Tag().Id(), storageID)
+		}
+		if volumeID := filesystem.VolumeID_; volumeID != "" && !allVolumes.Contains(volumeID) {
+			return errors.NotValidf("filesystem %q referencing unknown volume %q", filesystem.Tag().Id(), volumeID)
+		}
+		for _, attachment := range filesystem.Attachments_.Attachments_ {
+			if !allMachines.Contains(attachment.MachineID_) {
+				return errors.NotValidf("filesystem %q attachment referencing unknown machine %q", filesystem.Tag().Id(), attachment.MachineID_)
+			}
+		}
+	}
+	return nil
+}
+
 // validateRelations makes sure that for each endpoint in each relation there
 // are settings for all units of that application for that endpoint.
 func (m *model) validateRelations() error {
@@ -359,22 +522,30 @@ var modelDeserializationFuncs = map[int]modelDeserializationFunc{
 
 func importModelV1(source map[string]interface{}) (*model, error) {
 	fields := schema.Fields{
-		"owner":        schema.String(),
-		"cloud-region": schema.String(),
-		"config":       schema.StringMap(schema.Any()),
-		"latest-tools": schema.String(),
-		"blocks":       schema.StringMap(schema.String()),
-		"users":        schema.StringMap(schema.Any()),
-		"machines":     schema.StringMap(schema.Any()),
-		"applications": schema.StringMap(schema.Any()),
-		"relations":    schema.StringMap(schema.Any()),
-		"sequences":    schema.StringMap(schema.Int()),
+		"owner":         schema.String(),
+		"cloud-region":  schema.String(),
+		"config":        schema.StringMap(schema.Any()),
+		"latest-tools":  schema.String(),
+		"blocks":        schema.StringMap(schema.String()),
+		"users":         schema.StringMap(schema.Any()),
+		"machines":      schema.StringMap(schema.Any()),
+		"applications":  schema.StringMap(schema.Any()),
+		"relations":     schema.StringMap(schema.Any()),
+		"storages":      schema.StringMap(schema.Any()),
+		"volumes":       schema.StringMap(schema.Any()),
+		"filesystems":   schema.StringMap(schema.Any()),
+		"storage-pools": schema.StringMap(schema.Any()),
+		"sequences":     schema.StringMap(schema.Int()),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
-		"latest-tools": schema.Omit,
-		"blocks":       schema.Omit,
-		"cloud-region": schema.Omit,
+		"latest-tools":  schema.Omit,
+		"blocks":        schema.Omit,
+		"cloud-region":  schema.Omit,
+		"storages":      schema.Omit,
+		"volumes":       schema.Omit,
+		"filesystems":   schema.Omit,
+		"storage-pools": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -453,5 +624,41 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setRelations(relations)
 
+	var storageList []*storage
+	if storageMap, ok := valid["storages"]; ok {
+		storageList, err = importStorages(storageMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storages")
+		}
+	}
+	result.setStorages(storageList)
+
+	var volumeList []*volume
+	if volumeMap, ok := valid["volumes"]; ok {
+		volumeList, err = importVolumes(volumeMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "volumes")
+		}
+	}
+	result.setVolumes(volumeList)
+
+	var filesystemList []*filesystem
+	if filesystemMap, ok := valid["filesystems"]; ok {
+		filesystemList, err = importFilesystems(filesystemMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "filesystems")
+		}
+	}
+	result.setFilesystems(filesystemList)
+
+	var poolList []*storagepool
+	if poolMap, ok := valid["storage-pools"]; ok {
+		poolList, err = importStoragePools(poolMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storage-pools")
+		}
+	}
+	result.setStoragePools(poolList)
+
 	return result, nil
 }
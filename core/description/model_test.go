@@ -331,3 +331,98 @@ func (s *ModelSerializationSuite) TestModelSerializationWithRelations(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model, jc.DeepEquals, initial)
 }
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorageUnits(c *gc.C) {
+	model, _, _ := s.wordpressModel()
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Kind:        "block",
+		Owner:       names.NewUnitTag("wordpress/0"),
+		Name:        "data",
+		Attachments: []names.UnitTag{names.NewUnitTag("missing/0")},
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `storage "data/0" attachment referencing unknown unit "missing/0" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksVolumeStorage(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	volume := model.AddVolume(testVolumeArgs())
+	volume.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `volume "1234" referencing unknown storage "test/1" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksFilesystemMachines(c *gc.C) {
+	model := s.wordpressModelWithSettings()
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 5,
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(FilesystemAttachmentArgs{
+		Machine: names.NewMachineTag("42"),
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `filesystem "0" attachment referencing unknown machine "42" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelSerializationSuite) wordpressModelWithStorage() Model {
+	model := s.wordpressModelWithSettings()
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("test/1"),
+		Kind:        "block",
+		Owner:       names.NewUnitTag("wordpress/0"),
+		Name:        "test",
+		Attachments: []names.UnitTag{names.NewUnitTag("wordpress/0")},
+	})
+	volume := model.AddVolume(VolumeArgs{
+		Tag:     names.NewVolumeTag("0/0"),
+		Storage: names.NewStorageTag("test/1"),
+		Binding: names.NewMachineTag("0"),
+		Size:    20 * gig,
+		Pool:    "loop",
+	})
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(VolumeAttachmentArgs{
+		Machine: names.NewMachineTag("0"),
+	})
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:     names.NewFilesystemTag("0/1"),
+		Volume:  names.NewVolumeTag("0/0"),
+		Binding: names.NewMachineTag("0"),
+		Size:    20 * gig,
+		Pool:    "loop",
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(FilesystemAttachmentArgs{
+		Machine:    names.NewMachineTag("0"),
+		MountPoint: "/srv/data",
+	})
+	model.AddStoragePool(StoragePoolArgs{
+		Name:     "fast",
+		Provider: "loop",
+		Attributes: map[string]interface{}{
+			"foo": "bar",
+		},
+	})
+	return model
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorage(c *gc.C) {
+	model := s.wordpressModelWithStorage()
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithStorage(c *gc.C) {
+	initial := s.wordpressModelWithStorage()
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model, jc.DeepEquals, initial)
+}
@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type PayloadSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&PayloadSerializationSuite{})
+
+func (s *PayloadSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "payloads"
+	s.sliceName = "payloads"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importPayloads(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["payloads"] = []interface{}{}
+	}
+}
+
+func minimalPayloadMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"name":   "bob",
+		"type":   "docker",
+		"raw-id": "d06f00d",
+		"state":  "running",
+		"labels": []interface{}{"auto", "foo"},
+	}
+}
+
+func minimalPayload() *payload {
+	return newPayload(minimalPayloadArgs())
+}
+
+func minimalPayloadArgs() PayloadArgs {
+	return PayloadArgs{
+		Name:   "bob",
+		Type:   "docker",
+		RawID:  "d06f00d",
+		State:  "running",
+		Labels: []string{"auto", "foo"},
+	}
+}
+
+func (s *PayloadSerializationSuite) TestNewPayload(c *gc.C) {
+	p := minimalPayload()
+	c.Check(p.Name(), gc.Equals, "bob")
+	c.Check(p.Type(), gc.Equals, "docker")
+	c.Check(p.RawID(), gc.Equals, "d06f00d")
+	c.Check(p.State(), gc.Equals, "running")
+	c.Check(p.Labels(), jc.DeepEquals, []string{"auto", "foo"})
+}
+
+func (s *PayloadSerializationSuite) TestMissingRawID(c *gc.C) {
+	p := newPayload(PayloadArgs{Name: "bob"})
+	err := p.Validate()
+	c.Assert(err, gc.ErrorMatches, `payload "bob" missing raw id not valid`)
+}
+
+func (s *PayloadSerializationSuite) TestPayloadMatches(c *gc.C) {
+	bytes, err := yaml.Marshal(minimalPayload())
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[interface{}]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source, jc.DeepEquals, minimalPayloadMap())
+}
+
+func (s *PayloadSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := payloads{
+		Version: 1,
+		Payloads_: []*payload{
+			minimalPayload(),
+			newPayload(PayloadArgs{
+				Name:  "alice",
+				Type:  "kvm",
+				RawID: "b00b1e5",
+				State: "stopped",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := importPayloads(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, jc.DeepEquals, initial.Payloads_)
+}
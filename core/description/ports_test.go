@@ -16,6 +16,7 @@ func (*PortRangeCheck) AssertPortRange(c *gc.C, pr PortRange, args PortRangeArgs
 	c.Assert(pr.FromPort(), gc.Equals, args.FromPort)
 	c.Assert(pr.ToPort(), gc.Equals, args.ToPort)
 	c.Assert(pr.Protocol(), gc.Equals, args.Protocol)
+	c.Assert(pr.Endpoint(), gc.Equals, args.Endpoint)
 }
 
 type OpenedPortsSerializationSuite struct {
@@ -137,6 +138,7 @@ func (s *PortRangeSerializationSuite) TestNewPortRange(c *gc.C) {
 		FromPort: 1234,
 		ToPort:   2345,
 		Protocol: "tcp",
+		Endpoint: "website",
 	}
 	pr := newPortRange(args)
 	s.AssertPortRange(c, pr, args)
@@ -157,6 +159,7 @@ func (*PortRangeSerializationSuite) TestParsingSerializedData(c *gc.C) {
 				FromPort_: 8080,
 				ToPort_:   8080,
 				Protocol_: "tcp",
+				Endpoint_: "website",
 			},
 		},
 	}
@@ -0,0 +1,401 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+type resources struct {
+	Version    int         `yaml:"version"`
+	Resources_ []*resource `yaml:"resources"`
+}
+
+type resource struct {
+	Name_                string            `yaml:"name"`
+	ApplicationRevision_ *resourceRevision `yaml:"application-revision"`
+	CharmStoreRevision_  *resourceRevision `yaml:"charmstore-revision,omitempty"`
+}
+
+// ResourceArgs is an argument struct used to add a resource to an
+// Application.
+type ResourceArgs struct {
+	Name string
+}
+
+func newResource(args ResourceArgs) *resource {
+	return &resource{
+		Name_: args.Name,
+	}
+}
+
+// Name implements Resource.
+func (r *resource) Name() string {
+	return r.Name_
+}
+
+// ApplicationRevision implements Resource.
+func (r *resource) ApplicationRevision() ResourceRevision {
+	// To avoid typed nils check nil here.
+	if r.ApplicationRevision_ == nil {
+		return nil
+	}
+	return r.ApplicationRevision_
+}
+
+// SetApplicationRevision implements Resource.
+func (r *resource) SetApplicationRevision(args ResourceRevisionArgs) ResourceRevision {
+	r.ApplicationRevision_ = newResourceRevision(args)
+	return r.ApplicationRevision_
+}
+
+// CharmStoreRevision implements Resource.
+func (r *resource) CharmStoreRevision() ResourceRevision {
+	// To avoid typed nils check nil here.
+	if r.CharmStoreRevision_ == nil {
+		return nil
+	}
+	return r.CharmStoreRevision_
+}
+
+// SetCharmStoreRevision implements Resource.
+func (r *resource) SetCharmStoreRevision(args ResourceRevisionArgs) ResourceRevision {
+	r.CharmStoreRevision_ = newResourceRevision(args)
+	return r.CharmStoreRevision_
+}
+
+// Validate implements Resource.
+func (r *resource) Validate() error {
+	if r.Name_ == "" {
+		return errors.NotValidf("resource missing name")
+	}
+	if r.ApplicationRevision_ == nil {
+		return errors.NotValidf("resource %q missing application revision", r.Name_)
+	}
+	return nil
+}
+
+// ResourceRevisionArgs is an argument struct used to add a new resource
+// revision to a Resource or UnitResource.
+type ResourceRevisionArgs struct {
+	Revision       int
+	Type           string
+	Path           string
+	Description    string
+	Origin         string
+	FingerprintHex string
+	Size           int64
+	Timestamp      time.Time
+	Username       string
+}
+
+type resourceRevision struct {
+	Revision_       int    `yaml:"revision"`
+	Type_           string `yaml:"type"`
+	Path_           string `yaml:"path"`
+	Description_    string `yaml:"description"`
+	Origin_         string `yaml:"origin"`
+	FingerprintHex_ string `yaml:"fingerprint"`
+	Size_           int64  `yaml:"size"`
+	// Can't use omitempty with time.Time, it just doesn't work,
+	// so use a pointer in the struct.
+	Timestamp_ *time.Time `yaml:"timestamp,omitempty"`
+	Username_  string     `yaml:"username,omitempty"`
+}
+
+func newResourceRevision(args ResourceRevisionArgs) *resourceRevision {
+	r := &resourceRevision{
+		Revision_:       args.Revision,
+		Type_:           args.Type,
+		Path_:           args.Path,
+		Description_:    args.Description,
+		Origin_:         args.Origin,
+		FingerprintHex_: args.FingerprintHex,
+		Size_:           args.Size,
+		Username_:       args.Username,
+	}
+	if !args.Timestamp.IsZero() {
+		timestamp := args.Timestamp.UTC()
+		r.Timestamp_ = &timestamp
+	}
+	return r
+}
+
+// Revision implements ResourceRevision.
+func (r *resourceRevision) Revision() int {
+	return r.Revision_
+}
+
+// Type implements ResourceRevision.
+func (r *resourceRevision) Type() string {
+	return r.Type_
+}
+
+// Path implements ResourceRevision.
+func (r *resourceRevision) Path() string {
+	return r.Path_
+}
+
+// Description implements ResourceRevision.
+func (r *resourceRevision) Description() string {
+	return r.Description_
+}
+
+// Origin implements ResourceRevision.
+func (r *resourceRevision) Origin() string {
+	return r.Origin_
+}
+
+// FingerprintHex implements ResourceRevision.
+func (r *resourceRevision) FingerprintHex() string {
+	return r.FingerprintHex_
+}
+
+// Size implements ResourceRevision.
+func (r *resourceRevision) Size() int64 {
+	return r.Size_
+}
+
+// Timestamp implements ResourceRevision.
+func (r *resourceRevision) Timestamp() time.Time {
+	var zero time.Time
+	if r.Timestamp_ == nil {
+		return zero
+	}
+	return *r.Timestamp_
+}
+
+// Username implements ResourceRevision.
+func (r *resourceRevision) Username() string {
+	return r.Username_
+}
+
+func importResources(source map[string]interface{}) ([]*resource, error) {
+	checker := versionedChecker("resources")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resources version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := resourceDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["resources"].([]interface{})
+	return importResourceList(sourceList, importFunc)
+}
+
+func importResourceList(sourceList []interface{}, importFunc resourceDeserializationFunc) ([]*resource, error) {
+	result := make([]*resource, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for resource %d, %T", i, value)
+		}
+		resource, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %d", i)
+		}
+		result = append(result, resource)
+	}
+	return result, nil
+}
+
+type resourceDeserializationFunc func(map[string]interface{}) (*resource, error)
+
+var resourceDeserializationFuncs = map[int]resourceDeserializationFunc{
+	1: importResourceV1,
+}
+
+func importResourceV1(source map[string]interface{}) (*resource, error) {
+	fields := schema.Fields{
+		"name":                 schema.String(),
+		"application-revision": schema.StringMap(schema.Any()),
+		"charmstore-revision":  schema.StringMap(schema.Any()),
+	}
+	defaults := schema.Defaults{
+		"charmstore-revision": schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resource v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	result := &resource{
+		Name_: valid["name"].(string),
+	}
+
+	appRev, err := importResourceRevisionV1(valid["application-revision"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Annotatef(err, "resource %q application revision", result.Name_)
+	}
+	result.ApplicationRevision_ = appRev
+
+	if source, ok := valid["charmstore-revision"]; ok {
+		csRev, err := importResourceRevisionV1(source.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %q charmstore revision", result.Name_)
+		}
+		result.CharmStoreRevision_ = csRev
+	}
+
+	return result, nil
+}
+
+func importResourceRevisionV1(source map[string]interface{}) (*resourceRevision, error) {
+	fields := schema.Fields{
+		"revision":    schema.Int(),
+		"type":        schema.String(),
+		"path":        schema.String(),
+		"description": schema.String(),
+		"origin":      schema.String(),
+		"fingerprint": schema.String(),
+		"size":        schema.Int(),
+		"timestamp":   schema.Time(),
+		"username":    schema.String(),
+	}
+	defaults := schema.Defaults{
+		"timestamp": time.Time{},
+		"username":  "",
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resource revision v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	result := &resourceRevision{
+		Revision_:       int(valid["revision"].(int64)),
+		Type_:           valid["type"].(string),
+		Path_:           valid["path"].(string),
+		Description_:    valid["description"].(string),
+		Origin_:         valid["origin"].(string),
+		FingerprintHex_: valid["fingerprint"].(string),
+		Size_:           valid["size"].(int64),
+		Username_:       valid["username"].(string),
+	}
+
+	timestamp := valid["timestamp"].(time.Time)
+	if !timestamp.IsZero() {
+		result.Timestamp_ = &timestamp
+	}
+
+	return result, nil
+}
+
+type unitResources struct {
+	Version    int             `yaml:"version"`
+	Resources_ []*unitResource `yaml:"resources"`
+}
+
+type unitResource struct {
+	Name_     string            `yaml:"name"`
+	Revision_ *resourceRevision `yaml:"revision"`
+}
+
+// UnitResourceArgs is an argument struct used to add a resource in use
+// by a Unit.
+type UnitResourceArgs struct {
+	Name         string
+	RevisionArgs ResourceRevisionArgs
+}
+
+func newUnitResource(args UnitResourceArgs) *unitResource {
+	return &unitResource{
+		Name_:     args.Name,
+		Revision_: newResourceRevision(args.RevisionArgs),
+	}
+}
+
+// Name implements UnitResource.
+func (r *unitResource) Name() string {
+	return r.Name_
+}
+
+// Revision implements UnitResource.
+func (r *unitResource) Revision() ResourceRevision {
+	// To avoid typed nils check nil here.
+	if r.Revision_ == nil {
+		return nil
+	}
+	return r.Revision_
+}
+
+func importUnitResources(source map[string]interface{}) ([]*unitResource, error) {
+	checker := versionedChecker("resources")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "unit resources version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := unitResourceDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["resources"].([]interface{})
+	return importUnitResourceList(sourceList, importFunc)
+}
+
+func importUnitResourceList(sourceList []interface{}, importFunc unitResourceDeserializationFunc) ([]*unitResource, error) {
+	result := make([]*unitResource, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for unit resource %d, %T", i, value)
+		}
+		resource, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "unit resource %d", i)
+		}
+		result = append(result, resource)
+	}
+	return result, nil
+}
+
+type unitResourceDeserializationFunc func(map[string]interface{}) (*unitResource, error)
+
+var unitResourceDeserializationFuncs = map[int]unitResourceDeserializationFunc{
+	1: importUnitResourceV1,
+}
+
+func importUnitResourceV1(source map[string]interface{}) (*unitResource, error) {
+	fields := schema.Fields{
+		"name":     schema.String(),
+		"revision": schema.StringMap(schema.Any()),
+	}
+	checker := schema.FieldMap(fields, nil) // no defaults
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "unit resource v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	result := &unitResource{
+		Name_: valid["name"].(string),
+	}
+
+	revision, err := importResourceRevisionV1(valid["revision"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Annotatef(err, "unit resource %q revision", result.Name_)
+	}
+	result.Revision_ = revision
+
+	return result, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ResourceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&ResourceSerializationSuite{})
+
+func (s *ResourceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "resources"
+	s.sliceName = "resources"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importResources(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["resources"] = []interface{}{}
+	}
+}
+
+func minimalResourceRevisionArgs() ResourceRevisionArgs {
+	return ResourceRevisionArgs{
+		Revision:       1,
+		Type:           "file",
+		Path:           "db.tgz",
+		Description:    "the database",
+		Origin:         "upload",
+		FingerprintHex: "abcd",
+		Size:           1024,
+		Timestamp:      time.Date(2016, 10, 18, 2, 3, 4, 0, time.UTC),
+		Username:       "admin",
+	}
+}
+
+func minimalResourceRevisionMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"revision":    1,
+		"type":        "file",
+		"path":        "db.tgz",
+		"description": "the database",
+		"origin":      "upload",
+		"fingerprint": "abcd",
+		"size":        1024,
+		"timestamp":   "2016-10-18T02:03:04Z",
+		"username":    "admin",
+	}
+}
+
+func minimalResource() *resource {
+	r := newResource(ResourceArgs{Name: "database"})
+	r.SetApplicationRevision(minimalResourceRevisionArgs())
+	return r
+}
+
+func (s *ResourceSerializationSuite) TestNewResource(c *gc.C) {
+	r := minimalResource()
+	c.Check(r.Name(), gc.Equals, "database")
+	c.Check(r.CharmStoreRevision(), gc.IsNil)
+
+	rev := r.ApplicationRevision()
+	c.Assert(rev, gc.NotNil)
+	c.Check(rev.Revision(), gc.Equals, 1)
+	c.Check(rev.Type(), gc.Equals, "file")
+	c.Check(rev.Path(), gc.Equals, "db.tgz")
+	c.Check(rev.Description(), gc.Equals, "the database")
+	c.Check(rev.Origin(), gc.Equals, "upload")
+	c.Check(rev.FingerprintHex(), gc.Equals, "abcd")
+	c.Check(rev.Size(), gc.Equals, int64(1024))
+	c.Check(rev.Timestamp(), gc.Equals, time.Date(2016, 10, 18, 2, 3, 4, 0, time.UTC))
+	c.Check(rev.Username(), gc.Equals, "admin")
+}
+
+func (s *ResourceSerializationSuite) TestMissingApplicationRevision(c *gc.C) {
+	r := newResource(ResourceArgs{Name: "database"})
+	c.Check(r.ApplicationRevision(), gc.IsNil)
+	err := r.Validate()
+	c.Assert(err, gc.ErrorMatches, `resource "database" missing application revision not valid`)
+}
+
+func (s *ResourceSerializationSuite) TestMissingName(c *gc.C) {
+	r := newResource(ResourceArgs{})
+	err := r.Validate()
+	c.Assert(err, gc.ErrorMatches, `resource missing name not valid`)
+}
+
+func (s *ResourceSerializationSuite) TestResourceMatches(c *gc.C) {
+	bytes, err := yaml.Marshal(minimalResource())
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[interface{}]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source, jc.DeepEquals, map[interface{}]interface{}{
+		"name":                 "database",
+		"application-revision": minimalResourceRevisionMap(),
+	})
+}
+
+func (s *ResourceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	r := minimalResource()
+	r.SetCharmStoreRevision(ResourceRevisionArgs{
+		Revision:       2,
+		Type:           "file",
+		Path:           "db.tgz",
+		Description:    "the database",
+		Origin:         "store",
+		FingerprintHex: "deadbeef",
+		Size:           2048,
+	})
+	initial := resources{
+		Version:    1,
+		Resources_: []*resource{r},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := importResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, jc.DeepEquals, initial.Resources_)
+	c.Check(imported[0].CharmStoreRevision().Timestamp().IsZero(), jc.IsTrue)
+}
+
+type UnitResourceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&UnitResourceSerializationSuite{})
+
+func (s *UnitResourceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "unit resources"
+	s.sliceName = "resources"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importUnitResources(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["resources"] = []interface{}{}
+	}
+}
+
+func (s *UnitResourceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := unitResources{
+		Version: 1,
+		Resources_: []*unitResource{
+			newUnitResource(UnitResourceArgs{
+				Name:         "database",
+				RevisionArgs: minimalResourceRevisionArgs(),
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := importUnitResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, jc.DeepEquals, initial.Resources_)
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type StoragePoolSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&StoragePoolSerializationSuite{})
+
+func (s *StoragePoolSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "storagepools"
+	s.sliceName = "pools"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importStoragePools(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["pools"] = []interface{}{}
+	}
+}
+
+func testStoragePoolMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"name":     "test",
+		"provider": "magic",
+		"attributes": map[interface{}]interface{}{
+			"method": "lots",
+		},
+	}
+}
+
+func testStoragePool() *storagepool {
+	return newStoragePool(testStoragePoolArgs())
+}
+
+func testStoragePoolArgs() StoragePoolArgs {
+	return StoragePoolArgs{
+		Name:     "test",
+		Provider: "magic",
+		Attributes: map[string]interface{}{
+			"method": "lots",
+		},
+	}
+}
+
+func (s *StoragePoolSerializationSuite) TestNewStoragePool(c *gc.C) {
+	pool := testStoragePool()
+
+	c.Check(pool.Name(), gc.Equals, "test")
+	c.Check(pool.Provider(), gc.Equals, "magic")
+	c.Check(pool.Attributes(), jc.DeepEquals, map[string]interface{}{
+		"method": "lots",
+	})
+}
+
+func (s *StoragePoolSerializationSuite) TestStoragePoolMatches(c *gc.C) {
+	bytes, err := yaml.Marshal(testStoragePool())
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[interface{}]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(source, jc.DeepEquals, testStoragePoolMap())
+}
+
+func (s *StoragePoolSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := storagepools{
+		Version: 1,
+		Pools_:  []*storagepool{testStoragePool()},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools, err := importStoragePools(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, jc.DeepEquals, initial.Pools_)
+}
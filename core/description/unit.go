@@ -41,9 +41,14 @@ type unit struct {
 	MeterStatusCode_ string `yaml:"meter-status-code,omitempty"`
 	MeterStatusInfo_ string `yaml:"meter-status-info,omitempty"`
 
+	State_ map[string]string `yaml:"state,omitempty"`
+
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
+
+	Resources_ unitResources `yaml:"resources"`
+	Payloads_  payloads      `yaml:"payloads"`
 }
 
 // UnitArgs is an argument struct used to add a Unit to a Application in the Model.
@@ -58,6 +63,10 @@ type UnitArgs struct {
 	MeterStatusCode string
 	MeterStatusInfo string
 
+	// State holds the key-value pairs stored by the unit's charm
+	// with state-set.
+	State map[string]string
+
 	// TODO: storage attachment count
 }
 
@@ -66,7 +75,7 @@ func newUnit(args UnitArgs) *unit {
 	for _, s := range args.Subordinates {
 		subordinates = append(subordinates, s.Id())
 	}
-	return &unit{
+	u := &unit{
 		Name_:                   args.Tag.Id(),
 		Machine_:                args.Machine.Id(),
 		PasswordHash_:           args.PasswordHash,
@@ -75,10 +84,14 @@ func newUnit(args UnitArgs) *unit {
 		WorkloadVersion_:        args.WorkloadVersion,
 		MeterStatusCode_:        args.MeterStatusCode,
 		MeterStatusInfo_:        args.MeterStatusInfo,
+		State_:                  args.State,
 		WorkloadStatusHistory_:  newStatusHistory(),
 		WorkloadVersionHistory_: newStatusHistory(),
 		AgentStatusHistory_:     newStatusHistory(),
 	}
+	u.setResources(nil)
+	u.setPayloads(nil)
+	return u
 }
 
 // Tag implements Unit.
@@ -128,6 +141,11 @@ func (u *unit) MeterStatusInfo() string {
 	return u.MeterStatusInfo_
 }
 
+// State implements Unit.
+func (u *unit) State() map[string]string {
+	return u.State_
+}
+
 // Tools implements Unit.
 func (u *unit) Tools() AgentTools {
 	// To avoid a typed nil, check before returning.
@@ -205,6 +223,54 @@ func (u *unit) SetAgentStatusHistory(args []StatusArgs) {
 	u.AgentStatusHistory_.SetStatusHistory(args)
 }
 
+// Resources implements Unit.
+func (u *unit) Resources() []UnitResource {
+	rs := u.Resources_.Resources_
+	result := make([]UnitResource, len(rs))
+	for i, r := range rs {
+		result[i] = r
+	}
+	return result
+}
+
+// AddResource implements Unit.
+func (u *unit) AddResource(args UnitResourceArgs) UnitResource {
+	r := newUnitResource(args)
+	u.Resources_.Resources_ = append(u.Resources_.Resources_, r)
+	return r
+}
+
+func (u *unit) setResources(resourceList []*unitResource) {
+	u.Resources_ = unitResources{
+		Version:    1,
+		Resources_: resourceList,
+	}
+}
+
+// Payloads implements Unit.
+func (u *unit) Payloads() []Payload {
+	ps := u.Payloads_.Payloads_
+	result := make([]Payload, len(ps))
+	for i, p := range ps {
+		result[i] = p
+	}
+	return result
+}
+
+// AddPayload implements Unit.
+func (u *unit) AddPayload(args PayloadArgs) Payload {
+	p := newPayload(args)
+	u.Payloads_.Payloads_ = append(u.Payloads_.Payloads_, p)
+	return p
+}
+
+func (u *unit) setPayloads(payloadList []*payload) {
+	u.Payloads_ = payloads{
+		Version:   1,
+		Payloads_: payloadList,
+	}
+}
+
 // Constraints implements HasConstraints.
 func (u *unit) Constraints() Constraints {
 	if u.Constraints_ == nil {
@@ -232,6 +298,11 @@ func (u *unit) Validate() error {
 	if u.Tools_ == nil {
 		return errors.NotValidf("unit %q missing tools", u.Name_)
 	}
+	for _, p := range u.Payloads_.Payloads_ {
+		if err := p.Validate(); err != nil {
+			return errors.Annotatef(err, "unit %q", u.Name_)
+		}
+	}
 	return nil
 }
 
@@ -294,6 +365,11 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 
 		"meter-status-code": schema.String(),
 		"meter-status-info": schema.String(),
+
+		"state": schema.StringMap(schema.String()),
+
+		"resources": schema.StringMap(schema.Any()),
+		"payloads":  schema.StringMap(schema.Any()),
 	}
 	defaults := schema.Defaults{
 		"principal":         "",
@@ -301,6 +377,9 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 		"workload-version":  "",
 		"meter-status-code": "",
 		"meter-status-info": "",
+		"state":             schema.Omit,
+		"resources":         schema.Omit,
+		"payloads":          schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -328,6 +407,10 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 	}
 	result.importAnnotations(valid)
 
+	if state, ok := valid["state"]; ok {
+		result.State_ = convertToStringMap(state)
+	}
+
 	workloadStatusHistory := valid["workload-status-history"].(map[string]interface{})
 	if err := importStatusHistory(&result.WorkloadStatusHistory_, workloadStatusHistory); err != nil {
 		return nil, errors.Trace(err)
@@ -370,5 +453,23 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 	}
 	result.WorkloadStatus_ = workloadStatus
 
+	result.setResources(nil)
+	if resourcesMap, ok := valid["resources"]; ok {
+		resources, err := importUnitResources(resourcesMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.setResources(resources)
+	}
+
+	result.setPayloads(nil)
+	if payloadsMap, ok := valid["payloads"]; ok {
+		payloads, err := importPayloads(payloadsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.setPayloads(payloads)
+	}
+
 	return result, nil
 }
@@ -39,6 +39,14 @@ func minimalUnitMap() map[interface{}]interface{} {
 		"workload-version-history": emptyStatusHistoryMap(),
 		"password-hash":            "secure-hash",
 		"tools":                    minimalAgentToolsMap(),
+		"resources": map[interface{}]interface{}{
+			"version":   1,
+			"resources": []interface{}{},
+		},
+		"payloads": map[interface{}]interface{}{
+			"version":  1,
+			"payloads": []interface{}{},
+		},
 	}
 }
 
@@ -74,11 +82,17 @@ func (s *UnitSerializationSuite) completeUnit() *unit {
 		WorkloadVersion: "malachite",
 		MeterStatusCode: "meter code",
 		MeterStatusInfo: "meter info",
+		State:           map[string]string{"db-host": "10.0.0.5"},
 	}
 	unit := newUnit(args)
 	unit.SetAgentStatus(minimalStatusArgs())
 	unit.SetWorkloadStatus(minimalStatusArgs())
 	unit.SetTools(minimalAgentToolsArgs())
+	unit.AddResource(UnitResourceArgs{
+		Name:         "database",
+		RevisionArgs: minimalResourceRevisionArgs(),
+	})
+	unit.AddPayload(minimalPayloadArgs())
 	return unit
 }
 
@@ -97,9 +111,12 @@ func (s *UnitSerializationSuite) TestNewUnit(c *gc.C) {
 	c.Assert(unit.WorkloadVersion(), gc.Equals, "malachite")
 	c.Assert(unit.MeterStatusCode(), gc.Equals, "meter code")
 	c.Assert(unit.MeterStatusInfo(), gc.Equals, "meter info")
+	c.Assert(unit.State(), jc.DeepEquals, map[string]string{"db-host": "10.0.0.5"})
 	c.Assert(unit.Tools(), gc.NotNil)
 	c.Assert(unit.WorkloadStatus(), gc.NotNil)
 	c.Assert(unit.AgentStatus(), gc.NotNil)
+	c.Assert(unit.Resources(), gc.HasLen, 1)
+	c.Assert(unit.Payloads(), gc.HasLen, 1)
 }
 
 func (s *UnitSerializationSuite) TestMinimalUnitValid(c *gc.C) {
@@ -142,6 +159,37 @@ func (s *UnitSerializationSuite) TestParsingSerializedData(c *gc.C) {
 	c.Assert(unit, jc.DeepEquals, initial)
 }
 
+func (s *UnitSerializationSuite) TestResources(c *gc.C) {
+	initial := minimalUnit()
+	initial.AddResource(UnitResourceArgs{
+		Name:         "database",
+		RevisionArgs: minimalResourceRevisionArgs(),
+	})
+
+	unit := s.exportImport(c, initial)
+	resources := unit.Resources()
+	c.Assert(resources, gc.HasLen, 1)
+	c.Check(resources[0].Name(), gc.Equals, "database")
+	c.Check(resources[0].Revision(), jc.DeepEquals, newResourceRevision(minimalResourceRevisionArgs()))
+}
+
+func (s *UnitSerializationSuite) TestPayloads(c *gc.C) {
+	initial := minimalUnit()
+	initial.AddPayload(minimalPayloadArgs())
+
+	unit := s.exportImport(c, initial)
+	c.Assert(unit.Payloads(), jc.DeepEquals, []Payload{
+		newPayload(minimalPayloadArgs()),
+	})
+}
+
+func (s *UnitSerializationSuite) TestPayloadsValidated(c *gc.C) {
+	unit := minimalUnit()
+	unit.AddPayload(PayloadArgs{Name: "bad"})
+	err := unit.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0": payload "bad" missing raw id not valid`)
+}
+
 func (s *UnitSerializationSuite) TestAnnotations(c *gc.C) {
 	initial := minimalUnit()
 	annotations := map[string]string{
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+)
+
+// These codes identify the reasons a model migration precheck may
+// fail.
+const (
+	PrecheckCleanupNeeded          = "cleanup-needed"
+	PrecheckUpgradeInProgress      = "upgrade-in-progress"
+	PrecheckAgentVersionMismatch   = "agent-version-mismatch"
+	PrecheckMachineNotAlive        = "machine-not-alive"
+	PrecheckMachineError           = "machine-error"
+	PrecheckUnitNotAlive           = "unit-not-alive"
+	PrecheckUnitError              = "unit-error"
+	PrecheckCharmUnavailable       = "charm-unavailable"
+	PrecheckToolsUnavailable       = "tools-unavailable"
+	PrecheckModelExists            = "model-exists"
+	PrecheckModelNameTaken         = "model-name-taken"
+	PrecheckCloudUnknown           = "cloud-unknown"
+	PrecheckCloudRegionUnknown     = "cloud-region-unknown"
+	PrecheckCloudCredentialUnknown = "cloud-credential-unknown"
+)
+
+// PrecheckFailure describes a single problem, found while checking a
+// source or target controller, which prevents a model from being
+// migrated.
+type PrecheckFailure struct {
+	// Code identifies the type of the problem. It will be one of
+	// the Precheck* constants defined in this package.
+	Code string
+
+	// Entity holds the tag of the entity the problem relates to,
+	// if any.
+	Entity string
+
+	// Message holds a human readable description of the problem.
+	Message string
+}
+
+// String returns a human readable representation of the failure.
+func (f PrecheckFailure) String() string {
+	return fmt.Sprintf("%s: %s", f.Code, f.Message)
+}
+
+// ModelInfo holds the details of a model which a target controller
+// needs in order to decide whether the model can be migrated to it.
+type ModelInfo struct {
+	UUID            string
+	Name            string
+	Owner           names.UserTag
+	AgentVersion    version.Number
+	CloudName       string
+	CloudRegion     string
+	CloudCredential string
+}
@@ -23,6 +23,10 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -141,10 +145,70 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPURL sets the URL of the HTTP endpoint to which log
+	// records are POSTed as JSON.
+	LogFwdHTTPURL = "httpjson-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP endpoint's certificate.
+	LogFwdHTTPCACert = "httpjson-ca-cert"
+
+	// LogFwdHTTPClientCert sets the client certificate for HTTP
+	// forwarding.
+	LogFwdHTTPClientCert = "httpjson-client-cert"
+
+	// LogFwdHTTPClientKey sets the client key for HTTP forwarding.
+	LogFwdHTTPClientKey = "httpjson-client-key"
+
+	// LogFwdGELFHost sets the hostname:port of the Graylog server.
+	LogFwdGELFHost = "gelf-host"
+
+	// LogFwdGELFProtocol sets the protocol, "udp" or "tcp", used for
+	// GELF forwarding.
+	LogFwdGELFProtocol = "gelf-protocol"
+
+	// LogFwdGELFCACert sets the certificate of the CA that signed the
+	// Graylog server certificate.
+	LogFwdGELFCACert = "gelf-ca-cert"
+
+	// LogFwdGELFClientCert sets the client certificate for GELF
+	// forwarding.
+	LogFwdGELFClientCert = "gelf-client-cert"
+
+	// LogFwdGELFClientKey sets the client key for GELF forwarding.
+	LogFwdGELFClientKey = "gelf-client-key"
+
+	// LogFwdFilePath sets the path of the local file to which log
+	// records are forwarded, relative to the forwarded log directory
+	// within the agent's log directory.
+	LogFwdFilePath = "logfile-path"
+
+	// LogFwdFileMaxSize sets the size in megabytes at which the
+	// forwarded log file is rotated.
+	LogFwdFileMaxSize = "logfile-max-size"
+
+	// LogFwdFileMaxBackups sets the number of rotated forwarded log
+	// files which are kept.
+	LogFwdFileMaxBackups = "logfile-max-backups"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// MaxStatusHistoryAge is the maximum age of status history entries
+	// kept for the model.
+	MaxStatusHistoryAge = "max-status-history-age"
+
+	// MaxStatusHistorySize is the maximum size of the status history
+	// kept for the model.
+	MaxStatusHistorySize = "max-status-history-size"
+
+	// MaxLogsAge is the maximum age of log entries kept for the model.
+	MaxLogsAge = "max-logs-age"
+
+	// MaxLogsSize is the maximum size of the logs kept for the model.
+	MaxLogsSize = "max-logs-size"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -155,6 +219,20 @@ const (
 	IgnoreMachineAddresses = "ignore-machine-addresses"
 )
 
+const (
+	// DefaultStatusHistoryAge is the default value for MaxStatusHistoryAge.
+	DefaultStatusHistoryAge = "336h" // 2 weeks
+
+	// DefaultStatusHistorySize is the default value for MaxStatusHistorySize.
+	DefaultStatusHistorySize = "5G"
+
+	// DefaultLogsAge is the default value for MaxLogsAge.
+	DefaultLogsAge = "72h" // 3 days
+
+	// DefaultLogsSize is the default value for MaxLogsSize.
+	DefaultLogsSize = "4G"
+)
+
 // ParseHarvestMode parses description of harvesting method and
 // returns the representation.
 func ParseHarvestMode(description string) (HarvestMode, error) {
@@ -469,6 +547,39 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if lfCfg, ok := cfg.LogFwdHTTP(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return logFwdConfigError("HTTP", err, map[string]string{
+				"CACert":     LogFwdHTTPCACert,
+				"ClientCert": LogFwdHTTPClientCert,
+				"ClientKey":  LogFwdHTTPClientKey,
+				"URL":        LogFwdHTTPURL,
+			})
+		}
+	}
+
+	if lfCfg, ok := cfg.LogFwdGELF(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return logFwdConfigError("GELF", err, map[string]string{
+				"CACert":     LogFwdGELFCACert,
+				"ClientCert": LogFwdGELFClientCert,
+				"ClientKey":  LogFwdGELFClientKey,
+				"Protocol":   LogFwdGELFProtocol,
+				"Host":       LogFwdGELFHost,
+			})
+		}
+	}
+
+	if lfCfg, ok := cfg.LogFwdFile(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return logFwdConfigError("file", err, map[string]string{
+				"Path":       LogFwdFilePath,
+				"MaxSize":    LogFwdFileMaxSize,
+				"MaxBackups": LogFwdFileMaxBackups,
+			})
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -478,6 +589,18 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	// Check that the retention settings are valid if set explicitly.
+	for _, attr := range []string{MaxStatusHistoryAge, MaxLogsAge} {
+		if _, err := cfg.durationOrDefault(attr, "1s"); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", attr)
+		}
+	}
+	for _, attr := range []string{MaxStatusHistorySize, MaxLogsSize} {
+		if _, err := cfg.sizeOrDefault(attr, "1M"); err != nil {
+			return errors.Annotatef(err, "invalid %s in model configuration", attr)
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		allImmutableAttributes := append(immutableAttributes, controller.ControllerOnlyConfigAttributes...)
@@ -725,6 +848,63 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// logFwdConfigError cleans up the error returned when validating the
+// config for a log forwarding target, by naming the attribute which
+// corresponds to the invalid config field.
+func logFwdConfigError(target string, err error, attrs map[string]string) error {
+	if fieldErr, ok := err.(*logfwd.FieldError); ok {
+		if attr, ok := attrs[fieldErr.Field]; ok {
+			return errors.Annotatef(errors.Cause(fieldErr), "invalid %q", attr)
+		}
+	}
+	return errors.Annotatef(err, "invalid %s forwarding config", target)
+}
+
+// LogFwdHTTP returns the HTTP forwarding config.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	var lfCfg httpjson.RawConfig
+	lfCfg.URL = c.asString(LogFwdHTTPURL)
+	lfCfg.TLS.CACert = c.asString(LogFwdHTTPCACert)
+	lfCfg.TLS.ClientCert = c.asString(LogFwdHTTPClientCert)
+	lfCfg.TLS.ClientKey = c.asString(LogFwdHTTPClientKey)
+	if lfCfg == (httpjson.RawConfig{}) {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdGELF returns the GELF (Graylog) forwarding config.
+func (c *Config) LogFwdGELF() (*gelf.RawConfig, bool) {
+	var lfCfg gelf.RawConfig
+	lfCfg.Host = c.asString(LogFwdGELFHost)
+	lfCfg.Protocol = c.asString(LogFwdGELFProtocol)
+	lfCfg.TLS.CACert = c.asString(LogFwdGELFCACert)
+	lfCfg.TLS.ClientCert = c.asString(LogFwdGELFClientCert)
+	lfCfg.TLS.ClientKey = c.asString(LogFwdGELFClientKey)
+	if lfCfg == (gelf.RawConfig{}) {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdFile returns the local file forwarding config. The file size
+// and backup settings alone do not enable file forwarding; the path
+// must also be set.
+func (c *Config) LogFwdFile() (*logfile.RawConfig, bool) {
+	path := c.asString(LogFwdFilePath)
+	if path == "" {
+		return nil, false
+	}
+	lfCfg := logfile.RawConfig{Path: path}
+	if v, ok := c.defined[LogFwdFileMaxSize].(int); ok {
+		lfCfg.MaxSize = v
+	}
+	if v, ok := c.defined[LogFwdFileMaxBackups].(int); ok {
+		lfCfg.MaxBackups = v
+	}
+	return &lfCfg, true
+}
+
 // AdminSecret returns the administrator password.
 // It's empty if the password has not been set.
 // TODO(wallyworld) - remove this, it is a bootstrap parameter only
@@ -820,6 +1000,64 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// MaxStatusHistoryAge returns the maximum age of the status history
+// entries kept for the model.
+func (c *Config) MaxStatusHistoryAge() (time.Duration, error) {
+	return c.durationOrDefault(MaxStatusHistoryAge, DefaultStatusHistoryAge)
+}
+
+// MaxStatusHistorySizeMB returns the maximum size in MiB of the status
+// history kept for the model.
+func (c *Config) MaxStatusHistorySizeMB() (uint, error) {
+	return c.sizeOrDefault(MaxStatusHistorySize, DefaultStatusHistorySize)
+}
+
+// MaxLogsAge returns the maximum age of the log entries kept for the
+// model.
+func (c *Config) MaxLogsAge() (time.Duration, error) {
+	return c.durationOrDefault(MaxLogsAge, DefaultLogsAge)
+}
+
+// MaxLogSizeMB returns the maximum size in MiB of the logs kept for the
+// model.
+func (c *Config) MaxLogSizeMB() (uint, error) {
+	return c.sizeOrDefault(MaxLogsSize, DefaultLogsSize)
+}
+
+// durationOrDefault returns the positive duration held in the named
+// attribute, or the default value if the attribute is not set.
+func (c *Config) durationOrDefault(name, defaultValue string) (time.Duration, error) {
+	v, ok := c.defined[name].(string)
+	if !ok {
+		v = defaultValue
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if d <= 0 {
+		return 0, errors.NotValidf("non-positive duration %q", v)
+	}
+	return d, nil
+}
+
+// sizeOrDefault returns the positive size in MiB held in the named
+// attribute, or the default value if the attribute is not set.
+func (c *Config) sizeOrDefault(name, defaultValue string) (uint, error) {
+	v, ok := c.defined[name].(string)
+	if !ok {
+		v = defaultValue
+	}
+	size, err := utils.ParseSize(v)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if size == 0 {
+		return 0, errors.NotValidf("zero size %q", v)
+	}
+	return uint(size), nil
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -982,16 +1220,18 @@ var alwaysOptional = schema.Defaults{
 	// The following attributes are for the controller config
 	// but are included here because we currently parse model
 	// and controller config together.
-	controller.ControllerUUIDKey:       schema.Omit,
-	controller.CACertKey:               schema.Omit,
-	controller.CAPrivateKey:            schema.Omit,
-	controller.ApiPort:                 schema.Omit,
-	controller.StatePort:               schema.Omit,
-	controller.IdentityURL:             schema.Omit,
-	controller.IdentityPublicKey:       schema.Omit,
-	controller.CACertKey + "-path":     schema.Omit,
-	controller.CAPrivateKey + "-path":  schema.Omit,
-	controller.SetNumaControlPolicyKey: schema.Omit,
+	controller.ControllerUUIDKey:         schema.Omit,
+	controller.CACertKey:                 schema.Omit,
+	controller.CAPrivateKey:              schema.Omit,
+	controller.ApiPort:                   schema.Omit,
+	controller.StatePort:                 schema.Omit,
+	controller.IdentityURL:               schema.Omit,
+	controller.IdentityPublicKey:         schema.Omit,
+	controller.CACertKey + "-path":       schema.Omit,
+	controller.CAPrivateKey + "-path":    schema.Omit,
+	controller.SetNumaControlPolicyKey:   schema.Omit,
+	controller.AuditLogFileKey:           schema.Omit,
+	controller.AuditLogExcludeMethodsKey: schema.Omit,
 
 	// Model config attributes
 	AgentVersionKey:              schema.Omit,
@@ -1007,6 +1247,18 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:           schema.Omit,
 	LogFwdSyslogClientCert:       schema.Omit,
 	LogFwdSyslogClientKey:        schema.Omit,
+	LogFwdHTTPURL:                schema.Omit,
+	LogFwdHTTPCACert:             schema.Omit,
+	LogFwdHTTPClientCert:         schema.Omit,
+	LogFwdHTTPClientKey:          schema.Omit,
+	LogFwdGELFHost:               schema.Omit,
+	LogFwdGELFProtocol:           schema.Omit,
+	LogFwdGELFCACert:             schema.Omit,
+	LogFwdGELFClientCert:         schema.Omit,
+	LogFwdGELFClientKey:          schema.Omit,
+	LogFwdFilePath:               schema.Omit,
+	LogFwdFileMaxSize:            schema.Omit,
+	LogFwdFileMaxBackups:         schema.Omit,
 	HttpProxyKey:                 schema.Omit,
 	HttpsProxyKey:                schema.Omit,
 	FtpProxyKey:                  schema.Omit,
@@ -1024,6 +1276,12 @@ var alwaysOptional = schema.Defaults{
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
 
+	// Retention settings fall back to their defaults if missing.
+	MaxStatusHistoryAge:  schema.Omit,
+	MaxStatusHistorySize: schema.Omit,
+	MaxLogsAge:           schema.Omit,
+	MaxLogsSize:          schema.Omit,
+
 	// Storage related config.
 	// Environ providers will specify their own defaults.
 	StorageDefaultBlockSourceKey: schema.Omit,
@@ -1381,6 +1639,26 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxLogsAge: {
+		Description: "The maximum age of the log entries kept for the model, such as 72h (default " + DefaultLogsAge + ")",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxLogsSize: {
+		Description: "The maximum size of the logs kept for the model, such as 4G (default " + DefaultLogsSize + ")",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxStatusHistoryAge: {
+		Description: "The maximum age of the status history entries kept for the model, such as 336h (default " + DefaultStatusHistoryAge + ")",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxStatusHistorySize: {
+		Description: "The maximum size of the status history kept for the model, such as 5G (default " + DefaultStatusHistorySize + ")",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	NameKey: {
 		Description: "The name of the current model",
 		Type:        environschema.Tstring,
@@ -1436,6 +1714,69 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPURL: {
+		Description: `The URL of an HTTP endpoint to which log records are POSTed as JSON.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP endpoint's certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPClientCert: {
+		Description: `The client certificate for HTTP log forwarding in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPClientKey: {
+		Description: `The client key for HTTP log forwarding in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Secret:      true,
+	},
+	LogFwdGELFHost: {
+		Description: `The hostname:port of a Graylog server to which log records are sent in GELF format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFProtocol: {
+		Description: `The protocol used to send GELF messages (default udp).`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"udp", "tcp", ""},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFCACert: {
+		Description: `The certificate of the CA that signed the Graylog server certificate, in PEM format. Setting any TLS attribute enables TLS for tcp.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFClientCert: {
+		Description: `The client certificate for GELF log forwarding in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFClientKey: {
+		Description: `The client key for GELF log forwarding in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Secret:      true,
+	},
+	LogFwdFilePath: {
+		Description: `The path, relative to the "forwarded" directory in the controller agent's log directory, of a local file to which log records are written.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFileMaxSize: {
+		Description: `The size in megabytes at which the forwarded log file is rotated (default 100).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFileMaxBackups: {
+		Description: `The number of rotated forwarded log files to keep (default 10).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/testing"
 )
 
@@ -652,6 +653,34 @@ var configTests = []configTest{
 			"resource-tags": []string{"a"},
 		}),
 		err: `resource-tags: expected "key=value", got "a"`,
+	}, {
+		about:       "Invalid max-status-history-age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-status-history-age": "2 weeks",
+		}),
+		err: `invalid max-status-history-age in model configuration: time: invalid duration 2 weeks`,
+	}, {
+		about:       "Invalid max-logs-size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-logs-size": "lots",
+		}),
+		err: `invalid max-logs-size in model configuration: expected a non-negative number, got "lots"`,
+	}, {
+		about:       "Negative max-logs-age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-logs-age": "-1h",
+		}),
+		err: `invalid max-logs-age in model configuration: non-positive duration "-1h" not valid`,
+	}, {
+		about:       "Zero max-status-history-size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-status-history-size": "0M",
+		}),
+		err: `invalid max-status-history-size in model configuration: zero size "0M" not valid`,
 	}, {
 		about:       "Invalid syslog server cert",
 		useDefaults: config.UseDefaults,
@@ -736,6 +765,94 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Invalid HTTP log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"httpjson-url": "ftp://logs.example.com",
+		}),
+		err: `invalid "httpjson-url": URL scheme "ftp" not valid`,
+	}, {
+		about:       "HTTP log forwarding client cert without key",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"httpjson-url":         "https://logs.example.com",
+			"httpjson-client-cert": testing.ServerCert,
+		}),
+		err: `invalid "httpjson-client-key": empty ClientKey`,
+	}, {
+		about:       "Valid HTTP log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"httpjson-url":         "https://logs.example.com/juju",
+			"httpjson-ca-cert":     testing.CACert,
+			"httpjson-client-cert": testing.ServerCert,
+			"httpjson-client-key":  testing.ServerKey,
+		}),
+	}, {
+		about:       "GELF TLS over UDP",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"gelf-host":    "graylog.example.com",
+			"gelf-ca-cert": testing.CACert,
+		}),
+		err: `invalid "gelf-protocol": TLS settings given for UDP`,
+	}, {
+		about:       "Invalid GELF CA cert",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"gelf-host":     "graylog.example.com",
+			"gelf-protocol": "tcp",
+			"gelf-ca-cert":  "abc",
+		}),
+		err: `invalid "gelf-ca-cert": no certificates found`,
+	}, {
+		about:       "GELF protocol without host",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"gelf-protocol": "tcp",
+		}),
+		err: `invalid "gelf-host": empty Host`,
+	}, {
+		about:       "Valid GELF config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"gelf-host":        "graylog.example.com:12202",
+			"gelf-protocol":    "tcp",
+			"gelf-ca-cert":     testing.CACert,
+			"gelf-client-cert": testing.ServerCert,
+			"gelf-client-key":  testing.ServerKey,
+		}),
+	}, {
+		about:       "Absolute log forwarding file path",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logfile-path": "/etc/cron.d/forwarded",
+		}),
+		err: `invalid "logfile-path": absolute Path "/etc/cron.d/forwarded" not valid`,
+	}, {
+		about:       "Log forwarding file path outside the log directory",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logfile-path": "../../../etc/cron.d/forwarded",
+		}),
+		err: `invalid "logfile-path": Path "../../../etc/cron.d/forwarded" outside log directory not valid`,
+	}, {
+		about:       "Negative log forwarding file size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logfile-path":     "forwarded.log",
+			"logfile-max-size": -1,
+		}),
+		err: `invalid "logfile-max-size": negative MaxSize not valid`,
+	}, {
+		about:       "Valid log forwarding file config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logfile-path":        "forwarded.log",
+			"logfile-max-size":    50,
+			"logfile-max-backups": 2,
+		}),
 	}, {
 		about:       "Invalid identity URL value",
 		useDefaults: config.UseDefaults,
@@ -1466,6 +1583,98 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestRetentionDefaults(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	s.assertRetention(c, config, 336*time.Hour, 5120, 72*time.Hour, 4096)
+}
+
+func (s *ConfigSuite) TestRetentionSet(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"max-status-history-age":  "24h",
+		"max-status-history-size": "512M",
+		"max-logs-age":            "1h30m",
+		"max-logs-size":           "1G",
+	})
+	s.assertRetention(c, config, 24*time.Hour, 512, 90*time.Minute, 1024)
+}
+
+func (s *ConfigSuite) assertRetention(
+	c *gc.C, cfg *config.Config,
+	statusAge time.Duration, statusSizeMB uint,
+	logsAge time.Duration, logsSizeMB uint,
+) {
+	age, err := cfg.MaxStatusHistoryAge()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(age, gc.Equals, statusAge)
+	size, err := cfg.MaxStatusHistorySizeMB()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, statusSizeMB)
+	age, err = cfg.MaxLogsAge()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(age, gc.Equals, logsAge)
+	size, err = cfg.MaxLogSizeMB()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, logsSizeMB)
+}
+
+func (s *ConfigSuite) TestLogFwdNotSet(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.LogFwdHTTP()
+	c.Check(ok, jc.IsFalse)
+	_, ok = cfg.LogFwdGELF()
+	c.Check(ok, jc.IsFalse)
+	_, ok = cfg.LogFwdFile()
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestLogFwdHTTP(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"httpjson-url":     "https://logs.example.com/juju",
+		"httpjson-ca-cert": testing.CACert,
+	})
+	lfCfg, ok := cfg.LogFwdHTTP()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(lfCfg.URL, gc.Equals, "https://logs.example.com/juju")
+	c.Check(lfCfg.TLS.CACert, gc.Equals, testing.CACert)
+	c.Check(lfCfg.TLS.ClientCert, gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestLogFwdGELF(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"gelf-host":        "graylog.example.com",
+		"gelf-protocol":    "tcp",
+		"gelf-client-cert": testing.ServerCert,
+		"gelf-client-key":  testing.ServerKey,
+	})
+	lfCfg, ok := cfg.LogFwdGELF()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(lfCfg.Host, gc.Equals, "graylog.example.com")
+	c.Check(lfCfg.Protocol, gc.Equals, "tcp")
+	c.Check(lfCfg.TLS.ClientCert, gc.Equals, testing.ServerCert)
+	c.Check(lfCfg.TLS.ClientKey, gc.Equals, testing.ServerKey)
+}
+
+func (s *ConfigSuite) TestLogFwdFile(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logfile-path":     "forwarded.log",
+		"logfile-max-size": 20,
+	})
+	lfCfg, ok := cfg.LogFwdFile()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(*lfCfg, jc.DeepEquals, logfile.RawConfig{
+		Path:    "forwarded.log",
+		MaxSize: 20,
+	})
+}
+
+func (s *ConfigSuite) TestLogFwdFileRequiresPath(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logfile-max-size": 20,
+	})
+	_, ok := cfg.LogFwdFile()
+	c.Check(ok, jc.IsFalse)
+}
+
 func (s *ConfigSuite) TestCloudImageBaseURL(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{})
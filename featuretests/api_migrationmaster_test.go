@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package featuretests
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationmaster"
+	"github.com/juju/juju/core/migration"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/testing/factory"
+)
+
+type migrationMasterSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+func (s *migrationMasterSuite) TestReapRemovesModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	modelUUID := st.ModelUUID()
+
+	// Populate the model, including a charm archive in blob storage.
+	f := factory.NewFactory(st)
+	ch := f.MakeCharm(c, nil)
+	stor := storage.NewStorage(modelUUID, st.MongoSession())
+	err := stor.Put(ch.StoragePath(), strings.NewReader("charm"), 5)
+	c.Assert(err, jc.ErrorIsNil)
+	app := f.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	f.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	// Walk the migration through to the REAP phase.
+	mig, err := st.CreateModelMigration(state.ModelMigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag: names.NewModelTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5555"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("user"),
+			Password:      "password",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []migration.Phase{
+		migration.READONLY,
+		migration.PRECHECK,
+		migration.IMPORT,
+		migration.VALIDATION,
+		migration.SUCCESS,
+		migration.LOGTRANSFER,
+		migration.REAP,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+
+	client := migrationmaster.NewClient(s.openAPIAsController(c, st.ModelTag()))
+	err = client.Reap()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model is gone from the source controller...
+	_, err = s.State.GetModel(st.ModelTag())
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+
+	// ...along with all of its documents...
+	db := s.State.MongoSession().DB("juju")
+	collNames, err := db.CollectionNames()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range collNames {
+		if strings.HasPrefix(name, "migrations") {
+			// The migration records are kept on the source.
+			continue
+		}
+		n, err := db.C(name).Find(bson.M{"model-uuid": modelUUID}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(n, gc.Equals, 0, gc.Commentf("collection %q", name))
+	}
+
+	// ...and its binaries.
+	_, _, err = stor.Get(ch.StoragePath())
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+// openAPIAsController opens an API connection to the model given as
+// a controller machine agent, as the migration master worker does.
+func (s *migrationMasterSuite) openAPIAsController(c *gc.C, modelTag names.ModelTag) api.Connection {
+	machine, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Jobs:  []state.MachineJob{state.JobManageModel},
+		Nonce: "nonce",
+	})
+	info := s.APIInfo(c)
+	info.ModelTag = modelTag
+	info.Tag = machine.Tag()
+	info.Password = password
+	info.Nonce = "nonce"
+	conn, err := api.Open(info, api.DialOpts{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn
+}
@@ -0,0 +1,39 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"github.com/juju/errors"
+)
+
+// FieldError is returned when validating a log forwarding config
+// finds that one of its fields is invalid.
+type FieldError struct {
+	// Field is the name of the invalid field.
+	Field string
+
+	// Err describes what is wrong with the field.
+	Err error
+}
+
+// NewFieldError returns an error reporting that the named field is
+// invalid for the reason given by err.
+func NewFieldError(field string, err error) error {
+	return &FieldError{
+		Field: field,
+		Err:   err,
+	}
+}
+
+// Error implements error.
+func (err *FieldError) Error() string {
+	return err.Err.Error()
+}
+
+// Cause returns the cause of the underlying error, so that the
+// helpers in github.com/juju/errors (e.g. IsNotValid) see through
+// the field.
+func (err *FieldError) Cause() error {
+	return errors.Cause(err.Err)
+}
@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type FieldErrorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FieldErrorSuite{})
+
+func (s *FieldErrorSuite) TestError(c *gc.C) {
+	err := logfwd.NewFieldError("Host", errors.NotValidf("port %q in Host", "x"))
+
+	c.Check(err, gc.ErrorMatches, `port "x" in Host not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "Host")
+}
@@ -0,0 +1,203 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// dialTimeout is how long to wait when connecting to the server.
+	dialTimeout = 30 * time.Second
+
+	// maxDatagramSize is the largest UDP datagram sent to the server.
+	// Longer messages are split into chunks.
+	maxDatagramSize = 8192
+
+	// chunkHeaderSize is the size of the header at the start of each
+	// chunk: the magic bytes, the message ID, the sequence number and
+	// the sequence count.
+	chunkHeaderSize = 12
+
+	// maxChunks is the most chunks a message may be split into.
+	maxChunks = 128
+)
+
+// chunkMagic identifies a datagram as a chunk of a GELF message.
+var chunkMagic = []byte{0x1e, 0x0f}
+
+// Client is the wrapper around a connection to a Graylog server.
+type Client struct {
+	// Conn is the connection over which messages are sent.
+	Conn io.WriteCloser
+
+	// Protocol is the protocol used by Conn, which determines how
+	// messages are framed.
+	Protocol string
+}
+
+// Open connects to a Graylog server and wraps that connection in a
+// new client.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	conn, err := dial(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening client connection")
+	}
+	client := &Client{
+		Conn:     conn,
+		Protocol: cfg.protocol(),
+	}
+	return client, nil
+}
+
+func dial(cfg RawConfig) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if !cfg.UseTLS() {
+		conn, err := dialer.Dial(cfg.protocol(), cfg.Address())
+		return conn, errors.Trace(err)
+	}
+	tlsConfig, err := cfg.TLS.TLS()
+	if err != nil {
+		return nil, errors.Annotate(err, "obtaining TLS config")
+	}
+	host, _, err := net.SplitHostPort(cfg.Address())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig.ServerName = host
+	conn, err := tls.DialWithDialer(dialer, ProtocolTCP, cfg.Address(), tlsConfig)
+	return conn, errors.Trace(err)
+}
+
+// Close closes the client's connection.
+func (client Client) Close() error {
+	err := client.Conn.Close()
+	return errors.Trace(err)
+}
+
+// Send sends the record to the Graylog server.
+func (client Client) Send(rec logfwd.Record) error {
+	msg, err := messageFromRecord(rec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Annotate(err, "marshalling GELF message")
+	}
+
+	if client.Protocol == ProtocolTCP {
+		// Messages sent over TCP are delimited by a null byte, which
+		// never appears in the JSON encoding.
+		_, err := client.Conn.Write(append(data, 0))
+		return errors.Trace(err)
+	}
+	return errors.Trace(client.sendDatagrams(data))
+}
+
+// sendDatagrams writes the message in a single datagram if it fits,
+// or otherwise splits it into chunks as described by the GELF spec.
+func (client Client) sendDatagrams(data []byte) error {
+	if len(data) <= maxDatagramSize {
+		_, err := client.Conn.Write(data)
+		return errors.Trace(err)
+	}
+
+	const chunkDataSize = maxDatagramSize - chunkHeaderSize
+	count := (len(data) + chunkDataSize - 1) / chunkDataSize
+	if count > maxChunks {
+		return errors.Errorf("message too large (%d bytes)", len(data))
+	}
+	messageID := make([]byte, 8)
+	if _, err := rand.Read(messageID); err != nil {
+		return errors.Annotate(err, "generating message ID")
+	}
+	for seq := 0; seq < count; seq++ {
+		end := (seq + 1) * chunkDataSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk := make([]byte, 0, chunkHeaderSize+chunkDataSize)
+		chunk = append(chunk, chunkMagic...)
+		chunk = append(chunk, messageID...)
+		chunk = append(chunk, byte(seq), byte(count))
+		chunk = append(chunk, data[seq*chunkDataSize:end]...)
+		if _, err := client.Conn.Write(chunk); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Message is a GELF (version 1.1) message. Fields whose names begin
+// with an underscore are additional fields specific to Juju.
+type Message struct {
+	Version         string  `json:"version"`
+	Host            string  `json:"host"`
+	ShortMessage    string  `json:"short_message"`
+	Timestamp       float64 `json:"timestamp"`
+	Level           int     `json:"level"`
+	RecordID        int64   `json:"_record_id"`
+	ControllerUUID  string  `json:"_controller_uuid"`
+	ModelUUID       string  `json:"_model_uuid"`
+	OriginType      string  `json:"_origin_type"`
+	OriginName      string  `json:"_origin_name"`
+	Software        string  `json:"_software"`
+	SoftwareVersion string  `json:"_software_version"`
+	Module          string  `json:"_module,omitempty"`
+	Location        string  `json:"_location,omitempty"`
+}
+
+func messageFromRecord(rec logfwd.Record) (Message, error) {
+	msg := Message{
+		Version:         "1.1",
+		Host:            rec.Origin.Hostname,
+		ShortMessage:    rec.Message,
+		Timestamp:       float64(rec.Timestamp.UnixNano()/int64(time.Millisecond)) / 1000,
+		RecordID:        rec.ID,
+		ControllerUUID:  rec.Origin.ControllerUUID,
+		ModelUUID:       rec.Origin.ModelUUID,
+		OriginType:      rec.Origin.Type.String(),
+		OriginName:      rec.Origin.Name,
+		Software:        rec.Origin.Software.Name,
+		SoftwareVersion: rec.Origin.Software.Version.String(),
+		Module:          rec.Location.Module,
+		Location:        rec.Location.String(),
+	}
+	if msg.ShortMessage == "" {
+		// GELF requires a non-empty short message.
+		msg.ShortMessage = "-"
+	}
+
+	// GELF uses the syslog severity levels.
+	switch rec.Level {
+	case loggo.CRITICAL:
+		msg.Level = 2
+	case loggo.ERROR:
+		msg.Level = 3
+	case loggo.WARNING:
+		msg.Level = 4
+	case loggo.INFO:
+		msg.Level = 6
+	case loggo.DEBUG, loggo.TRACE:
+		msg.Level = 7
+	default:
+		return msg, errors.Errorf("unsupported log level %q", rec.Level)
+	}
+	return msg, nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	conn *stubConn
+	rec  logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.conn = &stubConn{stub: s.stub}
+
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	s.rec = logfwd.Record{
+		ID:        10,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 250000000),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
+
+func (s *ClientSuite) TestOpenUDP(c *gc.C) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+
+	client, err := gelf.Open(gelf.RawConfig{
+		Host: listener.LocalAddr().String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	c.Check(client.Protocol, gc.Equals, gelf.ProtocolUDP)
+
+	err = client.Send(s.rec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	buf := make([]byte, 8192)
+	listener.SetReadDeadline(time.Now().Add(coretesting.LongWait))
+	n, _, err := listener.ReadFrom(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	var msg gelf.Message
+	err = json.Unmarshal(buf[:n], &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg.RecordID, gc.Equals, int64(10))
+}
+
+func (s *ClientSuite) TestClose(c *gc.C) {
+	client := gelf.Client{Conn: s.conn, Protocol: gelf.ProtocolUDP}
+
+	err := client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Close")
+}
+
+func (s *ClientSuite) TestSendUDP(c *gc.C) {
+	client := gelf.Client{Conn: s.conn, Protocol: gelf.ProtocolUDP}
+
+	err := client.Send(s.rec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Write")
+	var msg map[string]interface{}
+	err = json.Unmarshal(s.conn.writes[0], &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg, jc.DeepEquals, map[string]interface{}{
+		"version":           "1.1",
+		"host":              "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"short_message":     "(╯°□°)╯︵ ┻━┻",
+		"timestamp":         12345.25,
+		"level":             float64(3),
+		"_record_id":        float64(10),
+		"_controller_uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"_model_uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"_origin_type":      "machine",
+		"_origin_name":      "99",
+		"_software":         "jujud-machine-agent",
+		"_software_version": "1.2.3",
+		"_module":           "juju.x.y",
+		"_location":         "x/y/spam.go:42",
+	})
+}
+
+func (s *ClientSuite) TestSendTCP(c *gc.C) {
+	client := gelf.Client{Conn: s.conn, Protocol: gelf.ProtocolTCP}
+
+	err := client.Send(s.rec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Write")
+	data := s.conn.writes[0]
+	c.Assert(data[len(data)-1], gc.Equals, byte(0))
+	var msg gelf.Message
+	err = json.Unmarshal(data[:len(data)-1], &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg.ShortMessage, gc.Equals, "(╯°□°)╯︵ ┻━┻")
+}
+
+func (s *ClientSuite) TestSendChunked(c *gc.C) {
+	s.rec.Message = strings.Repeat("x", 20000)
+	client := gelf.Client{Conn: s.conn, Protocol: gelf.ProtocolUDP}
+
+	err := client.Send(s.rec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Write", "Write", "Write")
+	var data []byte
+	for i, chunk := range s.conn.writes {
+		c.Assert(len(chunk) <= 8192, jc.IsTrue)
+		c.Check(chunk[:2], jc.DeepEquals, []byte{0x1e, 0x0f})
+		c.Check(chunk[2:10], jc.DeepEquals, s.conn.writes[0][2:10])
+		c.Check(chunk[10], gc.Equals, byte(i))
+		c.Check(chunk[11], gc.Equals, byte(3))
+		data = append(data, chunk[12:]...)
+	}
+	var msg gelf.Message
+	err = json.Unmarshal(data, &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg.ShortMessage, gc.Equals, s.rec.Message)
+}
+
+func (s *ClientSuite) TestSendTooLarge(c *gc.C) {
+	s.rec.Message = strings.Repeat("x", 129*8192)
+	client := gelf.Client{Conn: s.conn, Protocol: gelf.ProtocolUDP}
+
+	err := client.Send(s.rec)
+
+	c.Check(err, gc.ErrorMatches, `message too large \(\d+ bytes\)`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
+	client := gelf.Client{Conn: s.conn, Protocol: gelf.ProtocolTCP}
+
+	levels := map[loggo.Level]int{
+		loggo.CRITICAL: 2,
+		loggo.ERROR:    3,
+		loggo.WARNING:  4,
+		loggo.INFO:     6,
+		loggo.DEBUG:    7,
+		loggo.TRACE:    7,
+	}
+	for level, expected := range levels {
+		c.Logf("trying %s -> %d", level, expected)
+		s.conn.writes = nil
+		s.rec.Level = level
+
+		err := client.Send(s.rec)
+		c.Assert(err, jc.ErrorIsNil)
+
+		var msg gelf.Message
+		err = json.Unmarshal(bytes.TrimRight(s.conn.writes[0], "\x00"), &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(msg.Level, gc.Equals, expected)
+	}
+}
+
+type stubConn struct {
+	stub *testing.Stub
+
+	writes [][]byte
+}
+
+func (s *stubConn) Write(data []byte) (int, error) {
+	s.stub.AddCall("Write", data)
+	if err := s.stub.NextErr(); err != nil {
+		return 0, err
+	}
+	s.writes = append(s.writes, append([]byte(nil), data...))
+	return len(data), nil
+}
+
+func (s *stubConn) Close() error {
+	s.stub.AddCall("Close")
+	return s.stub.NextErr()
+}
@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// DefaultPort is the port used for GELF if none is given.
+	DefaultPort = 12201
+
+	// ProtocolUDP identifies GELF sent in (possibly chunked) UDP
+	// datagrams.
+	ProtocolUDP = "udp"
+
+	// ProtocolTCP identifies GELF sent as null-terminated messages
+	// over a TCP stream, optionally secured with TLS.
+	ProtocolTCP = "tcp"
+)
+
+// RawConfig holds the raw configuration data for a connection to a
+// Graylog (GELF) server.
+type RawConfig struct {
+	// Host is the host-port of the Graylog server. The format is:
+	//
+	//   [domain-or-ip-addr] or [domain-or-ip-addr][:port]
+	//
+	// If the port is not set then the default GELF port (12201) will
+	// be used.
+	Host string
+
+	// Protocol is the transport over which messages are sent: either
+	// "udp" or "tcp". If it is not set then UDP is used.
+	Protocol string
+
+	// TLS holds the TLS settings used for a TCP connection. If any
+	// are set then the connection is made over TLS. They must not be
+	// set for UDP.
+	TLS logfwd.RawTLSConfig
+}
+
+// Validate ensures that the config is currently valid. Any error
+// returned is a *logfwd.FieldError naming the invalid setting.
+func (cfg RawConfig) Validate() error {
+	if cfg.Host == "" {
+		return logfwd.NewFieldError("Host", errors.NewNotValid(nil, "empty Host"))
+	}
+	host, port, err := net.SplitHostPort(cfg.Address())
+	if err != nil {
+		return logfwd.NewFieldError("Host", errors.NewNotValid(err, "bad Host"))
+	}
+	if host == "" {
+		return logfwd.NewFieldError("Host", errors.NewNotValid(nil, "empty hostname in Host"))
+	}
+	if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+		return logfwd.NewFieldError("Host", errors.NotValidf("port %q in Host", port))
+	}
+
+	switch cfg.protocol() {
+	case ProtocolTCP:
+	case ProtocolUDP:
+		if !cfg.TLS.IsZero() {
+			return logfwd.NewFieldError("Protocol", errors.NewNotValid(nil, "TLS settings given for UDP"))
+		}
+	default:
+		return logfwd.NewFieldError("Protocol", errors.NotValidf("Protocol %q", cfg.Protocol))
+	}
+
+	// The TLS error already names its field, so it is not traced.
+	if err := cfg.TLS.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Address returns the host-port to connect to, adding the default
+// port to Host if it doesn't include one.
+func (cfg RawConfig) Address() string {
+	if _, _, err := net.SplitHostPort(cfg.Host); err == nil {
+		return cfg.Host
+	}
+	return net.JoinHostPort(cfg.Host, fmt.Sprint(DefaultPort))
+}
+
+// UseTLS reports whether the connection to the server is made over TLS.
+func (cfg RawConfig) UseTLS() bool {
+	return cfg.protocol() == ProtocolTCP && !cfg.TLS.IsZero()
+}
+
+func (cfg RawConfig) protocol() string {
+	if cfg.Protocol == "" {
+		return ProtocolUDP
+	}
+	return cfg.Protocol
+}
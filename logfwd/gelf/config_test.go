@@ -0,0 +1,139 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Host:     "a.b.c:9876",
+		Protocol: "tcp",
+		TLS: logfwd.RawTLSConfig{
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg.Address(), gc.Equals, "a.b.c:9876")
+	c.Check(cfg.UseTLS(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestRawValidateDefaults(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Host: "a.b.c",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg.Address(), gc.Equals, "a.b.c:12201")
+	c.Check(cfg.UseTLS(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestRawValidateIPv6WithoutPort(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Host: "::1",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg.Address(), gc.Equals, "[::1]:12201")
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg gelf.RawConfig
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty Host`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadPort(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Host: "a.b.c:99999",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `port "99999" in Host not valid`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "Host")
+}
+
+func (s *ConfigSuite) TestRawValidateMissingHostname(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Host: ":9876",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty hostname in Host`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadProtocol(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Host:     "a.b.c",
+		Protocol: "http",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `Protocol "http" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateTLSWithUDP(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Host:     "a.b.c",
+		Protocol: "udp",
+		TLS: logfwd.RawTLSConfig{
+			CACert: coretesting.CACert,
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `TLS settings given for UDP`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "Protocol")
+}
+
+func (s *ConfigSuite) TestRawValidateBadTLS(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Host:     "a.b.c",
+		Protocol: "tcp",
+		TLS: logfwd.RawTLSConfig{
+			CACert: "abc",
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `invalid CACert: no certificates found`)
+}
@@ -0,0 +1,6 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The gelf package holds the tools needed to perform log forwarding
+// from Juju to a Graylog server, using the GELF format over TCP or UDP.
+package gelf
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// sendTimeout is how long a single POST of log records may take,
+// including connecting to the endpoint.
+const sendTimeout = 30 * time.Second
+
+// maxErrorBodySize is the most of an error response's body that is
+// included in the error returned to the caller.
+const maxErrorBodySize = 1024
+
+// Doer exposes the underlying functionality needed by Client.
+type Doer interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client sends log records to an HTTP endpoint as JSON.
+type Client struct {
+	// URL is the address to which records are POSTed.
+	URL string
+
+	// Doer is used to send the HTTP requests.
+	Doer Doer
+}
+
+// Open returns a new client for the HTTP endpoint described by the
+// config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig, err := cfg.TLS.TLS()
+	if err != nil {
+		return nil, errors.Annotate(err, "obtaining TLS config")
+	}
+	doer := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+		Timeout: sendTimeout,
+	}
+	return OpenForDoer(cfg, doer)
+}
+
+// OpenForDoer returns a new client for the HTTP endpoint described
+// by the config, which sends its requests with the given Doer.
+func OpenForDoer(cfg RawConfig, doer Doer) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := &Client{
+		URL:  cfg.URL,
+		Doer: doer,
+	}
+	return client, nil
+}
+
+// Close implements io.Closer. There is no connection to close, so it
+// does nothing.
+func (client Client) Close() error {
+	return nil
+}
+
+// Send sends the record to the HTTP endpoint.
+func (client Client) Send(rec logfwd.Record) error {
+	return errors.Trace(client.SendBatch([]logfwd.Record{rec}))
+}
+
+// SendBatch POSTs the records to the HTTP endpoint as a JSON array,
+// in a single request. The endpoint must respond with a 2xx status
+// for the records to be considered sent.
+func (client Client) SendBatch(recs []logfwd.Record) error {
+	docs := make([]RecordDoc, len(recs))
+	for i, rec := range recs {
+		docs[i] = NewRecordDoc(rec)
+	}
+	body, err := json.Marshal(docs)
+	if err != nil {
+		return errors.Annotate(err, "marshalling log records")
+	}
+
+	req, err := http.NewRequest("POST", client.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending log records")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return errors.Errorf("sending log records: unexpected response %q: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	// Drain the body so that the connection may be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+// RecordDoc is the JSON representation of a log record sent to an
+// HTTP endpoint.
+type RecordDoc struct {
+	ID              int64     `json:"id"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name"`
+	Software        string    `json:"software"`
+	SoftwareVersion string    `json:"software-version"`
+	Timestamp       time.Time `json:"timestamp"`
+	Level           string    `json:"level"`
+	Module          string    `json:"module,omitempty"`
+	Location        string    `json:"location,omitempty"`
+	Message         string    `json:"message"`
+}
+
+// NewRecordDoc returns the JSON representation of the log record.
+func NewRecordDoc(rec logfwd.Record) RecordDoc {
+	return RecordDoc{
+		ID:              rec.ID,
+		ControllerUUID:  rec.Origin.ControllerUUID,
+		ModelUUID:       rec.Origin.ModelUUID,
+		Hostname:        rec.Origin.Hostname,
+		OriginType:      rec.Origin.Type.String(),
+		OriginName:      rec.Origin.Name,
+		Software:        rec.Origin.Software.Name,
+		SoftwareVersion: rec.Origin.Software.Version.String(),
+		Timestamp:       rec.Timestamp.UTC(),
+		Level:           rec.Level.String(),
+		Module:          rec.Location.Module,
+		Location:        rec.Location.String(),
+		Message:         rec.Message,
+	}
+}
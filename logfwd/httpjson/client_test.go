@@ -0,0 +1,178 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	doer *stubDoer
+	rec  logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.doer = &stubDoer{stub: s.stub, status: http.StatusOK}
+
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	s.rec = logfwd.Record{
+		ID:        10,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
+
+func (s *ClientSuite) TestOpenForDoer(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "http://logs.example.com/juju",
+	}
+
+	client, err := httpjson.OpenForDoer(cfg, s.doer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(client.URL, gc.Equals, "http://logs.example.com/juju")
+	c.Check(client.Doer, gc.Equals, s.doer)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpjson.OpenForDoer(httpjson.RawConfig{}, s.doer)
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := httpjson.Client{URL: "http://logs.example.com/juju", Doer: s.doer}
+
+	err := client.Send(s.rec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do")
+	c.Check(s.doer.method, gc.Equals, "POST")
+	c.Check(s.doer.url, gc.Equals, "http://logs.example.com/juju")
+	c.Check(s.doer.contentType, gc.Equals, "application/json")
+
+	var docs []map[string]interface{}
+	err = json.Unmarshal(s.doer.body, &docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(docs, jc.DeepEquals, []map[string]interface{}{{
+		"id":               float64(10),
+		"controller-uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":         "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin-type":      "machine",
+		"origin-name":      "99",
+		"software":         "jujud-machine-agent",
+		"software-version": "1.2.3",
+		"timestamp":        "1970-01-01T03:25:45Z",
+		"level":            "ERROR",
+		"module":           "juju.x.y",
+		"location":         "x/y/spam.go:42",
+		"message":          "(╯°□°)╯︵ ┻━┻",
+	}})
+}
+
+func (s *ClientSuite) TestSendBatch(c *gc.C) {
+	client := httpjson.Client{URL: "http://logs.example.com/juju", Doer: s.doer}
+	second := s.rec
+	second.ID = 11
+
+	err := client.SendBatch([]logfwd.Record{s.rec, second})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do")
+	var docs []httpjson.RecordDoc
+	err = json.Unmarshal(s.doer.body, &docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+	c.Check(docs[0].ID, gc.Equals, int64(10))
+	c.Check(docs[1].ID, gc.Equals, int64(11))
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	s.doer.status = http.StatusServiceUnavailable
+	s.doer.respBody = "try again later\n"
+	client := httpjson.Client{URL: "http://logs.example.com/juju", Doer: s.doer}
+
+	err := client.Send(s.rec)
+
+	c.Check(err, gc.ErrorMatches, `sending log records: unexpected response "503 Service Unavailable": try again later`)
+}
+
+func (s *ClientSuite) TestSendRequestFailure(c *gc.C) {
+	s.stub.SetErrors(errors.New("connection refused"))
+	client := httpjson.Client{URL: "http://logs.example.com/juju", Doer: s.doer}
+
+	err := client.Send(s.rec)
+
+	c.Check(err, gc.ErrorMatches, `sending log records: connection refused`)
+}
+
+type stubDoer struct {
+	stub *testing.Stub
+
+	status   int
+	respBody string
+
+	method      string
+	url         string
+	contentType string
+	body        []byte
+}
+
+func (s *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	s.stub.AddCall("Do", req)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+
+	s.method = req.Method
+	s.url = req.URL.String()
+	s.contentType = req.Header.Get("Content-Type")
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	s.body = body
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", s.status, http.StatusText(s.status)),
+		StatusCode: s.status,
+		Body:       ioutil.NopCloser(strings.NewReader(s.respBody)),
+	}, nil
+}
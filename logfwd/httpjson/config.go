@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// RawConfig holds the raw configuration data for forwarding log
+// records to an HTTP endpoint.
+type RawConfig struct {
+	// URL is the address of the endpoint to which log records are
+	// POSTed. Its scheme must be either "http" or "https".
+	URL string
+
+	// TLS holds the TLS settings used when connecting to an https
+	// URL. It must be empty for a plain http URL.
+	TLS logfwd.RawTLSConfig
+}
+
+// Validate ensures that the config is currently valid. Any error
+// returned is a *logfwd.FieldError naming the invalid setting.
+func (cfg RawConfig) Validate() error {
+	if cfg.URL == "" {
+		return logfwd.NewFieldError("URL", errors.NewNotValid(nil, "empty URL"))
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return logfwd.NewFieldError("URL", errors.NewNotValid(err, "bad URL"))
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !cfg.TLS.IsZero() {
+			return logfwd.NewFieldError("URL", errors.NewNotValid(nil, "TLS settings given for http URL"))
+		}
+	default:
+		return logfwd.NewFieldError("URL", errors.NotValidf("URL scheme %q", u.Scheme))
+	}
+	if u.Host == "" {
+		return logfwd.NewFieldError("URL", errors.NewNotValid(nil, "empty host in URL"))
+	}
+
+	// The TLS error already names its field, so it is not traced.
+	if err := cfg.TLS.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
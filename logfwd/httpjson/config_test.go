@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "https://logs.example.com:8443/juju",
+		TLS: logfwd.RawTLSConfig{
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidatePlainHTTP(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "http://10.0.0.1/logs",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg httpjson.RawConfig
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty URL`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadScheme(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "ftp://logs.example.com/juju",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `URL scheme "ftp" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingHost(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "https:///juju",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty host in URL`)
+}
+
+func (s *ConfigSuite) TestRawValidateTLSWithPlainHTTP(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "http://logs.example.com/juju",
+		TLS: logfwd.RawTLSConfig{
+			CACert: coretesting.CACert,
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `TLS settings given for http URL`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "URL")
+}
+
+func (s *ConfigSuite) TestRawValidateBadTLS(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "https://logs.example.com/juju",
+		TLS: logfwd.RawTLSConfig{
+			ClientCert: coretesting.ServerCert,
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty ClientKey`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "ClientKey")
+}
@@ -0,0 +1,7 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The httpjson package holds the tools needed to perform log forwarding
+// from Juju to a generic HTTP endpoint, which receives log records
+// POSTed as JSON.
+package httpjson
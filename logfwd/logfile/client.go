@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// timestampFormat is the format of the timestamps written to the file.
+const timestampFormat = "2006-01-02 15:04:05.000"
+
+// Client writes log records to a local file, one record per line.
+type Client struct {
+	// Writer is where the formatted records are written.
+	Writer io.WriteCloser
+}
+
+// Dir returns the directory, within the given agent log directory, to
+// which forwarded log files are confined.
+func Dir(logDir string) string {
+	return filepath.Join(logDir, "forwarded")
+}
+
+// Open returns a new client which writes to the file described by the
+// config, rotating it when it grows too large. The config's path is
+// resolved relative to dir.
+func Open(dir string, cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	path := filepath.Join(dir, cfg.Path)
+	if err := primeLogFile(path); err != nil {
+		return nil, errors.Annotate(err, "creating log file")
+	}
+	client := &Client{
+		Writer: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    cfg.maxSize(),
+			MaxBackups: cfg.maxBackups(),
+		},
+	}
+	return client, nil
+}
+
+// primeLogFile ensures the log file exists and is readable only by
+// its owner, before lumberjack opens it.
+func primeLogFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+// Close closes the file.
+func (client Client) Close() error {
+	err := client.Writer.Close()
+	return errors.Trace(err)
+}
+
+// Send writes the record to the file.
+func (client Client) Send(rec logfwd.Record) error {
+	_, err := io.WriteString(client.Writer, FormatRecord(rec))
+	return errors.Trace(err)
+}
+
+// FormatRecord returns the line written to the file for the record.
+// Newlines in the message are escaped so that each record occupies
+// exactly one line.
+func FormatRecord(rec logfwd.Record) string {
+	return fmt.Sprintf("%d %s %s %s: %s %s %s %s %s\n",
+		rec.ID,
+		rec.Origin.ControllerUUID,
+		rec.Origin.ModelUUID,
+		rec.Origin.Hostname,
+		rec.Timestamp.UTC().Format(timestampFormat),
+		rec.Level,
+		rec.Location.Module,
+		rec.Location,
+		strings.Replace(rec.Message, "\n", `\n`, -1),
+	)
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/logfile"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	rec logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	s.rec = logfwd.Record{
+		ID:        10,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "first line\nsecond line",
+	}
+}
+
+func (s *ClientSuite) TestFormatRecord(c *gc.C) {
+	line := logfile.FormatRecord(s.rec)
+
+	c.Check(line, gc.Equals, "10 9f484882-2f18-4fd2-967d-db9663db7bea deadbeef-2f18-4fd2-967d-db9663db7bea "+
+		"machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea: 1970-01-01 03:25:45.000 ERROR juju.x.y x/y/spam.go:42 "+
+		`first line\nsecond line`+"\n")
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	dir := c.MkDir()
+	client, err := logfile.Open(dir, logfile.RawConfig{Path: "logs/forwarded.log"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	path := filepath.Join(dir, "logs", "forwarded.log")
+
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	err = client.Send(s.rec)
+	c.Assert(err, jc.ErrorIsNil)
+	s.rec.ID = 11
+	err = client.Send(s.rec)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.rec.ID = 10
+	expected := logfile.FormatRecord(s.rec)
+	s.rec.ID = 11
+	expected += logfile.FormatRecord(s.rec)
+	c.Check(string(data), gc.Equals, expected)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	dir := c.MkDir()
+	_, err := logfile.Open(dir, logfile.RawConfig{Path: "../escaped.log"})
+
+	c.Check(err, gc.ErrorMatches, `Path "../escaped.log" outside log directory not valid`)
+	_, err = os.Stat(filepath.Join(dir, "..", "escaped.log"))
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ClientSuite) TestDir(c *gc.C) {
+	c.Check(logfile.Dir("/var/log/juju"), gc.Equals, "/var/log/juju/forwarded")
+}
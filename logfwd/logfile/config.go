@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// DefaultMaxSize is the size, in megabytes, at which the log file
+	// is rotated if no other size is configured.
+	DefaultMaxSize = 100
+
+	// DefaultMaxBackups is the number of rotated log files which are
+	// kept if no other number is configured.
+	DefaultMaxBackups = 10
+)
+
+// RawConfig holds the raw configuration data for forwarding log
+// records to a local file.
+type RawConfig struct {
+	// Path is the path of the file to which records are written,
+	// relative to the directory passed to Open. It may not be
+	// absolute or refer to a parent directory with "..".
+	Path string
+
+	// MaxSize is the size, in megabytes, at which the file is
+	// rotated. If it is zero then DefaultMaxSize is used.
+	MaxSize int
+
+	// MaxBackups is the number of rotated files to keep. If it is
+	// zero then DefaultMaxBackups is used.
+	MaxBackups int
+}
+
+// Validate ensures that the config is currently valid. Any error
+// returned is a *logfwd.FieldError naming the invalid setting.
+func (cfg RawConfig) Validate() error {
+	if cfg.Path == "" {
+		return logfwd.NewFieldError("Path", errors.NewNotValid(nil, "empty Path"))
+	}
+	if filepath.IsAbs(cfg.Path) {
+		return logfwd.NewFieldError("Path", errors.NotValidf("absolute Path %q", cfg.Path))
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(cfg.Path), "/") {
+		if elem == ".." {
+			return logfwd.NewFieldError("Path", errors.NotValidf("Path %q outside log directory", cfg.Path))
+		}
+	}
+	if cfg.MaxSize < 0 {
+		return logfwd.NewFieldError("MaxSize", errors.NotValidf("negative MaxSize"))
+	}
+	if cfg.MaxBackups < 0 {
+		return logfwd.NewFieldError("MaxBackups", errors.NotValidf("negative MaxBackups"))
+	}
+	return nil
+}
+
+func (cfg RawConfig) maxSize() int {
+	if cfg.MaxSize == 0 {
+		return DefaultMaxSize
+	}
+	return cfg.MaxSize
+}
+
+func (cfg RawConfig) maxBackups() int {
+	if cfg.MaxBackups == 0 {
+		return DefaultMaxBackups
+	}
+	return cfg.MaxBackups
+}
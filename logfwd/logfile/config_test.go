@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/logfile"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Path:       "forwarded.log",
+		MaxSize:    50,
+		MaxBackups: 3,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg logfile.RawConfig
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty Path`)
+}
+
+func (s *ConfigSuite) TestRawValidateSubdirectory(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Path: "app/forwarded.log",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateAbsolutePath(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Path: "/etc/passwd",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `absolute Path "/etc/passwd" not valid`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "Path")
+}
+
+func (s *ConfigSuite) TestRawValidateParentDirectory(c *gc.C) {
+	for _, path := range []string{"..", "../forwarded.log", "app/../../forwarded.log"} {
+		c.Logf("path %q", path)
+		cfg := logfile.RawConfig{
+			Path: path,
+		}
+
+		err := cfg.Validate()
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `Path ".*" outside log directory not valid`)
+	}
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeMaxSize(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Path:    "forwarded.log",
+		MaxSize: -1,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `negative MaxSize not valid`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "MaxSize")
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeMaxBackups(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Path:       "forwarded.log",
+		MaxBackups: -1,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `negative MaxBackups not valid`)
+}
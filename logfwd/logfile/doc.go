@@ -0,0 +1,6 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The logfile package holds the tools needed to perform log forwarding
+// from Juju to a local file, which is rotated when it grows too large.
+package logfile
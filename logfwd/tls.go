@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/cert"
+)
+
+// RawTLSConfig holds the optional TLS settings used by log forwarding
+// targets which connect to a remote host.
+type RawTLSConfig struct {
+	// CACert is the PEM-encoded certificate of the CA that signed the
+	// remote host's certificate. If it is not set then the system's
+	// trusted CAs are used.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to
+	// present to the remote host. It must be set if ClientKey is.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) matching
+	// ClientCert.
+	ClientKey string
+}
+
+// IsZero reports whether none of the TLS settings are set.
+func (cfg RawTLSConfig) IsZero() bool {
+	return cfg == RawTLSConfig{}
+}
+
+// Validate ensures that the config is currently valid. Any error
+// returned is a *FieldError naming the invalid setting.
+func (cfg RawTLSConfig) Validate() error {
+	if cfg.CACert != "" {
+		if _, err := cert.ParseCert(cfg.CACert); err != nil {
+			err = errors.NewNotValid(err, "")
+			return NewFieldError("CACert", errors.Annotate(err, "invalid CACert"))
+		}
+	}
+
+	switch {
+	case cfg.ClientCert == "" && cfg.ClientKey == "":
+		return nil
+	case cfg.ClientCert == "":
+		return NewFieldError("ClientCert", errors.NewNotValid(nil, "empty ClientCert"))
+	case cfg.ClientKey == "":
+		return NewFieldError("ClientKey", errors.NewNotValid(nil, "empty ClientKey"))
+	}
+	if _, _, err := cert.ParseCertAndKey(cfg.ClientCert, cfg.ClientKey); err != nil {
+		if _, err := cert.ParseCert(cfg.ClientCert); err != nil {
+			err = errors.NewNotValid(err, "")
+			return NewFieldError("ClientCert", errors.Annotate(err, "invalid ClientCert"))
+		}
+		err = errors.NewNotValid(err, "bad key or key does not match certificate")
+		return NewFieldError("ClientKey", errors.Annotate(err, "invalid ClientKey"))
+	}
+	return nil
+}
+
+// TLS returns the tls.Config that corresponds with this config.
+func (cfg RawTLSConfig) TLS() (*tls.Config, error) {
+	tlsConfig := utils.SecureTLSConfig()
+
+	if cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		pool := x509.NewCertPool()
+		pool.AddCert(caCert)
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type TLSConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TLSConfigSuite{})
+
+func (s *TLSConfigSuite) TestValidateFull(c *gc.C) {
+	cfg := logfwd.RawTLSConfig{
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *TLSConfigSuite) TestValidateZeroValue(c *gc.C) {
+	var cfg logfwd.RawTLSConfig
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg.IsZero(), jc.IsTrue)
+}
+
+func (s *TLSConfigSuite) TestValidateBadCACert(c *gc.C) {
+	cfg := logfwd.RawTLSConfig{
+		CACert: "abc",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `invalid CACert: no certificates found`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "CACert")
+}
+
+func (s *TLSConfigSuite) TestValidateMissingClientKey(c *gc.C) {
+	cfg := logfwd.RawTLSConfig{
+		ClientCert: coretesting.ServerCert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty ClientKey`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "ClientKey")
+}
+
+func (s *TLSConfigSuite) TestValidateMissingClientCert(c *gc.C) {
+	cfg := logfwd.RawTLSConfig{
+		ClientKey: coretesting.ServerKey,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty ClientCert`)
+	c.Assert(err, gc.FitsTypeOf, &logfwd.FieldError{})
+	c.Check(err.(*logfwd.FieldError).Field, gc.Equals, "ClientCert")
+}
+
+func (s *TLSConfigSuite) TestValidateMismatchedClientKey(c *gc.C) {
+	cfg := logfwd.RawTLSConfig{
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.CAKey,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `invalid ClientKey: bad key or key does not match certificate: .*`)
+}
+
+func (s *TLSConfigSuite) TestTLS(c *gc.C) {
+	cfg := logfwd.RawTLSConfig{
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+
+	tlsConfig, err := cfg.TLS()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(tlsConfig.RootCAs, gc.NotNil)
+	c.Check(tlsConfig.Certificates, gc.HasLen, 1)
+}
+
+func (s *TLSConfigSuite) TestTLSSystemRoots(c *gc.C) {
+	var cfg logfwd.RawTLSConfig
+
+	tlsConfig, err := cfg.TLS()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(tlsConfig.RootCAs, gc.IsNil)
+	c.Check(tlsConfig.Certificates, gc.HasLen, 0)
+}
@@ -102,6 +102,7 @@ type UploadBackend interface {
 	Charm(*charm.URL) (*state.Charm, error)
 	ModelUUID() string
 	MongoSession() *mgo.Session
+	Resources() (state.Resources, error)
 	ToolsStorage() (binarystorage.StorageCloser, error)
 }
 
@@ -117,6 +118,12 @@ type ToolsUploader interface {
 	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
 }
 
+// ResourceUploader defines a simple single method interface that is used
+// to upload the content of a resource to the target controller
+type ResourceUploader interface {
+	UploadResource(application, name string, content io.ReadSeeker) error
+}
+
 // UploadBinariesConfig provides all the configuration that the UploadBinaries
 // function needs to operate. The functions are configurable for testing
 // purposes. To construct the config with the default functions, use
@@ -126,8 +133,9 @@ type UploadBinariesConfig struct {
 	Model  description.Model
 	Target api.Connection
 
-	GetCharmUploader func(api.Connection) CharmUploader
-	GetToolsUploader func(api.Connection) ToolsUploader
+	GetCharmUploader    func(api.Connection) CharmUploader
+	GetToolsUploader    func(api.Connection) ToolsUploader
+	GetResourceUploader func(api.Connection) ResourceUploader
 
 	GetStateStorage     func(UploadBackend) storage.Storage
 	GetCharmStoragePath func(UploadBackend, *charm.URL) (string, error)
@@ -145,6 +153,7 @@ func NewUploadBinariesConfig(backend UploadBackend, model description.Model, tar
 		GetCharmUploader:    getCharmUploader,
 		GetStateStorage:     getStateStorage,
 		GetToolsUploader:    getToolsUploader,
+		GetResourceUploader: getResourceUploader,
 		GetCharmStoragePath: getCharmStoragePath,
 	}
 }
@@ -169,6 +178,9 @@ func (c *UploadBinariesConfig) Validate() error {
 	if c.GetToolsUploader == nil {
 		return errors.NotValidf("missing GetToolsUploader")
 	}
+	if c.GetResourceUploader == nil {
+		return errors.NotValidf("missing GetResourceUploader")
+	}
 	if c.GetCharmStoragePath == nil {
 		return errors.NotValidf("missing GetCharmStoragePath")
 	}
@@ -189,6 +201,10 @@ func UploadBinaries(config UploadBinariesConfig) error {
 		return errors.Trace(err)
 	}
 
+	if err := uploadResources(config); err != nil {
+		return errors.Trace(err)
+	}
+
 	return nil
 }
 
@@ -204,6 +220,10 @@ func getCharmUploader(target api.Connection) CharmUploader {
 	return target.Client()
 }
 
+func getResourceUploader(target api.Connection) ResourceUploader {
+	return target.Client()
+}
+
 func uploadTools(config UploadBinariesConfig) error {
 	storage, err := config.State.ToolsStorage()
 	if err != nil {
@@ -325,6 +345,48 @@ func uploadCharms(config UploadBinariesConfig) error {
 	return nil
 }
 
+func uploadResources(config UploadBinariesConfig) error {
+	resources, err := config.State.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resourceUploader := config.GetResourceUploader(config.Target)
+
+	for _, application := range config.Model.Applications() {
+		for _, res := range application.Resources() {
+			revision := res.ApplicationRevision()
+			if revision == nil || revision.Timestamp().IsZero() {
+				// Placeholder resources have no content to send.
+				continue
+			}
+			logger.Debugf("send resource %s/%s to target", application.Name(), res.Name())
+			if err := uploadResource(resources, resourceUploader, application.Name(), res.Name()); err != nil {
+				return errors.Annotatef(err, "resource %s/%s", application.Name(), res.Name())
+			}
+		}
+	}
+	return nil
+}
+
+func uploadResource(resources state.Resources, uploader ResourceUploader, application, name string) error {
+	_, reader, err := resources.OpenResource(application, name)
+	if err != nil {
+		return errors.Annotate(err, "cannot get resource from storage")
+	}
+	defer reader.Close()
+
+	content, cleanup, err := streamThroughTempFile(reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+
+	if err := uploader.UploadResource(application, name, content); err != nil {
+		return errors.Annotate(err, "cannot upload resource")
+	}
+	return nil
+}
+
 func getUsedCharms(model description.Model) set.Strings {
 	result := set.NewStrings()
 	for _, application := range model.Applications() {
@@ -341,22 +403,3 @@ func getCharmStoragePath(st UploadBackend, curl *charm.URL) (string, error) {
 
 	return ch.StoragePath(), nil
 }
-
-// PrecheckBackend is implemented by *state.State but defined as an interface
-// for easier testing.
-type PrecheckBackend interface {
-	NeedsCleanup() (bool, error)
-}
-
-// Precheck checks the database state to make sure that the preconditions
-// for model migration are met.
-func Precheck(backend PrecheckBackend) error {
-	cleanupNeeded, err := backend.NeedsCleanup()
-	if err != nil {
-		return errors.Annotate(err, "precheck cleanups")
-	}
-	if cleanupNeeded {
-		return errors.New("precheck failed: cleanup needed")
-	}
-	return nil
-}
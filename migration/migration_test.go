@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -27,6 +28,7 @@ import (
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/storage"
@@ -137,6 +139,7 @@ func (s *ImportSuite) TestUploadBinariesTools(c *gc.C) {
 		GetToolsUploader: func(target api.Connection) migration.ToolsUploader {
 			return uploader
 		},
+		GetResourceUploader: func(api.Connection) migration.ResourceUploader { return &noOpUploader{} },
 		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
 		GetCharmStoragePath: func(migration.UploadBackend, *charm.URL) (string, error) { return "", nil },
 	}
@@ -165,12 +168,13 @@ func (s *ImportSuite) TestStreamCharmsTools(c *gc.C) {
 
 	uploader := &fakeUploader{charms: make(map[string]string)}
 	config := migration.UploadBinariesConfig{
-		State:            &fakeStateStorage{},
-		Model:            model,
-		Target:           &fakeAPIConnection{},
-		GetCharmUploader: func(api.Connection) migration.CharmUploader { return uploader },
-		GetToolsUploader: func(target api.Connection) migration.ToolsUploader { return &noOpUploader{} },
-		GetStateStorage:  func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+		State:               &fakeStateStorage{},
+		Model:               model,
+		Target:              &fakeAPIConnection{},
+		GetCharmUploader:    func(api.Connection) migration.CharmUploader { return uploader },
+		GetToolsUploader:    func(target api.Connection) migration.ToolsUploader { return &noOpUploader{} },
+		GetResourceUploader: func(api.Connection) migration.ResourceUploader { return &noOpUploader{} },
+		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
 		GetCharmStoragePath: func(_ migration.UploadBackend, u *charm.URL) (string, error) {
 			return "/path/for/" + u.String(), nil
 		},
@@ -184,9 +188,54 @@ func (s *ImportSuite) TestStreamCharmsTools(c *gc.C) {
 	})
 }
 
+func (s *ImportSuite) TestUploadBinariesResources(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("me"),
+	})
+	application := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("magic"),
+		CharmURL: "local:trusty/magic",
+	})
+	spam := application.AddResource(description.ResourceArgs{Name: "spam"})
+	spam.SetApplicationRevision(description.ResourceRevisionArgs{
+		Type:      "file",
+		Origin:    "upload",
+		Timestamp: time.Now(),
+	})
+	// Placeholder resources have no content, so they aren't sent.
+	eggs := application.AddResource(description.ResourceArgs{Name: "eggs"})
+	eggs.SetApplicationRevision(description.ResourceRevisionArgs{
+		Type:   "file",
+		Origin: "upload",
+	})
+
+	uploader := &fakeUploader{resources: make(map[string]string)}
+	config := migration.UploadBinariesConfig{
+		State:               &fakeStateStorage{},
+		Model:               model,
+		Target:              &fakeAPIConnection{},
+		GetCharmUploader:    func(api.Connection) migration.CharmUploader { return &noOpUploader{} },
+		GetToolsUploader:    func(target api.Connection) migration.ToolsUploader { return &noOpUploader{} },
+		GetResourceUploader: func(api.Connection) migration.ResourceUploader { return uploader },
+		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+		GetCharmStoragePath: func(migration.UploadBackend, *charm.URL) (string, error) { return "", nil },
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{
+		"magic/spam": "fake resource magic/spam",
+	})
+}
+
 type fakeStateStorage struct {
-	tools  fakeToolsStorage
-	charms fakeCharmsStorage
+	tools     fakeToolsStorage
+	charms    fakeCharmsStorage
+	resources fakeResources
+}
+
+type fakeResources struct {
+	state.Resources
 }
 
 type fakeCharmsStorage struct {
@@ -218,6 +267,15 @@ func (f *fakeStateStorage) Charm(*charm.URL) (*state.Charm, error) {
 	return nil, nil
 }
 
+func (f *fakeStateStorage) Resources() (state.Resources, error) {
+	return &f.resources, nil
+}
+
+func (f *fakeResources) OpenResource(application, name string) (resource.Resource, io.ReadCloser, error) {
+	buff := bytes.NewBufferString(fmt.Sprintf("fake resource %s/%s", application, name))
+	return resource.Resource{}, ioutil.NopCloser(buff), nil
+}
+
 func (f *fakeToolsStorage) Open(v string) (binarystorage.Metadata, io.ReadCloser, error) {
 	buff := bytes.NewBufferString(fmt.Sprintf("fake tools %s", v))
 	return binarystorage.Metadata{}, ioutil.NopCloser(buff), nil
@@ -234,8 +292,9 @@ func (f *fakeCharmsStorage) Get(path string) (io.ReadCloser, int64, error) {
 }
 
 type fakeUploader struct {
-	tools  map[version.Binary]string
-	charms map[string]string
+	tools     map[version.Binary]string
+	charms    map[string]string
+	resources map[string]string
 }
 
 func (f *fakeUploader) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
@@ -262,6 +321,16 @@ func (f *fakeUploader) UploadCharm(u *charm.URL, r io.ReadSeeker) (*charm.URL, e
 	return u, nil
 }
 
+func (f *fakeUploader) UploadResource(application, name string, r io.ReadSeeker) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	f.resources[application+"/"+name] = string(data)
+	return nil
+}
+
 type noOpUploader struct{}
 
 func (*noOpUploader) UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error) {
@@ -272,6 +341,10 @@ func (*noOpUploader) UploadTools(io.ReadSeeker, version.Binary, ...string) (tool
 	return nil, nil
 }
 
+func (*noOpUploader) UploadResource(string, string, io.ReadSeeker) error {
+	return nil
+}
+
 type ExportSuite struct {
 	statetesting.StateSuite
 }
@@ -286,46 +359,6 @@ func (s *ExportSuite) TestExportModel(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-type PrecheckSuite struct {
-	testing.BaseSuite
-}
-
-var _ = gc.Suite(&PrecheckSuite{})
-
-// Assert that *state.State implements the PrecheckBackend
-var _ migration.PrecheckBackend = (*state.State)(nil)
-
-func (*PrecheckSuite) TestPrecheckCleanups(c *gc.C) {
-	backend := &fakePrecheckBackend{}
-	err := migration.Precheck(backend)
-	c.Assert(err, jc.ErrorIsNil)
-}
-
-func (*PrecheckSuite) TestPrecheckCleanupsError(c *gc.C) {
-	backend := &fakePrecheckBackend{
-		cleanupError: errors.New("boom"),
-	}
-	err := migration.Precheck(backend)
-	c.Assert(err, gc.ErrorMatches, "precheck cleanups: boom")
-}
-
-func (*PrecheckSuite) TestPrecheckCleanupsNeeded(c *gc.C) {
-	backend := &fakePrecheckBackend{
-		cleanupNeeded: true,
-	}
-	err := migration.Precheck(backend)
-	c.Assert(err, gc.ErrorMatches, "precheck failed: cleanup needed")
-}
-
-type fakePrecheckBackend struct {
-	cleanupNeeded bool
-	cleanupError  error
-}
-
-func (f *fakePrecheckBackend) NeedsCleanup() (bool, error) {
-	return f.cleanupNeeded, f.cleanupError
-}
-
 type InternalSuite struct {
 	testing.BaseSuite
 }
@@ -0,0 +1,328 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/tools"
+)
+
+// PrecheckBackend defines the state functionality required to check
+// whether a model can be migrated away from the source controller.
+// Use PrecheckShim to get an implementation backed by a *state.State.
+type PrecheckBackend interface {
+	ModelInfo() (coremigration.ModelInfo, error)
+	NeedsCleanup() (bool, error)
+	IsUpgrading() (bool, error)
+	AgentVersion() (version.Number, error)
+	AllMachines() ([]PrecheckMachine, error)
+	AllApplications() ([]PrecheckApplication, error)
+	CharmUploaded(*charm.URL) (bool, error)
+	ToolsAvailable(version.Binary) (bool, error)
+}
+
+// PrecheckMachine describes the state interface for a machine needed
+// by migration prechecks.
+type PrecheckMachine interface {
+	Id() string
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+	AgentTools() (*tools.Tools, error)
+}
+
+// PrecheckApplication describes the state interface for an
+// application needed by migration prechecks.
+type PrecheckApplication interface {
+	Name() string
+	CharmURL() (*charm.URL, bool)
+	AllUnits() ([]PrecheckUnit, error)
+}
+
+// PrecheckUnit describes the state interface for a unit needed by
+// migration prechecks.
+type PrecheckUnit interface {
+	Name() string
+	Life() state.Life
+	AgentStatus() (status.StatusInfo, error)
+	Status() (status.StatusInfo, error)
+	AgentTools() (*tools.Tools, error)
+}
+
+// TargetPrecheckBackend defines the state functionality required to
+// check whether a model can be migrated to the target controller.
+// Use TargetPrecheckShim to get an implementation backed by a
+// *state.State.
+type TargetPrecheckBackend interface {
+	IsUpgrading() (bool, error)
+	AgentVersion() (version.Number, error)
+	AllModels() ([]PrecheckModel, error)
+	CloudName() (string, error)
+	Cloud() (cloud.Cloud, error)
+	CloudCredentials(names.UserTag) (map[string]cloud.Credential, error)
+}
+
+// PrecheckModel describes the state interface for a model needed by
+// migration prechecks.
+type PrecheckModel interface {
+	UUID() string
+	Name() string
+	Owner() names.UserTag
+}
+
+// SourcePrecheck checks the state of the source controller and the
+// model to make sure that the preconditions for model migration are
+// met. The problems found are returned. An error is only returned if
+// the checks couldn't be performed.
+func SourcePrecheck(backend PrecheckBackend) ([]coremigration.PrecheckFailure, error) {
+	var failures []coremigration.PrecheckFailure
+	fail := func(code, entity, format string, args ...interface{}) {
+		failures = append(failures, coremigration.PrecheckFailure{
+			Code:    code,
+			Entity:  entity,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	cleanupNeeded, err := backend.NeedsCleanup()
+	if err != nil {
+		return nil, errors.Annotate(err, "checking cleanups")
+	}
+	if cleanupNeeded {
+		fail(coremigration.PrecheckCleanupNeeded, "", "cleanup needed")
+	}
+
+	upgrading, err := backend.IsUpgrading()
+	if err != nil {
+		return nil, errors.Annotate(err, "checking for upgrades")
+	}
+	if upgrading {
+		fail(coremigration.PrecheckUpgradeInProgress, "", "controller upgrade in progress")
+	}
+
+	modelVersion, err := backend.AgentVersion()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving model version")
+	}
+
+	usedTools := make(map[version.Binary]bool)
+	checkTools := func(entity, description string, agentTools *tools.Tools) {
+		if agentTools.Version.Number != modelVersion {
+			fail(coremigration.PrecheckAgentVersionMismatch, entity,
+				"%s agent version (%s) doesn't match model (%s)",
+				description, agentTools.Version.Number, modelVersion)
+		}
+		usedTools[agentTools.Version] = true
+	}
+
+	machines, err := backend.AllMachines()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving machines")
+	}
+	for _, machine := range machines {
+		tag := names.NewMachineTag(machine.Id())
+		description := names.ReadableString(tag)
+		if machine.Life() != state.Alive {
+			fail(coremigration.PrecheckMachineNotAlive, tag.String(),
+				"%s is %s", description, machine.Life())
+		}
+		statusInfo, err := machine.Status()
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving %s status", description)
+		}
+		if statusInfo.Status == status.StatusError {
+			fail(coremigration.PrecheckMachineError, tag.String(),
+				"%s is in error state: %s", description, statusInfo.Message)
+		}
+		agentTools, err := machine.AgentTools()
+		if errors.IsNotFound(err) {
+			// Not yet provisioned; nothing to check.
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "retrieving %s tools", description)
+		}
+		checkTools(tag.String(), description, agentTools)
+	}
+
+	applications, err := backend.AllApplications()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving applications")
+	}
+	for _, application := range applications {
+		if curl, _ := application.CharmURL(); curl != nil {
+			uploaded, err := backend.CharmUploaded(curl)
+			if err != nil {
+				return nil, errors.Annotatef(err, "checking charm %s", curl)
+			}
+			if !uploaded {
+				fail(coremigration.PrecheckCharmUnavailable,
+					names.NewApplicationTag(application.Name()).String(),
+					"charm %s for application %s is not available", curl, application.Name())
+			}
+		}
+		units, err := application.AllUnits()
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving units for %s", application.Name())
+		}
+		for _, unit := range units {
+			tag := names.NewUnitTag(unit.Name())
+			description := names.ReadableString(tag)
+			if unit.Life() != state.Alive {
+				fail(coremigration.PrecheckUnitNotAlive, tag.String(),
+					"%s is %s", description, unit.Life())
+			}
+			agentStatus, err := unit.AgentStatus()
+			if err != nil {
+				return nil, errors.Annotatef(err, "retrieving %s agent status", description)
+			}
+			workloadStatus, err := unit.Status()
+			if err != nil {
+				return nil, errors.Annotatef(err, "retrieving %s status", description)
+			}
+			for _, info := range []status.StatusInfo{agentStatus, workloadStatus} {
+				if info.Status == status.StatusError {
+					fail(coremigration.PrecheckUnitError, tag.String(),
+						"%s is in error state: %s", description, info.Message)
+					break
+				}
+			}
+			agentTools, err := unit.AgentTools()
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Annotatef(err, "retrieving %s tools", description)
+			}
+			checkTools(tag.String(), description, agentTools)
+		}
+	}
+
+	for _, toolsVersion := range sortedBinaries(usedTools) {
+		available, err := backend.ToolsAvailable(toolsVersion)
+		if err != nil {
+			return nil, errors.Annotatef(err, "checking tools %s", toolsVersion)
+		}
+		if !available {
+			fail(coremigration.PrecheckToolsUnavailable, "",
+				"tools %s are not available", toolsVersion)
+		}
+	}
+
+	return failures, nil
+}
+
+// TargetPrecheck checks the state of the target controller to make
+// sure that the model described can be migrated to it. The problems
+// found are returned. An error is only returned if the checks
+// couldn't be performed.
+func TargetPrecheck(backend TargetPrecheckBackend, model coremigration.ModelInfo) ([]coremigration.PrecheckFailure, error) {
+	var failures []coremigration.PrecheckFailure
+	fail := func(code, format string, args ...interface{}) {
+		failures = append(failures, coremigration.PrecheckFailure{
+			Code:    code,
+			Entity:  names.NewModelTag(model.UUID).String(),
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	upgrading, err := backend.IsUpgrading()
+	if err != nil {
+		return nil, errors.Annotate(err, "checking for upgrades")
+	}
+	if upgrading {
+		fail(coremigration.PrecheckUpgradeInProgress, "target controller upgrade in progress")
+	}
+
+	controllerVersion, err := backend.AgentVersion()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving controller version")
+	}
+	if controllerVersion.Compare(model.AgentVersion) < 0 {
+		fail(coremigration.PrecheckAgentVersionMismatch,
+			"model version (%s) is newer than target controller (%s)",
+			model.AgentVersion, controllerVersion)
+	}
+
+	models, err := backend.AllModels()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving models")
+	}
+	for _, existing := range models {
+		if existing.UUID() == model.UUID {
+			fail(coremigration.PrecheckModelExists,
+				"model with same UUID already exists (%s)", model.UUID)
+		} else if existing.Name() == model.Name && existing.Owner() == model.Owner {
+			fail(coremigration.PrecheckModelNameTaken,
+				"model named %q already exists for %s", model.Name, model.Owner.Canonical())
+		}
+	}
+
+	cloudName, err := backend.CloudName()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving cloud name")
+	}
+	if cloudName != model.CloudName {
+		fail(coremigration.PrecheckCloudUnknown,
+			"cloud %q is not known to target controller (uses %q)", model.CloudName, cloudName)
+		// There's no point checking the region and credential.
+		return failures, nil
+	}
+
+	if model.CloudRegion != "" {
+		cloudDetails, err := backend.Cloud()
+		if err != nil {
+			return nil, errors.Annotate(err, "retrieving cloud")
+		}
+		if !hasRegion(cloudDetails, model.CloudRegion) {
+			fail(coremigration.PrecheckCloudRegionUnknown,
+				"cloud region %q is not known to target controller", model.CloudRegion)
+		}
+	}
+
+	if model.CloudCredential != "" {
+		credentials, err := backend.CloudCredentials(model.Owner)
+		if err != nil {
+			return nil, errors.Annotate(err, "retrieving cloud credentials")
+		}
+		if _, ok := credentials[model.CloudCredential]; !ok {
+			fail(coremigration.PrecheckCloudCredentialUnknown,
+				"cloud credential %q for %s is not known to target controller",
+				model.CloudCredential, model.Owner.Canonical())
+		}
+	}
+
+	return failures, nil
+}
+
+func hasRegion(cloudDetails cloud.Cloud, regionName string) bool {
+	for _, region := range cloudDetails.Regions {
+		if region.Name == regionName {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedBinaries(versions map[version.Binary]bool) []version.Binary {
+	strs := set.NewStrings()
+	byString := make(map[string]version.Binary)
+	for v := range versions {
+		strs.Add(v.String())
+		byString[v.String()] = v
+	}
+	out := make([]version.Binary, 0, len(versions))
+	for _, s := range strs.SortedValues() {
+		out = append(out, byString[s])
+	}
+	return out
+}
@@ -0,0 +1,185 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+// PrecheckShim wraps a *state.State to implement PrecheckBackend.
+func PrecheckShim(st *state.State) PrecheckBackend {
+	return &precheckShim{st}
+}
+
+// TargetPrecheckShim wraps a *state.State to implement
+// TargetPrecheckBackend. The State should be for the target
+// controller's model.
+func TargetPrecheckShim(st *state.State) TargetPrecheckBackend {
+	return &precheckShim{st}
+}
+
+// precheckShim is untested, but is simple enough to be verified by
+// inspection.
+type precheckShim struct {
+	st *state.State
+}
+
+// ModelInfo implements PrecheckBackend.
+func (s *precheckShim) ModelInfo() (coremigration.ModelInfo, error) {
+	var empty coremigration.ModelInfo
+	model, err := s.st.Model()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	agentVersion, err := s.AgentVersion()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	cloudName, err := s.CloudName()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	return coremigration.ModelInfo{
+		UUID:            model.UUID(),
+		Name:            model.Name(),
+		Owner:           model.Owner(),
+		AgentVersion:    agentVersion,
+		CloudName:       cloudName,
+		CloudRegion:     model.CloudRegion(),
+		CloudCredential: model.CloudCredential(),
+	}, nil
+}
+
+// NeedsCleanup implements PrecheckBackend.
+func (s *precheckShim) NeedsCleanup() (bool, error) {
+	return s.st.NeedsCleanup()
+}
+
+// IsUpgrading implements PrecheckBackend and TargetPrecheckBackend.
+func (s *precheckShim) IsUpgrading() (bool, error) {
+	return s.st.IsUpgrading()
+}
+
+// AgentVersion implements PrecheckBackend and TargetPrecheckBackend.
+func (s *precheckShim) AgentVersion() (version.Number, error) {
+	cfg, err := s.st.ModelConfig()
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	vers, ok := cfg.AgentVersion()
+	if !ok {
+		return version.Zero, errors.New("no model agent version")
+	}
+	return vers, nil
+}
+
+// AllMachines implements PrecheckBackend.
+func (s *precheckShim) AllMachines() ([]PrecheckMachine, error) {
+	machines, err := s.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckMachine, len(machines))
+	for i, machine := range machines {
+		out[i] = machine
+	}
+	return out, nil
+}
+
+// AllApplications implements PrecheckBackend.
+func (s *precheckShim) AllApplications() ([]PrecheckApplication, error) {
+	apps, err := s.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckApplication, len(apps))
+	for i, app := range apps {
+		out[i] = &precheckAppShim{app}
+	}
+	return out, nil
+}
+
+// CharmUploaded implements PrecheckBackend.
+func (s *precheckShim) CharmUploaded(curl *charm.URL) (bool, error) {
+	ch, err := s.st.Charm(curl)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return ch.IsUploaded(), nil
+}
+
+// ToolsAvailable implements PrecheckBackend.
+func (s *precheckShim) ToolsAvailable(vers version.Binary) (bool, error) {
+	storage, err := s.st.ToolsStorage()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer storage.Close()
+	_, err = storage.Metadata(vers.String())
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// AllModels implements TargetPrecheckBackend.
+func (s *precheckShim) AllModels() ([]PrecheckModel, error) {
+	models, err := s.st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckModel, len(models))
+	for i, model := range models {
+		out[i] = model
+	}
+	return out, nil
+}
+
+// CloudName implements TargetPrecheckBackend.
+func (s *precheckShim) CloudName() (string, error) {
+	info, err := s.st.ControllerInfo()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return info.CloudName, nil
+}
+
+// Cloud implements TargetPrecheckBackend.
+func (s *precheckShim) Cloud() (cloud.Cloud, error) {
+	return s.st.Cloud()
+}
+
+// CloudCredentials implements TargetPrecheckBackend.
+func (s *precheckShim) CloudCredentials(owner names.UserTag) (map[string]cloud.Credential, error) {
+	return s.st.CloudCredentials(owner)
+}
+
+// precheckAppShim implements PrecheckApplication.
+type precheckAppShim struct {
+	*state.Application
+}
+
+// AllUnits implements PrecheckApplication.
+func (s *precheckAppShim) AllUnits() ([]PrecheckUnit, error) {
+	units, err := s.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckUnit, len(units))
+	for i, unit := range units {
+		out[i] = unit
+	}
+	return out, nil
+}
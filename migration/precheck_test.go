@@ -0,0 +1,506 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+var (
+	modelVersion    = version.MustParse("2.0.0")
+	modelTools      = version.MustParseBinary("2.0.0-xenial-amd64")
+	modelUUID       = "01234567-89ab-cdef-0123-456789abcdef"
+	modelOwner      = names.NewUserTag("bob")
+	targetModelInfo = coremigration.ModelInfo{
+		UUID:            modelUUID,
+		Name:            "model",
+		Owner:           modelOwner,
+		AgentVersion:    modelVersion,
+		CloudName:       "cloud",
+		CloudRegion:     "region",
+		CloudCredential: "cred",
+	}
+)
+
+type SourcePrecheckSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SourcePrecheckSuite{})
+
+func (*SourcePrecheckSuite) TestSuccess(c *gc.C) {
+	backend := newFakeBackend()
+	failures, err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestCleanupsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
+func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cleanupNeeded = true
+	failures, err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []coremigration.PrecheckFailure{{
+		Code:    coremigration.PrecheckCleanupNeeded,
+		Message: "cleanup needed",
+	}})
+}
+
+func (*SourcePrecheckSuite) TestUpgradeInProgress(c *gc.C) {
+	backend := newFakeBackend()
+	backend.upgrading = true
+	failures, err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []coremigration.PrecheckFailure{{
+		Code:    coremigration.PrecheckUpgradeInProgress,
+		Message: "controller upgrade in progress",
+	}})
+}
+
+func (*SourcePrecheckSuite) TestMachineProblems(c *gc.C) {
+	backend := newFakeBackend()
+	backend.machines = []migration.PrecheckMachine{
+		&fakeMachine{id: "0", life: state.Alive},
+		&fakeMachine{id: "1", life: state.Dying},
+		&fakeMachine{id: "2", life: state.Alive, status: status.StatusError, message: "kaboom"},
+		&fakeMachine{id: "3", life: state.Alive, tools: version.MustParseBinary("1.25.6-trusty-amd64")},
+		&fakeMachine{id: "4", life: state.Alive, noTools: true},
+	}
+	failures, err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []coremigration.PrecheckFailure{{
+		Code:    coremigration.PrecheckMachineNotAlive,
+		Entity:  "machine-1",
+		Message: "machine 1 is dying",
+	}, {
+		Code:    coremigration.PrecheckMachineError,
+		Entity:  "machine-2",
+		Message: "machine 2 is in error state: kaboom",
+	}, {
+		Code:    coremigration.PrecheckAgentVersionMismatch,
+		Entity:  "machine-3",
+		Message: "machine 3 agent version (1.25.6) doesn't match model (2.0.0)",
+	}})
+}
+
+func (*SourcePrecheckSuite) TestMachineStatusError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.machines = []migration.PrecheckMachine{
+		&fakeMachine{id: "0", life: state.Alive, statusErr: errors.New("boom")},
+	}
+	_, err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving machine 0 status: boom")
+}
+
+func (*SourcePrecheckSuite) TestUnitProblems(c *gc.C) {
+	backend := newFakeBackend()
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name: "foo",
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "foo/0", life: state.Alive},
+				&fakeUnit{name: "foo/1", life: state.Dead},
+				&fakeUnit{name: "foo/2", life: state.Alive, agentStatus: status.StatusError, message: "hook failed"},
+				&fakeUnit{name: "foo/3", life: state.Alive, workloadStatus: status.StatusError, message: "oops"},
+				&fakeUnit{name: "foo/4", life: state.Alive, tools: version.MustParseBinary("2.0.1-xenial-amd64")},
+			},
+		},
+	}
+	failures, err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []coremigration.PrecheckFailure{{
+		Code:    coremigration.PrecheckUnitNotAlive,
+		Entity:  "unit-foo-1",
+		Message: "unit foo/1 is dead",
+	}, {
+		Code:    coremigration.PrecheckUnitError,
+		Entity:  "unit-foo-2",
+		Message: "unit foo/2 is in error state: hook failed",
+	}, {
+		Code:    coremigration.PrecheckUnitError,
+		Entity:  "unit-foo-3",
+		Message: "unit foo/3 is in error state: oops",
+	}, {
+		Code:    coremigration.PrecheckAgentVersionMismatch,
+		Entity:  "unit-foo-4",
+		Message: "unit foo/4 agent version (2.0.1) doesn't match model (2.0.0)",
+	}, {
+		Code:    coremigration.PrecheckToolsUnavailable,
+		Message: "tools 2.0.1-xenial-amd64 are not available",
+	}})
+}
+
+func (*SourcePrecheckSuite) TestCharmUnavailable(c *gc.C) {
+	backend := newFakeBackend()
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{name: "foo", charmURL: "cs:xenial/foo-1"},
+		&fakeApp{name: "bar", charmURL: "cs:xenial/bar-2"},
+	}
+	backend.uploadedCharms = map[string]bool{"cs:xenial/foo-1": true}
+	failures, err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []coremigration.PrecheckFailure{{
+		Code:    coremigration.PrecheckCharmUnavailable,
+		Entity:  "application-bar",
+		Message: "charm cs:xenial/bar-2 for application bar is not available",
+	}})
+}
+
+func (*SourcePrecheckSuite) TestToolsUnavailable(c *gc.C) {
+	backend := newFakeBackend()
+	backend.availableTools = nil
+	failures, err := migration.SourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []coremigration.PrecheckFailure{{
+		Code:    coremigration.PrecheckToolsUnavailable,
+		Message: "tools 2.0.0-xenial-amd64 are not available",
+	}})
+}
+
+type TargetPrecheckSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&TargetPrecheckSuite{})
+
+func (*TargetPrecheckSuite) TestSuccess(c *gc.C) {
+	backend := newFakeTargetBackend()
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
+
+func (*TargetPrecheckSuite) TestUpgradeInProgress(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.upgrading = true
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	checkTargetFailure(c, failures, coremigration.PrecheckUpgradeInProgress,
+		"target controller upgrade in progress")
+}
+
+func (*TargetPrecheckSuite) TestOlderController(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.version = version.MustParse("1.99.0")
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	checkTargetFailure(c, failures, coremigration.PrecheckAgentVersionMismatch,
+		"model version (2.0.0) is newer than target controller (1.99.0)")
+}
+
+func (*TargetPrecheckSuite) TestNewerControllerOK(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.version = version.MustParse("2.1.0")
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
+
+func (*TargetPrecheckSuite) TestModelExists(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.models = []migration.PrecheckModel{
+		&fakeModel{uuid: modelUUID, name: "other", owner: names.NewUserTag("alice")},
+	}
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	checkTargetFailure(c, failures, coremigration.PrecheckModelExists,
+		"model with same UUID already exists ("+modelUUID+")")
+}
+
+func (*TargetPrecheckSuite) TestModelNameTaken(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.models = []migration.PrecheckModel{
+		// Same name, different owner is fine.
+		&fakeModel{uuid: "uuid1", name: "model", owner: names.NewUserTag("alice")},
+		&fakeModel{uuid: "uuid2", name: "model", owner: modelOwner},
+	}
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	checkTargetFailure(c, failures, coremigration.PrecheckModelNameTaken,
+		`model named "model" already exists for bob@local`)
+}
+
+func (*TargetPrecheckSuite) TestCloudUnknown(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.cloudName = "other"
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	checkTargetFailure(c, failures, coremigration.PrecheckCloudUnknown,
+		`cloud "cloud" is not known to target controller (uses "other")`)
+}
+
+func (*TargetPrecheckSuite) TestCloudRegionUnknown(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.cloud.Regions = []cloud.Region{{Name: "elsewhere"}}
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	checkTargetFailure(c, failures, coremigration.PrecheckCloudRegionUnknown,
+		`cloud region "region" is not known to target controller`)
+}
+
+func (*TargetPrecheckSuite) TestCloudCredentialUnknown(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.credentials = nil
+	failures, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	checkTargetFailure(c, failures, coremigration.PrecheckCloudCredentialUnknown,
+		`cloud credential "cred" for bob@local is not known to target controller`)
+}
+
+func (*TargetPrecheckSuite) TestAllModelsError(c *gc.C) {
+	backend := newFakeTargetBackend()
+	backend.modelsErr = errors.New("boom")
+	_, err := migration.TargetPrecheck(backend, targetModelInfo)
+	c.Assert(err, gc.ErrorMatches, "retrieving models: boom")
+}
+
+func checkTargetFailure(c *gc.C, failures []coremigration.PrecheckFailure, code, message string) {
+	c.Check(failures, jc.DeepEquals, []coremigration.PrecheckFailure{{
+		Code:    code,
+		Entity:  names.NewModelTag(modelUUID).String(),
+		Message: message,
+	}})
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0", life: state.Alive},
+		},
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:     "foo",
+				charmURL: "cs:xenial/foo-1",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", life: state.Alive},
+				},
+			},
+		},
+		uploadedCharms: map[string]bool{"cs:xenial/foo-1": true},
+		availableTools: map[version.Binary]bool{modelTools: true},
+	}
+}
+
+type fakeBackend struct {
+	cleanupNeeded  bool
+	cleanupErr     error
+	upgrading      bool
+	machines       []migration.PrecheckMachine
+	apps           []migration.PrecheckApplication
+	uploadedCharms map[string]bool
+	availableTools map[version.Binary]bool
+}
+
+func (b *fakeBackend) ModelInfo() (coremigration.ModelInfo, error) {
+	return targetModelInfo, nil
+}
+
+func (b *fakeBackend) NeedsCleanup() (bool, error) {
+	return b.cleanupNeeded, b.cleanupErr
+}
+
+func (b *fakeBackend) IsUpgrading() (bool, error) {
+	return b.upgrading, nil
+}
+
+func (b *fakeBackend) AgentVersion() (version.Number, error) {
+	return modelVersion, nil
+}
+
+func (b *fakeBackend) AllMachines() ([]migration.PrecheckMachine, error) {
+	return b.machines, nil
+}
+
+func (b *fakeBackend) AllApplications() ([]migration.PrecheckApplication, error) {
+	return b.apps, nil
+}
+
+func (b *fakeBackend) CharmUploaded(curl *charm.URL) (bool, error) {
+	return b.uploadedCharms[curl.String()], nil
+}
+
+func (b *fakeBackend) ToolsAvailable(vers version.Binary) (bool, error) {
+	return b.availableTools[vers], nil
+}
+
+type fakeMachine struct {
+	id        string
+	life      state.Life
+	status    status.Status
+	message   string
+	statusErr error
+	tools     version.Binary
+	noTools   bool
+}
+
+func (m *fakeMachine) Id() string {
+	return m.id
+}
+
+func (m *fakeMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *fakeMachine) Status() (status.StatusInfo, error) {
+	if m.statusErr != nil {
+		return status.StatusInfo{}, m.statusErr
+	}
+	return statusInfo(m.status, m.message), nil
+}
+
+func (m *fakeMachine) AgentTools() (*tools.Tools, error) {
+	return agentTools(m.tools, m.noTools)
+}
+
+type fakeApp struct {
+	name     string
+	charmURL string
+	units    []migration.PrecheckUnit
+}
+
+func (a *fakeApp) Name() string {
+	return a.name
+}
+
+func (a *fakeApp) CharmURL() (*charm.URL, bool) {
+	if a.charmURL == "" {
+		return nil, false
+	}
+	return charm.MustParseURL(a.charmURL), false
+}
+
+func (a *fakeApp) AllUnits() ([]migration.PrecheckUnit, error) {
+	return a.units, nil
+}
+
+type fakeUnit struct {
+	name           string
+	life           state.Life
+	agentStatus    status.Status
+	workloadStatus status.Status
+	message        string
+	tools          version.Binary
+}
+
+func (u *fakeUnit) Name() string {
+	return u.name
+}
+
+func (u *fakeUnit) Life() state.Life {
+	return u.life
+}
+
+func (u *fakeUnit) AgentStatus() (status.StatusInfo, error) {
+	return statusInfo(u.agentStatus, u.message), nil
+}
+
+func (u *fakeUnit) Status() (status.StatusInfo, error) {
+	return statusInfo(u.workloadStatus, u.message), nil
+}
+
+func (u *fakeUnit) AgentTools() (*tools.Tools, error) {
+	return agentTools(u.tools, false)
+}
+
+func statusInfo(value status.Status, message string) status.StatusInfo {
+	if value == "" {
+		return status.StatusInfo{Status: status.StatusIdle}
+	}
+	return status.StatusInfo{Status: value, Message: message}
+}
+
+func agentTools(vers version.Binary, missing bool) (*tools.Tools, error) {
+	if missing {
+		return nil, errors.NotFoundf("tools")
+	}
+	if vers == (version.Binary{}) {
+		vers = modelTools
+	}
+	return &tools.Tools{Version: vers}, nil
+}
+
+func newFakeTargetBackend() *fakeTargetBackend {
+	return &fakeTargetBackend{
+		version:   modelVersion,
+		cloudName: "cloud",
+		cloud: cloud.Cloud{
+			Regions: []cloud.Region{{Name: "region"}},
+		},
+		credentials: map[string]cloud.Credential{
+			"cred": {},
+		},
+	}
+}
+
+type fakeTargetBackend struct {
+	upgrading   bool
+	version     version.Number
+	models      []migration.PrecheckModel
+	modelsErr   error
+	cloudName   string
+	cloud       cloud.Cloud
+	credentials map[string]cloud.Credential
+}
+
+func (b *fakeTargetBackend) IsUpgrading() (bool, error) {
+	return b.upgrading, nil
+}
+
+func (b *fakeTargetBackend) AgentVersion() (version.Number, error) {
+	return b.version, nil
+}
+
+func (b *fakeTargetBackend) AllModels() ([]migration.PrecheckModel, error) {
+	return b.models, b.modelsErr
+}
+
+func (b *fakeTargetBackend) CloudName() (string, error) {
+	return b.cloudName, nil
+}
+
+func (b *fakeTargetBackend) Cloud() (cloud.Cloud, error) {
+	return b.cloud, nil
+}
+
+func (b *fakeTargetBackend) CloudCredentials(names.UserTag) (map[string]cloud.Credential, error) {
+	return b.credentials, nil
+}
+
+type fakeModel struct {
+	uuid  string
+	name  string
+	owner names.UserTag
+}
+
+func (m *fakeModel) UUID() string {
+	return m.uuid
+}
+
+func (m *fakeModel) Name() string {
+	return m.name
+}
+
+func (m *fakeModel) Owner() names.UserTag {
+	return m.owner
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+const (
+	// OpenToWorldCIDR is the source CIDR used for ingress rules which
+	// allow access from anywhere.
+	OpenToWorldCIDR = "0.0.0.0/0"
+
+	// OpenToWorldCIDRv6 is the IPv6 source CIDR which allows access
+	// from anywhere.
+	OpenToWorldCIDRv6 = "::/0"
+)
+
+// IsOpenToWorldCIDR reports whether the CIDR, IPv4 or IPv6, includes
+// every address.
+func IsOpenToWorldCIDR(cidr string) bool {
+	return cidr == OpenToWorldCIDR || cidr == OpenToWorldCIDRv6
+}
+
+// IngressRule represents a range of ports and the source CIDRs from
+// which incoming packets may access them.
+type IngressRule struct {
+	PortRange
+
+	// SourceCIDRs is the sorted set of CIDRs from which the port
+	// range may be accessed.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the given port range,
+// allowing access from the given source CIDRs. If no CIDRs are
+// given, the rule allows access from anywhere.
+func NewIngressRule(protocol string, from, to int, sourceCIDRs ...string) (IngressRule, error) {
+	rule := IngressRule{
+		PortRange: PortRange{
+			Protocol: protocol,
+			FromPort: from,
+			ToPort:   to,
+		},
+	}
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{OpenToWorldCIDR}
+	}
+	for _, cidr := range sourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return IngressRule{}, errors.NotValidf("source CIDR %q", cidr)
+		}
+	}
+	rule.SourceCIDRs = set.NewStrings(sourceCIDRs...).SortedValues()
+	return rule, nil
+}
+
+// MustNewIngressRule returns an IngressRule for the given port range
+// and source CIDRs. If any of the CIDRs are invalid, the function
+// panics.
+func MustNewIngressRule(protocol string, from, to int, sourceCIDRs ...string) IngressRule {
+	rule, err := NewIngressRule(protocol, from, to, sourceCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// NewOpenIngressRule returns an IngressRule allowing access to the
+// given port range from anywhere.
+func NewOpenIngressRule(protocol string, from, to int) IngressRule {
+	return MustNewIngressRule(protocol, from, to)
+}
+
+// PortRangesToIngressRules returns ingress rules allowing access to
+// the given port ranges from anywhere.
+func PortRangesToIngressRules(portRanges []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(portRanges))
+	for i, p := range portRanges {
+		rules[i] = NewOpenIngressRule(p.Protocol, p.FromPort, p.ToPort)
+	}
+	return rules
+}
+
+// Validate determines if the ingress rule is valid.
+func (r IngressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(r.SourceCIDRs) == 0 {
+		return errors.NotValidf("ingress rule %v without source CIDRs", r.PortRange)
+	}
+	for _, cidr := range r.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("source CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// IsOpenToWorld reports whether the rule allows access from anywhere,
+// over IPv4 or IPv6.
+func (r IngressRule) IsOpenToWorld() bool {
+	for _, cidr := range r.SourceCIDRs {
+		if IsOpenToWorldCIDR(cidr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r IngressRule) String() string {
+	onlyWorld := true
+	for _, cidr := range r.SourceCIDRs {
+		onlyWorld = onlyWorld && IsOpenToWorldCIDR(cidr)
+	}
+	if onlyWorld {
+		return r.PortRange.String()
+	}
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (p ingressRuleSlice) Len() int      { return len(p) }
+func (p ingressRuleSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p ingressRuleSlice) Less(i, j int) bool {
+	r1 := p[i]
+	r2 := p[j]
+	if r1.PortRange != r2.PortRange {
+		return portRangeSlice{r1.PortRange, r2.PortRange}.Less(0, 1)
+	}
+	return strings.Join(r1.SourceCIDRs, ",") < strings.Join(r2.SourceCIDRs, ",")
+}
+
+// SortIngressRules sorts the given rules, first by protocol, then by
+// port number, then by source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRule(c *gc.C) {
+	rule, err := network.NewIngressRule("tcp", 80, 80, "192.168.1.0/24", "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, network.IngressRule{
+		PortRange:   network.PortRange{80, 80, "tcp"},
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+	c.Assert(rule.IsOpenToWorld(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleDefaultsToWorld(c *gc.C) {
+	rule, err := network.NewIngressRule("udp", 53, 54)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(rule.IsOpenToWorld(), jc.IsTrue)
+	c.Assert(rule.String(), gc.Equals, "53-54/udp")
+}
+
+func (*IngressRuleSuite) TestIsOpenToWorldIPv6(c *gc.C) {
+	rule := network.MustNewIngressRule("tcp", 80, 80, "::/0")
+	c.Assert(rule.IsOpenToWorld(), jc.IsTrue)
+	c.Assert(rule.String(), gc.Equals, "80/tcp")
+
+	rule = network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "::/0")
+	c.Assert(rule.IsOpenToWorld(), jc.IsTrue)
+	c.Assert(rule.String(), gc.Equals, "80/tcp")
+
+	rule = network.MustNewIngressRule("tcp", 80, 80, "2001:db8::/32")
+	c.Assert(rule.IsOpenToWorld(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 2001:db8::/32")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleInvalidCIDR(c *gc.C) {
+	_, err := network.NewIngressRule("tcp", 80, 80, "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `source CIDR "10.0.0.0" not valid`)
+}
+
+func (*IngressRuleSuite) TestValidate(c *gc.C) {
+	c.Assert(network.NewOpenIngressRule("tcp", 80, 80).Validate(), jc.ErrorIsNil)
+	err := network.IngressRule{PortRange: network.PortRange{80, 80, "tcp"}}.Validate()
+	c.Assert(err, gc.ErrorMatches, "ingress rule 80/tcp without source CIDRs not valid")
+	err = network.IngressRule{
+		PortRange:   network.PortRange{80, 80, "icmp"},
+		SourceCIDRs: []string{"0.0.0.0/0"},
+	}.Validate()
+	c.Assert(err, gc.ErrorMatches, `invalid protocol "icmp", expected "tcp" or "udp"`)
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("udp", 80, 80),
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.1.0/24"),
+		network.MustNewIngressRule("tcp", 22, 22),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22),
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.1.0/24"),
+		network.MustNewIngressRule("udp", 80, 80),
+	})
+}
+
+func (*IngressRuleSuite) TestPortRangesToIngressRules(c *gc.C) {
+	rules := network.PortRangesToIngressRules([]network.PortRange{{80, 81, "tcp"}})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 81, "0.0.0.0/0"),
+	})
+}
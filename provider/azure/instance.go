@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/instance"
 	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/status"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 )
 
@@ -27,6 +28,8 @@ type azureInstance struct {
 	publicIPAddresses []network.PublicIPAddress
 }
 
+var _ instance.IngressRuleInstance = (*azureInstance)(nil)
+
 // Id is specified in the Instance interface.
 func (inst *azureInstance) Id() instance.Id {
 	// Note: we use Name and not Id, since all VM operations are in
@@ -185,6 +188,12 @@ func (inst *azureInstance) internalNetworkAddress() (jujunetwork.Address, error)
 
 // OpenPorts is specified in the Instance interface.
 func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.OpenIngressRules(machineId, jujunetwork.PortRangesToIngressRules(ports))
+}
+
+// OpenIngressRules is specified in the IngressRuleInstance interface.
+// A security rule is created for each of the rules' source CIDRs.
+func (inst *azureInstance) OpenIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
@@ -216,73 +225,88 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 	// NSG in memory, so we can easily tell which priorities are available.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-
-		// Check if the rule already exists; OpenPorts must be idempotent.
-		var found bool
-		for _, rule := range securityRules {
-			if to.String(rule.Name) == ruleName {
-				found = true
-				break
+	for _, ingressRule := range rules {
+		ports := ingressRule.PortRange
+		for _, cidr := range ingressRule.SourceCIDRs {
+			source := jujunetwork.IngressRule{PortRange: ports, SourceCIDRs: []string{cidr}}
+			ruleName := ingressSecurityRuleName(prefix, ports, cidr)
+
+			// Check if the rule already exists; OpenPorts must be idempotent.
+			var found bool
+			for _, rule := range securityRules {
+				if to.String(rule.Name) == ruleName {
+					found = true
+					break
+				}
 			}
-		}
-		if found {
-			logger.Debugf("security rule %q already exists", ruleName)
-			continue
-		}
-		logger.Debugf("creating security rule %q", ruleName)
+			if found {
+				logger.Debugf("security rule %q already exists", ruleName)
+				continue
+			}
+			logger.Debugf("creating security rule %q", ruleName)
 
-		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
-		if err != nil {
-			return errors.Annotatef(err, "getting security rule priority for %s", ports)
-		}
+			priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
+			if err != nil {
+				return errors.Annotatef(err, "getting security rule priority for %s", source)
+			}
 
-		var protocol network.SecurityRuleProtocol
-		switch ports.Protocol {
-		case "tcp":
-			protocol = network.TCP
-		case "udp":
-			protocol = network.UDP
-		default:
-			return errors.Errorf("invalid protocol %q", ports.Protocol)
-		}
+			var protocol network.SecurityRuleProtocol
+			switch ports.Protocol {
+			case "tcp":
+				protocol = network.TCP
+			case "udp":
+				protocol = network.UDP
+			default:
+				return errors.Errorf("invalid protocol %q", ports.Protocol)
+			}
 
-		var portRange string
-		if ports.FromPort != ports.ToPort {
-			portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
-		} else {
-			portRange = fmt.Sprint(ports.FromPort)
-		}
+			var portRange string
+			if ports.FromPort != ports.ToPort {
+				portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
+			} else {
+				portRange = fmt.Sprint(ports.FromPort)
+			}
 
-		rule := network.SecurityRule{
-			Properties: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(ports.String()),
-				Protocol:                 protocol,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr(portRange),
-				SourceAddressPrefix:      to.StringPtr("*"),
-				DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
-				Access:    network.Allow,
-				Priority:  to.IntPtr(priority),
-				Direction: network.Inbound,
-			},
-		}
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			result, err := securityRuleClient.CreateOrUpdate(
-				inst.env.resourceGroup, securityGroupName, ruleName, rule,
-			)
-			return result.Response, err
-		}); err != nil {
-			return errors.Annotatef(err, "creating security rule for %s", ports)
+			sourceAddressPrefix := "*"
+			if cidr != jujunetwork.OpenToWorldCIDR {
+				sourceAddressPrefix = cidr
+			}
+
+			rule := network.SecurityRule{
+				Properties: &network.SecurityRulePropertiesFormat{
+					Description:              to.StringPtr(source.String()),
+					Protocol:                 protocol,
+					SourcePortRange:          to.StringPtr("*"),
+					DestinationPortRange:     to.StringPtr(portRange),
+					SourceAddressPrefix:      to.StringPtr(sourceAddressPrefix),
+					DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
+					Access:                   network.Allow,
+					Priority:                 to.IntPtr(priority),
+					Direction:                network.Inbound,
+				},
+			}
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				result, err := securityRuleClient.CreateOrUpdate(
+					inst.env.resourceGroup, securityGroupName, ruleName, rule,
+				)
+				return result.Response, err
+			}); err != nil {
+				return errors.Annotatef(err, "creating security rule for %s", source)
+			}
+			rule.Name = to.StringPtr(ruleName)
+			securityRules = append(securityRules, rule)
 		}
-		securityRules = append(securityRules, rule)
 	}
 	return nil
 }
 
 // ClosePorts is specified in the Instance interface.
 func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.CloseIngressRules(machineId, jujunetwork.PortRangesToIngressRules(ports))
+}
+
+// CloseIngressRules is specified in the IngressRuleInstance interface.
+func (inst *azureInstance) CloseIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -292,19 +316,21 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	// on changes made by the provisioner.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-		logger.Debugf("deleting security rule %q", ruleName)
-		var result autorest.Response
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			var err error
-			result, err = securityRuleClient.Delete(
-				inst.env.resourceGroup, securityGroupName, ruleName,
-			)
-			return result, err
-		}); err != nil {
-			if result.Response == nil || result.StatusCode != http.StatusNotFound {
-				return errors.Annotatef(err, "deleting security rule %q", ruleName)
+	for _, ingressRule := range rules {
+		for _, cidr := range ingressRule.SourceCIDRs {
+			ruleName := ingressSecurityRuleName(prefix, ingressRule.PortRange, cidr)
+			logger.Debugf("deleting security rule %q", ruleName)
+			var result autorest.Response
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				var err error
+				result, err = securityRuleClient.Delete(
+					inst.env.resourceGroup, securityGroupName, ruleName,
+				)
+				return result, err
+			}); err != nil {
+				if result.Response == nil || result.StatusCode != http.StatusNotFound {
+					return errors.Annotatef(err, "deleting security rule %q", ruleName)
+				}
 			}
 		}
 	}
@@ -313,6 +339,58 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 
 // Ports is specified in the Instance interface.
 func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRange, err error) {
+	securityRules, err := inst.securityRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rule := range securityRules {
+		portRanges, err := securityRulePortRanges(rule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ports = append(ports, portRanges...)
+	}
+	return ports, nil
+}
+
+// IngressRules is specified in the IngressRuleInstance interface.
+func (inst *azureInstance) IngressRules(machineId string) ([]jujunetwork.IngressRule, error) {
+	securityRules, err := inst.securityRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sourceCIDRs := make(map[jujunetwork.PortRange]set.Strings)
+	for _, rule := range securityRules {
+		portRanges, err := securityRulePortRanges(rule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidr := to.String(rule.Properties.SourceAddressPrefix)
+		switch cidr {
+		case "", "*", "Internet":
+			cidr = jujunetwork.OpenToWorldCIDR
+		}
+		for _, portRange := range portRanges {
+			if _, ok := sourceCIDRs[portRange]; !ok {
+				sourceCIDRs[portRange] = set.NewStrings()
+			}
+			sourceCIDRs[portRange].Add(cidr)
+		}
+	}
+	rules := make([]jujunetwork.IngressRule, 0, len(sourceCIDRs))
+	for portRange, cidrs := range sourceCIDRs {
+		rules = append(rules, jujunetwork.IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: cidrs.SortedValues(),
+		})
+	}
+	jujunetwork.SortIngressRules(rules)
+	return rules, nil
+}
+
+// securityRules returns the inbound security rules in the internal
+// network security group which allow access to the given machine.
+func (inst *azureInstance) securityRules(machineId string) ([]network.SecurityRule, error) {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -332,6 +410,7 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
+	var securityRules []network.SecurityRule
 	for _, rule := range *nsg.Properties.SecurityRules {
 		if rule.Properties.Direction != network.Inbound {
 			continue
@@ -345,36 +424,44 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		if !strings.HasPrefix(to.String(rule.Name), prefix) {
 			continue
 		}
+		securityRules = append(securityRules, rule)
+	}
+	return securityRules, nil
+}
 
-		var portRange jujunetwork.PortRange
-		if *rule.Properties.DestinationPortRange == "*" {
-			portRange.FromPort = 0
-			portRange.ToPort = 65535
-		} else {
-			portRange, err = jujunetwork.ParsePortRange(
-				*rule.Properties.DestinationPortRange,
+// securityRulePortRanges returns the port ranges to which the security
+// rule allows access, one for each protocol.
+func securityRulePortRanges(rule network.SecurityRule) ([]jujunetwork.PortRange, error) {
+	var portRange jujunetwork.PortRange
+	if *rule.Properties.DestinationPortRange == "*" {
+		portRange.FromPort = 0
+		portRange.ToPort = 65535
+	} else {
+		var err error
+		portRange, err = jujunetwork.ParsePortRange(
+			*rule.Properties.DestinationPortRange,
+		)
+		if err != nil {
+			return nil, errors.Annotatef(
+				err, "parsing port range for security rule %q",
+				to.String(rule.Name),
 			)
-			if err != nil {
-				return nil, errors.Annotatef(
-					err, "parsing port range for security rule %q",
-					to.String(rule.Name),
-				)
-			}
 		}
+	}
 
-		var protocols []string
-		switch rule.Properties.Protocol {
-		case network.TCP:
-			protocols = []string{"tcp"}
-		case network.UDP:
-			protocols = []string{"udp"}
-		default:
-			protocols = []string{"tcp", "udp"}
-		}
-		for _, protocol := range protocols {
-			portRange.Protocol = protocol
-			ports = append(ports, portRange)
-		}
+	var protocols []string
+	switch rule.Properties.Protocol {
+	case network.TCP:
+		protocols = []string{"tcp"}
+	case network.UDP:
+		protocols = []string{"udp"}
+	default:
+		protocols = []string{"tcp", "udp"}
+	}
+	var ports []jujunetwork.PortRange
+	for _, protocol := range protocols {
+		portRange.Protocol = protocol
+		ports = append(ports, portRange)
 	}
 	return ports, nil
 }
@@ -437,3 +524,15 @@ func securityRuleName(prefix string, ports jujunetwork.PortRange) string {
 	}
 	return ruleName
 }
+
+// ingressSecurityRuleName returns the security rule name for the given
+// port range and source CIDR, and prefix returned by
+// instanceNetworkSecurityRulePrefix. Rules allowing access from anywhere
+// are named as by securityRuleName.
+func ingressSecurityRuleName(prefix string, ports jujunetwork.PortRange, cidr string) string {
+	ruleName := securityRuleName(prefix, ports)
+	if cidr != jujunetwork.OpenToWorldCIDR {
+		ruleName += "-" + strings.NewReplacer("/", "-", ":", "_").Replace(cidr)
+	}
+	return ruleName
+}
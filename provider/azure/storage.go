@@ -88,6 +88,11 @@ func (e *azureStorageProvider) Dynamic() bool {
 	return true
 }
 
+// SupportsResize is defined on the storage.ResizeSupporter interface.
+func (e *azureStorageProvider) SupportsResize() bool {
+	return true
+}
+
 // VolumeSource is defined on the Provider interface.
 func (e *azureStorageProvider) VolumeSource(environConfig *config.Config, cfg *storage.Config) (storage.VolumeSource, error) {
 	if err := e.ValidateConfig(cfg); err != nil {
@@ -304,6 +309,113 @@ func (v *azureVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.Descr
 	return results, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeSource interface.
+//
+// Volumes are resized by growing the data disk in the storage profile
+// of the virtual machine that the volume is attached to; Azure then
+// grows the VHD backing the disk.
+func (v *azureVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	var anyValid bool
+	for i, p := range params {
+		if err := validateVolumeSize(p.Size); err != nil {
+			results[i].Error = err
+			continue
+		}
+		anyValid = true
+	}
+	if !anyValid {
+		return results, nil
+	}
+	instances, err := v.env.allInstances(v.env.resourceGroup, false /* don't refresh addresses */)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting virtual machines")
+	}
+
+	// Update VirtualMachine objects in-memory,
+	// and then perform the updates all at once.
+	//
+	// A resize does not require an update if the
+	// disk is already large enough, so we only
+	// record the VMs that need updating.
+	virtualMachines := make(map[instance.Id]*maybeVirtualMachine)
+	instanceIds := make([]instance.Id, len(params))
+	for i, p := range params {
+		if results[i].Error != nil {
+			continue
+		}
+		vm, disk := v.findDataDisk(instances, p.VolumeId)
+		if disk == nil {
+			results[i].Error = errors.NotFoundf("data disk for volume %s", p.VolumeId)
+			continue
+		}
+		sizeInGib := mibToGib(p.Size)
+		if size := uint64(to.Int(disk.DiskSizeGB)); size >= sizeInGib {
+			sizeInGib = size
+		} else {
+			disk.DiskSizeGB = to.IntPtr(int(sizeInGib))
+			instanceId := instance.Id(to.String(vm.Name))
+			if _, ok := virtualMachines[instanceId]; !ok {
+				virtualMachines[instanceId] = &maybeVirtualMachine{vm: vm}
+			}
+			instanceIds[i] = instanceId
+		}
+		results[i].Volume = &storage.Volume{
+			p.Tag,
+			storage.VolumeInfo{
+				VolumeId:   p.VolumeId,
+				Size:       gibToMib(sizeInGib),
+				Persistent: true,
+			},
+		}
+	}
+
+	updateResults, err := v.updateVirtualMachines(virtualMachines, instanceIds)
+	if err != nil {
+		return nil, errors.Annotate(err, "updating virtual machines")
+	}
+	for i, err := range updateResults {
+		if results[i].Error != nil || err == nil {
+			continue
+		}
+		results[i].Error = errors.Annotatef(err, "resizing volume %s", params[i].VolumeId)
+		results[i].Volume = nil
+	}
+	return results, nil
+}
+
+// findDataDisk returns the virtual machine that the volume with the
+// given ID is attached to, and the data disk in that virtual machine's
+// storage profile, or nil if the volume is not attached.
+func (v *azureVolumeSource) findDataDisk(
+	instances []instance.Instance,
+	volumeId string,
+) (*compute.VirtualMachine, *compute.DataDisk) {
+
+	dataDisksRoot := dataDiskVhdRoot(v.env.config.storageEndpoint, v.env.config.storageAccount)
+	vhdURI := dataDisksRoot + volumeId + vhdExtension
+
+	for _, inst := range instances {
+		vm := &inst.(*azureInstance).VirtualMachine
+		if vm.Properties == nil ||
+			vm.Properties.StorageProfile == nil ||
+			vm.Properties.StorageProfile.DataDisks == nil {
+			continue
+		}
+		dataDisks := *vm.Properties.StorageProfile.DataDisks
+		for i, disk := range dataDisks {
+			if to.String(disk.Name) != volumeId {
+				continue
+			}
+			if disk.Vhd == nil || to.String(disk.Vhd.URI) != vhdURI {
+				continue
+			}
+			return vm, &dataDisks[i]
+		}
+	}
+	return nil, nil
+}
+
 // DestroyVolumes is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
 	client, err := v.env.getStorageClient()
@@ -323,10 +435,16 @@ func (v *azureVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error)
 
 // ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
-	if mibToGib(params.Size) > volumeSizeMaxGiB {
+	return validateVolumeSize(params.Size)
+}
+
+// validateVolumeSize returns an error if the size, in MiB, is larger
+// than the maximum size of an Azure data disk.
+func validateVolumeSize(size uint64) error {
+	if mibToGib(size) > volumeSizeMaxGiB {
 		return errors.Errorf(
 			"%d GiB exceeds the maximum of %d GiB",
-			mibToGib(params.Size),
+			mibToGib(size),
 			volumeSizeMaxGiB,
 		)
 	}
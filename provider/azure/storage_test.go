@@ -339,6 +339,116 @@ func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
 	s.storageClient.CheckCall(c, 2, "DeleteBlobIfExists", "datavhds", "volume-42.vhd")
 }
 
+func (s *storageSuite) TestSupportsResize(c *gc.C) {
+	c.Assert(storage.SupportsResize(s.provider), jc.IsTrue)
+}
+
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	makeDataDisk := func(volume string, sizeInGib int) compute.DataDisk {
+		return compute.DataDisk{
+			Lun:        to.IntPtr(0),
+			Name:       to.StringPtr("volume-" + volume),
+			DiskSizeGB: to.IntPtr(sizeInGib),
+			Vhd: &compute.VirtualHardDisk{
+				URI: to.StringPtr(fmt.Sprintf(
+					"https://%s.blob.storage.azurestack.local/datavhds/volume-%s.vhd",
+					fakeStorageAccount, volume,
+				)),
+			},
+		}
+	}
+	// volume-0 is attached to machine-0, and is 1 GiB.
+	// volume-1 is attached to machine-1, and is 10 GiB.
+	machine0DataDisks := []compute.DataDisk{makeDataDisk("0", 1)}
+	machine1DataDisks := []compute.DataDisk{makeDataDisk("1", 10)}
+
+	makeParams := func(volume string, size uint64) storage.VolumeResizeParams {
+		return storage.VolumeResizeParams{
+			Tag:      names.NewVolumeTag(volume),
+			VolumeId: "volume-" + volume,
+			Size:     size,
+			Provider: "azure",
+		}
+	}
+	params := []storage.VolumeResizeParams{
+		makeParams("0", 2048),
+		makeParams("1", 4096),
+		makeParams("2", 1024),
+		makeParams("3", 2048*1024),
+	}
+
+	virtualMachines := []compute.VirtualMachine{{
+		Name: to.StringPtr("machine-0"),
+		Properties: &compute.VirtualMachineProperties{
+			StorageProfile: &compute.StorageProfile{DataDisks: &machine0DataDisks},
+		},
+	}, {
+		Name: to.StringPtr("machine-1"),
+		Properties: &compute.VirtualMachineProperties{
+			StorageProfile: &compute.StorageProfile{DataDisks: &machine1DataDisks},
+		},
+	}}
+
+	// There should be a couple of API calls to list instances,
+	// and one update for machine-0, whose disk must grow.
+	nics := []network.Interface{
+		makeNetworkInterface("nic-0", "machine-0"),
+		makeNetworkInterface("nic-1", "machine-1"),
+	}
+	nicsSender := azuretesting.NewSenderWithValue(network.InterfaceListResult{
+		Value: &nics,
+	})
+	nicsSender.PathPattern = `.*/Microsoft\.Network/networkInterfaces`
+	virtualMachinesSender := azuretesting.NewSenderWithValue(compute.VirtualMachineListResult{
+		Value: &virtualMachines,
+	})
+	virtualMachinesSender.PathPattern = `.*/Microsoft\.Compute/virtualMachines`
+	updateVirtualMachine0Sender := azuretesting.NewSenderWithValue(&compute.VirtualMachine{})
+	updateVirtualMachine0Sender.PathPattern = `.*/Microsoft\.Compute/virtualMachines/machine-0`
+	volumeSource := s.volumeSource(c)
+	s.sender = azuretesting.Senders{
+		nicsSender,
+		virtualMachinesSender,
+		updateVirtualMachine0Sender,
+	}
+
+	results, err := volumeSource.ResizeVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, len(params))
+	c.Check(results[0], jc.DeepEquals, storage.ResizeVolumesResult{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("0"),
+			storage.VolumeInfo{
+				VolumeId:   "volume-0",
+				Size:       2048,
+				Persistent: true,
+			},
+		},
+	})
+	// volume-1 is already larger than requested, so is left alone.
+	c.Check(results[1], jc.DeepEquals, storage.ResizeVolumesResult{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("1"),
+			storage.VolumeInfo{
+				VolumeId:   "volume-1",
+				Size:       10 * 1024,
+				Persistent: true,
+			},
+		},
+	})
+	c.Check(results[2].Error, gc.ErrorMatches, "data disk for volume volume-2 not found")
+	c.Check(results[3].Error, gc.ErrorMatches, "2048 GiB exceeds the maximum of 1023 GiB")
+
+	// Validate HTTP request bodies.
+	c.Assert(s.requests, gc.HasLen, 3)
+	c.Assert(s.requests[0].Method, gc.Equals, "GET") // list NICs
+	c.Assert(s.requests[1].Method, gc.Equals, "GET") // list virtual machines
+	c.Assert(s.requests[2].Method, gc.Equals, "PUT") // update machine-0
+
+	machine0DataDisks[0].DiskSizeGB = to.IntPtr(2)
+	assertRequestBody(c, s.requests[2], &virtualMachines[0])
+}
+
 func (s *storageSuite) TestAttachVolumes(c *gc.C) {
 	// machine-1 has a single data disk with LUN 0.
 	machine1DataDisks := []compute.DataDisk{{
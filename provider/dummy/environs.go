@@ -38,6 +38,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
@@ -178,6 +179,7 @@ type OpOpenPorts struct {
 	MachineId  string
 	InstanceId instance.Id
 	Ports      []network.PortRange
+	Rules      []network.IngressRule
 }
 
 type OpClosePorts struct {
@@ -185,6 +187,7 @@ type OpClosePorts struct {
 	MachineId  string
 	InstanceId instance.Id
 	Ports      []network.PortRange
+	Rules      []network.IngressRule
 }
 
 type OpPutFile struct {
@@ -220,7 +223,7 @@ type environState struct {
 	maxId           int // maximum instance id allocated so far.
 	maxAddr         int // maximum allocated address last byte
 	insts           map[instance.Id]*dummyInstance
-	globalPorts     map[network.PortRange]bool
+	globalRules     ingressRules
 	bootstrapped    bool
 	apiListener     net.Listener
 	apiServer       *apiserver.Server
@@ -357,7 +360,7 @@ func newState(name string, ops chan<- Operation, policy state.Policy) *environSt
 		ops:         ops,
 		statePolicy: policy,
 		insts:       make(map[instance.Id]*dummyInstance),
-		globalPorts: make(map[network.PortRange]bool),
+		globalRules: make(ingressRules),
 	}
 	return s
 }
@@ -678,7 +681,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, args environs.Bootstr
 	i := &dummyInstance{
 		id:           BootstrapInstanceId,
 		addresses:    network.NewAddresses("localhost"),
-		ports:        make(map[network.PortRange]bool),
+		rules:        make(ingressRules),
 		machineId:    agent.BootstrapMachineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -914,7 +917,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	i := &dummyInstance{
 		id:           instance.Id(idString),
 		addresses:    addrs,
-		ports:        make(map[network.PortRange]bool),
+		rules:        make(ingressRules),
 		machineId:    machineId,
 		series:       series,
 		firewallMode: e.Config().FirewallMode(),
@@ -1305,7 +1308,74 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 	return insts, nil
 }
 
+// ingressRules models a firewall, holding the source CIDRs from which
+// each open port range may be accessed.
+type ingressRules map[network.PortRange]set.Strings
+
+func (r ingressRules) open(rules []network.IngressRule) {
+	for _, rule := range rules {
+		if r[rule.PortRange] == nil {
+			r[rule.PortRange] = set.NewStrings()
+		}
+		r[rule.PortRange] = r[rule.PortRange].Union(set.NewStrings(rule.SourceCIDRs...))
+	}
+}
+
+func (r ingressRules) close(rules []network.IngressRule) {
+	for _, rule := range rules {
+		cidrs := r[rule.PortRange].Difference(set.NewStrings(rule.SourceCIDRs...))
+		if cidrs.IsEmpty() {
+			delete(r, rule.PortRange)
+			continue
+		}
+		r[rule.PortRange] = cidrs
+	}
+}
+
+func (r ingressRules) rules() []network.IngressRule {
+	var result []network.IngressRule
+	for portRange, cidrs := range r {
+		result = append(result, network.IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: cidrs.SortedValues(),
+		})
+	}
+	network.SortIngressRules(result)
+	return result
+}
+
+func (r ingressRules) ports() []network.PortRange {
+	var result []network.PortRange
+	for portRange := range r {
+		result = append(result, portRange)
+	}
+	network.SortPortRanges(result)
+	return result
+}
+
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.PortRangesToIngressRules(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.PortRangesToIngressRules(ports))
+}
+
+func (e *environ) Ports() (ports []network.PortRange, err error) {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	return estate.globalRules.ports(), nil
+}
+
+// OpenIngressRules is specified on environs.IngressRuleFirewaller.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
 	}
@@ -1315,13 +1385,12 @@ func (e *environ) OpenPorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		estate.globalPorts[p] = true
-	}
+	estate.globalRules.open(rules)
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is specified on environs.IngressRuleFirewaller.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on model", mode)
 	}
@@ -1331,13 +1400,12 @@ func (e *environ) ClosePorts(ports []network.PortRange) error {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for _, p := range ports {
-		delete(estate.globalPorts, p)
-	}
+	estate.globalRules.close(rules)
 	return nil
 }
 
-func (e *environ) Ports() (ports []network.PortRange, err error) {
+// IngressRules is specified on environs.IngressRuleFirewaller.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model", mode)
 	}
@@ -1347,11 +1415,7 @@ func (e *environ) Ports() (ports []network.PortRange, err error) {
 	}
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
-	for p := range estate.globalPorts {
-		ports = append(ports, p)
-	}
-	network.SortPortRanges(ports)
-	return
+	return estate.globalRules.rules(), nil
 }
 
 func (*environ) Provider() environs.EnvironProvider {
@@ -1360,7 +1424,7 @@ func (*environ) Provider() environs.EnvironProvider {
 
 type dummyInstance struct {
 	state        *environState
-	ports        map[network.PortRange]bool
+	rules        ingressRules
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1443,55 +1507,71 @@ func (inst *dummyInstance) Addresses() ([]network.Address, error) {
 }
 
 func (inst *dummyInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return inst.openIngressRules("OpenPorts", machineId, network.PortRangesToIngressRules(ports))
+}
+
+// OpenIngressRules is specified on instance.IngressRuleInstance.
+func (inst *dummyInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.openIngressRules("OpenIngressRules", machineId, rules)
+}
+
+func (inst *dummyInstance) openIngressRules(method, machineId string, rules []network.IngressRule) error {
 	defer delay()
-	logger.Infof("openPorts %s, %#v", machineId, ports)
+	logger.Infof("openIngressRules %s, %#v", machineId, rules)
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("OpenPorts with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+		panic(fmt.Errorf("%s with mismatched machine id, expected %q got %q", method, inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("OpenPorts"); err != nil {
+	if err := inst.checkBroken(method); err != nil {
 		return err
 	}
 	inst.state.ops <- OpOpenPorts{
 		Env:        inst.state.name,
 		MachineId:  machineId,
 		InstanceId: inst.Id(),
-		Ports:      ports,
-	}
-	for _, p := range ports {
-		inst.ports[p] = true
+		Ports:      rulesPortRanges(rules),
+		Rules:      rules,
 	}
+	inst.rules.open(rules)
 	return nil
 }
 
 func (inst *dummyInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return inst.closeIngressRules("ClosePorts", machineId, network.PortRangesToIngressRules(ports))
+}
+
+// CloseIngressRules is specified on instance.IngressRuleInstance.
+func (inst *dummyInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.closeIngressRules("CloseIngressRules", machineId, rules)
+}
+
+func (inst *dummyInstance) closeIngressRules(method, machineId string, rules []network.IngressRule) error {
 	defer delay()
 	if inst.firewallMode != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			inst.firewallMode)
 	}
 	if inst.machineId != machineId {
-		panic(fmt.Errorf("ClosePorts with mismatched machine id, expected %s got %s", inst.machineId, machineId))
+		panic(fmt.Errorf("%s with mismatched machine id, expected %s got %s", method, inst.machineId, machineId))
 	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
-	if err := inst.checkBroken("ClosePorts"); err != nil {
+	if err := inst.checkBroken(method); err != nil {
 		return err
 	}
 	inst.state.ops <- OpClosePorts{
 		Env:        inst.state.name,
 		MachineId:  machineId,
 		InstanceId: inst.Id(),
-		Ports:      ports,
-	}
-	for _, p := range ports {
-		delete(inst.ports, p)
+		Ports:      rulesPortRanges(rules),
+		Rules:      rules,
 	}
+	inst.rules.close(rules)
 	return nil
 }
 
@@ -1509,11 +1589,33 @@ func (inst *dummyInstance) Ports(machineId string) (ports []network.PortRange, e
 	if err := inst.checkBroken("Ports"); err != nil {
 		return nil, err
 	}
-	for p := range inst.ports {
-		ports = append(ports, p)
+	return inst.rules.ports(), nil
+}
+
+// IngressRules is specified on instance.IngressRuleInstance.
+func (inst *dummyInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
+			inst.firewallMode)
 	}
-	network.SortPortRanges(ports)
-	return
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("IngressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("IngressRules"); err != nil {
+		return nil, err
+	}
+	return inst.rules.rules(), nil
+}
+
+func rulesPortRanges(rules []network.IngressRule) []network.PortRange {
+	ports := make([]network.PortRange, len(rules))
+	for i, rule := range rules {
+		ports[i] = rule.PortRange
+	}
+	return ports
 }
 
 // providerDelay controls the delay before dummy responds.
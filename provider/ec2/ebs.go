@@ -130,6 +130,7 @@ func init() {
 type ebsProvider struct{}
 
 var _ storage.Provider = (*ebsProvider)(nil)
+var _ storage.ResizeSupporter = (*ebsProvider)(nil)
 
 var ebsConfigFields = schema.Fields{
 	EBS_VolumeType: schema.OneOf(
@@ -209,6 +210,11 @@ func (e *ebsProvider) Dynamic() bool {
 	return true
 }
 
+// SupportsResize is defined on the storage.ResizeSupporter interface.
+func (e *ebsProvider) SupportsResize() bool {
+	return true
+}
+
 // VolumeSource is defined on the Provider interface.
 func (e *ebsProvider) VolumeSource(environConfig *config.Config, cfg *storage.Config) (storage.VolumeSource, error) {
 	ec2, _, _, err := awsClients(environConfig)
@@ -235,6 +241,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeImporter = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -428,6 +435,72 @@ func (v *ebsVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVo
 	return results, nil
 }
 
+// ImportVolume is specified on the storage.VolumeImporter interface.
+func (v *ebsVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string, force bool) (storage.VolumeInfo, error) {
+	vol, err := describeVolume(v.ec2, volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Trace(err)
+	}
+	if vol.Status != volumeStatusAvailable {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume with status %q", vol.Status,
+		)
+	}
+	var owner string
+	for _, tag := range vol.Tags {
+		if tag.Key == tags.JujuModel {
+			owner = tag.Value
+		}
+	}
+	if err := storage.ValidateImportOwner(volumeId, owner, v.modelUUID, force); err != nil {
+		return storage.VolumeInfo{}, errors.Trace(err)
+	}
+	if err := tagResources(v.ec2, resourceTags, volumeId); err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "tagging volume")
+	}
+	return storage.VolumeInfo{
+		VolumeId:   vol.Id,
+		Size:       gibToMib(uint64(vol.Size)),
+		Persistent: true,
+	}, nil
+}
+
+// ResizeVolumes is specified on the storage.VolumeSource interface.
+func (v *ebsVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		volume, err := v.resizeVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", p.VolumeId)
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) resizeVolume(p storage.VolumeResizeParams) (*storage.Volume, error) {
+	vol, err := describeVolume(v.ec2, p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	size := uint64(vol.Size)
+	if sizeInGib := mibToGib(p.Size); size < sizeInGib {
+		if err := modifyVolume(v.ec2, p.VolumeId, sizeInGib); err != nil {
+			return nil, errors.Trace(err)
+		}
+		size = sizeInGib
+	}
+	return &storage.Volume{
+		p.Tag,
+		storage.VolumeInfo{
+			VolumeId:   vol.Id,
+			Size:       gibToMib(size),
+			Persistent: true,
+		},
+	}, nil
+}
+
 // DestroyVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
 	return destroyVolumes(v.ec2, volIds), nil
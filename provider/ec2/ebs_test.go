@@ -416,6 +416,118 @@ func (s *ebsVolumeSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	c.Assert(vols[0].Error, gc.ErrorMatches, "vol-42 not found")
 }
 
+func (s *ebsVolumeSuite) TestImportVolume(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	_, err := vs.DetachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(vs, gc.Implements, new(storage.VolumeImporter))
+	info, err := vs.(storage.VolumeImporter).ImportVolume("vol-0", map[string]string{
+		"juju-controller-uuid": "controller-uuid",
+	}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   "vol-0",
+		Size:       10240,
+		Persistent: true,
+	})
+
+	ec2Client := ec2.StorageEC2(vs)
+	ec2Vols, err := ec2Client.Volumes([]string{"vol-0"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"juju-model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+		{"juju-controller-uuid", "controller-uuid"},
+		{"Name", "juju-sample-volume-0"},
+	})
+}
+
+func (s *ebsVolumeSuite) TestImportVolumeInUse(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	_, err := vs.(storage.VolumeImporter).ImportVolume("vol-0", nil, false)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume with status "in-use"`)
+}
+
+func (s *ebsVolumeSuite) TestImportVolumeOtherModel(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	_, err := vs.DetachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+	ec2Client := ec2.StorageEC2(vs)
+	_, err = ec2Client.CreateTags([]string{"vol-0"}, []awsec2.Tag{
+		{"juju-model-uuid", "other-model-uuid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelTag := func() string {
+		ec2Vols, err := ec2Client.Volumes([]string{"vol-0"}, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, tag := range ec2Vols.Volumes[0].Tags {
+			if tag.Key == "juju-model-uuid" {
+				return tag.Value
+			}
+		}
+		return ""
+	}
+
+	resourceTags := map[string]string{
+		"juju-model-uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	}
+	_, err = vs.(storage.VolumeImporter).ImportVolume("vol-0", resourceTags, false)
+	c.Assert(err, gc.ErrorMatches, `volume "vol-0" belongs to model "other-model-uuid", .*`)
+	c.Assert(modelTag(), gc.Equals, "other-model-uuid")
+
+	_, err = vs.(storage.VolumeImporter).ImportVolume("vol-0", resourceTags, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelTag(), gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
+func (s *ebsVolumeSuite) TestImportVolumeNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	_, err := vs.(storage.VolumeImporter).ImportVolume("vol-42", nil, false)
+	c.Assert(err, gc.ErrorMatches, ".*vol-42.*")
+}
+
+func (s *ebsVolumeSuite) TestResizeVolumesAlreadyResized(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	_, err := s.createVolumes(vs, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The test server does not support ModifyVolume, so the
+	// request would fail if the volume were not large enough.
+	results, err := vs.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     10000,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("0"),
+			storage.VolumeInfo{
+				VolumeId:   "vol-0",
+				Size:       10240,
+				Persistent: true,
+			},
+		},
+	}})
+}
+
+func (s *ebsVolumeSuite) TestResizeVolumesNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	results, err := vs.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-42",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing volume vol-42: .*vol-42.*")
+}
+
 func (s *ebsVolumeSuite) TestListVolumes(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.assertCreateVolumes(c, vs, "")
@@ -1103,41 +1103,49 @@ func (e *environ) allControllerManagedVolumes(controllerUUID string) ([]string,
 }
 
 func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(ports))
-	for i, p := range ports {
+	return rulesToIPPerms(network.PortRangesToIngressRules(ports))
+}
+
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
 		ipPerms[i] = ec2.IPPerm{
-			Protocol:  p.Protocol,
-			FromPort:  p.FromPort,
-			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			Protocol:  r.Protocol,
+			FromPort:  r.FromPort,
+			ToPort:    r.ToPort,
+			SourceIPs: r.SourceCIDRs,
 		}
 	}
 	return ipPerms
 }
 
-func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+func (e *environ) openIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Give permissions for anyone to access the given ports.
+	// Give permissions for the rules' sources to access the given ports.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
+	ipPerms := rulesToIPPerms(rules)
 	_, err = e.ec2().AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(rules) == 1 && len(rules[0].SourceCIDRs) == 1 {
 			return nil
 		}
-		// If there's more than one port and we get a duplicate error,
-		// then we go through authorizing each port individually,
-		// otherwise the ports that were *not* duplicates will have
-		// been ignored
-		for i := range ipPerms {
-			_, err := e.ec2().AuthorizeSecurityGroup(g, ipPerms[i:i+1])
-			if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
-				return fmt.Errorf("cannot open port %v: %v", ipPerms[i], err)
+		// If there's more than one port or source and we get a
+		// duplicate error, then we go through authorizing each port
+		// and source individually, otherwise the ones that were *not*
+		// duplicates will have been ignored
+		for _, perm := range ipPerms {
+			for _, sourceIP := range perm.SourceIPs {
+				single := perm
+				single.SourceIPs = []string{sourceIP}
+				_, err := e.ec2().AuthorizeSecurityGroup(g, []ec2.IPPerm{single})
+				if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
+					return fmt.Errorf("cannot open port %v: %v", single, err)
+				}
 			}
 		}
 		return nil
@@ -1148,71 +1156,104 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 	return nil
 }
 
-func (e *environ) closePortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+func (e *environ) closeIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
-	// Note that ec2 allows the revocation of permissions that aren't
-	// granted, so this is naturally idempotent.
+	// Revoke permissions for the rules' sources to access the given
+	// ports. Note that ec2 allows the revocation of permissions that
+	// aren't granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2().RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2().RevokeSecurityGroup(g, rulesToIPPerms(rules))
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
-func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
+func (e *environ) portsInGroup(name string) ([]network.PortRange, error) {
+	rules, err := e.ingressRulesInGroup(name)
+	if err != nil {
+		return nil, err
+	}
+	var ports []network.PortRange
+	for _, r := range rules {
+		if len(ports) > 0 && ports[len(ports)-1] == r.PortRange {
+			continue
+		}
+		ports = append(ports, r.PortRange)
+	}
+	return ports, nil
+}
+
+func (e *environ) ingressRulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range group.IPPerms {
-		if len(p.SourceIPs) != 1 {
-			logger.Errorf("expected exactly one IP permission, found: %v", p)
+		if len(p.SourceIPs) == 0 {
+			logger.Errorf("expected at least one IP permission, found: %v", p)
 			continue
 		}
-		ports = append(ports, network.PortRange{
-			Protocol: p.Protocol,
-			FromPort: p.FromPort,
-			ToPort:   p.ToPort,
-		})
+		rule, err := network.NewIngressRule(p.Protocol, p.FromPort, p.ToPort, p.SourceIPs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
 	}
-	network.SortPortRanges(ports)
-	return ports, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.PortRangesToIngressRules(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.PortRangesToIngressRules(ports))
+}
+
+func (e *environ) Ports() ([]network.PortRange, error) {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", e.Config().FirewallMode())
+	}
+	return e.portsInGroup(e.globalGroupName())
+}
+
+// OpenIngressRules is specified on environs.IngressRuleFirewaller.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
 	}
-	if err := e.openPortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.openIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ingress rules in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is specified on environs.IngressRuleFirewaller.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for closing ports on model", e.Config().FirewallMode())
 	}
-	if err := e.closePortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.closeIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ingress rules in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) Ports() ([]network.PortRange, error) {
+// IngressRules is specified on environs.IngressRuleFirewaller.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", e.Config().FirewallMode())
 	}
-	return e.portsInGroup(e.globalGroupName())
+	return e.ingressRulesInGroup(e.globalGroupName())
 }
 
 func (*environ) Provider() environs.EnvironProvider {
@@ -10,16 +10,19 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
 // Ensure EC2 provider supports the expected interfaces,
 var (
-	_ environs.NetworkingEnviron = (*environ)(nil)
-	_ simplestreams.HasRegion    = (*environ)(nil)
-	_ state.Prechecker           = (*environ)(nil)
-	_ state.InstanceDistributor  = (*environ)(nil)
+	_ environs.NetworkingEnviron     = (*environ)(nil)
+	_ environs.IngressRuleFirewaller = (*environ)(nil)
+	_ instance.IngressRuleInstance   = (*ec2Instance)(nil)
+	_ simplestreams.HasRegion        = (*environ)(nil)
+	_ state.Prechecker               = (*environ)(nil)
+	_ state.InstanceDistributor      = (*environ)(nil)
 )
 
 type Suite struct{}
@@ -164,3 +167,21 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 		c.Assert(ipperms, gc.DeepEquals, t.expected)
 	}
 }
+
+func (*Suite) TestRulesToIPPerms(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.1.0/24", "10.0.0.0/24"),
+		network.MustNewIngressRule("udp", 53, 53),
+	}
+	c.Assert(rulesToIPPerms(rules), gc.DeepEquals, []amzec2.IPPerm{{
+		Protocol:  "tcp",
+		FromPort:  80,
+		ToPort:    80,
+		SourceIPs: []string{"10.0.0.0/24", "192.168.1.0/24"},
+	}, {
+		Protocol:  "udp",
+		FromPort:  53,
+		ToPort:    53,
+		SourceIPs: []string{"0.0.0.0/0"},
+	}})
+}
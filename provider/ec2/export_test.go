@@ -420,3 +420,5 @@ var TestRegions = map[string]aws.Region{
 		EC2Endpoint: "https://ec2.endpoint.com",
 	},
 }
+
+var ModifyVolume = modifyVolume
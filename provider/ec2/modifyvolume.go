@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// modifyVolumeAPIVersion is the first version of the EC2 API
+// that supports the ModifyVolume action.
+const modifyVolumeAPIVersion = "2016-11-15"
+
+// modifyVolume grows the EBS volume with the specified ID to the
+// specified size in GiB. The EBS volume is resized while it is in
+// use, so it may remain attached.
+//
+// The version of the EC2 API supported by the ec2 package does not
+// include the ModifyVolume action, so we sign and send the request
+// ourselves using the client's credentials.
+func modifyVolume(client *ec2.EC2, volumeId string, sizeInGib uint64) error {
+	query := url.Values{
+		"Action":   {"ModifyVolume"},
+		"Version":  {modifyVolumeAPIVersion},
+		"VolumeId": {volumeId},
+		"Size":     {strconv.FormatUint(sizeInGib, 10)},
+	}
+	req, err := http.NewRequest("GET", client.Region.EC2Endpoint+"/?"+query.Encode(), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.Sign(req, client.Auth); err != nil {
+		return errors.Annotate(err, "signing ModifyVolume request")
+	}
+	resp, err := utils.GetValidatingHTTPClient().Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "modifying volume %s", volumeId)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return modifyVolumeError(resp)
+	}
+	return nil
+}
+
+// modifyVolumeErrorResponse is the body of an EC2 error response.
+type modifyVolumeErrorResponse struct {
+	RequestId string `xml:"RequestID"`
+	Errors    []struct {
+		Code    string
+		Message string
+	} `xml:"Errors>Error"`
+}
+
+// modifyVolumeError returns an *ec2.Error describing the failed
+// response, so that callers may inspect the error code as they do
+// for requests sent with the ec2 package. If the volume cannot be
+// modified at all, the error satisfies errors.IsNotSupported.
+func modifyVolumeError(resp *http.Response) error {
+	var body modifyVolumeErrorResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return errors.Annotatef(err, "decoding ModifyVolume error (%s)", resp.Status)
+	}
+	err := &ec2.Error{
+		StatusCode: resp.StatusCode,
+		RequestId:  body.RequestId,
+	}
+	if len(body.Errors) > 0 {
+		err.Code = body.Errors[0].Code
+		err.Message = body.Errors[0].Message
+	}
+	if err.Code == "UnsupportedOperation" {
+		// The volume's type does not support modification,
+		// so there is no point in retrying.
+		return errors.NewNotSupported(err, "")
+	}
+	return err
+}
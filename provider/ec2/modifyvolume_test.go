@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	awsec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/ec2"
+	"github.com/juju/juju/testing"
+)
+
+type modifyVolumeSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&modifyVolumeSuite{})
+
+// newModifyVolumeClient returns an EC2 client that sends requests
+// to a test server that responds with the given status and body,
+// and records the queries it receives.
+func (s *modifyVolumeSuite) newModifyVolumeClient(c *gc.C, status int, body string, queries *[]url.Values) *awsec2.EC2 {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		*queries = append(*queries, req.URL.Query())
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	s.AddCleanup(func(*gc.C) { srv.Close() })
+	region := aws.Region{Name: "test", EC2Endpoint: srv.URL}
+	auth := aws.Auth{AccessKey: "access", SecretKey: "secret"}
+	return awsec2.New(auth, region, aws.SignV4Factory(region.Name, "ec2"))
+}
+
+func (s *modifyVolumeSuite) TestModifyVolume(c *gc.C) {
+	var queries []url.Values
+	client := s.newModifyVolumeClient(c, http.StatusOK, `<ModifyVolumeResponse/>`, &queries)
+	err := ec2.ModifyVolume(client, "vol-0", 20)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queries, gc.HasLen, 1)
+	c.Assert(queries[0].Get("Action"), gc.Equals, "ModifyVolume")
+	c.Assert(queries[0].Get("VolumeId"), gc.Equals, "vol-0")
+	c.Assert(queries[0].Get("Size"), gc.Equals, "20")
+	c.Assert(queries[0].Get("Version"), gc.Equals, "2016-11-15")
+}
+
+func (s *modifyVolumeSuite) TestModifyVolumeError(c *gc.C) {
+	var queries []url.Values
+	client := s.newModifyVolumeClient(c, http.StatusBadRequest, `
+<Response><Errors><Error>
+<Code>VolumeModificationRateExceeded</Code>
+<Message>too many modifications</Message>
+</Error></Errors><RequestID>req-0</RequestID></Response>`, &queries)
+	err := ec2.ModifyVolume(client, "vol-0", 20)
+	c.Assert(err, jc.DeepEquals, &awsec2.Error{
+		StatusCode: http.StatusBadRequest,
+		Code:       "VolumeModificationRateExceeded",
+		Message:    "too many modifications",
+		RequestId:  "req-0",
+	})
+}
+
+func (s *modifyVolumeSuite) TestModifyVolumeUnsupported(c *gc.C) {
+	var queries []url.Values
+	client := s.newModifyVolumeClient(c, http.StatusBadRequest, `
+<Response><Errors><Error>
+<Code>UnsupportedOperation</Code>
+<Message>volume type cannot be modified</Message>
+</Error></Errors><RequestID>req-0</RequestID></Response>`, &queries)
+	err := ec2.ModifyVolume(client, "vol-0", 20)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "volume type cannot be modified.*")
+}
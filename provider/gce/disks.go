@@ -28,6 +28,8 @@ func init() {
 type storageProvider struct{}
 
 var _ storage.Provider = (*storageProvider)(nil)
+var _ storage.ResizeSupporter = (*storageProvider)(nil)
+var _ storage.VolumeImporter = (*volumeSource)(nil)
 
 func (g *storageProvider) ValidateConfig(cfg *storage.Config) error {
 	return nil
@@ -45,6 +47,11 @@ func (g *storageProvider) Dynamic() bool {
 	return true
 }
 
+// SupportsResize is defined on the storage.ResizeSupporter interface.
+func (g *storageProvider) SupportsResize() bool {
+	return true
+}
+
 func (g *storageProvider) FilesystemSource(environConfig *config.Config, providerConfig *storage.Config) (storage.FilesystemSource, error) {
 	return nil, errors.NotSupportedf("filesystems")
 }
@@ -315,6 +322,78 @@ func (v *volumeSource) DescribeVolumes(volNames []string) ([]storage.DescribeVol
 	return results, nil
 }
 
+// ImportVolume is specified on the storage.VolumeImporter interface.
+//
+// The resource tags are not applied. The version of the compute API
+// used by the provider supports neither labels nor metadata on disks,
+// and Juju records the owning model in the disk's description, which
+// cannot be changed once the disk has been created. The description
+// of a disk created by another model is still checked, though.
+func (v *volumeSource) ImportVolume(volName string, resourceTags map[string]string, force bool) (storage.VolumeInfo, error) {
+	zone, _, err := parseVolumeId(volName)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "cannot import %q", volName)
+	}
+	disk, err := v.gce.Disk(zone, volName)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotatef(err, "cannot get volume %q", volName)
+	}
+	if disk.Status != google.StatusReady {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with status %q", volName, disk.Status,
+		)
+	}
+	if err := storage.ValidateImportOwner(volName, disk.Description, v.modelUUID, force); err != nil {
+		return storage.VolumeInfo{}, errors.Trace(err)
+	}
+	return storage.VolumeInfo{
+		Size:       disk.Size,
+		VolumeId:   disk.Name,
+		Persistent: true,
+	}, nil
+}
+
+// ResizeVolumes is specified on the storage.VolumeSource interface.
+func (v *volumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		volume, err := v.resizeOneVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "cannot resize volume %q", p.VolumeId)
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(p storage.VolumeResizeParams) (*storage.Volume, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	disk, err := v.gce.Disk(zone, p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	size := disk.Size
+	if size < p.Size {
+		sizeGB := mibToGib(p.Size)
+		if err := v.gce.ResizeDisk(zone, p.VolumeId, int64(sizeGB)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		size = sizeGB * 1024
+	}
+	return &storage.Volume{
+		p.Tag,
+		storage.VolumeInfo{
+			VolumeId:   disk.Name,
+			Size:       size,
+			Persistent: true,
+		},
+	}, nil
+}
+
 func (v *volumeSource) describeOneVolume(volName string) (storage.DescribeVolumesResult, error) {
 	zone, _, err := parseVolumeId(volName)
 	if err != nil {
@@ -261,6 +261,90 @@ func (s *volumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	c.Assert(call[0].ID, gc.Equals, volName)
 }
 
+func (s *volumeSourceSuite) TestImportVolume(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	c.Assert(s.source, gc.Implements, new(storage.VolumeImporter))
+	info, err := s.source.(storage.VolumeImporter).ImportVolume(volName, map[string]string{"a": "b"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   volName,
+		Size:       1024,
+		Persistent: true,
+	})
+
+	diskCalled, call := s.FakeConn.WasCalled("Disk")
+	c.Assert(diskCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].ID, gc.Equals, volName)
+}
+
+func (s *volumeSourceSuite) TestImportVolumeNotReady(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	s.FakeConn.GoogleDisk.Status = google.StatusCreating
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	_, err := s.source.(storage.VolumeImporter).ImportVolume(volName, nil, false)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume ".*" with status "CREATING"`)
+}
+
+func (s *volumeSourceSuite) TestImportVolumeOtherModel(c *gc.C) {
+	otherDisk := *s.BaseDisk
+	otherDisk.Description = "a-different-model-uuid"
+	s.FakeConn.GoogleDisk = &otherDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	_, err := s.source.(storage.VolumeImporter).ImportVolume(volName, nil, false)
+	c.Assert(err, gc.ErrorMatches, `volume ".*" belongs to model "a-different-model-uuid", .*`)
+
+	_, err = s.source.(storage.VolumeImporter).ImportVolume(volName, nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	results, err := s.source.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: volName,
+		Size:     1536,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("0"),
+			storage.VolumeInfo{
+				VolumeId:   volName,
+				Size:       2048,
+				Persistent: true,
+			},
+		},
+	}})
+
+	resizeCalled, call := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].ID, gc.Equals, volName)
+	c.Assert(call[0].SizeGB, gc.Equals, int64(2))
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesAlreadyResized(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	results, err := s.source.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: volName,
+		Size:     1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.Size, gc.Equals, uint64(1024))
+
+	resizeCalled, _ := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsFalse)
+}
+
 func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
 	attachments := []storage.VolumeAttachmentParams{*s.attachmentParams}
@@ -30,6 +30,9 @@ type gceConnection interface {
 	Ports(fwname string) ([]network.PortRange, error)
 	OpenPorts(fwname string, ports ...network.PortRange) error
 	ClosePorts(fwname string, ports ...network.PortRange) error
+	IngressRules(fwname string) ([]network.IngressRule, error)
+	OpenIngressRules(fwname string, rules ...network.IngressRule) error
+	CloseIngressRules(fwname string, rules ...network.IngressRule) error
 
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 
@@ -45,6 +48,9 @@ type gceConnection interface {
 	Disk(zone, id string) (*google.Disk, error)
 	// RemoveDisk will destroy the disk identified by <name> in <zone>.
 	RemoveDisk(zone, id string) error
+	// ResizeDisk will grow the disk identified by <id> in <zone>
+	// to <sizeGB> gigabytes.
+	ResizeDisk(zone, id string, sizeGB int64) error
 	// AttachDisk will attach the volume identified by <volumeName> into the instance
 	// <instanceId> and return an AttachedDisk representing it or error.
 	AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error)
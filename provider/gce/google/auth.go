@@ -4,6 +4,8 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	"golang.org/x/oauth2"
 	goauth2 "golang.org/x/oauth2/google"
@@ -18,22 +20,26 @@ var (
 )
 
 // newConnection opens a new low-level connection to the GCE API using
-// the Auth's data and returns it. This includes building the
-// OAuth-wrapping network transport.
-func newConnection(creds *Credentials) (*compute.Service, error) {
+// the Auth's data and returns it, along with the HTTP client used by
+// the connection. This includes building the OAuth-wrapping network
+// transport.
+func newConnection(creds *Credentials) (*compute.Service, *http.Client, error) {
 	jsonKey := creds.JSONKey
 	if jsonKey == nil {
 		built, err := creds.buildJSONKey()
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 		jsonKey = built
 	}
 	cfg, err := goauth2.JWTConfigFromJSON(jsonKey, driverScopes...)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	client := cfg.Client(oauth2.NoContext)
 	service, err := compute.New(client)
-	return service, errors.Trace(err)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return service, client, nil
 }
@@ -59,6 +59,14 @@ func (gce *Connection) Disk(zone, name string) (*Disk, error) {
 	return NewDisk(d), nil
 }
 
+// ResizeDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeDisk(zone, name string, sizeGB int64) error {
+	if err := gce.raw.ResizeDisk(gce.projectID, zone, name, sizeGB); err != nil {
+		return errors.Annotatef(err, "cannot resize disk %q in zone %q", name, zone)
+	}
+	return nil
+}
+
 // deviceName will generate a device name from the passed
 // <zone> and <diskId>, the device name must not be confused
 // with the volume name, as it is used mainly to name the
@@ -4,7 +4,15 @@
 package google
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
@@ -111,3 +119,225 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	}
 	return nil
 }
+
+// ingressFirewallName returns the name of the firewall, derived from
+// the base firewall name, which allows access from the given sorted
+// source CIDRs. The firewall allowing access from anywhere keeps the
+// base name, so that it is shared with Ports, OpenPorts and ClosePorts.
+func ingressFirewallName(fwname string, sourceCIDRs []string) string {
+	if len(sourceCIDRs) == 1 && sourceCIDRs[0] == network.OpenToWorldCIDR {
+		return fwname
+	}
+	hash := sha256.Sum256([]byte(strings.Join(sourceCIDRs, ",")))
+	return fmt.Sprintf("%s-%x", fwname, hash[:4])
+}
+
+// ingressFirewalls returns all the firewalls derived from the given
+// base firewall name, along with the ports they open for each source
+// CIDR.
+func (gce Connection) ingressFirewalls(fwname string) ([]*compute.Firewall, map[string]network.PortSet, error) {
+	pattern := regexp.QuoteMeta(fwname) + "(-[0-9a-f]{8})?"
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, pattern)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+
+	sources := make(map[string]network.PortSet)
+	for _, firewall := range firewalls {
+		ports, err := firewallPortSet(firewall)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		for _, cidr := range firewall.SourceRanges {
+			cidrPorts, ok := sources[cidr]
+			if !ok {
+				cidrPorts = network.NewPortSet()
+				sources[cidr] = cidrPorts
+			}
+			cidrPorts.AddRanges(ports.PortRanges()...)
+		}
+	}
+	return firewalls, sources, nil
+}
+
+// firewallPortSet returns the set of ports allowed by the firewall.
+func firewallPortSet(firewall *compute.Firewall) (network.PortSet, error) {
+	ports := network.NewPortSet()
+	for _, allowed := range firewall.Allowed {
+		for _, portRangeStr := range allowed.Ports {
+			portRange, err := network.ParsePortRange(portRangeStr)
+			if err != nil {
+				return ports, errors.Annotate(err, "bad ports from GCE")
+			}
+			portRange.Protocol = allowed.IPProtocol
+			ports.AddRanges(portRange)
+		}
+	}
+	return ports, nil
+}
+
+// ingressFirewallSpecs groups the ports opened for each source CIDR
+// into one firewall for each distinct set of source CIDRs, and returns
+// the firewalls keyed by name.
+func ingressFirewallSpecs(fwname string, sources map[string]network.PortSet) map[string]*compute.Firewall {
+	portCIDRs := make(map[network.Port]set.Strings)
+	for cidr, ports := range sources {
+		for _, port := range ports.Ports() {
+			cidrs, ok := portCIDRs[port]
+			if !ok {
+				cidrs = set.NewStrings()
+				portCIDRs[port] = cidrs
+			}
+			cidrs.Add(cidr)
+		}
+	}
+
+	groups := make(map[string]network.PortSet)
+	for port, cidrs := range portCIDRs {
+		key := strings.Join(cidrs.SortedValues(), ",")
+		ports, ok := groups[key]
+		if !ok {
+			ports = network.NewPortSet()
+			groups[key] = ports
+		}
+		ports.Add(port.Protocol, port.Number)
+	}
+
+	firewalls := make(map[string]*compute.Firewall)
+	for key, ports := range groups {
+		sourceCIDRs := strings.Split(key, ",")
+		name := ingressFirewallName(fwname, sourceCIDRs)
+		firewalls[name] = ingressFirewallSpec(name, fwname, sourceCIDRs, ports)
+	}
+	return firewalls
+}
+
+// IngressRules returns the ingress rules opened by all the firewalls
+// derived from the given base firewall name (within the Connection's
+// project). If there are no such firewalls then the list will be empty
+// and no error is returned.
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	firewalls, _, err := gce.ingressFirewalls(fwname)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var rules []network.IngressRule
+	for _, firewall := range firewalls {
+		ports, err := firewallPortSet(firewall)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sourceCIDRs := set.NewStrings(firewall.SourceRanges...).SortedValues()
+		for _, portRange := range ports.PortRanges() {
+			rules = append(rules, network.IngressRule{
+				PortRange:   portRange,
+				SourceCIDRs: sourceCIDRs,
+			})
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// OpenIngressRules sends requests to the GCE API to open the provided
+// ingress rules. Ports are grouped into firewalls by the set of source
+// CIDRs allowed to access them, each firewall targeting the instances
+// tagged with the base firewall name. Firewalls are created, updated
+// and removed as needed. The call blocks until the rules are opened or
+// a request fails.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	return gce.updateIngressRules(fwname, rules, true)
+}
+
+// CloseIngressRules sends requests to the GCE API to close the provided
+// ingress rules, updating and removing the firewalls derived from the
+// base firewall name as needed. The call blocks until the rules are
+// closed or a request fails.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	return gce.updateIngressRules(fwname, rules, false)
+}
+
+func (gce Connection) updateIngressRules(fwname string, rules []network.IngressRule, open bool) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	firewalls, sources, err := gce.ingressFirewalls(fwname)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			ports, ok := sources[cidr]
+			if !ok {
+				ports = network.NewPortSet()
+				sources[cidr] = ports
+			}
+			if open {
+				ports.AddRanges(rule.PortRange)
+			} else {
+				ports.RemoveRanges(rule.PortRange)
+			}
+		}
+	}
+
+	verb := "opening"
+	if !open {
+		verb = "closing"
+	}
+	existing := make(map[string]*compute.Firewall)
+	for _, firewall := range firewalls {
+		existing[firewall.Name] = firewall
+	}
+	wanted := ingressFirewallSpecs(fwname, sources)
+	var names []string
+	for name := range wanted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		firewall := wanted[name]
+		current, ok := existing[name]
+		if !ok {
+			if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
+				return errors.Annotatef(err, "%s ingress rule(s) %v", verb, rules)
+			}
+			continue
+		}
+		if sameFirewallPorts(current, firewall) {
+			continue
+		}
+		if err := gce.raw.UpdateFirewall(gce.projectID, name, firewall); err != nil {
+			return errors.Annotatef(err, "%s ingress rule(s) %v", verb, rules)
+		}
+	}
+	for _, firewall := range firewalls {
+		if _, ok := wanted[firewall.Name]; ok {
+			continue
+		}
+		if err := gce.raw.RemoveFirewall(gce.projectID, firewall.Name); err != nil {
+			return errors.Annotatef(err, "%s ingress rule(s) %v", verb, rules)
+		}
+	}
+	return nil
+}
+
+// sameFirewallPorts reports whether the two firewalls allow access to
+// the same ports from the same sources.
+func sameFirewallPorts(a, b *compute.Firewall) bool {
+	aSources := set.NewStrings(a.SourceRanges...)
+	bSources := set.NewStrings(b.SourceRanges...)
+	if !aSources.Difference(bSources).IsEmpty() || !bSources.Difference(aSources).IsEmpty() {
+		return false
+	}
+	aPorts, err := firewallPortSet(a)
+	if err != nil {
+		return false
+	}
+	bPorts, err := firewallPortSet(b)
+	if err != nil {
+		return false
+	}
+	return aPorts.Difference(bPorts).Size() == 0 && bPorts.Difference(aPorts).Size() == 0
+}
@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
 )
 
 func (s *connSuite) TestConnectionPorts(c *gc.C) {
@@ -173,3 +174,119 @@ func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 		}},
 	})
 }
+
+func (s *connSuite) TestConnectionIngressRules(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80-81"},
+		}},
+	}, {
+		Name:         google.IngressFirewallName("spam", []string{"10.0.0.0/8", "192.168.1.0/24"}),
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"192.168.1.0/24", "10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+
+	rules, err := s.Conn.IngressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 81),
+		network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "spam(-[0-9a-f]{8})?")
+}
+
+func (s *connSuite) TestConnectionOpenIngressRulesAdd(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	}}
+
+	err := s.Conn.OpenIngressRules("spam", network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:         google.IngressFirewallName("spam", []string{"10.0.0.0/8"}),
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionOpenIngressRulesSplit(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80", "443"},
+		}},
+	}}
+
+	err := s.Conn.OpenIngressRules("spam", network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListFirewalls")
+	for _, call := range s.FakeConn.Calls[1:] {
+		switch call.FuncName {
+		case "UpdateFirewall":
+			c.Check(call.Name, gc.Equals, "spam")
+			c.Check(call.Firewall.Allowed, jc.DeepEquals, []*compute.FirewallAllowed{{
+				IPProtocol: "tcp",
+				Ports:      []string{"80"},
+			}})
+		case "AddFirewall":
+			c.Check(call.Firewall.SourceRanges, jc.DeepEquals, []string{"0.0.0.0/0", "10.0.0.0/8"})
+			c.Check(call.Firewall.Allowed, jc.DeepEquals, []*compute.FirewallAllowed{{
+				IPProtocol: "tcp",
+				Ports:      []string{"443"},
+			}})
+		default:
+			c.Errorf("unexpected call %q", call.FuncName)
+		}
+	}
+}
+
+func (s *connSuite) TestConnectionCloseIngressRulesRemove(c *gc.C) {
+	name := google.IngressFirewallName("spam", []string{"10.0.0.0/8"})
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         name,
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+
+	err := s.Conn.CloseIngressRules("spam", network.MustNewIngressRule("tcp", 443, 443, "10.0.0.0/8"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, name)
+}
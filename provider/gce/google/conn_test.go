@@ -4,6 +4,8 @@
 package google_test
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"google.golang.org/api/compute/v1"
@@ -23,8 +25,8 @@ var _ = gc.Suite(&connSuite{})
 func (s *connSuite) TestConnect(c *gc.C) {
 	google.SetRawConn(s.Conn, nil)
 	service := &compute.Service{}
-	s.PatchValue(google.NewRawConnection, func(auth *google.Credentials) (*compute.Service, error) {
-		return service, nil
+	s.PatchValue(google.NewRawConnection, func(auth *google.Credentials) (*compute.Service, *http.Client, error) {
+		return service, nil, nil
 	})
 
 	conn, err := google.Connect(s.ConnCfg, s.Credentials)
@@ -4,6 +4,8 @@
 package google
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"path"
@@ -39,6 +41,10 @@ func convertRawAPIError(err error) error {
 
 type rawConn struct {
 	*compute.Service
+
+	// client is the HTTP client used by the compute service, for
+	// requests that are not supported by the compute package.
+	client *http.Client
 }
 
 func (rc *rawConn) GetProject(projectID string) (*compute.Project, error) {
@@ -129,6 +135,16 @@ func (rc *rawConn) GetFirewall(projectID, name string) (*compute.Firewall, error
 	return firewallList.Items[0], nil
 }
 
+func (rc *rawConn) ListFirewalls(projectID, namePattern string) ([]*compute.Firewall, error) {
+	call := rc.Firewalls.List(projectID)
+	call = call.Filter("name eq " + namePattern)
+	firewallList, err := call.Do()
+	if err != nil {
+		return nil, errors.Annotate(err, "while listing firewalls from GCE")
+	}
+	return firewallList.Items, nil
+}
+
 func (rc *rawConn) AddFirewall(projectID string, firewall *compute.Firewall) error {
 	call := rc.Firewalls.Insert(projectID, firewall)
 	operation, err := call.Do()
@@ -249,6 +265,34 @@ func (rc *rawConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
 	return disk, nil
 }
 
+func (rc *rawConn) ResizeDisk(project, zone, id string, sizeGB int64) error {
+	// The version of the compute package in use does not support
+	// resizing disks, so we must send the request ourselves.
+	body, err := json.Marshal(map[string]int64{"sizeGb": sizeGB})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	url := rc.BasePath + path.Join(project, "zones", zone, "disks", id, "resize")
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", id)
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", id)
+	}
+	var op compute.Operation
+	if err := json.NewDecoder(resp.Body).Decode(&op); err != nil {
+		return errors.Annotate(err, "decoding resize operation")
+	}
+	return errors.Trace(rc.waitOperation(project, &op, attemptsLong))
+}
+
 func (rc *rawConn) AttachDisk(project, zone, instanceId string, disk *compute.AttachedDisk) error {
 	call := rc.Instances.AttachDisk(project, zone, instanceId, disk)
 	_, err := call.Do() // Perhaps return something from the Op
@@ -35,7 +35,7 @@ func (s *rawConnSuite) SetUpTest(c *gc.C) {
 	service.ZoneOperations = compute.NewZoneOperationsService(service)
 	service.RegionOperations = compute.NewRegionOperationsService(service)
 	service.GlobalOperations = compute.NewGlobalOperationsService(service)
-	s.rawConn = &rawConn{service}
+	s.rawConn = &rawConn{Service: service}
 	s.strategy.Min = 4
 
 	s.callCount = 0
@@ -17,6 +17,7 @@ type rawProvider struct {
 	lxdInstances
 	lxdProfiles
 	lxdImages
+	lxdStorage
 	common.Firewaller
 	policyProvider
 }
@@ -37,6 +38,15 @@ type lxdImages interface {
 	EnsureImageExists(series string, sources []lxdclient.Remote, copyProgressHandler func(string)) error
 }
 
+type lxdStorage interface {
+	EnsureStoragePool(name, driver string, config map[string]string) error
+	StorageVolume(pool, volume string) (lxdclient.StorageVolume, error)
+	CreateStorageVolume(pool, volume string, config map[string]string) error
+	DestroyStorageVolume(pool, volume string) error
+	AttachDisk(container, device string, disk lxdclient.DiskDevice) error
+	DetachDisk(container, device string) error
+}
+
 func newRawProvider(ecfg *environConfig) (*rawProvider, error) {
 	client, err := newClient(ecfg)
 	if err != nil {
@@ -54,6 +64,7 @@ func newRawProvider(ecfg *environConfig) (*rawProvider, error) {
 		lxdInstances:   client,
 		lxdProfiles:    client,
 		lxdImages:      client,
+		lxdStorage:     client,
 		Firewaller:     firewaller,
 		policyProvider: policy,
 	}
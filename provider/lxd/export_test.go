@@ -7,6 +7,7 @@ package lxd
 
 import (
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/tools/lxdclient"
 )
 
@@ -39,3 +40,7 @@ func ExposeEnvClient(env *environ) lxdInstances {
 func GetImageSources(env *environ) ([]lxdclient.Remote, error) {
 	return env.getImageSources()
 }
+
+func NewFilesystemSource(env *environ) storage.FilesystemSource {
+	return &lxdFilesystemSource{env}
+}
@@ -0,0 +1,318 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+const (
+	lxdStorageProviderType = storage.ProviderType("lxd")
+
+	// attrLXDStorageDriver is the attribute name for the
+	// storage pool's LXD storage driver. This and "lxd-pool"
+	// are the only predefined storage pool attributes; all
+	// others are passed on to LXD directly.
+	attrLXDStorageDriver = "driver"
+
+	// attrLXDStoragePool is the attribute name for the
+	// storage pool's corresponding LXD storage pool name.
+	// If this is not provided, the LXD storage pool name
+	// will be "juju".
+	attrLXDStoragePool = "lxd-pool"
+
+	defaultLXDStorageDriver = "dir"
+	defaultLXDStoragePool   = "juju"
+)
+
+func init() {
+	zfsPool, _ := storage.NewConfig("lxd-zfs", lxdStorageProviderType, map[string]interface{}{
+		attrLXDStorageDriver: "zfs",
+		attrLXDStoragePool:   "juju-zfs",
+		"zfs.pool_name":      "juju-lxd",
+	})
+	btrfsPool, _ := storage.NewConfig("lxd-btrfs", lxdStorageProviderType, map[string]interface{}{
+		attrLXDStorageDriver: "btrfs",
+		attrLXDStoragePool:   "juju-btrfs",
+	})
+	poolmanager.RegisterDefaultStoragePools([]*storage.Config{zfsPool, btrfsPool})
+}
+
+// lxdStorageProvider creates filesystem sources which use custom
+// volumes in LXD storage pools. Custom volumes are attached to
+// containers as disk devices, and outlive the containers.
+type lxdStorageProvider struct{}
+
+var _ storage.Provider = (*lxdStorageProvider)(nil)
+
+var lxdStorageConfigFields = schema.Fields{
+	attrLXDStorageDriver: schema.OneOf(
+		schema.Const("dir"),
+		schema.Const("zfs"),
+		schema.Const("btrfs"),
+	),
+	attrLXDStoragePool: schema.String(),
+}
+
+var lxdStorageConfigChecker = schema.FieldMap(
+	lxdStorageConfigFields,
+	schema.Defaults{
+		attrLXDStorageDriver: defaultLXDStorageDriver,
+		attrLXDStoragePool:   defaultLXDStoragePool,
+	},
+)
+
+type lxdStorageConfig struct {
+	lxdPool    string
+	driver     string
+	attributes map[string]string
+}
+
+func newLXDStorageConfig(attrs map[string]interface{}) (*lxdStorageConfig, error) {
+	coerced, err := lxdStorageConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating LXD storage config")
+	}
+	out := coerced.(map[string]interface{})
+	lxdStorageConfig := &lxdStorageConfig{
+		lxdPool:    out[attrLXDStoragePool].(string),
+		driver:     out[attrLXDStorageDriver].(string),
+		attributes: make(map[string]string),
+	}
+	if lxdStorageConfig.lxdPool == "" {
+		return nil, errors.NotValidf("empty %q", attrLXDStoragePool)
+	}
+	for k, v := range attrs {
+		if _, ok := lxdStorageConfigFields[k]; ok {
+			continue
+		}
+		lxdStorageConfig.attributes[k] = fmt.Sprint(v)
+	}
+	return lxdStorageConfig, nil
+}
+
+// ValidateConfig is defined on the storage.Provider interface.
+func (e *lxdStorageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newLXDStorageConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// Supports is defined on the storage.Provider interface.
+func (e *lxdStorageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the storage.Provider interface.
+func (e *lxdStorageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the storage.Provider interface.
+func (e *lxdStorageProvider) Dynamic() bool {
+	return true
+}
+
+// VolumeSource is defined on the storage.Provider interface.
+func (e *lxdStorageProvider) VolumeSource(environConfig *config.Config, cfg *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the storage.Provider interface.
+func (e *lxdStorageProvider) FilesystemSource(environConfig *config.Config, cfg *storage.Config) (storage.FilesystemSource, error) {
+	env, err := newEnviron(environConfig, newRawProvider)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating environ")
+	}
+	return &lxdFilesystemSource{env}, nil
+}
+
+type lxdFilesystemSource struct {
+	env *environ
+}
+
+var _ storage.FilesystemSource = (*lxdFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the storage.FilesystemSource interface.
+func (s *lxdFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// Sizes are only enforced by the zfs and btrfs drivers; the
+	// dir driver creates volumes that may grow to fill the pool,
+	// and so is not given the size.
+	_, err := newLXDStorageConfig(params.Attributes)
+	return errors.Trace(err)
+}
+
+// CreateFilesystems is defined on the storage.FilesystemSource interface.
+func (s *lxdFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	ensuredPools := make(set.Strings)
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg, ensuredPools)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating filesystem %s", arg.Tag.Id())
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) createFilesystem(
+	arg storage.FilesystemParams,
+	ensuredPools set.Strings,
+) (*storage.Filesystem, error) {
+	cfg, err := newLXDStorageConfig(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !ensuredPools.Contains(cfg.lxdPool) {
+		if err := s.env.raw.EnsureStoragePool(cfg.lxdPool, cfg.driver, cfg.attributes); err != nil {
+			return nil, errors.Annotatef(err, "ensuring LXD storage pool %q", cfg.lxdPool)
+		}
+		ensuredPools.Add(cfg.lxdPool)
+	}
+
+	volumeName := s.env.namespace.Prefix() + arg.Tag.String()
+	config := make(map[string]string)
+	if cfg.driver != "dir" {
+		// The dir driver does not support volume quotas, and
+		// rejects volumes with a size.
+		config["size"] = fmt.Sprintf("%dMiB", arg.Size)
+	}
+	for k, v := range arg.ResourceTags {
+		config["user."+k] = v
+	}
+
+	// The volume may have been created by an earlier attempt whose
+	// result was not recorded; if so, it will have the same tags.
+	existing, err := s.env.raw.StorageVolume(cfg.lxdPool, volumeName)
+	if err == nil {
+		if !jujuTagsMatch(existing.Config, config) {
+			return nil, errors.Errorf("LXD volume %q already exists with different tags", volumeName)
+		}
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	} else if err := s.env.raw.CreateStorageVolume(cfg.lxdPool, volumeName, config); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.Filesystem{
+		Tag: arg.Tag,
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: cfg.lxdPool + ":" + volumeName,
+			Size:         arg.Size,
+		},
+	}, nil
+}
+
+// jujuTagsMatch reports whether the Juju resource tags in the existing
+// volume config match those in the desired config.
+func jujuTagsMatch(existing, desired map[string]string) bool {
+	for k, v := range desired {
+		if strings.HasPrefix(k, "user.juju-") && existing[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// DestroyFilesystems is defined on the storage.FilesystemSource interface.
+func (s *lxdFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	results := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		results[i] = s.destroyFilesystem(filesystemId)
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) destroyFilesystem(filesystemId string) error {
+	poolName, volumeName, err := parseFilesystemId(filesystemId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.env.raw.DestroyStorageVolume(poolName, volumeName)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "destroying LXD volume %q", filesystemId)
+	}
+	return nil
+}
+
+// AttachFilesystems is defined on the storage.FilesystemSource interface.
+func (s *lxdFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(
+				err, "attaching filesystem %s to machine %s",
+				arg.Filesystem.Id(), arg.Machine.Id(),
+			)
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lxdFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	poolName, volumeName, err := parseFilesystemId(arg.FilesystemId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := s.env.raw.AttachDisk(string(arg.InstanceId), volumeName, lxdclient.DiskDevice{
+		Pool:     poolName,
+		Source:   volumeName,
+		Path:     arg.Path,
+		ReadOnly: arg.ReadOnly,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.FilesystemAttachment{
+		Filesystem: arg.Filesystem,
+		Machine:    arg.Machine,
+		FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+			Path:     arg.Path,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the storage.FilesystemSource interface.
+func (s *lxdFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		_, volumeName, err := parseFilesystemId(arg.FilesystemId)
+		if err == nil {
+			err = s.env.raw.DetachDisk(string(arg.InstanceId), volumeName)
+		}
+		if err != nil {
+			results[i] = errors.Annotatef(
+				err, "detaching filesystem %s from machine %s",
+				arg.Filesystem.Id(), arg.Machine.Id(),
+			)
+		}
+	}
+	return results, nil
+}
+
+// parseFilesystemId parses a filesystem ID of the form
+// "<lxd-pool>:<volume-name>", returning the LXD storage pool
+// and custom volume names.
+func parseFilesystemId(id string) (string, string, error) {
+	fields := strings.SplitN(id, ":", 2)
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		return "", "", errors.NotValidf("filesystem ID %q", id)
+	}
+	return fields[0], fields[1], nil
+}
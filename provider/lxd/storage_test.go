@@ -0,0 +1,302 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/registry"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type storageSuite struct {
+	lxd.BaseSuite
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) filesystemSource() storage.FilesystemSource {
+	return lxd.NewFilesystemSource(s.Env)
+}
+
+func (s *storageSuite) TestStorageProvider(c *gc.C) {
+	c.Assert(registry.IsProviderSupported("lxd", "lxd"), jc.IsTrue)
+	provider, err := registry.StorageProvider("lxd")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(provider.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(provider.Dynamic(), jc.IsTrue)
+	_, err = provider.VolumeSource(nil, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageSuite) TestValidateConfig(c *gc.C) {
+	provider, err := registry.StorageProvider("lxd")
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, driver := range []string{"dir", "zfs", "btrfs"} {
+		cfg, err := storage.NewConfig("pool", "lxd", map[string]interface{}{
+			"driver": driver,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(provider.ValidateConfig(cfg), jc.ErrorIsNil)
+	}
+
+	cfg, err := storage.NewConfig("pool", "lxd", map[string]interface{}{
+		"driver": "lvm",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating LXD storage config: driver: unexpected value "lvm"`)
+
+	cfg, err = storage.NewConfig("pool", "lxd", map[string]interface{}{
+		"lxd-pool": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `empty "lxd-pool" not valid`)
+}
+
+func (s *storageSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.filesystemSource()
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:      names.NewFilesystemTag("0"),
+		Size:     1024,
+		Provider: "lxd",
+		Attributes: map[string]interface{}{
+			"driver":        "zfs",
+			"lxd-pool":      "juju-zfs",
+			"zfs.pool_name": "juju-lxd",
+		},
+		ResourceTags: map[string]string{"juju-model-uuid": "foo"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	volumeName := s.Prefix() + "filesystem-0"
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("0"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "juju-zfs:" + volumeName,
+				Size:         1024,
+			},
+		},
+	}})
+	s.Stub.CheckCallNames(c, "EnsureStoragePool", "StorageVolume", "CreateStorageVolume")
+	s.Stub.CheckCall(c, 0, "EnsureStoragePool", "juju-zfs", "zfs", map[string]string{
+		"zfs.pool_name": "juju-lxd",
+	})
+	s.Stub.CheckCall(c, 1, "StorageVolume", "juju-zfs", volumeName)
+	s.Stub.CheckCall(c, 2, "CreateStorageVolume", "juju-zfs", volumeName, map[string]string{
+		"size":                 "1024MiB",
+		"user.juju-model-uuid": "foo",
+	})
+}
+
+func (s *storageSuite) TestCreateFilesystemsDefaults(c *gc.C) {
+	source := s.filesystemSource()
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:      names.NewFilesystemTag("0"),
+		Size:     1024,
+		Provider: "lxd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Filesystem.FilesystemId, gc.Equals, "juju:"+s.Prefix()+"filesystem-0")
+	s.Stub.CheckCall(c, 0, "EnsureStoragePool", "juju", "dir", map[string]string{})
+	// The dir driver does not support sizes.
+	s.Stub.CheckCall(c, 2, "CreateStorageVolume", "juju", s.Prefix()+"filesystem-0", map[string]string{})
+}
+
+func (s *storageSuite) TestCreateFilesystemsAlreadyCreated(c *gc.C) {
+	s.Client.Volume = &lxdclient.StorageVolume{
+		Config: map[string]string{
+			"size":                 "1024MiB",
+			"user.juju-model-uuid": "foo",
+		},
+	}
+	source := s.filesystemSource()
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:          names.NewFilesystemTag("0"),
+		Size:         1024,
+		Provider:     "lxd",
+		Attributes:   map[string]interface{}{"driver": "zfs"},
+		ResourceTags: map[string]string{"juju-model-uuid": "foo"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Filesystem.FilesystemId, gc.Equals, "juju:"+s.Prefix()+"filesystem-0")
+	s.Stub.CheckCallNames(c, "EnsureStoragePool", "StorageVolume")
+}
+
+func (s *storageSuite) TestCreateFilesystemsAlreadyExistsWithDifferentTags(c *gc.C) {
+	s.Client.Volume = &lxdclient.StorageVolume{
+		Config: map[string]string{"user.juju-model-uuid": "bar"},
+	}
+	source := s.filesystemSource()
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:          names.NewFilesystemTag("0"),
+		Size:         1024,
+		Provider:     "lxd",
+		ResourceTags: map[string]string{"juju-model-uuid": "foo"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating filesystem 0: LXD volume ".*filesystem-0" already exists with different tags`)
+	s.Stub.CheckCallNames(c, "EnsureStoragePool", "StorageVolume")
+}
+
+func (s *storageSuite) TestCreateFilesystemsEnsuresPoolOnce(c *gc.C) {
+	source := s.filesystemSource()
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:      names.NewFilesystemTag("0"),
+		Size:     1024,
+		Provider: "lxd",
+	}, {
+		Tag:      names.NewFilesystemTag("1"),
+		Size:     1024,
+		Provider: "lxd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "EnsureStoragePool",
+		"StorageVolume", "CreateStorageVolume",
+		"StorageVolume", "CreateStorageVolume",
+	)
+}
+
+func (s *storageSuite) TestCreateFilesystemsPoolError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	source := s.filesystemSource()
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:      names.NewFilesystemTag("0"),
+		Size:     1024,
+		Provider: "lxd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating filesystem 0: ensuring LXD storage pool "juju": boom`)
+	s.Stub.CheckCallNames(c, "EnsureStoragePool")
+}
+
+func (s *storageSuite) TestCreateFilesystemsVolumeError(c *gc.C) {
+	s.Stub.SetErrors(nil, nil, errors.New("boom"))
+	source := s.filesystemSource()
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:      names.NewFilesystemTag("0"),
+		Size:     1024,
+		Provider: "lxd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating filesystem 0: boom")
+}
+
+func (s *storageSuite) TestDestroyFilesystems(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.NotFoundf("volume"), errors.New("boom"))
+	source := s.filesystemSource()
+	results, err := source.DestroyFilesystems([]string{
+		"juju:vol0", "juju:vol1", "juju:vol2", "invalid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 4)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches, `destroying LXD volume "juju:vol2": boom`)
+	c.Assert(results[3], gc.ErrorMatches, `filesystem ID "invalid" not valid`)
+	s.Stub.CheckCallNames(c, "DestroyStorageVolume", "DestroyStorageVolume", "DestroyStorageVolume")
+	s.Stub.CheckCall(c, 0, "DestroyStorageVolume", "juju", "vol0")
+}
+
+func (s *storageSuite) TestAttachFilesystems(c *gc.C) {
+	source := s.filesystemSource()
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "lxd",
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: instance.Id("juju-machine-0"),
+			ReadOnly:   true,
+		},
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "juju:vol0",
+		Path:         "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0"),
+			Machine:    names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     "/srv",
+				ReadOnly: true,
+			},
+		},
+	}})
+	s.Stub.CheckCallNames(c, "AttachDisk")
+	s.Stub.CheckCall(c, 0, "AttachDisk", "juju-machine-0", "vol0", lxdclient.DiskDevice{
+		Pool:     "juju",
+		Source:   "vol0",
+		Path:     "/srv",
+		ReadOnly: true,
+	})
+}
+
+func (s *storageSuite) TestAttachFilesystemsError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	source := s.filesystemSource()
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "lxd",
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: instance.Id("juju-machine-0"),
+		},
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "juju:vol0",
+		Path:         "/srv",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "attaching filesystem 0 to machine 0: boom")
+}
+
+func (s *storageSuite) TestDetachFilesystems(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.New("boom"))
+	source := s.filesystemSource()
+	results, err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "lxd",
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: instance.Id("juju-machine-0"),
+		},
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "juju:vol0",
+	}, {
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   "lxd",
+			Machine:    names.NewMachineTag("1"),
+			InstanceId: instance.Id("juju-machine-1"),
+		},
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: "juju:vol1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, "detaching filesystem 1 from machine 1: boom")
+	s.Stub.CheckCallNames(c, "DetachDisk", "DetachDisk")
+	s.Stub.CheckCall(c, 0, "DetachDisk", "juju-machine-0", "vol0")
+	s.Stub.CheckCall(c, 1, "DetachDisk", "juju-machine-1", "vol1")
+}
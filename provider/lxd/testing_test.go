@@ -314,6 +314,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	s.Env.raw = &rawProvider{
 		lxdInstances:   s.Client,
 		lxdImages:      s.Client,
+		lxdStorage:     s.Client,
 		Firewaller:     s.Firewaller,
 		policyProvider: s.Policy,
 	}
@@ -490,8 +491,9 @@ func (s *stubPolicy) SupportedArchitectures() ([]string, error) {
 type StubClient struct {
 	*gitjujutesting.Stub
 
-	Insts []lxdclient.Instance
-	Inst  *lxdclient.Instance
+	Insts  []lxdclient.Instance
+	Inst   *lxdclient.Instance
+	Volume *lxdclient.StorageVolume
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -543,6 +545,63 @@ func (conn *StubClient) Addresses(name string) ([]network.Address, error) {
 	}}, nil
 }
 
+func (conn *StubClient) EnsureStoragePool(name, driver string, config map[string]string) error {
+	conn.AddCall("EnsureStoragePool", name, driver, config)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (conn *StubClient) StorageVolume(pool, volume string) (lxdclient.StorageVolume, error) {
+	conn.AddCall("StorageVolume", pool, volume)
+	if err := conn.NextErr(); err != nil {
+		return lxdclient.StorageVolume{}, errors.Trace(err)
+	}
+	if conn.Volume == nil {
+		return lxdclient.StorageVolume{}, errors.NotFoundf("storage volume %q", volume)
+	}
+
+	return *conn.Volume, nil
+}
+
+func (conn *StubClient) CreateStorageVolume(pool, volume string, config map[string]string) error {
+	conn.AddCall("CreateStorageVolume", pool, volume, config)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (conn *StubClient) DestroyStorageVolume(pool, volume string) error {
+	conn.AddCall("DestroyStorageVolume", pool, volume)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (conn *StubClient) AttachDisk(container, device string, disk lxdclient.DiskDevice) error {
+	conn.AddCall("AttachDisk", container, device, disk)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (conn *StubClient) DetachDisk(container, device string) error {
+	conn.AddCall("DetachDisk", container, device)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 // TODO(ericsnow) Move stubFirewaller to environs/testing or provider/common/testing.
 
 type stubFirewaller struct {
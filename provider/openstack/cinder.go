@@ -5,13 +5,17 @@ package openstack
 
 import (
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"gopkg.in/goose.v1/cinder"
+	"gopkg.in/goose.v1/client"
+	goosehttp "gopkg.in/goose.v1/http"
 	"gopkg.in/goose.v1/identity"
 	"gopkg.in/goose.v1/nova"
 
@@ -38,6 +42,7 @@ type cinderProvider struct {
 }
 
 var _ storage.Provider = (*cinderProvider)(nil)
+var _ storage.ResizeSupporter = (*cinderProvider)(nil)
 
 var cinderAttempt = utils.AttemptStrategy{
 	Total: 1 * time.Minute,
@@ -92,6 +97,14 @@ func (p *cinderProvider) Dynamic() bool {
 	return true
 }
 
+// SupportsResize is defined on the storage.ResizeSupporter interface.
+//
+// Cinder can only extend volumes that are not attached to a server,
+// so resizing an attached volume fails with a NotSupported error.
+func (p *cinderProvider) SupportsResize() bool {
+	return true
+}
+
 type cinderVolumeSource struct {
 	storageAdapter openstackStorage
 	envName        string // non unique, informational only
@@ -99,6 +112,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeImporter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -209,6 +223,29 @@ func (s *cinderVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.Desc
 	return results, nil
 }
 
+// ImportVolume implements storage.VolumeImporter.
+func (s *cinderVolumeSource) ImportVolume(volumeId string, resourceTags map[string]string, force bool) (storage.VolumeInfo, error) {
+	volume, err := s.storageAdapter.GetVolume(volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "getting volume")
+	}
+	if volume.Status != volumeStatusAvailable {
+		return storage.VolumeInfo{}, errors.Errorf(
+			"cannot import volume %q with status %q", volumeId, volume.Status,
+		)
+	}
+	owner := volume.Metadata[tags.JujuModel]
+	if err := storage.ValidateImportOwner(volumeId, owner, s.modelUUID, force); err != nil {
+		return storage.VolumeInfo{}, errors.Trace(err)
+	}
+	if len(resourceTags) > 0 {
+		if err := s.storageAdapter.SetVolumeMetadata(volumeId, resourceTags); err != nil {
+			return storage.VolumeInfo{}, errors.Annotate(err, "tagging volume")
+		}
+	}
+	return cinderToJujuVolumeInfo(volume), nil
+}
+
 // DestroyVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
 	return destroyVolumes(s.storageAdapter, volumeIds), nil
@@ -357,6 +394,42 @@ func (s *cinderVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams
 	return detachVolumes(s.storageAdapter, args)
 }
 
+// ResizeVolumes is specified on the storage.VolumeSource interface.
+func (s *cinderVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.VolumeId)
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (*storage.Volume, error) {
+	cinderVolume, err := s.storageAdapter.GetVolume(arg.VolumeId)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume")
+	}
+	size := int(math.Ceil(float64(arg.Size) / 1024))
+	if cinderVolume.Size < size {
+		// The version of the volume API in use cannot extend
+		// volumes that are attached to a server. Detaching the
+		// volume would disrupt the unit using it, so we refuse
+		// rather than letting Cinder reject the request.
+		if len(cinderVolume.Attachments) > 0 || cinderVolume.Status == volumeStatusInUse {
+			return nil, errors.NotSupportedf("resizing attached Cinder volume %s", arg.VolumeId)
+		}
+		if err := s.storageAdapter.ExtendVolume(arg.VolumeId, size); err != nil {
+			return nil, errors.Trace(err)
+		}
+		cinderVolume.Size = size
+	}
+	return &storage.Volume{arg.Tag, cinderToJujuVolumeInfo(cinderVolume)}, nil
+}
+
 func detachVolumes(storageAdapter openstackStorage, args []storage.VolumeAttachmentParams) ([]error, error) {
 	results := make([]error, len(args))
 	for i, arg := range args {
@@ -415,6 +488,8 @@ type openstackStorage interface {
 	GetVolumesDetail() ([]cinder.Volume, error)
 	DeleteVolume(volumeId string) error
 	CreateVolume(cinder.CreateVolumeVolumeParams) (*cinder.Volume, error)
+	SetVolumeMetadata(volumeId string, metadata map[string]string) error
+	ExtendVolume(volumeId string, size int) error
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
@@ -425,17 +500,26 @@ type endpointResolver interface {
 }
 
 func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, error) {
+	serviceType, err := volumeServiceType(client, region)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return url.Parse(client.EndpointsForRegion(region)[serviceType])
+}
+
+// volumeServiceType returns the type of the volume service in the
+// region's service catalog, preferring the v2 API.
+func volumeServiceType(client endpointResolver, region string) (string, error) {
 	endpointMap := client.EndpointsForRegion(region)
 	// The cinder openstack charm appends 'v2' to the type for the v2 api.
-	endpoint, ok := endpointMap["volumev2"]
-	if !ok {
-		logger.Debugf(`endpoint "volumev2" not found for %q region, trying "volume"`, region)
-		endpoint, ok = endpointMap["volume"]
-		if !ok {
-			return nil, errors.NotFoundf(`endpoint "volume" in region %q`, region)
-		}
+	if _, ok := endpointMap["volumev2"]; ok {
+		return "volumev2", nil
 	}
-	return url.Parse(endpoint)
+	logger.Debugf(`endpoint "volumev2" not found for %q region, trying "volume"`, region)
+	if _, ok := endpointMap["volume"]; ok {
+		return "volume", nil
+	}
+	return "", errors.NotFoundf(`endpoint "volume" in region %q`, region)
 }
 
 func newOpenstackStorageAdapter(environConfig *config.Config) (openstackStorage, error) {
@@ -457,16 +541,22 @@ func newOpenstackStorageAdapter(environConfig *config.Config) (openstackStorage,
 		}
 		return nil, errors.Annotate(err, "getting volume endpoint")
 	}
+	serviceType, err := volumeServiceType(client, ecfg.region())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	return &openstackStorageAdapter{
 		cinderClient{cinder.Basic(endpointUrl, client.TenantId(), client.Token)},
 		novaClient{nova.New(client)},
+		volumeActionsClient{client, serviceType},
 	}, nil
 }
 
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	volumeActionsClient
 }
 
 type cinderClient struct {
@@ -477,6 +567,60 @@ type novaClient struct {
 	*nova.Client
 }
 
+// volumeActionsClient sets the metadata of, and extends, Cinder
+// volumes, which the goose Cinder client does not support. Requests
+// are sent with the authenticating client, so that they are made with
+// its TLS settings and reauthenticated when the token expires.
+type volumeActionsClient struct {
+	client      client.AuthenticatingClient
+	serviceType string
+}
+
+// SetVolumeMetadata is part of the openstackStorage interface. The
+// given metadata items are added to the volume's existing metadata.
+func (c volumeActionsClient) SetVolumeMetadata(volumeId string, metadata map[string]string) error {
+	body := map[string]interface{}{"metadata": metadata}
+	if err := c.post(volumeAPICall(volumeId, "metadata"), body, http.StatusOK); err != nil {
+		return errors.Annotatef(err, "setting metadata for volume %q", volumeId)
+	}
+	return nil
+}
+
+// ExtendVolume is part of the openstackStorage interface. The volume
+// is extended to the given size, in GiB.
+func (c volumeActionsClient) ExtendVolume(volumeId string, size int) error {
+	body := map[string]interface{}{
+		"os-extend": map[string]interface{}{"new_size": size},
+	}
+	if err := c.post(volumeAPICall(volumeId, "action"), body, http.StatusAccepted); err != nil {
+		return errors.Annotatef(err, "extending volume %q", volumeId)
+	}
+	return nil
+}
+
+// post sends a POST request with the given JSON body to the API call,
+// relative to the volume endpoint, and checks the response status.
+func (c volumeActionsClient) post(apiCall string, body interface{}, expectStatus int) error {
+	requestData := goosehttp.RequestData{
+		ReqValue:       body,
+		ExpectedStatus: []int{expectStatus},
+	}
+	err := c.client.SendRequest(client.POST, c.serviceType, apiCall, &requestData)
+	return errors.Trace(err)
+}
+
+// volumeAPICall returns the API call, relative to the volume endpoint,
+// for the given volume's sub-resource. The volume ID is escaped, so
+// that it cannot refer to any other resource.
+func volumeAPICall(volumeId, resource string) string {
+	escapedId := (&url.URL{Path: volumeId}).EscapedPath()
+	escapedId = strings.Replace(escapedId, "/", "%2F", -1)
+	if escapedId == "." || escapedId == ".." {
+		escapedId = strings.Replace(escapedId, ".", "%2E", -1)
+	}
+	return "volumes/" + escapedId + "/" + resource
+}
+
 // CreateVolume is part of the openstackStorage interface.
 func (ga *openstackStorageAdapter) CreateVolume(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
 	resp, err := ga.cinderClient.CreateVolume(args)
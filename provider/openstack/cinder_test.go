@@ -256,6 +256,68 @@ func (s *cinderVolumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	}})
 }
 
+func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   mockVolSize / 1024,
+				Status: "available",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeImporter))
+	tags := map[string]string{"a": "b"}
+	info, err := volSource.(storage.VolumeImporter).ImportVolume(mockVolId, tags, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId:   mockVolId,
+		Size:       mockVolSize,
+		Persistent: true,
+	})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"SetVolumeMetadata", []interface{}{mockVolId, tags}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Status: "in-use",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	_, err := volSource.(storage.VolumeImporter).ImportVolume(mockVolId, nil, false)
+	c.Assert(err, gc.ErrorMatches, `cannot import volume "0" with status "in-use"`)
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestImportVolumeOtherModel(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:       volumeId,
+				Status:   "available",
+				Metadata: map[string]string{"juju-model-uuid": "other-model-uuid"},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	_, err := volSource.(storage.VolumeImporter).ImportVolume(mockVolId, map[string]string{"a": "b"}, false)
+	c.Assert(err, gc.ErrorMatches, `volume "0" belongs to model "other-model-uuid", .*`)
+	mockAdapter.CheckCallNames(c, "GetVolume")
+
+	mockAdapter.ResetCalls()
+	_, err = volSource.(storage.VolumeImporter).ImportVolume(mockVolId, map[string]string{"a": "b"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	mockAdapter.CheckCallNames(c, "GetVolume", "SetVolumeMetadata")
+}
+
 func (s *cinderVolumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	mockAdapter := &mockAdapter{}
 	volSource := openstack.NewCinderVolumeSource(mockAdapter)
@@ -432,6 +494,102 @@ func (s *cinderVolumeSourceSuite) TestCreateVolumeCleanupDestroys(c *gc.C) {
 	c.Assert(numDestroyCalls, gc.Equals, 1)
 }
 
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   1,
+				Status: "available",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     1536,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		Volume: &storage.Volume{mockVolumeTag, storage.VolumeInfo{
+			VolumeId:   mockVolId,
+			Size:       2048,
+			Persistent: true,
+		}},
+	}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"ExtendVolume", []interface{}{mockVolId, 2}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesAlreadyResized(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   2,
+				Status: "available",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.Size, gc.Equals, uint64(2048))
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesError(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		extendVolume: func(volumeId string, size int) error {
+			return errors.New("quota exceeded")
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume 0: quota exceeded`)
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesAttached(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   1,
+				Status: "in-use",
+				Attachments: []cinder.VolumeAttachment{{
+					ServerId: mockServerId,
+				}},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume 0: resizing attached Cinder volume 0 not supported`)
+	c.Assert(errors.Cause(results[0].Error), jc.Satisfies, errors.IsNotSupported)
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
 type mockAdapter struct {
 	gitjujutesting.Stub
 	getVolume             func(string) (*cinder.Volume, error)
@@ -442,6 +600,8 @@ type mockAdapter struct {
 	volumeStatusNotifier  func(string, string, int, time.Duration) <-chan error
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
+	setVolumeMetadata     func(string, map[string]string) error
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -503,6 +663,22 @@ func (ma *mockAdapter) ListVolumeAttachments(serverId string) ([]nova.VolumeAtta
 	return nil, nil
 }
 
+func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]string) error {
+	ma.MethodCall(ma, "SetVolumeMetadata", volumeId, metadata)
+	if ma.setVolumeMetadata != nil {
+		return ma.setVolumeMetadata(volumeId, metadata)
+	}
+	return nil
+}
+
+func (ma *mockAdapter) ExtendVolume(volumeId string, size int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, size)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, size)
+	}
+	return nil
+}
+
 type testEndpointResolver struct {
 	regionEndpoints map[string]identity.ServiceURLs
 }
@@ -557,3 +733,35 @@ func (s *cinderVolumeSourceSuite) TestGetVolumeEndpointBadURL(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `parse some %4: .*`)
 	c.Assert(url, gc.IsNil)
 }
+
+func (s *cinderVolumeSourceSuite) TestVolumeServiceType(c *gc.C) {
+	client := testEndpointResolver{regionEndpoints: map[string]identity.ServiceURLs{
+		"west": map[string]string{"volume": "http://cinder.testing/v1"},
+		"south": map[string]string{
+			"volume":   "http://cinder.testing/v1",
+			"volumev2": "http://cinder.testing/v2",
+		},
+	}}
+	serviceType, err := openstack.VolumeServiceType(client, "west")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serviceType, gc.Equals, "volume")
+	serviceType, err = openstack.VolumeServiceType(client, "south")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serviceType, gc.Equals, "volumev2")
+	_, err = openstack.VolumeServiceType(client, "east")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *cinderVolumeSourceSuite) TestVolumeAPICallEscapesVolumeId(c *gc.C) {
+	for _, test := range []struct {
+		volumeId string
+		expected string
+	}{
+		{"0c7b7a3e-4f7c-4f0c-a1d1-6e0fd5f6c7e5", "volumes/0c7b7a3e-4f7c-4f0c-a1d1-6e0fd5f6c7e5/metadata"},
+		{"../../servers/x", "volumes/..%2F..%2Fservers%2Fx/metadata"},
+		{"a b?c#d", "volumes/a%20b%3Fc%23d/metadata"},
+		{"..", "volumes/%2E%2E/metadata"},
+	} {
+		c.Check(openstack.VolumeAPICall(test.volumeId, "metadata"), gc.Equals, test.expected)
+	}
+}
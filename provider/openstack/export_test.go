@@ -494,9 +494,12 @@ func ResolveNetwork(e environs.Environ, networkName string) (string, error) {
 }
 
 var PortsToRuleInfo = portsToRuleInfo
+var IngressRulesToRuleInfo = ingressRulesToRuleInfo
 var RuleMatchesPortRange = ruleMatchesPortRange
 
 var MakeServiceURL = &makeServiceURL
 var ProviderInstance = providerInstance
 
 var GetVolumeEndpointURL = getVolumeEndpointURL
+var VolumeServiceType = volumeServiceType
+var VolumeAPICall = volumeAPICall
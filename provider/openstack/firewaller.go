@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	gooseerrors "gopkg.in/goose.v1/errors"
 	"gopkg.in/goose.v1/nova"
 
@@ -38,6 +39,18 @@ type Firewaller interface {
 	// Ports returns the port ranges opened for the whole environment.
 	Ports() ([]network.PortRange, error)
 
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment.
+	IngressRules() ([]network.IngressRule, error)
+
 	// Implementations are expected to delete all security groups for the
 	// environment.
 	DeleteAllModelGroups() error
@@ -63,6 +76,18 @@ type Firewaller interface {
 
 	// InstancePorts returns the port ranges opened for the specified  instance.
 	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
+
+	// OpenInstanceIngressRules opens the given ingress rules for the
+	// specified instance.
+	OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// CloseInstanceIngressRules closes the given ingress rules for the
+	// specified instance.
+	CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// InstanceIngressRules returns the ingress rules opened for the
+	// specified instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
 }
 
 type firewallerFactory struct {
@@ -383,6 +408,78 @@ func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId stri
 	return portRanges, nil
 }
 
+// OpenIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.openIngressRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in global group: %v", rules)
+	return nil
+}
+
+// CloseIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.closeIngressRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in global group: %v", rules)
+	return nil
+}
+
+// IngressRules implements Firewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from model",
+			c.environ.Config().FirewallMode())
+	}
+	return c.ingressRulesInGroup(c.globalGroupRegexp())
+}
+
+// OpenInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ingress rules on instance",
+			c.environ.Config().FirewallMode())
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := c.openIngressRulesInGroup(nameRegexp, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// CloseInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ingress rules on instance",
+			c.environ.Config().FirewallMode())
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := c.closeIngressRulesInGroup(nameRegexp, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// InstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ingress rules from instance",
+			c.environ.Config().FirewallMode())
+	}
+	return c.ingressRulesInGroup(c.machineGroupRegexp(machineId))
+}
+
 func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
 	re, err := regexp.Compile(nameRegExp)
 	if err != nil {
@@ -457,6 +554,83 @@ func (c *defaultFirewaller) closePortsInGroup(nameRegExp string, portRanges []ne
 	return nil
 }
 
+func (c *defaultFirewaller) openIngressRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return err
+	}
+	novaclient := c.environ.nova()
+	for _, rule := range ingressRulesToRuleInfo(group.Id, rules) {
+		_, err := novaclient.CreateSecurityGroupRule(rule)
+		if err != nil {
+			// TODO: if err is not rule already exists, raise?
+			logger.Debugf("error creating security group rule: %v", err.Error())
+		}
+	}
+	return nil
+}
+
+// ruleSourceCIDR returns the source CIDR of the supplied nova security
+// group rule, or "" if the rule allows access from a group instead.
+func ruleSourceCIDR(rule nova.SecurityGroupRule) string {
+	return rule.IPRange["cidr"]
+}
+
+func (c *defaultFirewaller) closeIngressRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return err
+	}
+	novaclient := c.environ.nova()
+	for _, rule := range rules {
+		cidrs := set.NewStrings(rule.SourceCIDRs...)
+		for _, p := range group.Rules {
+			if !ruleMatchesPortRange(p, rule.PortRange) || !cidrs.Contains(ruleSourceCIDR(p)) {
+				continue
+			}
+			if err := novaclient.DeleteSecurityGroupRule(p.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *defaultFirewaller) ingressRulesInGroup(nameRegexp string) ([]network.IngressRule, error) {
+	group, err := c.matchingGroup(nameRegexp)
+	if err != nil {
+		return nil, err
+	}
+	cidrs := make(map[network.PortRange]set.Strings)
+	for _, p := range group.Rules {
+		cidr := ruleSourceCIDR(p)
+		if cidr == "" || p.IPProtocol == nil || p.FromPort == nil || p.ToPort == nil {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+			FromPort: *p.FromPort,
+			ToPort:   *p.ToPort,
+		}
+		if _, ok := cidrs[portRange]; !ok {
+			cidrs[portRange] = set.NewStrings()
+		}
+		cidrs[portRange].Add(cidr)
+	}
+	var rules []network.IngressRule
+	for portRange, sourceCIDRs := range cidrs {
+		rules = append(rules, network.IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: sourceCIDRs.SortedValues(),
+		})
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
 func (c *defaultFirewaller) portsInGroup(nameRegexp string) (portRanges []network.PortRange, err error) {
 	group, err := c.matchingGroup(nameRegexp)
 	if err != nil {
@@ -228,6 +228,7 @@ type Environ struct {
 }
 
 var _ environs.Environ = (*Environ)(nil)
+var _ environs.IngressRuleFirewaller = (*Environ)(nil)
 var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ state.Prechecker = (*Environ)(nil)
 var _ state.InstanceDistributor = (*Environ)(nil)
@@ -249,6 +250,7 @@ func (inst *openstackInstance) String() string {
 }
 
 var _ instance.Instance = (*openstackInstance)(nil)
+var _ instance.IngressRuleInstance = (*openstackInstance)(nil)
 
 func (inst *openstackInstance) Refresh() error {
 	inst.mu.Lock()
@@ -395,6 +397,18 @@ func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, err
 	return inst.e.firewaller.InstancePorts(inst, machineId)
 }
 
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.OpenInstanceIngressRules(inst, machineId, rules)
+}
+
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.CloseInstanceIngressRules(inst, machineId, rules)
+}
+
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -1366,6 +1380,24 @@ func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo
 	return rules
 }
 
+// ingressRulesToRuleInfo maps ingress rules to nova rules, one for each
+// source CIDR.
+func ingressRulesToRuleInfo(groupId string, rules []network.IngressRule) []nova.RuleInfo {
+	var result []nova.RuleInfo
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			result = append(result, nova.RuleInfo{
+				ParentGroupId: groupId,
+				FromPort:      rule.FromPort,
+				ToPort:        rule.ToPort,
+				IPProtocol:    rule.Protocol,
+				Cidr:          cidr,
+			})
+		}
+	}
+	return result
+}
+
 func (e *Environ) OpenPorts(ports []network.PortRange) error {
 	return e.firewaller.OpenPorts(ports)
 }
@@ -1378,6 +1410,18 @@ func (e *Environ) Ports() ([]network.PortRange, error) {
 	return e.firewaller.Ports()
 }
 
+func (e *Environ) OpenIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.OpenIngressRules(rules)
+}
+
+func (e *Environ) CloseIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.CloseIngressRules(rules)
+}
+
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	return e.firewaller.IngressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
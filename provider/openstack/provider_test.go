@@ -281,6 +281,33 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 	}
 }
 
+func (*localTests) TestIngressRulesToRuleInfo(c *gc.C) {
+	groupId := "groupid"
+	rules := IngressRulesToRuleInfo(groupId, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 82, "10.0.0.0/8", "192.168.1.0/24"),
+		network.MustNewIngressRule("udp", 53, 53),
+	})
+	c.Check(rules, gc.DeepEquals, []nova.RuleInfo{{
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        82,
+		Cidr:          "10.0.0.0/8",
+		ParentGroupId: groupId,
+	}, {
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        82,
+		Cidr:          "192.168.1.0/24",
+		ParentGroupId: groupId,
+	}, {
+		IPProtocol:    "udp",
+		FromPort:      53,
+		ToPort:        53,
+		Cidr:          "0.0.0.0/0",
+		ParentGroupId: groupId,
+	}})
+}
+
 func (*localTests) TestRuleMatchesPortRange(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
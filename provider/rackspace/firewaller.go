@@ -5,6 +5,7 @@ package rackspace
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/goose.v1/nova"
 
 	"github.com/juju/juju/environs"
@@ -14,6 +15,8 @@ import (
 	"github.com/juju/juju/provider/openstack"
 )
 
+var logger = loggo.GetLogger("juju.provider.rackspace")
+
 type firewallerFactory struct {
 }
 
@@ -55,6 +58,21 @@ func (c *rackspaceFirewaller) Ports() ([]network.PortRange, error) {
 	return nil, errors.NotSupportedf("Ports")
 }
 
+// OpenIngressRules is not supported.
+func (c *rackspaceFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenIngressRules")
+}
+
+// CloseIngressRules is not supported.
+func (c *rackspaceFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseIngressRules")
+}
+
+// IngressRules is not supported.
+func (c *rackspaceFirewaller) IngressRules() ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("IngressRules")
+}
+
 // DeleteAllModelGroups implements OpenstackFirewaller interface.
 func (c *rackspaceFirewaller) DeleteAllModelGroups() error {
 	return nil
@@ -94,6 +112,49 @@ func (c *rackspaceFirewaller) InstancePorts(inst instance.Instance, machineId st
 	return configurator.FindOpenPorts()
 }
 
+// OpenInstanceIngressRules implements Firewaller interface. The
+// instance firewall can only open ports to the world, so rules
+// restricted to other sources are not opened.
+func (c *rackspaceFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	ports := openToWorldPortRanges(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return c.changePorts(inst, true, ports)
+}
+
+// CloseInstanceIngressRules implements Firewaller interface.
+func (c *rackspaceFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	ports := openToWorldPortRanges(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return c.changePorts(inst, false, ports)
+}
+
+// InstanceIngressRules implements Firewaller interface.
+func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	ports, err := c.InstancePorts(inst, machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.PortRangesToIngressRules(ports), nil
+}
+
+// openToWorldPortRanges returns the port ranges of the rules which
+// allow access from anywhere.
+func openToWorldPortRanges(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	for _, rule := range rules {
+		if !rule.IsOpenToWorld() {
+			logger.Warningf("cannot restrict ingress to %v: not supported by rackspace", rule)
+			continue
+		}
+		ports = append(ports, rule.PortRange)
+	}
+	return ports
+}
+
 func (c *rackspaceFirewaller) changePorts(inst instance.Instance, insert bool, ports []network.PortRange) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(inst)
 	if err != nil {
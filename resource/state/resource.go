@@ -241,6 +241,22 @@ func (st resourceState) setResource(pendingID, applicationID, userID string, chR
 	return res, nil
 }
 
+// StoreResourceData adds the content of an existing resource to blob
+// storage. The resource metadata already recorded in the model (for
+// example by a model import) is kept as it is.
+func (st resourceState) StoreResourceData(applicationID, name string, r io.Reader) (resource.Resource, error) {
+	logger.Tracef("storing data for resource %q of application %q", name, applicationID)
+	id := newResourceID(applicationID, name)
+	res, _, err := st.persist.GetResource(id)
+	if err != nil {
+		return resource.Resource{}, errors.Annotate(err, "while getting resource info")
+	}
+	if err := st.storeResource(res, r); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return res, nil
+}
+
 func (st resourceState) storeResource(res resource.Resource, r io.Reader) error {
 	// We use a staging approach for adding the resource metadata
 	// to the model. This is necessary because the resource data
@@ -345,6 +345,45 @@ func (s *ResourceSuite) TestSetResourceSetFailureExtra(c *gc.C) {
 	s.stub.CheckCall(c, 4, "Remove", path)
 }
 
+func (s *ResourceSuite) TestStoreResourceDataOkay(c *gc.C) {
+	expected := newUploadResource(c, "spam", "spamspamspam")
+	expected.Timestamp = s.timestamp.Add(-time.Hour)
+	s.persist.ReturnGetResource = expected
+	hash := expected.Fingerprint.String()
+	path := "application-a-application/resources/spam"
+	file := &stubReader{stub: s.stub}
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+
+	res, err := st.StoreResourceData("a-application", "spam", file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"GetResource",
+		"StageResource",
+		"PutAndCheckHash",
+		"Activate",
+	)
+	s.stub.CheckCall(c, 0, "GetResource", "a-application/spam")
+	s.stub.CheckCall(c, 1, "StageResource", expected, path)
+	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
+	c.Check(res, jc.DeepEquals, expected)
+}
+
+func (s *ResourceSuite) TestStoreResourceDataNotFound(c *gc.C) {
+	file := &stubReader{stub: s.stub}
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	failure := errors.NotFoundf("resource")
+	s.stub.SetErrors(failure)
+
+	_, err := st.StoreResourceData("a-application", "spam", file)
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.stub.CheckCallNames(c, "GetResource")
+}
+
 func (s *ResourceSuite) TestUpdatePendingResourceOkay(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.PendingID = "some-unique-id"
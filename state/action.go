@@ -5,6 +5,7 @@ package state
 
 import (
 	"time"
+	"unicode/utf8"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -20,6 +21,14 @@ const (
 )
 
 var (
+	// maxActionLogMessages is the number of progress messages kept
+	// for an action; older messages are discarded.
+	maxActionLogMessages = 1000
+
+	// maxActionLogMessageLength is the length in bytes beyond which
+	// progress messages are truncated.
+	maxActionLogMessageLength = 1024
+
 	actionLogger = loggo.GetLogger("juju.state.action")
 
 	// NewUUID wraps the utils.NewUUID() call, and exposes it as a var to
@@ -85,6 +94,10 @@ type actionDoc struct {
 	// against the schema defined by the named action in the unit's charm.
 	Parameters map[string]interface{} `bson:"parameters"`
 
+	// Timeout is the maximum time the action may run for, or zero if
+	// it may run for as long as it takes.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
 	// Enqueued is the time the action was added.
 	Enqueued time.Time `bson:"enqueued"`
 
@@ -104,6 +117,16 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the progress messages logged by the action while
+	// it runs.
+	Logs []ActionMessage `bson:"logs,omitempty"`
+}
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp"`
+	Message   string    `bson:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -136,6 +159,12 @@ func (a *action) Parameters() map[string]interface{} {
 	return a.doc.Parameters
 }
 
+// Timeout returns the maximum time the action may run for, or zero if
+// it may run for as long as it takes.
+func (a *action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
 // Enqueued returns the time the action was added to state as a pending
 // Action.
 func (a *action) Enqueued() time.Time {
@@ -162,6 +191,12 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action, oldest
+// first.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -201,12 +236,79 @@ func (a *action) Begin() (Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// Log appends a timestamped progress message to the action. It
+// asserts that the action is currently running. Long messages are
+// truncated, and only the most recent messages are kept.
+func (a *action) Log(message string) error {
+	err := a.st.runTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: bson.D{{"$push", bson.D{{"logs", bson.D{
+			{"$each", []ActionMessage{{
+				Timestamp: nowToTheSecond(),
+				Message:   truncateActionMessage(message),
+			}}},
+			{"$slice", -maxActionLogMessages},
+		}}}}},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action is not running", a.Id())
+	}
+	return errors.Annotatef(err, "cannot log message to action %q", a.Id())
+}
+
+// truncateActionMessage returns the message truncated to at most
+// maxActionLogMessageLength bytes, without splitting a character.
+func truncateActionMessage(message string) string {
+	if len(message) <= maxActionLogMessageLength {
+		return message
+	}
+	message = message[:maxActionLogMessageLength]
+	for !utf8.ValidString(message) {
+		message = message[:len(message)-1]
+	}
+	return message
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
 	return a.removeAndLog(results.Status, results.Results, results.Message)
 }
 
+// Cancel removes action from the pending queue and marks it as
+// cancelled. It asserts that the action is currently pending, so that
+// an action which has begun running is left alone.
+func (a *action) Cancel(message string) (Action, error) {
+	err := a.st.runTransaction([]txn.Op{
+		{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"status", ActionPending}},
+			Update: bson.D{{"$set", bson.D{
+				{"status", ActionCancelled},
+				{"message", message},
+				{"completed", nowToTheSecond()},
+			}}},
+		}, {
+			C:      actionNotificationsC,
+			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+			Remove: true,
+		}})
+	if err == txn.ErrAborted {
+		current, err := a.st.Action(a.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Errorf("cannot cancel action %s: action is %s", a.Id(), current.Status())
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot cancel action %s", a.Id())
+	}
+	return a.st.Action(a.Id())
+}
+
 // removeAndLog takes the action off of the pending queue, and creates
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
@@ -246,8 +348,9 @@ func newAction(st *State, adoc actionDoc) Action {
 	}
 }
 
-// newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+// newActionDoc builds the actionDoc with the given name, parameters and
+// timeout.
+func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}, timeout time.Duration) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -261,6 +364,7 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Receiver:   receiverTag.Id(),
 			Name:       actionName,
 			Parameters: parameters,
+			Timeout:    timeout,
 			Enqueued:   nowToTheSecond(),
 			Status:     ActionPending,
 		}, actionNotificationDoc{
@@ -338,16 +442,25 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 
 // EnqueueAction
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.enqueueAction(receiver, actionName, payload, 0)
+}
+
+// enqueueAction adds an action for the receiver, which must finish
+// running within the given timeout unless that is zero.
+func (st *State) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative action timeout")
+	}
 
 	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload, timeout)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
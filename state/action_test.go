@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -171,6 +172,30 @@ func (s *ActionSuite) TestAddAction(c *gc.C) {
 	}
 }
 
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	a, err := s.unit.AddActionWithTimeout("snapshot", nil, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Timeout(), gc.Equals, 5*time.Minute)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action.Timeout(), gc.Equals, 5*time.Minute)
+
+	a, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Timeout(), gc.Equals, time.Duration(0))
+
+	_, err = s.unit.AddActionWithTimeout("snapshot", nil, -time.Second)
+	c.Assert(err, gc.ErrorMatches, "negative action timeout not valid")
+}
+
+func (s *ActionSuite) TestAddMachineActionWithTimeout(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machine.AddActionWithTimeout("juju-run", map[string]interface{}{"command": "ls"}, time.Minute)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *ActionSuite) TestAddActionInsertsDefaults(c *gc.C) {
 	units := make(map[string]*state.Unit)
 	schemas := map[string]string{
@@ -463,6 +488,105 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 0)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("dumping database")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("compressing dump")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "dumping database")
+	c.Assert(messages[1].Message, gc.Equals, "compressing dump")
+	for _, message := range messages {
+		diff := message.Timestamp.Sub(a.Started())
+		c.Assert(diff >= 0, jc.IsTrue)
+		c.Assert(diff < testing.LongWait, jc.IsTrue)
+	}
+
+	// The messages are kept once the action has finished.
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 2)
+}
+
+func (s *ActionSuite) TestLogLimits(c *gc.C) {
+	s.PatchValue(state.MaxActionLogMessages, 2)
+	s.PatchValue(state.MaxActionLogMessageLength, 5)
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, message := range []string{"one", "two", "three", "fouré"} {
+		err = a.Log(message)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message, gc.Equals, "three")
+	// The message is truncated without splitting the last character.
+	c.Assert(messages[1].Message, gc.Equals, "four")
+}
+
+func (s *ActionSuite) TestCancel(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = a.Cancel("changed my mind")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionCancelled)
+	_, message := a.Results()
+	c.Assert(message, gc.Equals, "changed my mind")
+
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestCancelBeganConcurrently(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := a.Begin()
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = a.Cancel("changed my mind")
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action .*: action is running`)
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionRunning)
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too early")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+	c.Assert(a.Messages(), gc.HasLen, 0)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -878,6 +1002,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeout(string, map[string]interface{}, time.Duration) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -588,8 +589,39 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments to existing filesystems and volumes, e.g.
+	// shared storage, or storage detached from another unit.
+	// Attachments are made in order of tag, to simplify testing.
+	for _, tag := range sortedFilesystemTags(args.filesystemAttachments) {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, incMachineStorageAttachmentCountOp(
+			filesystemsC, tag.Id(),
+		))
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag, names.NewStorageTag(f.doc.StorageId), args.filesystemAttachments[tag],
+		})
+		if f.doc.VolumeId != "" {
+			// The filesystem is backed by a volume, so attach
+			// the volume too.
+			volumeOps = append(volumeOps, incMachineStorageAttachmentCountOp(
+				volumesC, f.doc.VolumeId,
+			))
+			volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+				names.NewVolumeTag(f.doc.VolumeId), VolumeAttachmentParams{},
+			})
+		}
+	}
+	for _, tag := range sortedVolumeTags(args.volumeAttachments) {
+		volumeOps = append(volumeOps, incMachineStorageAttachmentCountOp(
+			volumesC, tag.Id(),
+		))
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			tag, args.volumeAttachments[tag],
+		})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
@@ -605,6 +637,43 @@ func (st *State) machineStorageOps(
 	return ops, volumeAttachments, fsAttachments, nil
 }
 
+// incMachineStorageAttachmentCountOp returns a txn.Op that increments
+// the attachment count of an existing, alive volume or filesystem.
+func incMachineStorageAttachmentCountOp(collection, id string) txn.Op {
+	return txn.Op{
+		C:      collection,
+		Id:     id,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+	}
+}
+
+func sortedFilesystemTags(m map[names.FilesystemTag]FilesystemAttachmentParams) []names.FilesystemTag {
+	ids := make([]string, 0, len(m))
+	for tag := range m {
+		ids = append(ids, tag.Id())
+	}
+	sort.Strings(ids)
+	tags := make([]names.FilesystemTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewFilesystemTag(id)
+	}
+	return tags
+}
+
+func sortedVolumeTags(m map[names.VolumeTag]VolumeAttachmentParams) []names.VolumeTag {
+	ids := make([]string, 0, len(m))
+	for tag := range m {
+		ids = append(ids, tag.Id())
+	}
+	sort.Strings(ids)
+	tags := make([]names.VolumeTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewVolumeTag(id)
+	}
+	return tags
+}
+
 // addMachineStorageAttachmentsOps returns txn.Ops for adding the IDs of
 // attached volumes and filesystems to an existing machine. Filesystem
 // mount points are checked against existing filesystem attachments for
@@ -17,6 +17,14 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection used for audit entries defaults to 100MB, with
+// the oldest entries discarded once it is full. It's tweaked in
+// export_test.go, as txnLogSize is.
+var (
+	auditLogSize      = 100000000
+	auditLogSizeTests = 1000000
+)
+
 // allCollections should be the single source of truth for information about
 // any collection we use. It's broken up into 4 main sections:
 //
@@ -124,6 +132,11 @@ func allCollections() collectionSchema {
 		// one model migration document exists per environment.
 		migrationsActiveC: {global: true},
 
+		// This collection tracks reports from agents which are
+		// involved in model migrations, indicating whether they have
+		// completed the actions required for each migration phase.
+		migrationsMinionSyncC: {global: true},
+
 		// This collection holds user information that's not specific to any
 		// one model.
 		usersC: {
@@ -242,6 +255,10 @@ func allCollections() collectionSchema {
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC:  {},
 		settingsrefsC: {},
+
+		// unitStatesC holds the key-value state stored by each unit's
+		// charm using the state-set hook tool.
+		unitStatesC: {},
 		relationsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "endpoints.relationname"},
@@ -362,9 +379,24 @@ func allCollections() collectionSchema {
 
 		// metrics; status-history; logs; ..?
 
+		// This collection holds the audit trail of user requests made
+		// to any API server in the controller. It's capped so that it
+		// can't grow without bound, and indexed for the filters
+		// offered by show-audit-log.
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
+			indexes: []mgo.Index{{
+				Key: []string{"-timestamp"},
+			}, {
+				Key: []string{"model-uuid", "-timestamp"},
+			}, {
+				Key: []string{"origin-name", "-timestamp"},
+			}},
 		},
 	}
 }
@@ -404,6 +436,7 @@ const (
 	minUnitsC                = "minunits"
 	migrationsStatusC        = "migrations.status"
 	migrationsActiveC        = "migrations.active"
+	migrationsMinionSyncC    = "migrations.minionsync"
 	migrationsC              = "migrations"
 	modelSettingsSourcesC    = "modelSettingsSources"
 	modelUserLastConnectionC = "modelUserLastConnection"
@@ -437,6 +470,7 @@ const (
 	txnLogC                  = "txns.log"
 	txnsC                    = "txns"
 	unitsC                   = "units"
+	unitStatesC              = "unitstates"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
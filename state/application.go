@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -23,6 +25,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -51,6 +54,10 @@ type applicationDoc struct {
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	// ExposedEndpoints holds the expose settings of each endpoint,
+	// keyed by endpoint name. The empty key applies to all endpoints.
+	ExposedEndpoints map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -292,26 +299,212 @@ func (s *Application) SetExposed() error {
 	return s.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the service.
-// See SetExposed and IsExposed.
+// ClearExposed removes the exposed flag, and any expose settings,
+// from the service. See SetExposed and IsExposed.
 func (s *Application) ClearExposed() error {
 	return s.setExposed(false)
 }
 
 func (s *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-endpoints", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for application %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	if !exposed {
+		s.doc.ExposedEndpoints = nil
+	}
+	return nil
+}
+
+// ExposedEndpoint holds the sources from which the ports opened by
+// an exposed application endpoint may be accessed.
+type ExposedEndpoint struct {
+	// ExposeToSpaces holds the names of the spaces whose subnets
+	// may access the endpoint.
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+
+	// ExposeToCIDRs holds the CIDRs which may access the endpoint.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllowsAll reports whether the endpoint may be accessed from
+// anywhere, which is the case when no sources are specified.
+func (e ExposedEndpoint) AllowsAll() bool {
+	return len(e.ExposeToSpaces) == 0 && len(e.ExposeToCIDRs) == 0
+}
+
+// ExposedEndpoints returns the expose settings of the application's
+// endpoints, keyed by endpoint name. Settings under the empty key
+// apply to all of the application's endpoints. An exposed application
+// without any settings may be accessed from anywhere.
+func (s *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(s.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(s.doc.ExposedEndpoints))
+	for name, ep := range s.doc.ExposedEndpoints {
+		result[name] = ep
+	}
+	return result
+}
+
+// MergeExposeSettings marks the application as exposed and merges
+// the given expose settings, keyed by endpoint name, into the existing
+// ones. Use the empty endpoint name to apply settings to all of the
+// application's endpoints. Settings for a named endpoint apply to the
+// ports opened for that endpoint (see Unit.OpenPortsForEndpoint).
+func (s *Application) MergeExposeSettings(exposed map[string]ExposedEndpoint) error {
+	if err := s.validateExposeSettings(exposed); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", s)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if s.doc.Life != Alive {
+				return nil, errNotAlive
+			}
+		}
+		merged := s.ExposedEndpoints()
+		if merged == nil {
+			merged = make(map[string]ExposedEndpoint)
+		}
+		for name, ep := range exposed {
+			merged[name] = ep
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: s.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"txn-revno", s.doc.TxnRevno},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"exposed", true},
+				{"exposed-endpoints", merged},
+			}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", s)
+	}
+	return errors.Trace(s.Refresh())
+}
+
+func (s *Application) validateExposeSettings(exposed map[string]ExposedEndpoint) error {
+	if len(exposed) == 0 {
+		return nil
+	}
+	eps, err := s.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	known := make(map[string]bool)
+	for _, ep := range eps {
+		known[ep.Name] = true
+	}
+	for name, ep := range exposed {
+		if name != "" && !known[name] {
+			return errors.NotFoundf("endpoint %q", name)
+		}
+		for _, spaceName := range ep.ExposeToSpaces {
+			if _, err := s.st.Space(spaceName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, cidr := range ep.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
 	return nil
 }
 
+// IngressCIDRs returns the sorted source CIDRs from which any of the
+// ports opened by the application's units may be accessed, combining
+// the sources of all endpoints. Unexposed applications have none. See
+// EndpointIngressCIDRs for the sources of each endpoint's ports.
+func (s *Application) IngressCIDRs() ([]string, error) {
+	byEndpoint, err := s.EndpointIngressCIDRs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := set.NewStrings()
+	for _, endpointCIDRs := range byEndpoint {
+		cidrs = cidrs.Union(set.NewStrings(endpointCIDRs...))
+	}
+	if cidrs.Contains(network.OpenToWorldCIDR) {
+		return []string{network.OpenToWorldCIDR}, nil
+	}
+	return cidrs.SortedValues(), nil
+}
+
+// EndpointIngressCIDRs returns the sorted source CIDRs from which the
+// ports opened by the application's units may be accessed, keyed by
+// the name of the endpoint the ports were opened for. The spaces in
+// the expose settings are resolved to the CIDRs of their subnets.
+//
+// The sources of ports opened for an endpoint without its own expose
+// settings are those under the empty endpoint name. If there are none,
+// because only other endpoints were exposed, the ports are not
+// accessible. Unexposed applications have no sources at all.
+//
+// If the expose settings only name spaces without any subnets, the
+// endpoint's list is empty: its ports may not be accessed from
+// anywhere, rather than from everywhere.
+func (s *Application) EndpointIngressCIDRs() (map[string][]string, error) {
+	if !s.doc.Exposed {
+		return nil, nil
+	}
+	if len(s.doc.ExposedEndpoints) == 0 {
+		return map[string][]string{"": {network.OpenToWorldCIDR}}, nil
+	}
+	result := make(map[string][]string, len(s.doc.ExposedEndpoints))
+	for name, ep := range s.doc.ExposedEndpoints {
+		cidrs, err := s.exposedEndpointCIDRs(ep)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[name] = cidrs
+	}
+	return result, nil
+}
+
+// exposedEndpointCIDRs returns the sorted source CIDRs allowed by the
+// expose settings of one endpoint.
+func (s *Application) exposedEndpointCIDRs(ep ExposedEndpoint) ([]string, error) {
+	if ep.AllowsAll() {
+		return []string{network.OpenToWorldCIDR}, nil
+	}
+	cidrs := set.NewStrings(ep.ExposeToCIDRs...)
+	for _, spaceName := range ep.ExposeToSpaces {
+		space, err := s.st.Space(spaceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnets, err := space.Subnets()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			cidrs.Add(subnet.CIDR())
+		}
+	}
+	return cidrs.SortedValues(), nil
+}
+
 // Charm returns the service's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (s *Application) Charm() (ch *Charm, force bool, err error) {
@@ -872,7 +1065,7 @@ const MessageWaitForAgentInit = "Waiting for agent initialization to finish"
 // application will be assigned to a given principal. The asserts param can be used
 // to include additional assertions for the application document.  This method
 // assumes that the application already exists in the db.
-func (s *Application) addUnitOps(principalName string, asserts bson.D) (string, []txn.Op, error) {
+func (s *Application) addUnitOps(principalName string, params AddUnitParams, asserts bson.D) (string, []txn.Op, error) {
 	var cons constraints.Value
 	if !s.doc.Subordinate {
 		scons, err := s.Constraints()
@@ -895,6 +1088,7 @@ func (s *Application) addUnitOps(principalName string, asserts bson.D) (string,
 		cons:          cons,
 		principalName: principalName,
 		storageCons:   storageCons,
+		attachStorage: params.AttachStorage,
 	}
 	names, ops, err := s.addUnitOpsWithCons(args)
 	if err != nil {
@@ -910,6 +1104,7 @@ type applicationAddUnitOpsArgs struct {
 	principalName string
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
+	attachStorage []names.StorageTag
 }
 
 // addServiceUnitOps is just like addUnitOps but explicitly takes a
@@ -935,7 +1130,7 @@ func (s *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	}
 
 	// Create instances of the charm's declared stores.
-	storageOps, numStorageAttachments, err := s.unitStorageOps(name, args.storageCons)
+	storageOps, numStorageAttachments, err := s.unitStorageOps(name, args.storageCons, args.attachStorage)
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
@@ -1016,10 +1211,17 @@ func (s *Application) incUnitCountOp(asserts bson.D) txn.Op {
 }
 
 // unitStorageOps returns operations for creating storage
-// instances and attachments for a new unit. unitStorageOps
-// returns the number of initial storage attachments, to
-// initialise the unit's storage attachment refcount.
-func (s *Application) unitStorageOps(unitName string, cons map[string]StorageConstraints) (ops []txn.Op, numStorageAttachments int, err error) {
+// instances and attachments for a new unit, and for attaching
+// the specified detached storage instances to it. Each attached
+// storage instance takes the place of one that would otherwise
+// be created. unitStorageOps returns the number of initial
+// storage attachments, to initialise the unit's storage
+// attachment refcount.
+func (s *Application) unitStorageOps(
+	unitName string,
+	cons map[string]StorageConstraints,
+	attachStorage []names.StorageTag,
+) (ops []txn.Op, numStorageAttachments int, err error) {
 	charm, _, err := s.Charm()
 	if err != nil {
 		return nil, -1, err
@@ -1027,6 +1229,36 @@ func (s *Application) unitStorageOps(unitName string, cons map[string]StorageCon
 	meta := charm.Meta()
 	url := charm.URL()
 	tag := names.NewUnitTag(unitName)
+
+	var attachOps []txn.Op
+	if len(attachStorage) > 0 {
+		// Take a copy of the constraints, so we can reduce
+		// the counts without affecting the caller's map.
+		consCopy := make(map[string]StorageConstraints)
+		for name, c := range cons {
+			consCopy[name] = c
+		}
+		cons = consCopy
+		numAttached := make(map[string]uint64)
+		for _, storageTag := range attachStorage {
+			si, err := s.st.storageInstance(storageTag)
+			if err != nil {
+				return nil, -1, errors.Trace(err)
+			}
+			name := si.StorageName()
+			if c, ok := cons[name]; ok && c.Count > 0 {
+				c.Count--
+				cons[name] = c
+			}
+			count := numAttached[name] + cons[name].Count
+			if err := s.st.validateStorageAttach(si, meta, count); err != nil {
+				return nil, -1, errors.Annotatef(err, "attaching storage %s", storageTag.Id())
+			}
+			numAttached[name]++
+			attachOps = append(attachOps, attachStorageOps(si, tag)...)
+		}
+	}
+
 	// TODO(wallyworld) - record constraints info in data model - size and pool name
 	ops, numStorageAttachments, err = createStorageOps(
 		s.st, tag, meta, url, cons,
@@ -1036,13 +1268,29 @@ func (s *Application) unitStorageOps(unitName string, cons map[string]StorageCon
 	if err != nil {
 		return nil, -1, errors.Trace(err)
 	}
-	return ops, numStorageAttachments, nil
+	ops = append(ops, attachOps...)
+	return ops, numStorageAttachments + len(attachStorage), nil
+}
+
+// AddUnitParams contains parameters for the Application.AddUnitWithParams
+// method.
+type AddUnitParams struct {
+	// AttachStorage identifies storage instances to attach to the unit.
+	// The storage instances must have been detached from their previous
+	// units.
+	AttachStorage []names.StorageTag
 }
 
 // AddUnit adds a new principal unit to the service.
 func (s *Application) AddUnit() (unit *Unit, err error) {
+	return s.AddUnitWithParams(AddUnitParams{})
+}
+
+// AddUnitWithParams adds a new principal unit to the application, with
+// the given parameters.
+func (s *Application) AddUnitWithParams(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", s)
-	name, ops, err := s.addUnitOps("", nil)
+	name, ops, err := s.addUnitOps("", args, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1094,6 +1342,7 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 			Remove: true,
 		},
 		removeMeterStatusOp(s.st, u.globalMeterStatusKey()),
+		removeUnitStateOp(s.st, u.globalKey()),
 		removeStatusOp(s.st, u.globalAgentKey()),
 		removeStatusOp(s.st, u.globalKey()),
 		removeConstraintsOp(s.st, u.globalAgentKey()),
@@ -1345,6 +1345,108 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestMergeExposeSettings(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("admin", "", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"admin"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"":       {ExposeToSpaces: []string{"admin"}},
+		"server": {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+	})
+	byEndpoint, err := s.mysql.EndpointIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(byEndpoint, jc.DeepEquals, map[string][]string{
+		"":       {"10.0.0.0/24"},
+		"server": {"192.168.1.0/24"},
+	})
+	cidrs, err := s.mysql.IngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/24", "192.168.1.0/24"})
+
+	// Clearing the exposed flag discards the settings.
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+	byEndpoint, err = s.mysql.EndpointIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(byEndpoint, gc.HasLen, 0)
+	cidrs, err = s.mysql.IngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+
+	// Exposing without settings allows access from anywhere.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	byEndpoint, err = s.mysql.EndpointIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(byEndpoint, jc.DeepEquals, map[string][]string{"": {"0.0.0.0/0"}})
+	cidrs, err = s.mysql.IngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+}
+
+func (s *ServiceSuite) TestEndpointIngressCIDRsOnlyNamedEndpoints(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the ports opened for the server endpoint are accessible,
+	// from anywhere as the endpoint names no sources.
+	byEndpoint, err := s.mysql.EndpointIngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(byEndpoint, jc.DeepEquals, map[string][]string{"server": {"0.0.0.0/0"}})
+	cidrs, err := s.mysql.IngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+}
+
+func (s *ServiceSuite) TestIngressCIDRsSpacesWithoutSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"admin"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Access is restricted to the spaces' subnets, of which there
+	// are none, so it is not allowed from anywhere.
+	cidrs, err := s.mysql.IngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"foo": {},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": endpoint "foo" not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToSpaces: []string{"missing"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": space "missing" not found`)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.1"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": CIDR "10.0.0.1" not valid`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type AuditSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&AuditSuite{})
+
+func (s *AuditSuite) putEntries(c *gc.C, entries ...audit.AuditEntry) {
+	put := s.State.PutAuditEntryFn()
+	for _, entry := range entries {
+		err := put(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *AuditSuite) entry(originName, operation string, timestamp time.Time) audit.AuditEntry {
+	return audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.0.0"),
+		ModelUUID:         s.State.ModelUUID(),
+		Timestamp:         timestamp,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        originName,
+		Operation:         operation,
+		Data:              map[string]interface{}{"request-body": "x"},
+	}
+}
+
+func (s *AuditSuite) TestAuditEntries(c *gc.C) {
+	t0 := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.putEntries(c,
+		s.entry("user-bob", "Client:v1 - FullStatus", t0),
+		s.entry("user-mary", "Application:v1 - Deploy", t0.Add(time.Minute)),
+		s.entry("user-bob", "Application:v1 - Deploy", t0.Add(2*time.Minute)),
+	)
+
+	entries, err := s.State.AuditEntries(audit.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 3)
+	c.Check(entries[0], jc.DeepEquals, s.entry("user-bob", "Application:v1 - Deploy", t0.Add(2*time.Minute)))
+	c.Check(entries[1].OriginName, gc.Equals, "user-mary")
+	c.Check(entries[2].Operation, gc.Equals, "Client:v1 - FullStatus")
+}
+
+func (s *AuditSuite) TestAuditEntriesFiltered(c *gc.C) {
+	t0 := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.putEntries(c,
+		s.entry("user-bob", "Client:v1 - FullStatus", t0),
+		s.entry("user-mary", "Application:v1 - Deploy", t0.Add(time.Minute)),
+		s.entry("user-bob", "Application:v1 - Deploy", t0.Add(2*time.Minute)),
+		s.entry("user-bob", "Application:v1 - Expose", t0.Add(3*time.Minute)),
+	)
+
+	check := func(filter audit.Filter, expect ...time.Duration) {
+		entries, err := s.State.AuditEntries(filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var offsets []time.Duration
+		for _, entry := range entries {
+			offsets = append(offsets, entry.Timestamp.Sub(t0))
+		}
+		c.Check(offsets, jc.DeepEquals, expect)
+	}
+	check(audit.Filter{OriginName: "user-bob"}, 3*time.Minute, 2*time.Minute, 0)
+	check(audit.Filter{Operation: "deploy"}, 2*time.Minute, time.Minute)
+	check(audit.Filter{OriginName: "user-bob", Operation: "application:"}, 3*time.Minute, 2*time.Minute)
+	check(audit.Filter{From: t0.Add(time.Minute), To: t0.Add(2 * time.Minute)}, 2*time.Minute, time.Minute)
+	check(audit.Filter{Limit: 2}, 3*time.Minute, 2*time.Minute)
+	check(audit.Filter{ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d"})
+}
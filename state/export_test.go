@@ -55,6 +55,8 @@ var (
 	ControllerInheritedSettingsGlobalKey = controllerInheritedSettingsGlobalKey
 	MergeBindings                        = mergeBindings
 	UpgradeInProgressError               = errUpgradeInProgress
+	MaxActionLogMessages                 = &maxActionLogMessages
+	MaxActionLogMessageLength            = &maxActionLogMessageLength
 )
 
 type (
@@ -232,6 +234,7 @@ func GetPasswordHash(e Authenticator) string {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -493,3 +496,13 @@ func LeadershipLeases(st *State) (map[string]lease.Info, error) {
 func DeleteCharm(st *State, curl *charm.URL) error {
 	return st.deleteCharm(curl)
 }
+
+// StorageAttachmentCount returns the number of attachments recorded on
+// the storage instance document.
+func StorageAttachmentCount(instance StorageInstance) int {
+	internal, ok := instance.(*storageInstance)
+	if !ok {
+		return -1
+	}
+	return internal.doc.AttachmentCount
+}
@@ -60,6 +60,10 @@ type Filesystem interface {
 	// if it needs to be provisioned. Params returns true if the returned
 	// parameters are usable for provisioning, otherwise false.
 	Params() (FilesystemParams, bool)
+
+	// PendingSize returns the size, in MiB, that the filesystem has
+	// been requested to grow to, or zero if there is no pending resize.
+	PendingSize() uint64
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -111,6 +115,7 @@ type filesystemDoc struct {
 	Binding         string            `bson:"binding,omitempty"`
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
+	PendingSize     uint64            `bson:"pendingsize,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -269,6 +274,11 @@ func (f *filesystem) Params() (FilesystemParams, bool) {
 	return *f.doc.Params, true
 }
 
+// PendingSize is required to implement Filesystem.
+func (f *filesystem) PendingSize() uint64 {
+	return f.doc.PendingSize
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return f.st.FilesystemStatus(f.FilesystemTag())
@@ -891,6 +901,16 @@ func (st *State) SetFilesystemInfo(tag names.FilesystemTag, info FilesystemInfo)
 			}
 		}
 		ops := setFilesystemInfoOps(tag, info, unsetParams)
+		if pendingSize := fs.PendingSize(); pendingSize != 0 && info.Size >= pendingSize {
+			// The filesystem has been grown, so the
+			// pending resize request is complete.
+			ops = append(ops, txn.Op{
+				C:      filesystemsC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"pendingsize", pendingSize}},
+				Update: bson.D{{"$unset", bson.D{{"pendingsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
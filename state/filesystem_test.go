@@ -521,6 +521,67 @@ func (s *FilesystemStateSuite) TestSetFilesystemInfoVolumeAttachmentNotProvision
 	c.Assert(err, gc.ErrorMatches, `cannot set info for filesystem "0/0": volume attachment "0/0" on "0" not provisioned`)
 }
 
+// provisionVolumeBackedFilesystem provisions the volume-backed
+// filesystem of the unit added by addUnitWithFilesystem, with
+// the given size.
+func (s *FilesystemStateSuite) provisionVolumeBackedFilesystem(c *gc.C, size uint64) (state.Filesystem, state.Volume) {
+	filesystemAttachment, _ := s.addUnitWithFilesystem(c, "loop", true)
+	filesystemTag := filesystemAttachment.Filesystem()
+	volumeTag, err := s.filesystem(c, filesystemTag).Volume()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-0", Size: size})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(
+		filesystemAttachment.Machine(), volumeTag, state.VolumeAttachmentInfo{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{FilesystemId: "fs-0", Size: size})
+	c.Assert(err, jc.ErrorIsNil)
+	return s.filesystem(c, filesystemTag), s.volume(c, volumeTag)
+}
+
+func (s *FilesystemStateSuite) TestResizeStorageVolumeBacked(c *gc.C) {
+	filesystem, volume := s.provisionVolumeBackedFilesystem(c, 1024)
+
+	err := s.State.ResizeStorage(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volume.VolumeTag()).PendingSize(), gc.Equals, uint64(2048))
+	c.Assert(s.filesystem(c, filesystem.FilesystemTag()).PendingSize(), gc.Equals, uint64(2048))
+
+	// Resizing the volume leaves the filesystem resize pending,
+	// until the filesystem has been grown to fill the volume.
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-0", Size: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volume.VolumeTag()).PendingSize(), gc.Equals, uint64(0))
+	c.Assert(s.filesystem(c, filesystem.FilesystemTag()).PendingSize(), gc.Equals, uint64(2048))
+
+	err = s.State.SetFilesystemInfo(filesystem.FilesystemTag(), state.FilesystemInfo{FilesystemId: "fs-0", Size: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem = s.filesystem(c, filesystem.FilesystemTag())
+	c.Assert(filesystem.PendingSize(), gc.Equals, uint64(0))
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2048))
+}
+
+func (s *FilesystemStateSuite) TestResizeStorageFilesystemNotProvisioned(c *gc.C) {
+	s.addUnitWithFilesystem(c, "loop", true)
+	err := s.State.ResizeStorage(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: filesystem "0/0" not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *FilesystemStateSuite) TestCancelVolumeResizeCancelsFilesystemResize(c *gc.C) {
+	filesystem, volume := s.provisionVolumeBackedFilesystem(c, 1024)
+	err := s.State.ResizeStorage(names.NewStorageTag("data/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CancelVolumeResize(volume.VolumeTag(), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volume.VolumeTag()).PendingSize(), gc.Equals, uint64(0))
+	c.Assert(s.filesystem(c, filesystem.FilesystemTag()).PendingSize(), gc.Equals, uint64(0))
+}
+
 func (s *FilesystemStateSuite) TestDestroyFilesystem(c *gc.C) {
 	filesystem, _ := s.setupFilesystemAttachment(c, "rootfs")
 	assertDestroy := func() {
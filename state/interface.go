@@ -148,6 +148,11 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddActionWithTimeout queues an action like AddAction, which must
+	// finish running within the given timeout. A zero timeout means
+	// the action may run for as long as it takes.
+	AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -199,6 +204,10 @@ type Action interface {
 	// definition of the Action.
 	Parameters() map[string]interface{}
 
+	// Timeout returns the maximum time the action may run for, or zero
+	// if it may run for as long as it takes.
+	Timeout() time.Duration
+
 	// Enqueued returns the time the action was added to state as a pending
 	// Action.
 	Enqueued() time.Time
@@ -215,6 +224,10 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the progress messages logged by the action,
+	// oldest first.
+	Messages() []ActionMessage
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -223,7 +236,17 @@ type Action interface {
 	// It asserts that the action is currently pending.
 	Begin() (Action, error)
 
+	// Log appends a timestamped progress message to the action. It
+	// asserts that the action is currently running. Long messages are
+	// truncated, and only the most recent messages are kept.
+	Log(message string) error
+
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Cancel removes action from the pending queue and marks it as
+	// cancelled. It asserts that the action is currently pending, so
+	// that an action which has begun running is left alone.
+	Cancel(message string) (Action, error)
 }
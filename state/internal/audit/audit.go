@@ -4,11 +4,14 @@
 package audit
 
 import (
-	"github.com/juju/errors"
+	"regexp"
+	"time"
 
-	"github.com/juju/juju/audit"
+	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
 )
 
@@ -22,10 +25,10 @@ type auditEntryDoc struct {
 	// ModelID is the ID of the model the audit entry was written on.
 	ModelUUID string `bson:"model-uuid"`
 
-	// Timestamp is when the audit entry was written. It is marshaled
-	// to a bytestream via time.Time::MarshalText and can be
-	// unmarshaled via time.Time::UnmarshalText.
-	Timestamp string `bson:"timestamp"`
+	// Timestamp is when the audit entry was written. It is stored
+	// as a native date so that entries can be indexed and queried
+	// by time.
+	Timestamp time.Time `bson:"timestamp"`
 
 	// RemoteAddress is the IP of the machine from which the
 	// audit-event was triggered.
@@ -43,6 +46,20 @@ type auditEntryDoc struct {
 	// the audit event.
 	Operation string `bson:"operation"`
 
+	// Facade, FacadeVersion and Method identify the API call which
+	// triggered the audit event, if any.
+	Facade        string `bson:"facade,omitempty"`
+	FacadeVersion int    `bson:"facade-version,omitempty"`
+	Method        string `bson:"method,omitempty"`
+
+	// Duration is how long the operation took, in nanoseconds.
+	Duration int64 `bson:"duration,omitempty"`
+
+	// ErrorCode and ErrorMessage describe the error the operation
+	// failed with, if any.
+	ErrorCode    string `bson:"error-code,omitempty"`
+	ErrorMessage string `bson:"error-message,omitempty"`
+
 	// Data is a catch-all for storing random data.
 	Data map[string]interface{} `bson:"data"`
 }
@@ -65,21 +82,89 @@ func PutAuditEntryFn(
 	}
 }
 
-func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, error) {
+// GetAuditEntriesFn creates a closure which when passed a Filter
+// will read the matching entries from the audit collection, most
+// recent first. The findDocs function is expected to run the query
+// sorted by descending timestamp, returning at most limit documents
+// when limit is positive.
+func GetAuditEntriesFn(
+	collectionName string,
+	findDocs func(collectionName string, query bson.D, limit int, docs interface{}) error,
+) func(audit.Filter) ([]audit.AuditEntry, error) {
+	return func(filter audit.Filter) ([]audit.AuditEntry, error) {
+		var docs []auditEntryDoc
+		if err := findDocs(collectionName, filterQuery(filter), filter.Limit, &docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries := make([]audit.AuditEntry, len(docs))
+		for i, doc := range docs {
+			entries[i] = auditEntryFromAuditEntryDoc(doc)
+		}
+		return entries, nil
+	}
+}
 
-	timeAsBlob, err := auditEntry.Timestamp.MarshalText()
-	if err != nil {
-		return auditEntryDoc{}, errors.Trace(err)
+func filterQuery(filter audit.Filter) bson.D {
+	query := bson.D{}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.OriginName != "" {
+		query = append(query, bson.DocElem{"origin-name", filter.OriginName})
+	}
+	if filter.Operation != "" {
+		query = append(query, bson.DocElem{"operation", bson.RegEx{
+			Pattern: regexp.QuoteMeta(filter.Operation),
+			Options: "i",
+		}})
+	}
+	timestamp := bson.D{}
+	if !filter.From.IsZero() {
+		timestamp = append(timestamp, bson.DocElem{"$gte", filter.From.UTC()})
+	}
+	if !filter.To.IsZero() {
+		timestamp = append(timestamp, bson.DocElem{"$lte", filter.To.UTC()})
+	}
+	if len(timestamp) > 0 {
+		query = append(query, bson.DocElem{"timestamp", timestamp})
 	}
+	return query
+}
 
+func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, error) {
 	return auditEntryDoc{
 		JujuServerVersion: auditEntry.JujuServerVersion,
 		ModelUUID:         auditEntry.ModelUUID,
-		Timestamp:         string(timeAsBlob),
+		Timestamp:         auditEntry.Timestamp.UTC(),
 		RemoteAddress:     auditEntry.RemoteAddress,
 		OriginType:        auditEntry.OriginType,
 		OriginName:        auditEntry.OriginName,
 		Operation:         auditEntry.Operation,
+		Facade:            auditEntry.Facade,
+		FacadeVersion:     auditEntry.FacadeVersion,
+		Method:            auditEntry.Method,
+		Duration:          int64(auditEntry.Duration),
+		ErrorCode:         auditEntry.ErrorCode,
+		ErrorMessage:      auditEntry.ErrorMessage,
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) audit.AuditEntry {
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         doc.Timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Facade:            doc.Facade,
+		FacadeVersion:     doc.FacadeVersion,
+		Method:            doc.Method,
+		Duration:          time.Duration(doc.Duration),
+		ErrorCode:         doc.ErrorCode,
+		ErrorMessage:      doc.ErrorMessage,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}
+}
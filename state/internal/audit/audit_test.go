@@ -35,6 +35,12 @@ func (*AuditSuite) TestPutAuditEntry_PersistAuditEntry(c *gc.C) {
 		OriginType:        "user",
 		OriginName:        "bob",
 		Operation:         "status",
+		Facade:            "Client",
+		FacadeVersion:     1,
+		Method:            "FullStatus",
+		Duration:          250 * time.Millisecond,
+		ErrorCode:         "unauthorized access",
+		ErrorMessage:      "permission denied",
 		Data: map[string]interface{}{
 			"a": "b",
 			"$a.b": map[string]interface{}{
@@ -52,17 +58,20 @@ func (*AuditSuite) TestPutAuditEntry_PersistAuditEntry(c *gc.C) {
 		serializedAuditDoc, err := bson.Marshal(docs[0])
 		c.Assert(err, jc.ErrorIsNil)
 
-		requestedTimeBlob, err := requested.Timestamp.MarshalText()
-		c.Assert(err, jc.ErrorIsNil)
-
 		c.Check(string(serializedAuditDoc), jc.BSONEquals, map[string]interface{}{
 			"juju-server-version": requested.JujuServerVersion,
 			"model-uuid":          requested.ModelUUID,
-			"timestamp":           string(requestedTimeBlob),
+			"timestamp":           requested.Timestamp,
 			"remote-address":      "8.8.8.8",
 			"origin-type":         requested.OriginType,
 			"origin-name":         requested.OriginName,
 			"operation":           requested.Operation,
+			"facade":              "Client",
+			"facade-version":      1,
+			"method":              "FullStatus",
+			"duration":            int64(250 * time.Millisecond),
+			"error-code":          "unauthorized access",
+			"error-message":       "permission denied",
 			"data":                mongoutils.EscapeKeys(requested.Data),
 		})
 
@@ -112,3 +121,56 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestGetAuditEntries_BuildsQuery(c *gc.C) {
+	from := time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	filter := audit.Filter{
+		ModelUUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		OriginName: "user-bob",
+		Operation:  "Client:v1 - FullStatus",
+		From:       from,
+		To:         to,
+		Limit:      10,
+	}
+
+	var findDocsCalled bool
+	findDocs := func(collectionName string, query bson.D, limit int, docs interface{}) error {
+		findDocsCalled = true
+		c.Check(collectionName, gc.Equals, "audit.log")
+		c.Check(limit, gc.Equals, 10)
+		c.Check(query, jc.DeepEquals, bson.D{
+			{"model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+			{"origin-name", "user-bob"},
+			{"operation", bson.RegEx{Pattern: `Client:v1 - FullStatus`, Options: "i"}},
+			{"timestamp", bson.D{{"$gte", from}, {"$lte", to}}},
+		})
+		return nil
+	}
+
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	entries, err := getAuditEntries(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+	c.Assert(findDocsCalled, jc.IsTrue)
+}
+
+func (*AuditSuite) TestGetAuditEntries_EmptyFilter(c *gc.C) {
+	findDocs := func(collectionName string, query bson.D, limit int, docs interface{}) error {
+		c.Check(query, gc.HasLen, 0)
+		c.Check(limit, gc.Equals, 0)
+		return nil
+	}
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	_, err := getAuditEntries(audit.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*AuditSuite) TestGetAuditEntries_PropagatesReadError(c *gc.C) {
+	findDocs := func(string, bson.D, int, interface{}) error {
+		return errors.New("my error")
+	}
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	_, err := getAuditEntries(audit.Filter{})
+	c.Check(err, gc.ErrorMatches, "my error")
+}
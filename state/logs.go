@@ -269,6 +269,11 @@ type LogRecord struct {
 	ID   int64
 	Time time.Time
 
+	// DocID is the hex-encoded ID of the record's document in the
+	// logs collection. It distinguishes records which share a
+	// timestamp.
+	DocID string
+
 	// origin fields
 	ModelUUID string
 	Entity    names.Tag
@@ -286,13 +291,16 @@ type LogRecord struct {
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time // If set, the tailer doesn't tail the oplog.
 	MinLevel      loggo.Level
 	InitialLines  int
+	Limit         int // If set, the tailer stops after this many records.
 	NoTail        bool
 	IncludeEntity []string
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
+	MessageRegex  string          // A regular expression the message must match.
 	Oplog         *mgo.Collection // For testing only
 	AllModels     bool
 }
@@ -358,6 +366,7 @@ type logTailer struct {
 	logCh     chan *LogRecord
 	lastID    int64
 	lastTime  time.Time
+	sentCount int
 	recentIds *recentIdTracker
 }
 
@@ -388,7 +397,7 @@ func (t *logTailer) loop() error {
 		return errors.Trace(err)
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() || t.limitReached() {
 		return nil
 	}
 
@@ -411,6 +420,9 @@ func (t *logTailer) processCollection() error {
 			query = query.Skip(skipOver)
 		}
 	}
+	if t.params.Limit > 0 {
+		query = query.Limit(t.params.Limit)
+	}
 
 	// In tests, sorting by time can leave the result ordering
 	// underconstrained. Since object ids are (timestamp, machine id,
@@ -433,11 +445,18 @@ func (t *logTailer) processCollection() error {
 			t.lastID = rec.ID
 			t.lastTime = rec.Time
 			t.recentIds.Add(doc.Id)
+			t.sentCount++
 		}
 	}
 	return errors.Trace(iter.Close())
 }
 
+// limitReached reports whether the tailer has sent as many records as
+// the params allow.
+func (t *logTailer) limitReached() bool {
+	return t.params.Limit > 0 && t.sentCount >= t.params.Limit
+}
+
 func (t *logTailer) tailOplog() error {
 	recentIds := t.recentIds.AsSet()
 
@@ -491,18 +510,29 @@ func (t *logTailer) tailOplog() error {
 			case <-t.tomb.Dying():
 				return errors.Trace(tomb.ErrDying)
 			case t.logCh <- rec:
+				t.sentCount++
+			}
+			if t.limitReached() {
+				return nil
 			}
 		}
 	}
 }
 
 func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bson.D {
+	// All the time bounds are combined into a single condition, as
+	// later conditions on the same field would replace earlier ones.
+	start := params.StartID
+	if !params.StartTime.IsZero() && params.StartTime.UnixNano() > start {
+		start = params.StartTime.UnixNano()
+	}
+	timeRange := bson.M{"$gte": start}
+	if !params.EndTime.IsZero() {
+		timeRange["$lte"] = params.EndTime.UnixNano()
+	}
 	sel := bson.D{
 		// "t" -> "_id" once it is a sequential int.
-		{"t", bson.M{"$gte": params.StartID}},
-	}
-	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		{"t", timeRange},
 	}
 	if !params.AllModels {
 		sel = append(sel, bson.DocElem{"e", t.modelUUID})
@@ -526,6 +556,9 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.MessageRegex != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessageRegex}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -621,8 +654,9 @@ func logDocToRecord(doc *logDoc) (*LogRecord, error) {
 	}
 
 	rec := &LogRecord{
-		ID:   doc.Time,
-		Time: time.Unix(0, doc.Time).UTC(), // not worth preserving TZ
+		ID:    doc.Time,
+		Time:  time.Unix(0, doc.Time).UTC(), // not worth preserving TZ
+		DocID: doc.Id.Hex(),
 
 		ModelUUID: doc.ModelUUID,
 		Entity:    entity,
@@ -636,39 +670,225 @@ func logDocToRecord(doc *logDoc) (*LogRecord, error) {
 	return rec, nil
 }
 
-// PruneLogs removes old log documents in order to control the size of
-// logs collection. All logs older than minLogTime are
-// removed. Further removal is also performed if the logs collection
-// size is greater than maxLogsMB.
-func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
+// ModelLogsAfter returns at most maxCount log records for the model
+// associated with st which follow the record with the time and
+// document ID given. The records are returned ordered by time and
+// then document ID, so that records sharing a timestamp are neither
+// skipped nor repeated between batches. It is used to transfer a
+// model's logs to another controller during model migration.
+//
+// If afterID is empty, all records written at or after the time
+// given are returned (or all records, if the time is zero). This is
+// used to resume a transfer when only the time of the last record
+// transferred is known; ImportModelLogs ignores any records that are
+// sent again.
+func ModelLogsAfter(st ModelSessioner, after time.Time, afterID string, maxCount int) ([]*LogRecord, error) {
+	sel := bson.M{"e": st.ModelUUID()}
+	if afterID != "" {
+		if !bson.IsObjectIdHex(afterID) {
+			return nil, errors.NotValidf("log record ID %q", afterID)
+		}
+		t := after.UnixNano()
+		sel["$or"] = []bson.M{
+			{"t": bson.M{"$gt": t}},
+			{"t": t, "_id": bson.M{"$gt": bson.ObjectIdHex(afterID)}},
+		}
+	} else if !after.IsZero() {
+		sel["t"] = bson.M{"$gte": after.UnixNano()}
+	}
+
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+	query := logsColl.Find(sel).Sort("t", "_id")
+	if maxCount > 0 {
+		query = query.Limit(maxCount)
+	}
+
+	var records []*LogRecord
+	iter := query.Iter()
+	doc := new(logDoc)
+	for iter.Next(doc) {
+		rec, err := logDocToRecord(doc)
+		if err != nil {
+			iter.Close()
+			return nil, errors.Annotate(err, "deserialization failed (possible DB corruption)")
+		}
+		records = append(records, rec)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "log query failed")
+	}
+	return records, nil
+}
+
+// ImportModelLogs writes the log records given into the logs
+// collection for the model associated with st. The records' model
+// UUIDs are ignored. It is used to receive a model's logs from
+// another controller during model migration.
+//
+// The records' document IDs are preserved, and records which have
+// already been imported are skipped, so that a batch of records may
+// safely be imported again if a transfer is retried.
+func ImportModelLogs(st ModelSessioner, records []*LogRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
 	session, logsColl := initLogsSession(st)
 	defer session.Close()
 
-	modelUUIDs, err := getEnvsInLogs(logsColl)
+	ids := make([]bson.ObjectId, len(records))
+	for i, rec := range records {
+		if bson.IsObjectIdHex(rec.DocID) {
+			ids[i] = bson.ObjectIdHex(rec.DocID)
+		} else {
+			ids[i] = bson.NewObjectId()
+		}
+	}
+	var existing []struct {
+		Id bson.ObjectId `bson:"_id"`
+	}
+	err := logsColl.Find(bson.M{"_id": bson.M{"$in": ids}}).Select(bson.M{"_id": 1}).All(&existing)
 	if err != nil {
-		return errors.Annotate(err, "failed to get log counts")
+		return errors.Annotate(err, "failed to query existing log records")
+	}
+	imported := make(map[bson.ObjectId]bool)
+	for _, doc := range existing {
+		imported[doc.Id] = true
 	}
 
-	pruneCounts := make(map[string]int)
+	docs := make([]interface{}, 0, len(records))
+	for i, rec := range records {
+		if imported[ids[i]] {
+			continue
+		}
+		imported[ids[i]] = true
+		docs = append(docs, &logDoc{
+			Id:        ids[i],
+			Time:      rec.Time.UnixNano(),
+			ModelUUID: st.ModelUUID(),
+			Entity:    rec.Entity.String(),
+			Version:   rec.Version.String(),
+			Module:    rec.Module,
+			Location:  rec.Location,
+			Level:     int(rec.Level),
+			Message:   rec.Message,
+		})
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	if err := logsColl.Insert(docs...); err != nil {
+		return errors.Annotate(err, "failed to insert log records")
+	}
+	return nil
+}
 
-	// Remove old log entries (per model UUID to take advantage
-	// of indexes on the logs collection).
-	for _, modelUUID := range modelUUIDs {
+// removeModelLogs removes all log records for the model with the
+// UUID given.
+func removeModelLogs(st MongoSessioner, modelUUID string) error {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	_, err := logsColl.RemoveAll(bson.M{"e": modelUUID})
+	return errors.Annotate(err, "removing model logs")
+}
+
+// PruneLogs removes old log documents of the model with the given UUID
+// in order to control the size of the logs collection. Unless
+// minLogTime is zero, all the model's logs older than it are removed.
+// Further removal is also performed if the model's share of the logs
+// collection is greater than maxLogsMB, when that is positive. It
+// returns the number of log records removed.
+func PruneLogs(st MongoSessioner, modelUUID string, minLogTime time.Time, maxLogsMB int) (int, error) {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	// Remove old log entries.
+	removed := 0
+	if !minLogTime.IsZero() {
 		removeInfo, err := logsColl.RemoveAll(bson.M{
 			"e": modelUUID,
 			"t": bson.M{"$lt": minLogTime.UnixNano()},
 		})
 		if err != nil {
-			return errors.Annotate(err, "failed to prune logs by time")
+			return 0, errors.Annotate(err, "failed to prune logs by time")
 		}
-		pruneCounts[modelUUID] = removeInfo.Removed
+		removed = removeInfo.Removed
+	}
+	if maxLogsMB <= 0 {
+		return removed, nil
+	}
+
+	// Do further pruning if the model's logs are over the maximum size.
+	collMB, err := getCollectionMB(logsColl)
+	if err != nil {
+		return removed, errors.Annotate(err, "failed to retrieve log counts")
+	}
+	if collMB <= maxLogsMB {
+		// The model's share cannot be larger than the whole collection.
+		return removed, nil
+	}
+	total, err := logsColl.Count()
+	if err != nil {
+		return removed, errors.Annotate(err, "failed to get log count")
+	}
+	count, err := getLogCountForEnv(logsColl, modelUUID)
+	if err != nil {
+		return removed, errors.Trace(err)
+	}
+	if total == 0 || count == 0 {
+		return removed, nil
+	}
+	// Log records are assumed to be of similar size, so that the model's
+	// share of the collection size is proportional to its record count.
+	sizePerLog := float64(collMB) / float64(total)
+	if float64(count)*sizePerLog <= float64(maxLogsMB) {
+		return removed, nil
+	}
+	toKeep := int(float64(maxLogsMB) / sizePerLog)
+
+	// Find the threshold timestamp to start removing from.
+	// NOTE: this assumes that there are no more logs being added
+	// for the time range being pruned (which should be true for
+	// any realistic minimum log collection size).
+	tsQuery := logsColl.Find(bson.M{"e": modelUUID}).Sort("-t")
+	tsQuery = tsQuery.Skip(toKeep)
+	tsQuery = tsQuery.Select(bson.M{"t": 1})
+	var doc bson.M
+	if err := tsQuery.One(&doc); err != nil {
+		return removed, errors.Annotate(err, "log pruning timestamp query failed")
+	}
+	thresholdTs := doc["t"]
+
+	// Remove old records.
+	removeInfo, err := logsColl.RemoveAll(bson.M{
+		"e": modelUUID,
+		"t": bson.M{"$lte": thresholdTs},
+	})
+	if err != nil {
+		return removed, errors.Annotate(err, "log pruning failed")
 	}
+	return removed + removeInfo.Removed, nil
+}
 
-	// Do further pruning if the logs collection is over the maximum size.
+// PruneLogsToSize removes the oldest log documents of the models with
+// the most logs until the whole logs collection is no larger than
+// maxLogsMB. This bounds the space used by the logs of the controller,
+// whatever the limits of the individual models. It returns the number
+// of log records removed for each model.
+func PruneLogsToSize(st MongoSessioner, maxLogsMB int) (map[string]int, error) {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	var modelUUIDs []string
+	if err := logsColl.Find(nil).Distinct("e", &modelUUIDs); err != nil {
+		return nil, errors.Annotate(err, "failed to get log counts")
+	}
+	removed := make(map[string]int)
 	for {
 		collMB, err := getCollectionMB(logsColl)
 		if err != nil {
-			return errors.Annotate(err, "failed to retrieve log counts")
+			return removed, errors.Annotate(err, "failed to retrieve log counts")
 		}
 		if collMB <= maxLogsMB {
 			break
@@ -676,7 +896,7 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 
 		modelUUID, count, err := findEnvWithMostLogs(logsColl, modelUUIDs)
 		if err != nil {
-			return errors.Annotate(err, "log count query failed")
+			return removed, errors.Annotate(err, "log count query failed")
 		}
 		if count < 5000 {
 			break // Pruning is not worthwhile
@@ -685,7 +905,7 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 		// Remove the oldest 1% of log records for the model.
 		toRemove := int(float64(count) * 0.01)
 
-		// Find the threshold timestammp to start removing from.
+		// Find the threshold timestamp to start removing from.
 		// NOTE: this assumes that there are no more logs being added
 		// for the time range being pruned (which should be true for
 		// any realistic minimum log collection size).
@@ -693,9 +913,8 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 		tsQuery = tsQuery.Skip(toRemove)
 		tsQuery = tsQuery.Select(bson.M{"t": 1})
 		var doc bson.M
-		err = tsQuery.One(&doc)
-		if err != nil {
-			return errors.Annotate(err, "log pruning timestamp query failed")
+		if err := tsQuery.One(&doc); err != nil {
+			return removed, errors.Annotate(err, "log pruning timestamp query failed")
 		}
 		thresholdTs := doc["t"]
 
@@ -705,17 +924,29 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 			"t": bson.M{"$lt": thresholdTs},
 		})
 		if err != nil {
-			return errors.Annotate(err, "log pruning failed")
+			return removed, errors.Annotate(err, "log pruning failed")
 		}
-		pruneCounts[modelUUID] += removeInfo.Removed
+		removed[modelUUID] += removeInfo.Removed
 	}
+	return removed, nil
+}
 
-	for modelUUID, count := range pruneCounts {
-		if count > 0 {
-			logger.Debugf("pruned %d logs for model %s", count, modelUUID)
+// findEnvWithMostLogs returns the modelUUID and log count for the
+// model with the most logs in the logs collection.
+func findEnvWithMostLogs(logsColl *mgo.Collection, modelUUIDs []string) (string, int, error) {
+	var maxModelUUID string
+	var maxCount int
+	for _, modelUUID := range modelUUIDs {
+		count, err := getLogCountForEnv(logsColl, modelUUID)
+		if err != nil {
+			return "", -1, errors.Trace(err)
+		}
+		if count > maxCount {
+			maxModelUUID = modelUUID
+			maxCount = count
 		}
 	}
-	return nil
+	return maxModelUUID, maxCount, nil
 }
 
 // initLogsSession creates a new session suitable for logging updates,
@@ -747,36 +978,6 @@ func getCollectionMB(coll *mgo.Collection) (int, error) {
 	return result["size"].(int), nil
 }
 
-// getEnvsInLogs returns the unique model UUIDs that exist in
-// the logs collection. This uses the one of the indexes on the
-// collection and should be fast.
-func getEnvsInLogs(coll *mgo.Collection) ([]string, error) {
-	var modelUUIDs []string
-	err := coll.Find(nil).Distinct("e", &modelUUIDs)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	return modelUUIDs, nil
-}
-
-// findEnvWithMostLogs returns the modelUUID and log count for the
-// model with the most logs in the logs collection.
-func findEnvWithMostLogs(logsColl *mgo.Collection, modelUUIDs []string) (string, int, error) {
-	var maxModelUUID string
-	var maxCount int
-	for _, modelUUID := range modelUUIDs {
-		count, err := getLogCountForEnv(logsColl, modelUUID)
-		if err != nil {
-			return "", -1, errors.Trace(err)
-		}
-		if count > maxCount {
-			maxModelUUID = modelUUID
-			maxCount = count
-		}
-	}
-	return maxModelUUID, maxCount, nil
-}
-
 // getLogCountForEnv returns the number of log records stored for a
 // given model.
 func getLogCountForEnv(coll *mgo.Collection, modelUUID string) (int, error) {
@@ -180,6 +180,146 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
 }
 
+func (s *LogsSuite) TestModelLogsAfter(c *gc.C) {
+	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("22"), jujuversion.Current)
+	defer dbLogger.Close()
+	t0 := time.Now().Truncate(time.Millisecond)
+	for i := 0; i < 5; i++ {
+		t := t0.Add(time.Duration(i) * time.Second)
+		err := dbLogger.Log(t, "module", "loc", loggo.INFO, strconv.Itoa(i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Logs for other models shouldn't be returned.
+	otherState := s.NewStateForModelNamed(c, "other-model")
+	otherLogger := state.NewDbLogger(otherState, names.NewMachineTag("0"), jujuversion.Current)
+	defer otherLogger.Close()
+	err := otherLogger.Log(t0.Add(time.Minute), "module", "loc", loggo.INFO, "other")
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := state.ModelLogsAfter(s.State, time.Time{}, "", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 2)
+	c.Check(records[0].Message, gc.Equals, "0")
+	c.Check(records[1].Message, gc.Equals, "1")
+
+	records, err = state.ModelLogsAfter(s.State, records[1].Time, records[1].DocID, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 3)
+	c.Check(records[0].Message, gc.Equals, "2")
+	c.Check(records[0].Entity, gc.Equals, names.NewMachineTag("22"))
+	c.Check(records[2].Message, gc.Equals, "4")
+	c.Check(records[2].ModelUUID, gc.Equals, s.State.ModelUUID())
+
+	records, err = state.ModelLogsAfter(s.State, records[2].Time, records[2].DocID, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 0)
+}
+
+func (s *LogsSuite) TestModelLogsAfterSharedTimestamp(c *gc.C) {
+	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("22"), jujuversion.Current)
+	defer dbLogger.Close()
+	t0 := time.Now().Truncate(time.Millisecond)
+	for i := 0; i < 5; i++ {
+		err := dbLogger.Log(t0, "module", "loc", loggo.INFO, strconv.Itoa(i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Paging through records which share a timestamp
+	// neither skips nor repeats any of them.
+	var messages []string
+	var after time.Time
+	var afterID string
+	for {
+		records, err := state.ModelLogsAfter(s.State, after, afterID, 2)
+		c.Assert(err, jc.ErrorIsNil)
+		if len(records) == 0 {
+			break
+		}
+		for _, rec := range records {
+			messages = append(messages, rec.Message)
+		}
+		after = records[len(records)-1].Time
+		afterID = records[len(records)-1].DocID
+	}
+	c.Assert(messages, jc.SameContents, []string{"0", "1", "2", "3", "4"})
+
+	// Without a record ID, all records written
+	// at the time given are returned.
+	records, err := state.ModelLogsAfter(s.State, t0, "", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 5)
+}
+
+func (s *LogsSuite) TestModelLogsAfterInvalidID(c *gc.C) {
+	_, err := state.ModelLogsAfter(s.State, time.Now(), "foo", 0)
+	c.Assert(err, gc.ErrorMatches, `log record ID "foo" not valid`)
+}
+
+func (s *LogsSuite) TestImportModelLogs(c *gc.C) {
+	t0 := time.Now().Truncate(time.Millisecond).UTC()
+	err := state.ImportModelLogs(s.State, []*state.LogRecord{{
+		Time:      t0,
+		ModelUUID: "some-other-uuid",
+		Entity:    names.NewUnitTag("foo/0"),
+		Version:   jujuversion.Current,
+		Level:     loggo.WARNING,
+		Module:    "some.where",
+		Location:  "foo.go:99",
+		Message:   "all is well",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	err = s.logsColl.Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 1)
+	c.Check(docs[0]["t"], gc.Equals, t0.UnixNano())
+	c.Check(docs[0]["e"], gc.Equals, s.State.ModelUUID())
+	c.Check(docs[0]["n"], gc.Equals, "unit-foo-0")
+	c.Check(docs[0]["r"], gc.Equals, jujuversion.Current.String())
+	c.Check(docs[0]["m"], gc.Equals, "some.where")
+	c.Check(docs[0]["l"], gc.Equals, "foo.go:99")
+	c.Check(docs[0]["v"], gc.Equals, int(loggo.WARNING))
+	c.Check(docs[0]["x"], gc.Equals, "all is well")
+}
+
+func (s *LogsSuite) TestImportModelLogsRepeated(c *gc.C) {
+	t0 := time.Now().Truncate(time.Millisecond).UTC()
+	id := bson.NewObjectId()
+	records := []*state.LogRecord{{
+		Time:    t0,
+		DocID:   id.Hex(),
+		Entity:  names.NewUnitTag("foo/0"),
+		Version: jujuversion.Current,
+		Level:   loggo.WARNING,
+		Message: "all is well",
+	}}
+	err := state.ImportModelLogs(s.State, records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Importing the same record again, alongside a new
+	// one, only imports the new record.
+	records = append(records, &state.LogRecord{
+		Time:    t0,
+		DocID:   bson.NewObjectId().Hex(),
+		Entity:  names.NewUnitTag("foo/0"),
+		Version: jujuversion.Current,
+		Level:   loggo.WARNING,
+		Message: "still well",
+	})
+	err = state.ImportModelLogs(s.State, records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	err = s.logsColl.Find(nil).Sort("x").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+	c.Check(docs[0]["_id"], gc.Equals, id)
+	c.Check(docs[0]["x"], gc.Equals, "all is well")
+	c.Check(docs[1]["x"], gc.Equals, "still well")
+}
+
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("22"), jujuversion.Current)
 	defer dbLogger.Close()
@@ -197,8 +337,9 @@ func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	log(maxLogTime.Add(-(2 * time.Second)), "prune")
 
 	noPruneMB := 100
-	err := state.PruneLogs(s.State, maxLogTime, noPruneMB)
+	removed, err := state.PruneLogs(s.State, s.State.ModelUUID(), maxLogTime, noPruneMB)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.Equals, 2)
 
 	// After pruning there should just be 3 "keep" messages left.
 	var docs []bson.M
@@ -229,31 +370,63 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	startingLogsS2 := 12000
 	s.generateLogs(c, s2, now, startingLogsS2)
 
-	// Prune logs collection back to 1 MiB.
+	// Prune the logs of the second model back to 1 MiB.
 	tsNoPrune := time.Now().Add(-3 * 24 * time.Hour)
-	err := state.PruneLogs(s.State, tsNoPrune, 1)
+	removed, err := state.PruneLogs(s.State, s1.ModelUUID(), tsNoPrune, 1)
 	c.Assert(err, jc.ErrorIsNil)
 
-	// Logs for first env should not be touched.
+	// Logs for the second model should be pruned.
+	c.Assert(removed, jc.GreaterThan, 0)
+	c.Assert(s.countLogs(c, s1), gc.Equals, startingLogsS1-removed)
+
+	// Logs for the other models should not be touched.
 	c.Assert(s.countLogs(c, s0), gc.Equals, startingLogsS0)
+	c.Assert(s.countLogs(c, s2), gc.Equals, startingLogsS2)
 
-	// Logs for second env should be pruned.
-	c.Assert(s.countLogs(c, s1), jc.LessThan, startingLogsS1)
+	// Ensure that the latest log records are still there.
+	var doc bson.M
+	err = s.logsColl.Find(bson.M{"e": s1.ModelUUID()}).Sort("-t").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc["t"], gc.Equals, now.UnixNano())
+}
 
-	// Logs for third env should be pruned to a similar level as
-	// second env.
-	c.Assert(s.countLogs(c, s2), jc.LessThan, startingLogsS1)
+func (s *LogsSuite) TestPruneLogsBySizeSmallModel(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s.generateLogs(c, s.State, now, 10)
 
-	// Ensure that the latest log records are still there.
-	assertLatestTs := func(st *state.State) {
-		var doc bson.M
-		err := s.logsColl.Find(bson.M{"e": st.ModelUUID()}).Sort("-t").One(&doc)
-		c.Assert(err, jc.ErrorIsNil)
-		c.Assert(doc["t"], gc.Equals, now.UnixNano())
-	}
-	assertLatestTs(s0)
-	assertLatestTs(s1)
-	assertLatestTs(s2)
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	s.generateLogs(c, s1, now, 12000)
+
+	// The model's share of the collection is within the limit even
+	// though the collection as a whole is not.
+	tsNoPrune := time.Now().Add(-3 * 24 * time.Hour)
+	removed, err := state.PruneLogs(s.State, s.State.ModelUUID(), tsNoPrune, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.Equals, 0)
+	c.Assert(s.countLogs(c, s.State), gc.Equals, 10)
+}
+
+func (s *LogsSuite) TestPruneLogsToSize(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s0 := s.State
+	startingLogsS0 := 10
+	s.generateLogs(c, s0, now, startingLogsS0)
+
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	startingLogsS1 := 12000
+	s.generateLogs(c, s1, now, startingLogsS1)
+
+	removed, err := state.PruneLogsToSize(s.State, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the model with the most logs is pruned.
+	c.Assert(removed[s1.ModelUUID()], jc.GreaterThan, 0)
+	c.Assert(s.countLogs(c, s1), gc.Equals, startingLogsS1-removed[s1.ModelUUID()])
+	c.Assert(s.countLogs(c, s0), gc.Equals, startingLogsS0)
+	_, ok := removed[s0.ModelUUID()]
+	c.Assert(ok, jc.IsFalse)
 }
 
 func (s *LogsSuite) generateLogs(c *gc.C, st *state.State, endTime time.Time, count int) {
@@ -557,6 +730,78 @@ func (s *LogTailerSuite) TestNoTail(c *gc.C) {
 	}
 }
 
+func (s *LogTailerSuite) TestEndTime(c *gc.C) {
+	threshT := time.Now()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, threshT.Add(-5*time.Second), threshT, 5, want)
+	s.writeLogsT(c,
+		threshT.Add(time.Millisecond), threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// The tailer stops by itself, as no later logs can match.
+	s.assertTailer(c, tailer, 5, want)
+	s.assertTailerStopped(c, tailer)
+}
+
+func (s *LogTailerSuite) TestLimit(c *gc.C) {
+	want := logTemplate{Message: "want"}
+	s.writeLogs(c, 3, want)
+	s.writeLogs(c, 2, logTemplate{Message: "dont want"})
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		Limit: 3,
+		Oplog: s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	s.assertTailer(c, tailer, 3, want)
+	s.assertTailerStopped(c, tailer)
+}
+
+func (s *LogTailerSuite) TestLimitIncludesTailedLogs(c *gc.C) {
+	want := logTemplate{Message: "want"}
+	s.writeLogs(c, 2, want)
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		Limit: 3,
+		Oplog: s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 2, want)
+
+	// Write more logs. The first will be read from the oplog, and
+	// then the limit is reached.
+	s.writeLogs(c, 2, want)
+	s.assertTailer(c, tailer, 1, want)
+	s.assertTailerStopped(c, tailer)
+}
+
+func (s *LogTailerSuite) TestMessageRegex(c *gc.C) {
+	good := logTemplate{Message: "connection refused by peer"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, logTemplate{Message: "all is well"})
+		s.writeLogs(c, 2, good)
+		s.writeLogs(c, 1, logTemplate{Message: "refused"})
+	}
+	params := &state.LogTailerParams{
+		MessageRegex: "^connection .* peer$",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, good)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) TestIncludeEntity(c *gc.C) {
 	machine0 := logTemplate{Entity: names.NewMachineTag("0")}
 	foo0 := logTemplate{Entity: names.NewUnitTag("foo/0")}
@@ -848,3 +1093,17 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 		}
 	}
 }
+
+// assertTailerStopped checks that the tailer stops by itself without
+// reporting any further logs.
+func (s *LogTailerSuite) assertTailerStopped(c *gc.C, tailer state.LogTailer) {
+	select {
+	case log, ok := <-tailer.Logs():
+		if ok {
+			c.Fatalf("unexpected log: %v", log.Message)
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+}
@@ -10,10 +10,14 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 // Export the current model for the State.
@@ -85,6 +89,9 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.relations(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.storage(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	if err := export.model.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -331,6 +338,7 @@ func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []ports
 					FromPort: p.FromPort,
 					ToPort:   p.ToPort,
 					Protocol: p.Protocol,
+					Endpoint: p.Endpoint,
 				})
 			}
 			result = append(result, args)
@@ -407,21 +415,61 @@ func (e *exporter) applications() error {
 		return errors.Trace(err)
 	}
 
+	unitStates, err := e.readAllUnitStates()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	leaders, err := e.readApplicationLeaders()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	payloads, err := e.readAllPayloads()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	resources := NewResourcePersistence(e.st.newPersistence())
+
 	for _, application := range applications {
-		applicationUnits := e.units[application.Name()]
-		leader := leaders[application.Name()]
-		if err := e.addApplication(application, refcounts, applicationUnits, meterStatus, leader); err != nil {
+		applicationResources, err := resources.ListResources(application.Name())
+		if err != nil {
+			return errors.Annotatef(err, "resources for application %s", application.Name())
+		}
+		ctx := addApplicationContext{
+			application: application,
+			refcounts:   refcounts,
+			units:       e.units[application.Name()],
+			meterStatus: meterStatus,
+			unitStates:  unitStates,
+			leader:      leaders[application.Name()],
+			payloads:    payloads,
+			resources:   applicationResources,
+		}
+		if err := e.addApplication(ctx); err != nil {
 			return errors.Trace(err)
 		}
 	}
 	return nil
 }
 
+func (e *exporter) readAllPayloads() (map[string][]payload.FullPayloadInfo, error) {
+	result := make(map[string][]payload.FullPayloadInfo)
+	all, err := e.st.EnvPayloads()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	payloads, err := all.ListAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, payload := range payloads {
+		result[payload.Unit] = append(result[payload.Unit], payload)
+	}
+	return result, nil
+}
+
 func (e *exporter) readApplicationLeaders() (map[string]string, error) {
 	client, err := e.st.getLeadershipLeaseClient()
 	if err != nil {
@@ -435,7 +483,19 @@ func (e *exporter) readApplicationLeaders() (map[string]string, error) {
 	return result, nil
 }
 
-func (e *exporter) addApplication(application *Application, refcounts map[string]int, units []*Unit, meterStatus map[string]*meterStatusDoc, leader string) error {
+type addApplicationContext struct {
+	application *Application
+	refcounts   map[string]int
+	units       []*Unit
+	meterStatus map[string]*meterStatusDoc
+	unitStates  map[string]map[string]string
+	leader      string
+	payloads    map[string][]payload.FullPayloadInfo
+	resources   resource.ServiceResources
+}
+
+func (e *exporter) addApplication(ctx addApplicationContext) error {
+	application := ctx.application
 	settingsKey := application.settingsKey()
 	leadershipKey := leadershipSettingsKey(application.Name())
 
@@ -443,7 +503,7 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 	if !found {
 		return errors.Errorf("missing settings for application %q", application.Name())
 	}
-	refCount, found := refcounts[settingsKey]
+	refCount, found := ctx.refcounts[settingsKey]
 	if !found {
 		return errors.Errorf("missing settings refcount for application %q", application.Name())
 	}
@@ -461,10 +521,11 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
 		Exposed:              application.doc.Exposed,
+		ExposedEndpoints:     exportExposedEndpoints(application.doc.ExposedEndpoints),
 		MinUnits:             application.doc.MinUnits,
 		Settings:             applicationSettingsDoc.Settings,
 		SettingsRefCount:     refCount,
-		Leader:               leader,
+		Leader:               ctx.leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   application.doc.MetricCredentials,
 	}
@@ -485,9 +546,13 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 	}
 	exApplication.SetConstraints(constraintsArgs)
 
-	for _, unit := range units {
+	if err := e.setResources(exApplication, ctx.resources); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, unit := range ctx.units {
 		agentKey := unit.globalAgentKey()
-		unitMeterStatus, found := meterStatus[agentKey]
+		unitMeterStatus, found := ctx.meterStatus[agentKey]
 		if !found {
 			return errors.Errorf("missing meter status for unit %s", unit.Name())
 		}
@@ -503,6 +568,7 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 			PasswordHash:    unit.doc.PasswordHash,
 			MeterStatusCode: unitMeterStatus.Code,
 			MeterStatusInfo: unitMeterStatus.Info,
+			State:           ctx.unitStates[unit.globalKey()],
 		}
 		if principalName, isSubordinate := unit.PrincipalName(); isSubordinate {
 			args.Principal = names.NewUnitTag(principalName)
@@ -551,11 +617,77 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 			return errors.Trace(err)
 		}
 		exUnit.SetConstraints(constraintsArgs)
+
+		for _, payload := range ctx.payloads[unit.Name()] {
+			exUnit.AddPayload(description.PayloadArgs{
+				Name:   payload.Name,
+				Type:   payload.Type,
+				RawID:  payload.ID,
+				State:  payload.Status,
+				Labels: payload.Labels,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (e *exporter) setResources(exApp description.Application, resources resource.ServiceResources) error {
+	if len(resources.Resources) != len(resources.CharmStoreResources) {
+		return errors.New("number of resources don't match charm store resources")
+	}
+
+	for i, res := range resources.Resources {
+		exResource := exApp.AddResource(description.ResourceArgs{
+			Name: res.Name,
+		})
+		exResource.SetApplicationRevision(resourceToRevisionArgs(res))
+		// The charm store resource has no name until the resource has
+		// been polled.
+		if csRes := resources.CharmStoreResources[i]; csRes.Name != "" {
+			exResource.SetCharmStoreRevision(charmResourceToRevisionArgs(csRes))
+		}
+	}
+
+	exUnits := make(map[string]description.Unit)
+	for _, exUnit := range exApp.Units() {
+		exUnits[exUnit.Name()] = exUnit
+	}
+	for _, unitResources := range resources.UnitResources {
+		exUnit, found := exUnits[unitResources.Tag.Id()]
+		if !found {
+			return errors.Errorf("missing unit for resources %s", unitResources.Tag.Id())
+		}
+		for _, res := range unitResources.Resources {
+			exUnit.AddResource(description.UnitResourceArgs{
+				Name:         res.Name,
+				RevisionArgs: resourceToRevisionArgs(res),
+			})
+		}
 	}
 
 	return nil
 }
 
+func resourceToRevisionArgs(res resource.Resource) description.ResourceRevisionArgs {
+	args := charmResourceToRevisionArgs(res.Resource)
+	args.Timestamp = res.Timestamp
+	args.Username = res.Username
+	return args
+}
+
+func charmResourceToRevisionArgs(res charmresource.Resource) description.ResourceRevisionArgs {
+	return description.ResourceRevisionArgs{
+		Revision:       res.Revision,
+		Type:           res.Type.String(),
+		Path:           res.Path,
+		Description:    res.Description,
+		Origin:         res.Origin.String(),
+		FingerprintHex: res.Fingerprint.Hex(),
+		Size:           res.Size,
+	}
+}
+
 func (e *exporter) relations() error {
 	rels, err := e.st.AllRelations()
 	if err != nil {
@@ -606,6 +738,314 @@ func (e *exporter) relations() error {
 	return nil
 }
 
+func (e *exporter) storage() error {
+	if err := e.volumes(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.filesystems(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.storageInstances(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.storagePools(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (e *exporter) volumes() error {
+	coll, closer := e.st.getCollection(volumesC)
+	defer closer()
+
+	attachments, err := e.readVolumeAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var doc volumeDoc
+	iter := coll.Find(nil).Sort("_id").Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		vol := &volume{e.st, doc}
+		if err := e.addVolume(vol, attachments[doc.Name]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Annotate(err, "failed to read volumes")
+	}
+	return nil
+}
+
+func (e *exporter) addVolume(vol *volume, volAttachments []volumeAttachmentDoc) error {
+	// Here we don't care about the case where the volume is not assigned
+	// to a storage instance, in which case we have an empty tag.
+	storageTag, _ := vol.StorageInstance()
+	args := description.VolumeArgs{
+		Tag:     vol.VolumeTag(),
+		Storage: storageTag,
+	}
+	if vol.doc.Binding != "" {
+		binding, err := names.ParseTag(vol.doc.Binding)
+		if err != nil {
+			return errors.Annotatef(err, "volume %s binding", vol.doc.Name)
+		}
+		args.Binding = binding
+	}
+	if info, err := vol.Info(); err == nil {
+		args.Provisioned = true
+		args.Size = info.Size
+		args.Pool = info.Pool
+		args.HardwareID = info.HardwareId
+		args.VolumeID = info.VolumeId
+		args.Persistent = info.Persistent
+	} else {
+		params, _ := vol.Params()
+		args.Size = params.Size
+		args.Pool = params.Pool
+	}
+
+	globalKey := vol.globalKey()
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotatef(err, "status for volume %s", vol.doc.Name)
+	}
+
+	exVolume := e.model.AddVolume(args)
+	exVolume.SetStatus(statusArgs)
+	exVolume.SetStatusHistory(e.statusHistoryArgs(globalKey))
+	if count := len(volAttachments); count != vol.doc.AttachmentCount {
+		return errors.Errorf("volume attachment count mismatch, have %d, expected %d",
+			count, vol.doc.AttachmentCount)
+	}
+	for _, doc := range volAttachments {
+		va := volumeAttachment{doc}
+		args := description.VolumeAttachmentArgs{
+			Machine: va.Machine(),
+		}
+		if info, err := va.Info(); err == nil {
+			args.Provisioned = true
+			args.ReadOnly = info.ReadOnly
+			args.DeviceName = info.DeviceName
+			args.DeviceLink = info.DeviceLink
+			args.BusAddress = info.BusAddress
+		} else {
+			params, _ := va.Params()
+			args.ReadOnly = params.ReadOnly
+		}
+		exVolume.AddAttachment(args)
+	}
+	return nil
+}
+
+func (e *exporter) readVolumeAttachments() (map[string][]volumeAttachmentDoc, error) {
+	coll, closer := e.st.getCollection(volumeAttachmentsC)
+	defer closer()
+
+	result := make(map[string][]volumeAttachmentDoc)
+	var doc volumeAttachmentDoc
+	var count int
+	iter := coll.Find(nil).Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		result[doc.Volume] = append(result[doc.Volume], doc)
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		return nil, errors.Annotate(err, "failed to read volumes attachments")
+	}
+	e.logger.Debugf("read %d volume attachment documents", count)
+	return result, nil
+}
+
+func (e *exporter) filesystems() error {
+	coll, closer := e.st.getCollection(filesystemsC)
+	defer closer()
+
+	attachments, err := e.readFilesystemAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var doc filesystemDoc
+	iter := coll.Find(nil).Sort("_id").Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		fs := &filesystem{e.st, doc}
+		if err := e.addFilesystem(fs, attachments[doc.FilesystemId]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Annotate(err, "failed to read filesystems")
+	}
+	return nil
+}
+
+func (e *exporter) addFilesystem(fs *filesystem, fsAttachments []filesystemAttachmentDoc) error {
+	// Here we don't care about the cases where the filesystem is not
+	// assigned to a storage instance, or has no backing volume. In both
+	// of those cases we have empty tags.
+	storageTag, _ := fs.Storage()
+	volumeTag, _ := fs.Volume()
+	args := description.FilesystemArgs{
+		Tag:     fs.FilesystemTag(),
+		Storage: storageTag,
+		Volume:  volumeTag,
+	}
+	if fs.doc.Binding != "" {
+		binding, err := names.ParseTag(fs.doc.Binding)
+		if err != nil {
+			return errors.Annotatef(err, "filesystem %s binding", fs.doc.FilesystemId)
+		}
+		args.Binding = binding
+	}
+	if info, err := fs.Info(); err == nil {
+		args.Provisioned = true
+		args.Size = info.Size
+		args.Pool = info.Pool
+		args.FilesystemID = info.FilesystemId
+	} else {
+		params, _ := fs.Params()
+		args.Size = params.Size
+		args.Pool = params.Pool
+	}
+
+	globalKey := fs.globalKey()
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotatef(err, "status for filesystem %s", fs.doc.FilesystemId)
+	}
+
+	exFilesystem := e.model.AddFilesystem(args)
+	exFilesystem.SetStatus(statusArgs)
+	exFilesystem.SetStatusHistory(e.statusHistoryArgs(globalKey))
+	if count := len(fsAttachments); count != fs.doc.AttachmentCount {
+		return errors.Errorf("filesystem attachment count mismatch, have %d, expected %d",
+			count, fs.doc.AttachmentCount)
+	}
+	for _, doc := range fsAttachments {
+		fa := filesystemAttachment{doc}
+		args := description.FilesystemAttachmentArgs{
+			Machine: fa.Machine(),
+		}
+		if info, err := fa.Info(); err == nil {
+			args.Provisioned = true
+			args.ReadOnly = info.ReadOnly
+			args.MountPoint = info.MountPoint
+		} else {
+			params, _ := fa.Params()
+			args.ReadOnly = params.ReadOnly
+			args.MountPoint = params.Location
+		}
+		exFilesystem.AddAttachment(args)
+	}
+	return nil
+}
+
+func (e *exporter) readFilesystemAttachments() (map[string][]filesystemAttachmentDoc, error) {
+	coll, closer := e.st.getCollection(filesystemAttachmentsC)
+	defer closer()
+
+	result := make(map[string][]filesystemAttachmentDoc)
+	var doc filesystemAttachmentDoc
+	var count int
+	iter := coll.Find(nil).Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		result[doc.Filesystem] = append(result[doc.Filesystem], doc)
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		return nil, errors.Annotate(err, "failed to read filesystem attachments")
+	}
+	e.logger.Debugf("read %d filesystem attachment documents", count)
+	return result, nil
+}
+
+func (e *exporter) storageInstances() error {
+	coll, closer := e.st.getCollection(storageInstancesC)
+	defer closer()
+
+	attachments, err := e.readStorageAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var doc storageInstanceDoc
+	iter := coll.Find(nil).Sort("_id").Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		instance := &storageInstance{e.st, doc}
+		if err := e.addStorage(instance, attachments[doc.Id]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Annotate(err, "failed to read storage instances")
+	}
+	return nil
+}
+
+func (e *exporter) addStorage(instance *storageInstance, attachments []names.UnitTag) error {
+	var owner names.Tag
+	if instance.doc.Owner != "" {
+		// Storage that has been detached from its unit has no owner.
+		var err error
+		owner, err = names.ParseTag(instance.doc.Owner)
+		if err != nil {
+			return errors.Annotatef(err, "storage %s owner", instance.doc.Id)
+		}
+	}
+	args := description.StorageArgs{
+		Tag:         instance.StorageTag(),
+		Kind:        instance.Kind().String(),
+		Owner:       owner,
+		Name:        instance.StorageName(),
+		Attachments: attachments,
+	}
+	e.model.AddStorage(args)
+	return nil
+}
+
+func (e *exporter) readStorageAttachments() (map[string][]names.UnitTag, error) {
+	coll, closer := e.st.getCollection(storageAttachmentsC)
+	defer closer()
+
+	result := make(map[string][]names.UnitTag)
+	var doc storageAttachmentDoc
+	var count int
+	iter := coll.Find(nil).Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		unit := names.NewUnitTag(doc.Unit)
+		result[doc.StorageInstance] = append(result[doc.StorageInstance], unit)
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		return nil, errors.Annotate(err, "failed to read storage attachments")
+	}
+	e.logger.Debugf("read %d storage attachment documents", count)
+	return result, nil
+}
+
+func (e *exporter) storagePools() error {
+	pm := poolmanager.New(NewStateSettings(e.st))
+	poolConfigs, err := pm.List()
+	if err != nil {
+		return errors.Annotate(err, "listing pools")
+	}
+	for _, cfg := range poolConfigs {
+		e.model.AddStoragePool(description.StoragePoolArgs{
+			Name:       cfg.Name(),
+			Provider:   string(cfg.Provider()),
+			Attributes: cfg.Attrs(),
+		})
+	}
+	return nil
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.getCollection(relationScopesC)
 	defer closer()
@@ -659,6 +1099,22 @@ func (e *exporter) readAllMeterStatus() (map[string]*meterStatusDoc, error) {
 	return result, nil
 }
 
+func (e *exporter) readAllUnitStates() (map[string]map[string]string, error) {
+	unitStates, closer := e.st.getCollection(unitStatesC)
+	defer closer()
+
+	var docs []unitStateDoc
+	if err := unitStates.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all unit state docs")
+	}
+	e.logger.Debugf("found %d unit state docs", len(docs))
+	result := make(map[string]map[string]string)
+	for _, doc := range docs {
+		result[e.st.localID(doc.DocID)] = unescapeUnitState(doc.State)
+	}
+	return result, nil
+}
+
 func (e *exporter) readLastConnectionTimes() (map[string]time.Time, error) {
 	lastConnections, closer := e.st.getCollection(modelUserLastConnectionC)
 	defer closer()
@@ -946,3 +1402,17 @@ func (e *exporter) logExtras() {
 		e.logger.Warningf("unexported annotation for %s, %s", doc.Tag, key)
 	}
 }
+
+func exportExposedEndpoints(exposed map[string]ExposedEndpoint) map[string]description.ExposedEndpointArgs {
+	if len(exposed) == 0 {
+		return nil
+	}
+	result := make(map[string]description.ExposedEndpointArgs)
+	for name, ep := range exposed {
+		result[name] = description.ExposedEndpointArgs{
+			ExposeToSpaces: ep.ExposeToSpaces,
+			ExposeToCIDRs:  ep.ExposeToCIDRs,
+		}
+	}
+	return result
+}
@@ -4,18 +4,24 @@
 package state_test
 
 import (
+	"bytes"
 	"math/rand"
 	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -83,6 +89,27 @@ func (s *MigrationSuite) makeApplicationWithLeader(c *gc.C, applicationname stri
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *MigrationSuite) makeUnitWithStorage(c *gc.C) (*state.Application, *state.Unit, names.StorageTag) {
+	pool := "loop-pool"
+	pm := poolmanager.New(state.NewStateSettings(s.State))
+	_, err := pm.Create(pool, provider.LoopProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons(pool, 1024, 1),
+	}
+	application := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine := s.Factory.MakeMachine(c, nil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	return application, unit, names.NewStorageTag("data/0")
+}
+
 type MigrationExportSuite struct {
 	MigrationSuite
 }
@@ -294,6 +321,8 @@ func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 		err = unit.SetWorkloadVersion(version)
 		c.Assert(err, jc.ErrorIsNil)
 	}
+	err = unit.SetState(map[string]string{"db.host": "10.0.0.5"})
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(unit, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, unit, status.StatusActive, addedHistoryCount)
@@ -317,6 +346,7 @@ func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 	c.Assert(exported.MeterStatusCode(), gc.Equals, "GREEN")
 	c.Assert(exported.MeterStatusInfo(), gc.Equals, "some info")
 	c.Assert(exported.WorkloadVersion(), gc.Equals, "steven")
+	c.Assert(exported.State(), jc.DeepEquals, map[string]string{"db.host": "10.0.0.5"})
 	c.Assert(exported.Annotations(), jc.DeepEquals, testAnnotations)
 	constraints := exported.Constraints()
 	c.Assert(constraints, gc.NotNil)
@@ -448,3 +478,273 @@ type goodToken struct{}
 func (*goodToken) Check(interface{}) error {
 	return nil
 }
+
+func (s *MigrationExportSuite) TestVolumes(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume:     state.VolumeParams{Size: 1234},
+			Attachment: state.VolumeAttachmentParams{ReadOnly: true},
+		}, {
+			Volume: state.VolumeParams{Size: 4000},
+		}},
+	})
+	machineTag := machine.MachineTag()
+
+	// We know that the first volume is called "0/0" as it is the first volume
+	// (volumes use sequences), and it is bound to machine 0.
+	volTag := names.NewVolumeTag("0/0")
+	err := s.State.SetVolumeInfo(volTag, state.VolumeInfo{
+		HardwareId: "magic",
+		Size:       1500,
+		VolumeId:   "volume id",
+		Pool:       "loop",
+		Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volTag, state.VolumeAttachmentInfo{
+		DeviceName: "device name",
+		DeviceLink: "device link",
+		BusAddress: "bus address",
+		ReadOnly:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes := model.Volumes()
+	c.Assert(volumes, gc.HasLen, 2)
+	provisioned, notProvisioned := volumes[0], volumes[1]
+
+	c.Check(provisioned.Tag(), gc.Equals, volTag)
+	binding, err := provisioned.Binding()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(binding, gc.Equals, machineTag)
+	c.Check(provisioned.Provisioned(), jc.IsTrue)
+	c.Check(provisioned.Size(), gc.Equals, uint64(1500))
+	c.Check(provisioned.Pool(), gc.Equals, "loop")
+	c.Check(provisioned.HardwareID(), gc.Equals, "magic")
+	c.Check(provisioned.VolumeID(), gc.Equals, "volume id")
+	c.Check(provisioned.Persistent(), jc.IsTrue)
+	attachments := provisioned.Attachments()
+	c.Assert(attachments, gc.HasLen, 1)
+	attachment := attachments[0]
+	c.Check(attachment.Machine(), gc.Equals, machineTag)
+	c.Check(attachment.Provisioned(), jc.IsTrue)
+	c.Check(attachment.ReadOnly(), jc.IsTrue)
+	c.Check(attachment.DeviceName(), gc.Equals, "device name")
+	c.Check(attachment.DeviceLink(), gc.Equals, "device link")
+	c.Check(attachment.BusAddress(), gc.Equals, "bus address")
+
+	c.Check(notProvisioned.Tag(), gc.Equals, names.NewVolumeTag("0/1"))
+	binding, err = notProvisioned.Binding()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(binding, gc.Equals, machineTag)
+	c.Check(notProvisioned.Provisioned(), jc.IsFalse)
+	c.Check(notProvisioned.Size(), gc.Equals, uint64(4000))
+	c.Check(notProvisioned.Pool(), gc.Equals, "loop")
+	c.Check(notProvisioned.HardwareID(), gc.Equals, "")
+	c.Check(notProvisioned.VolumeID(), gc.Equals, "")
+	c.Check(notProvisioned.Persistent(), jc.IsFalse)
+	attachments = notProvisioned.Attachments()
+	c.Assert(attachments, gc.HasLen, 1)
+	attachment = attachments[0]
+	c.Check(attachment.Machine(), gc.Equals, machineTag)
+	c.Check(attachment.Provisioned(), jc.IsFalse)
+	c.Check(attachment.ReadOnly(), jc.IsFalse)
+	c.Check(attachment.DeviceName(), gc.Equals, "")
+	c.Check(attachment.DeviceLink(), gc.Equals, "")
+	c.Check(attachment.BusAddress(), gc.Equals, "")
+}
+
+func (s *MigrationExportSuite) TestFilesystems(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 1234},
+			Attachment: state.FilesystemAttachmentParams{
+				Location: "location",
+				ReadOnly: true},
+		}, {
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 4000},
+		}},
+	})
+	machineTag := machine.MachineTag()
+
+	// We know that the first filesystem is called "0/0" as it is the first
+	// filesystem (filesystems use sequences), and it is bound to machine 0.
+	fsTag := names.NewFilesystemTag("0/0")
+	err := s.State.SetFilesystemInfo(fsTag, state.FilesystemInfo{
+		Size:         1500,
+		Pool:         "rootfs",
+		FilesystemId: "filesystem id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemAttachmentInfo(machineTag, fsTag, state.FilesystemAttachmentInfo{
+		MountPoint: "/mnt/foo",
+		ReadOnly:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystems := model.Filesystems()
+	c.Assert(filesystems, gc.HasLen, 2)
+	provisioned, notProvisioned := filesystems[0], filesystems[1]
+
+	c.Check(provisioned.Tag(), gc.Equals, fsTag)
+	c.Check(provisioned.Volume(), gc.Equals, names.VolumeTag{})
+	c.Check(provisioned.Storage(), gc.Equals, names.StorageTag{})
+	binding, err := provisioned.Binding()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(binding, gc.Equals, machineTag)
+	c.Check(provisioned.Provisioned(), jc.IsTrue)
+	c.Check(provisioned.Size(), gc.Equals, uint64(1500))
+	c.Check(provisioned.Pool(), gc.Equals, "rootfs")
+	c.Check(provisioned.FilesystemID(), gc.Equals, "filesystem id")
+	attachments := provisioned.Attachments()
+	c.Assert(attachments, gc.HasLen, 1)
+	attachment := attachments[0]
+	c.Check(attachment.Machine(), gc.Equals, machineTag)
+	c.Check(attachment.Provisioned(), jc.IsTrue)
+	c.Check(attachment.MountPoint(), gc.Equals, "/mnt/foo")
+	c.Check(attachment.ReadOnly(), jc.IsTrue)
+
+	c.Check(notProvisioned.Tag(), gc.Equals, names.NewFilesystemTag("0/1"))
+	c.Check(notProvisioned.Volume(), gc.Equals, names.VolumeTag{})
+	c.Check(notProvisioned.Storage(), gc.Equals, names.StorageTag{})
+	binding, err = notProvisioned.Binding()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(binding, gc.Equals, machineTag)
+	c.Check(notProvisioned.Provisioned(), jc.IsFalse)
+	c.Check(notProvisioned.Size(), gc.Equals, uint64(4000))
+	c.Check(notProvisioned.Pool(), gc.Equals, "rootfs")
+	c.Check(notProvisioned.FilesystemID(), gc.Equals, "")
+	attachments = notProvisioned.Attachments()
+	c.Assert(attachments, gc.HasLen, 1)
+	attachment = attachments[0]
+	c.Check(attachment.Machine(), gc.Equals, machineTag)
+	c.Check(attachment.Provisioned(), jc.IsFalse)
+	c.Check(attachment.MountPoint(), gc.Equals, "")
+	c.Check(attachment.ReadOnly(), jc.IsFalse)
+}
+
+func (s *MigrationExportSuite) TestStorage(c *gc.C) {
+	_, u, storageTag := s.makeUnitWithStorage(c)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	storages := model.Storages()
+	c.Assert(storages, gc.HasLen, 1)
+
+	storage := storages[0]
+
+	c.Check(storage.Tag(), gc.Equals, storageTag)
+	c.Check(storage.Kind(), gc.Equals, "block")
+	owner, err := storage.Owner()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(owner, gc.Equals, u.Tag())
+	c.Check(storage.Name(), gc.Equals, "data")
+	c.Check(storage.Attachments(), jc.DeepEquals, []names.UnitTag{
+		u.UnitTag(),
+	})
+}
+
+func (s *MigrationExportSuite) TestStoragePools(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State))
+	_, err := pm.Create("test-pool", provider.LoopProviderType, map[string]interface{}{
+		"value": 42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools := model.StoragePools()
+	c.Assert(pools, gc.HasLen, 1)
+	pool := pools[0]
+	c.Assert(pool.Name(), gc.Equals, "test-pool")
+	c.Assert(pool.Provider(), gc.Equals, "loop")
+	c.Assert(pool.Attributes(), jc.DeepEquals, map[string]interface{}{
+		"value": 42,
+	})
+}
+
+func (s *MigrationExportSuite) TestResources(c *gc.C) {
+	ch := s.AddTestingCharm(c, "wordpress")
+	s.AddTestingService(c, "a-application", ch)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.SetResource("a-application", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.SetCharmStoreResources("a-application", []charmresource.Resource{res.Resource}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	resources := applications[0].Resources()
+	c.Assert(resources, gc.HasLen, 1)
+
+	exported := resources[0]
+	c.Check(exported.Name(), gc.Equals, "spam")
+	appRev := exported.ApplicationRevision()
+	c.Assert(appRev, gc.NotNil)
+	c.Check(appRev.Type(), gc.Equals, res.Type.String())
+	c.Check(appRev.Origin(), gc.Equals, res.Origin.String())
+	c.Check(appRev.Revision(), gc.Equals, res.Revision)
+	c.Check(appRev.FingerprintHex(), gc.Equals, res.Fingerprint.Hex())
+	c.Check(appRev.Size(), gc.Equals, res.Size)
+	c.Check(appRev.Username(), gc.Equals, res.Username)
+	c.Check(appRev.Timestamp().IsZero(), jc.IsFalse)
+
+	csRev := exported.CharmStoreRevision()
+	c.Assert(csRev, gc.NotNil)
+	c.Check(csRev.FingerprintHex(), gc.Equals, res.Fingerprint.Hex())
+	c.Check(csRev.Revision(), gc.Equals, res.Revision)
+}
+
+func (s *MigrationExportSuite) TestPayloads(c *gc.C) {
+	unit := addUnit(c, s.ConnSuite, unitArgs{
+		charm:    "dummy",
+		service:  "a-application",
+		metadata: payloadsMetaYAML,
+		machine:  "0",
+	})
+	up, err := s.State.UnitPayloads(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = up.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "payloadA",
+			Type: "docker",
+		},
+		Status: payload.StateRunning,
+		ID:     "xyz",
+		Labels: []string{"foo"},
+		Unit:   unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	units := applications[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	payloads := units[0].Payloads()
+	c.Assert(payloads, gc.HasLen, 1)
+
+	exported := payloads[0]
+	c.Check(exported.Name(), gc.Equals, "payloadA")
+	c.Check(exported.Type(), gc.Equals, "docker")
+	c.Check(exported.RawID(), gc.Equals, "xyz")
+	c.Check(exported.State(), gc.Equals, payload.StateRunning)
+	c.Check(exported.Labels(), jc.DeepEquals, []string{"foo"})
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -17,7 +19,11 @@ import (
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/tools"
 )
 
@@ -101,6 +107,9 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
+	if err := restore.storage(); err != nil {
+		return nil, nil, errors.Annotate(err, "storage")
+	}
 
 	// NOTE: at the end of the import make sure that the mode of the model
 	// is set to "imported" not "active" (or whatever we call it). This way
@@ -260,7 +269,6 @@ func (i *importer) machine(m description.Machine) error {
 	//    - adds status doc
 	//    - adds machine block devices doc
 
-	// TODO: consider filesystems and volumes
 	mStatus := m.Status()
 	if mStatus == nil {
 		return errors.NotValidf("missing status")
@@ -347,6 +355,7 @@ func (i *importer) machinePortsOps(m description.Machine) []txn.Op {
 				FromPort: opened.FromPort(),
 				ToPort:   opened.ToPort(),
 				Protocol: opened.Protocol(),
+				Endpoint: opened.Endpoint(),
 			})
 		}
 		result = append(result, txn.Op{
@@ -431,9 +440,39 @@ func (i *importer) makeMachineDoc(m description.Machine) (*machineDoc, error) {
 		SupportedContainersKnown: supportedSet,
 		SupportedContainers:      supportedContainers,
 		Placement:                m.Placement(),
+		Volumes:                  i.machineVolumes(id),
+		Filesystems:              i.machineFilesystems(id),
 	}, nil
 }
 
+// machineVolumes returns the names of the volumes in the model that
+// are attached to the specified machine.
+func (i *importer) machineVolumes(machineId string) []string {
+	var result []string
+	for _, volume := range i.model.Volumes() {
+		for _, attachment := range volume.Attachments() {
+			if attachment.Machine().Id() == machineId {
+				result = append(result, volume.Tag().Id())
+			}
+		}
+	}
+	return result
+}
+
+// machineFilesystems returns the names of the filesystems in the model
+// that are attached to the specified machine.
+func (i *importer) machineFilesystems(machineId string) []string {
+	var result []string
+	for _, filesystem := range i.model.Filesystems() {
+		for _, attachment := range filesystem.Attachments() {
+			if attachment.Machine().Id() == machineId {
+				result = append(result, filesystem.Tag().Id())
+			}
+		}
+	}
+	return result
+}
+
 func (i *importer) makeMachineJobs(jobs []string) ([]MachineJob, error) {
 	// At time of writing, there are three valid jobs. If any jobs gets
 	// deprecated or changed in the future, older models that specify those
@@ -578,6 +617,10 @@ func (i *importer) application(s description.Application) error {
 		}
 	}
 
+	if err := i.resources(s); err != nil {
+		return errors.Annotate(err, "resources")
+	}
+
 	if s.Leader() != "" {
 		if err := i.st.LeadershipClaimer().ClaimLeadership(
 			s.Name(),
@@ -651,6 +694,18 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 		ops = append(ops, createConstraintsOp(i.st, agentGlobalKey, i.constraints(cons)))
 	}
 
+	if state := u.State(); len(state) > 0 {
+		ops = append(ops, txn.Op{
+			C:      unitStatesC,
+			Id:     i.st.docID(unitGlobalKey(u.Name())),
+			Assert: txn.DocMissing,
+			Insert: &unitStateDoc{
+				ModelUUID: i.st.ModelUUID(),
+				State:     escapeUnitState(state),
+			},
+		})
+	}
+
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
 	}
@@ -670,10 +725,124 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 	if err := i.importStatusHistory(unit.globalWorkloadVersionKey(), u.WorkloadVersionHistory()); err != nil {
 		return errors.Trace(err)
 	}
+	if err := i.importUnitPayloads(unit, u.Payloads()); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (i *importer) importUnitPayloads(unit *Unit, payloads []description.Payload) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+	up, err := i.st.UnitPayloads(unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, p := range payloads {
+		if err := up.Track(payload.Payload{
+			PayloadClass: charm.PayloadClass{
+				Name: p.Name(),
+				Type: p.Type(),
+			},
+			ID:     p.RawID(),
+			Status: p.State(),
+			Labels: p.Labels(),
+			Unit:   unit.Name(),
+		}); err != nil {
+			return errors.Annotatef(err, "payload %q", p.Name())
+		}
+	}
+	return nil
+}
+
+func (i *importer) resources(app description.Application) error {
+	i.logger.Debugf("importing resources for %s", app.Name())
+	persist := NewResourcePersistence(i.st.newPersistence())
+
+	for _, r := range app.Resources() {
+		appRev := r.ApplicationRevision()
+		res, err := descriptionRevisionToResource(app.Name(), r.Name(), appRev)
+		if err != nil {
+			return errors.Annotatef(err, "resource %q", r.Name())
+		}
+		if err := persist.SetResource(res); err != nil {
+			return errors.Annotatef(err, "resource %q", r.Name())
+		}
+
+		if csRev := r.CharmStoreRevision(); csRev != nil {
+			csRes, err := descriptionRevisionToResource(app.Name(), r.Name(), csRev)
+			if err != nil {
+				return errors.Annotatef(err, "resource %q charm store revision", r.Name())
+			}
+			// The time the charm store was last polled isn't
+			// recorded in the description, use the timestamp
+			// of the revision instead.
+			lastPolled := csRev.Timestamp()
+			if lastPolled.IsZero() {
+				lastPolled = time.Now().UTC()
+			}
+			if err := persist.SetCharmStoreResource(res.ID, app.Name(), csRes.Resource, lastPolled); err != nil {
+				return errors.Annotatef(err, "resource %q charm store revision", r.Name())
+			}
+		}
+	}
 
+	for _, u := range app.Units() {
+		for _, ur := range u.Resources() {
+			res, err := descriptionRevisionToResource(app.Name(), ur.Name(), ur.Revision())
+			if err != nil {
+				return errors.Annotatef(err, "unit %s resource %q", u.Name(), ur.Name())
+			}
+			if err := persist.SetUnitResource(u.Name(), res); err != nil {
+				return errors.Annotatef(err, "unit %s resource %q", u.Name(), ur.Name())
+			}
+		}
+	}
 	return nil
 }
 
+func descriptionRevisionToResource(appName, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	if rev == nil {
+		return resource.Resource{}, errors.NotValidf("missing revision")
+	}
+	resType, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if hex := rev.FingerprintHex(); hex != "" {
+		fp, err = charmresource.ParseFingerprint(hex)
+		if err != nil {
+			return resource.Resource{}, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        resType,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Fingerprint: fp,
+			Size:        rev.Size(),
+		},
+		ID:            appName + "/" + name,
+		ApplicationID: appName,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
+
 func (i *importer) makeApplicationDoc(s description.Application) (*applicationDoc, error) {
 	charmUrl, err := charm.ParseURL(s.CharmURL())
 	if err != nil {
@@ -694,9 +863,24 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		Exposed:              s.Exposed(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
+		ExposedEndpoints:     importExposedEndpoints(s.ExposedEndpoints()),
 	}, nil
 }
 
+func importExposedEndpoints(exposed map[string]description.ExposedEndpoint) map[string]ExposedEndpoint {
+	if len(exposed) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint)
+	for name, ep := range exposed {
+		result[name] = ExposedEndpoint{
+			ExposeToSpaces: ep.ExposeToSpaces(),
+			ExposeToCIDRs:  ep.ExposeToCIDRs(),
+		}
+	}
+	return result
+}
+
 func (i *importer) relationCount(application string) int {
 	count := 0
 
@@ -730,20 +914,32 @@ func (i *importer) makeUnitDoc(s description.Application, u description.Unit) (*
 	}
 
 	return &unitDoc{
-		Name:         u.Name(),
-		Application:  s.Name(),
-		Series:       s.Series(),
-		CharmURL:     charmUrl,
-		Principal:    u.Principal().Id(),
-		Subordinates: subordinates,
-		// StorageAttachmentCount int `bson:"storageattachmentcount"`
-		MachineId:    u.Machine().Id(),
-		Tools:        i.makeTools(u.Tools()),
-		Life:         Alive,
-		PasswordHash: u.PasswordHash(),
+		Name:                   u.Name(),
+		Application:            s.Name(),
+		Series:                 s.Series(),
+		CharmURL:               charmUrl,
+		Principal:              u.Principal().Id(),
+		Subordinates:           subordinates,
+		StorageAttachmentCount: i.unitStorageAttachmentCount(u.Tag()),
+		MachineId:              u.Machine().Id(),
+		Tools:                  i.makeTools(u.Tools()),
+		Life:                   Alive,
+		PasswordHash:           u.PasswordHash(),
 	}, nil
 }
 
+func (i *importer) unitStorageAttachmentCount(unit names.UnitTag) int {
+	count := 0
+	for _, instance := range i.model.Storages() {
+		for _, tag := range instance.Attachments() {
+			if tag == unit {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func (i *importer) relations() error {
 	i.logger.Debugf("importing relations")
 	for _, r := range i.model.Relations() {
@@ -825,6 +1021,314 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 	return doc
 }
 
+func (i *importer) storage() error {
+	if err := i.storageInstances(); err != nil {
+		return errors.Annotate(err, "storage instances")
+	}
+	if err := i.volumes(); err != nil {
+		return errors.Annotate(err, "volumes")
+	}
+	if err := i.filesystems(); err != nil {
+		return errors.Annotate(err, "filesystems")
+	}
+	if err := i.storagePools(); err != nil {
+		return errors.Annotate(err, "storage pools")
+	}
+	return nil
+}
+
+func (i *importer) storageInstances() error {
+	i.logger.Debugf("importing storage instances")
+	for _, instance := range i.model.Storages() {
+		if err := i.addStorageInstance(instance); err != nil {
+			i.logger.Errorf("error importing storage %s: %s", instance.Tag(), err)
+			return errors.Annotate(err, instance.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing storage instances succeeded")
+	return nil
+}
+
+func (i *importer) addStorageInstance(instance description.Storage) error {
+	kind, err := parseStorageKind(instance.Kind())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner, err := instance.Owner()
+	if err != nil {
+		return errors.Annotate(err, "storage owner")
+	}
+	var charmURL *charm.URL
+	var ownerString string
+	if owner != nil {
+		charmURL, err = i.storageCharmURL(owner)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ownerString = owner.String()
+	}
+	attachments := instance.Attachments()
+	tag := instance.Tag()
+	var ops []txn.Op
+	for _, unit := range attachments {
+		ops = append(ops, createStorageAttachmentOp(tag, unit))
+	}
+	doc := &storageInstanceDoc{
+		Id:              tag.Id(),
+		Kind:            kind,
+		Owner:           ownerString,
+		StorageName:     instance.Name(),
+		AttachmentCount: len(attachments),
+		CharmURL:        charmURL,
+	}
+	ops = append(ops, txn.Op{
+		C:      storageInstancesC,
+		Id:     tag.Id(),
+		Assert: txn.DocMissing,
+		Insert: doc,
+	})
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// storageCharmURL returns the charm URL of the application that owns,
+// or whose unit owns, a storage instance.
+func (i *importer) storageCharmURL(owner names.Tag) (*charm.URL, error) {
+	var appName string
+	switch owner := owner.(type) {
+	case names.ApplicationTag:
+		appName = owner.Id()
+	case names.UnitTag:
+		appName = names.UnitApplication(owner.Id())
+	default:
+		return nil, errors.NotValidf("storage owner %q", owner)
+	}
+	for _, app := range i.model.Applications() {
+		if app.Name() == appName {
+			curl, err := charm.ParseURL(app.CharmURL())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return curl, nil
+		}
+	}
+	return nil, errors.NotFoundf("application %q", appName)
+}
+
+func (i *importer) volumes() error {
+	i.logger.Debugf("importing volumes")
+	for _, vol := range i.model.Volumes() {
+		if err := i.addVolume(vol); err != nil {
+			i.logger.Errorf("error importing volume %s: %s", vol.Tag(), err)
+			return errors.Annotate(err, vol.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing volumes succeeded")
+	return nil
+}
+
+func (i *importer) addVolume(vol description.Volume) error {
+	attachments := vol.Attachments()
+	tag := vol.Tag()
+	var binding string
+	bindingTag, err := vol.Binding()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if bindingTag != nil {
+		binding = bindingTag.String()
+	}
+	status := vol.Status()
+	if status == nil {
+		return errors.NotValidf("missing status")
+	}
+	doc := volumeDoc{
+		Name:            tag.Id(),
+		StorageId:       vol.Storage().Id(),
+		AttachmentCount: len(attachments),
+		Binding:         binding,
+	}
+	if vol.Provisioned() {
+		doc.Info = &VolumeInfo{
+			HardwareId: vol.HardwareID(),
+			Size:       vol.Size(),
+			Pool:       vol.Pool(),
+			VolumeId:   vol.VolumeID(),
+			Persistent: vol.Persistent(),
+		}
+	} else {
+		doc.Params = &VolumeParams{
+			Size: vol.Size(),
+			Pool: vol.Pool(),
+		}
+	}
+	ops := []txn.Op{
+		createStatusOp(i.st, volumeGlobalKey(tag.Id()), i.makeStatusDoc(status)),
+		{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		},
+	}
+	for _, attachment := range attachments {
+		ops = append(ops, i.addVolumeAttachmentOp(tag.Id(), attachment))
+	}
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := i.importStatusHistory(volumeGlobalKey(tag.Id()), vol.StatusHistory()); err != nil {
+		return errors.Annotate(err, "status history")
+	}
+	return nil
+}
+
+func (i *importer) addVolumeAttachmentOp(volId string, attachment description.VolumeAttachment) txn.Op {
+	var info *VolumeAttachmentInfo
+	var params *VolumeAttachmentParams
+	if attachment.Provisioned() {
+		info = &VolumeAttachmentInfo{
+			DeviceName: attachment.DeviceName(),
+			DeviceLink: attachment.DeviceLink(),
+			BusAddress: attachment.BusAddress(),
+			ReadOnly:   attachment.ReadOnly(),
+		}
+	} else {
+		params = &VolumeAttachmentParams{
+			ReadOnly: attachment.ReadOnly(),
+		}
+	}
+
+	machineId := attachment.Machine().Id()
+	return txn.Op{
+		C:      volumeAttachmentsC,
+		Id:     volumeAttachmentId(machineId, volId),
+		Assert: txn.DocMissing,
+		Insert: &volumeAttachmentDoc{
+			Volume:  volId,
+			Machine: machineId,
+			Params:  params,
+			Info:    info,
+		},
+	}
+}
+
+func (i *importer) filesystems() error {
+	i.logger.Debugf("importing filesystems")
+	for _, fs := range i.model.Filesystems() {
+		if err := i.addFilesystem(fs); err != nil {
+			i.logger.Errorf("error importing filesystem %s: %s", fs.Tag(), err)
+			return errors.Annotate(err, fs.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing filesystems succeeded")
+	return nil
+}
+
+func (i *importer) addFilesystem(fs description.Filesystem) error {
+	attachments := fs.Attachments()
+	tag := fs.Tag()
+	var binding string
+	bindingTag, err := fs.Binding()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if bindingTag != nil {
+		binding = bindingTag.String()
+	}
+	status := fs.Status()
+	if status == nil {
+		return errors.NotValidf("missing status")
+	}
+	doc := filesystemDoc{
+		FilesystemId:    tag.Id(),
+		StorageId:       fs.Storage().Id(),
+		VolumeId:        fs.Volume().Id(),
+		AttachmentCount: len(attachments),
+		Binding:         binding,
+	}
+	if fs.Provisioned() {
+		doc.Info = &FilesystemInfo{
+			Size:         fs.Size(),
+			Pool:         fs.Pool(),
+			FilesystemId: fs.FilesystemID(),
+		}
+	} else {
+		doc.Params = &FilesystemParams{
+			Size: fs.Size(),
+			Pool: fs.Pool(),
+		}
+	}
+	ops := []txn.Op{
+		createStatusOp(i.st, filesystemGlobalKey(tag.Id()), i.makeStatusDoc(status)),
+		{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		},
+	}
+	for _, attachment := range attachments {
+		ops = append(ops, i.addFilesystemAttachmentOp(tag.Id(), attachment))
+	}
+
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := i.importStatusHistory(filesystemGlobalKey(tag.Id()), fs.StatusHistory()); err != nil {
+		return errors.Annotate(err, "status history")
+	}
+	return nil
+}
+
+func (i *importer) addFilesystemAttachmentOp(fsId string, attachment description.FilesystemAttachment) txn.Op {
+	var info *FilesystemAttachmentInfo
+	var params *FilesystemAttachmentParams
+	if attachment.Provisioned() {
+		info = &FilesystemAttachmentInfo{
+			MountPoint: attachment.MountPoint(),
+			ReadOnly:   attachment.ReadOnly(),
+		}
+	} else {
+		params = &FilesystemAttachmentParams{
+			Location: attachment.MountPoint(),
+			ReadOnly: attachment.ReadOnly(),
+		}
+	}
+
+	machineId := attachment.Machine().Id()
+	return txn.Op{
+		C:      filesystemAttachmentsC,
+		Id:     filesystemAttachmentId(machineId, fsId),
+		Assert: txn.DocMissing,
+		Insert: &filesystemAttachmentDoc{
+			Filesystem: fsId,
+			Machine:    machineId,
+			Params:     params,
+			Info:       info,
+		},
+	}
+}
+
+func (i *importer) storagePools() error {
+	i.logger.Debugf("importing storage pools")
+	pm := poolmanager.New(NewStateSettings(i.st))
+	for _, pool := range i.model.StoragePools() {
+		_, err := pm.Create(pool.Name(), storage.ProviderType(pool.Provider()), pool.Attributes())
+		if err != nil {
+			return errors.Annotatef(err, "creating pool %q", pool.Name())
+		}
+	}
+	i.logger.Debugf("importing storage pools succeeded")
+	return nil
+}
+
 func (i *importer) importStatusHistory(globalKey string, history []description.Status) error {
 	docs := make([]interface{}, len(history))
 	for i, statusVal := range history {
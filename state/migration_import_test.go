@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"bytes"
+	"io/ioutil"
 	"time"
 
 	"github.com/juju/errors"
@@ -11,13 +13,18 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -308,6 +315,7 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(imported.ApplicationTag(), gc.Equals, exported.ApplicationTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedEndpoints(), gc.IsNil)
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -331,6 +339,27 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(newCons.String(), gc.Equals, cons.String())
 }
 
+func (s *MigrationImportSuite) TestApplicationExposeSettings(c *gc.C) {
+	service := s.Factory.MakeApplication(c, nil)
+	err := service.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Application(service.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.IsExposed(), jc.IsTrue)
+	c.Assert(imported.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	cidrs, err := imported.IngressCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/24"})
+}
+
 func (s *MigrationImportSuite) TestApplicationLeaders(c *gc.C) {
 	s.makeApplicationWithLeader(c, "mysql", 2, 1)
 	s.makeApplicationWithLeader(c, "wordpress", 4, 2)
@@ -359,6 +388,8 @@ func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = exported.SetWorkloadVersion("amethyst")
 	c.Assert(err, jc.ErrorIsNil)
+	err = exported.SetState(map[string]string{"db.host": "10.0.0.5"})
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(exported, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, exported, status.StatusActive, 5)
@@ -381,6 +412,9 @@ func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	version, err := imported.WorkloadVersion()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(version, gc.Equals, "amethyst")
+	unitState, err := imported.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"db.host": "10.0.0.5"})
 
 	exportedMachineId, err := exported.AssignedMachineId()
 	c.Assert(err, jc.ErrorIsNil)
@@ -470,6 +504,292 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestUnitsOpenPortsForEndpoint(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.OpenPortsForEndpoint("server", "tcp", 3306, 3306)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	machine, err := newSt.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{FromPort: 3306, ToPort: 3306, Protocol: "tcp"}: "server",
+	})
+}
+
+func (s *MigrationImportSuite) TestVolumes(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume:     state.VolumeParams{Size: 1234},
+			Attachment: state.VolumeAttachmentParams{ReadOnly: true},
+		}, {
+			Volume: state.VolumeParams{Size: 4000},
+		}},
+	})
+	machineTag := machine.MachineTag()
+	volTag := names.NewVolumeTag("0/0")
+	volInfo := state.VolumeInfo{
+		HardwareId: "magic",
+		Size:       1500,
+		VolumeId:   "volume id",
+		Pool:       "loop",
+		Persistent: true,
+	}
+	err := s.State.SetVolumeInfo(volTag, volInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	volAttachmentInfo := state.VolumeAttachmentInfo{
+		DeviceName: "device name",
+		DeviceLink: "device link",
+		BusAddress: "bus address",
+		ReadOnly:   true,
+	}
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volTag, volAttachmentInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	volume, err := newSt.Volume(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, volInfo)
+
+	attachment, err := newSt.VolumeAttachment(machineTag, volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	attInfo, err := attachment.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attInfo, jc.DeepEquals, volAttachmentInfo)
+
+	volTag = names.NewVolumeTag("0/1")
+	volume, err = newSt.Volume(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	params, needsProvisioning := volume.Params()
+	c.Check(needsProvisioning, jc.IsTrue)
+	c.Check(params.Pool, gc.Equals, "loop")
+	c.Check(params.Size, gc.Equals, uint64(4000))
+
+	attachment, err = newSt.VolumeAttachment(machineTag, volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	attParams, needsProvisioning := attachment.Params()
+	c.Check(needsProvisioning, jc.IsTrue)
+	c.Check(attParams.ReadOnly, jc.IsFalse)
+
+	volumes, err := newSt.MachineVolumeAttachments(machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(volumes, gc.HasLen, 2)
+}
+
+func (s *MigrationImportSuite) TestFilesystems(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 1234},
+			Attachment: state.FilesystemAttachmentParams{
+				Location: "location",
+				ReadOnly: true},
+		}, {
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 4000},
+		}},
+	})
+	machineTag := machine.MachineTag()
+	fsTag := names.NewFilesystemTag("0/0")
+	fsInfo := state.FilesystemInfo{
+		Size:         1500,
+		Pool:         "rootfs",
+		FilesystemId: "filesystem id",
+	}
+	err := s.State.SetFilesystemInfo(fsTag, fsInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	fsAttachmentInfo := state.FilesystemAttachmentInfo{
+		MountPoint: "/mnt/foo",
+		ReadOnly:   true,
+	}
+	err = s.State.SetFilesystemAttachmentInfo(machineTag, fsTag, fsAttachmentInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	filesystem, err := newSt.Filesystem(fsTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := filesystem.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, fsInfo)
+
+	attachment, err := newSt.FilesystemAttachment(machineTag, fsTag)
+	c.Assert(err, jc.ErrorIsNil)
+	attInfo, err := attachment.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attInfo, jc.DeepEquals, fsAttachmentInfo)
+
+	fsTag = names.NewFilesystemTag("0/1")
+	filesystem, err = newSt.Filesystem(fsTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	params, needsProvisioning := filesystem.Params()
+	c.Check(needsProvisioning, jc.IsTrue)
+	c.Check(params.Pool, gc.Equals, "rootfs")
+	c.Check(params.Size, gc.Equals, uint64(4000))
+
+	attachment, err = newSt.FilesystemAttachment(machineTag, fsTag)
+	c.Assert(err, jc.ErrorIsNil)
+	attParams, needsProvisioning := attachment.Params()
+	c.Check(needsProvisioning, jc.IsTrue)
+	c.Check(attParams.ReadOnly, jc.IsFalse)
+
+	filesystems, err := newSt.MachineFilesystemAttachments(machineTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(filesystems, gc.HasLen, 2)
+}
+
+func (s *MigrationImportSuite) TestStorage(c *gc.C) {
+	_, u, storageTag := s.makeUnitWithStorage(c)
+
+	original, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	originalCount := state.StorageAttachmentCount(original)
+	c.Assert(originalCount, gc.Equals, 1)
+	originalAttachments, err := s.State.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(originalAttachments, gc.HasLen, 1)
+	c.Assert(originalAttachments[0].Unit(), gc.Equals, u.UnitTag())
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	instance, err := newSt.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(instance.Tag(), gc.Equals, original.Tag())
+	c.Check(instance.Kind(), gc.Equals, original.Kind())
+	c.Check(instance.Life(), gc.Equals, original.Life())
+	c.Check(instance.StorageName(), gc.Equals, original.StorageName())
+	c.Check(state.StorageAttachmentCount(instance), gc.Equals, originalCount)
+
+	attachments, err := newSt.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Assert(attachments[0].Unit(), gc.Equals, u.UnitTag())
+
+	unitAttachments, err := newSt.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitAttachments, gc.HasLen, 1)
+	c.Assert(unitAttachments[0].StorageInstance(), gc.Equals, storageTag)
+}
+
+func (s *MigrationImportSuite) TestStoragePools(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State))
+	_, err := pm.Create("test-pool", provider.LoopProviderType, map[string]interface{}{
+		"value": 42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	pm = poolmanager.New(state.NewStateSettings(newSt))
+	pools, err := pm.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, gc.HasLen, 1)
+
+	pool := pools[0]
+	c.Assert(pool.Name(), gc.Equals, "test-pool")
+	c.Assert(pool.Provider(), gc.Equals, provider.LoopProviderType)
+	c.Assert(pool.Attrs(), jc.DeepEquals, map[string]interface{}{
+		"value": 42,
+	})
+}
+
+func (s *MigrationImportSuite) TestResources(c *gc.C) {
+	ch := s.AddTestingCharm(c, "wordpress")
+	s.AddTestingService(c, "a-application", ch)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.SetResource("a-application", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.SetCharmStoreResources("a-application", []charmresource.Resource{res.Resource}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	original, err := st.ListResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	newResources, err := newSt.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := newResources.ListResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Resources, gc.HasLen, 1)
+	c.Assert(imported.CharmStoreResources, jc.DeepEquals, original.CharmStoreResources)
+
+	importedRes := imported.Resources[0]
+	originalRes := original.Resources[0]
+	c.Check(importedRes.Timestamp.Equal(originalRes.Timestamp), jc.IsTrue)
+	importedRes.Timestamp = originalRes.Timestamp
+	c.Check(importedRes, jc.DeepEquals, originalRes)
+
+	// The data isn't migrated as part of the model, so it needs to
+	// be stored separately before the resource can be opened.
+	_, err = newResources.StoreResourceData("a-application", "spam", bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	_, reader, err := newResources.OpenResource("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, data)
+}
+
+func (s *MigrationImportSuite) TestPayloads(c *gc.C) {
+	originalUnit := addUnit(c, s.ConnSuite, unitArgs{
+		charm:    "dummy",
+		service:  "a-application",
+		metadata: payloadsMetaYAML,
+		machine:  "0",
+	})
+	up, err := s.State.UnitPayloads(originalUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	original := payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "payloadA",
+			Type: "docker",
+		},
+		Status: payload.StateRunning,
+		ID:     "xyz",
+		Labels: []string{"foo"},
+		Unit:   originalUnit.Name(),
+	}
+	err = up.Track(original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	unit, err := newSt.Unit(originalUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	newUP, err := newSt.UnitPayloads(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	payloads, err := newUP.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(payloads, gc.HasLen, 1)
+	c.Assert(payloads[0].Payload, gc.NotNil)
+	c.Check(payloads[0].Payload.Payload, jc.DeepEquals, original)
+	c.Check(payloads[0].Payload.Machine, gc.Equals, "0")
+}
+
 func (s *MigrationImportSuite) TestDestroyEmptyModel(c *gc.C) {
 	newModel, newSt := s.importModel(c)
 	defer newSt.Close()
@@ -39,6 +39,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		applicationsC,
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
+		unitStatesC,  // key-value state stored by unit charms
+		"payloads",
+		"resources",
 
 		// settings reference counts are only used for applications
 		settingsrefsC,
@@ -46,6 +49,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// relation
 		relationsC,
 		relationScopesC,
+
+		// storage
+		storageInstancesC,
+		storageAttachmentsC,
+		volumesC,
+		volumeAttachmentsC,
+		filesystemsC,
+		filesystemAttachmentsC,
 	)
 
 	ignoredCollections := set.NewStrings(
@@ -88,6 +99,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsC,
 		migrationsStatusC,
 		migrationsActiveC,
+		migrationsMinionSyncC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -126,19 +138,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// service / unit
 		charmsC,
-		"payloads",
-		"resources",
 		endpointBindingsC,
 
 		// storage
 		blockDevicesC,
-		filesystemsC,
-		filesystemAttachmentsC,
-		storageInstancesC,
-		storageAttachmentsC,
 		storageConstraintsC,
-		volumesC,
-		volumeAttachmentsC,
 
 		// network
 		ipAddressesC,
@@ -259,16 +263,18 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"SupportedContainers",
 		"SupportedContainersKnown",
 		"Tools",
+		// Volumes and Filesystems are derived from the volume and
+		// filesystem attachments in the model.
+		"Volumes",
+		"Filesystems",
 
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
 	)
 	todo := set.NewStrings(
-		"Volumes",
 		"NoVote",
 		"Clean",
-		"Filesystems",
 		"HasVote",
 	)
 	s.AssertExportedFields(c, machineDoc{}, fields.Union(todo))
@@ -322,6 +328,7 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedEndpoints",
 		"MinUnits",
 		"MetricCredentials",
 	)
@@ -363,12 +370,12 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		// TxnRevno isn't migrated.
 		"TxnRevno",
 		"PasswordHash",
-	)
-	todo := set.NewStrings(
+		// StorageAttachmentCount is derived from the storage attachments
+		// in the model.
 		"StorageAttachmentCount",
 	)
 
-	s.AssertExportedFields(c, unitDoc{}, fields.Union(todo))
+	s.AssertExportedFields(c, unitDoc{}, fields)
 }
 
 func (s *MigrationSuite) TestPortsDocFields(c *gc.C) {
@@ -522,6 +529,143 @@ func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
 	s.AssertExportedFields(c, historicalStatusDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Id",
+		"Kind",
+		"Owner",
+		"StorageName",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		// AttachmentCount is derived from the storage attachments.
+		"AttachmentCount",
+		// CharmURL comes from the owning application.
+		"CharmURL",
+	)
+	s.AssertExportedFields(c, storageInstanceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestStorageAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Unit",
+		"StorageInstance",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+	)
+	s.AssertExportedFields(c, storageAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Name",
+		"StorageId",
+		"Binding",
+		"Info",
+		"Params",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		// AttachmentCount is derived from the volume attachments.
+		"AttachmentCount",
+		// PendingSize isn't migrated; pending resizes must be
+		// requested again in the target model.
+		"PendingSize",
+	)
+	s.AssertExportedFields(c, volumeDoc{}, fields)
+	// The info and params fields are structs.
+	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
+		"HardwareId", "Size", "Pool", "VolumeId", "Persistent"))
+	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
+		"Pool", "Size"))
+}
+
+func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Volume",
+		"Machine",
+		"Info",
+		"Params",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+	)
+	s.AssertExportedFields(c, volumeAttachmentDoc{}, fields)
+	// The info and params fields are structs.
+	s.AssertExportedFields(c, VolumeAttachmentInfo{}, set.NewStrings(
+		"DeviceName", "DeviceLink", "BusAddress", "ReadOnly"))
+	s.AssertExportedFields(c, VolumeAttachmentParams{}, set.NewStrings(
+		"ReadOnly"))
+}
+
+func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"FilesystemId",
+		"StorageId",
+		"VolumeId",
+		"Binding",
+		"Info",
+		"Params",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+		// AttachmentCount is derived from the filesystem attachments.
+		"AttachmentCount",
+		// PendingSize isn't migrated; pending resizes must be
+		// requested again in the target model.
+		"PendingSize",
+	)
+	s.AssertExportedFields(c, filesystemDoc{}, fields)
+	// The info and params fields are structs.
+	s.AssertExportedFields(c, FilesystemInfo{}, set.NewStrings(
+		"Size", "Pool", "FilesystemId"))
+	s.AssertExportedFields(c, FilesystemParams{}, set.NewStrings(
+		"Pool", "Size"))
+}
+
+func (s *MigrationSuite) TestFilesystemAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Filesystem",
+		"Machine",
+		"Info",
+		"Params",
+		// Life isn't migrated as we only migrate live things.
+		"Life",
+	)
+	s.AssertExportedFields(c, filesystemAttachmentDoc{}, fields)
+	// The info and params fields are structs.
+	s.AssertExportedFields(c, FilesystemAttachmentInfo{}, set.NewStrings(
+		"MountPoint", "ReadOnly"))
+	s.AssertExportedFields(c, FilesystemAttachmentParams{}, set.NewStrings(
+		"Location", "ReadOnly"))
+}
+
 func (s *MigrationSuite) AssertExportedFields(c *gc.C, doc interface{}, fields set.Strings) {
 	expected := getExportedFields(doc)
 	unknown := expected.Difference(fields)
@@ -5,16 +5,20 @@ package state
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/mongo"
 )
 
 // This file contains functionality for managing the state documents
@@ -71,6 +75,31 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// PrecheckFailures returns the problems found by the source and
+	// target controllers when checking whether the model could be
+	// migrated.
+	PrecheckFailures() []migration.PrecheckFailure
+
+	// SetPrecheckFailures records the problems found by the source
+	// and target controllers when checking whether the model could
+	// be migrated.
+	SetPrecheckFailures(failures []migration.PrecheckFailure) error
+
+	// SubmitMinionReport records a report from a migration minion
+	// worker about the success or failure to complete its actions
+	// for a given migration phase.
+	SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error
+
+	// MinionReports returns details of the minions that have
+	// reported success or failure for the current migration phase,
+	// as well as those which are yet to report.
+	MinionReports() (*MinionReports, error)
+
+	// WatchMinionReports returns a notify watcher which triggers when
+	// a migration minion has reported back about the success or
+	// failure of its actions for the current migration phase.
+	WatchMinionReports() (NotifyWatcher, error)
+
 	// Refresh updates the contents of the ModelMigration from the
 	// underlying state.
 	Refresh() error
@@ -153,6 +182,48 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// PrecheckFailures holds the problems found during the
+	// migration's PRECHECK phase.
+	PrecheckFailures []modelMigPrecheckFailureDoc `bson:"precheck-failures,omitempty"`
+}
+
+// modelMigPrecheckFailureDoc records a single problem found during
+// the PRECHECK phase of a migration.
+type modelMigPrecheckFailureDoc struct {
+	Code    string `bson:"code"`
+	Entity  string `bson:"entity,omitempty"`
+	Message string `bson:"message"`
+}
+
+// modelMigMinionSyncDoc records a report from a migration minion
+// worker about the success or failure to complete its actions for a
+// given migration phase. These are written into
+// migrationsMinionSyncC.
+type modelMigMinionSyncDoc struct {
+	// Id has the format "<migration id>:<phase>:<entity tag>".
+	Id string `bson:"_id"`
+
+	MigrationId string `bson:"migration-id"`
+	Phase       string `bson:"phase"`
+
+	// EntityKey holds the tag of the agent which made the report.
+	EntityKey string `bson:"entity-key"`
+
+	// Time holds the time the report was received (stored as per
+	// UnixNano).
+	Time int64 `bson:"time"`
+
+	Success bool `bson:"success"`
+}
+
+// MinionReports indicates the sets of agents in a model which have
+// reported success, reported failure or not reported at all for a
+// migration phase.
+type MinionReports struct {
+	Succeeded []names.Tag
+	Failed    []names.Tag
+	Unknown   []names.Tag
 }
 
 // Id implements ModelMigration.
@@ -252,11 +323,19 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 		"phase":              nextDoc.Phase,
 		"phase-changed-time": now,
 	}
+	var ops []txn.Op
 	if nextPhase == migration.SUCCESS {
 		nextDoc.SuccessTime = now
 		update["success-time"] = now
+		// The model now belongs to the target controller. Prevent
+		// further changes to it here until it is removed.
+		ops = append(ops, txn.Op{
+			C:      modelsC,
+			Id:     mig.doc.ModelUUID,
+			Assert: txn.DocExists,
+			Update: bson.M{"$set": bson.M{"migration-mode": MigrationModeExporting}},
+		})
 	}
-	var ops []txn.Op
 	if nextPhase.IsTerminal() {
 		nextDoc.EndTime = now
 		update["end-time"] = now
@@ -301,6 +380,214 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// PrecheckFailures implements ModelMigration.
+func (mig *modelMigration) PrecheckFailures() []migration.PrecheckFailure {
+	var failures []migration.PrecheckFailure
+	for _, doc := range mig.statusDoc.PrecheckFailures {
+		failures = append(failures, migration.PrecheckFailure{
+			Code:    doc.Code,
+			Entity:  doc.Entity,
+			Message: doc.Message,
+		})
+	}
+	return failures
+}
+
+// SetPrecheckFailures implements ModelMigration.
+func (mig *modelMigration) SetPrecheckFailures(failures []migration.PrecheckFailure) error {
+	docs := make([]modelMigPrecheckFailureDoc, len(failures))
+	for i, failure := range failures {
+		docs[i] = modelMigPrecheckFailureDoc{
+			Code:    failure.Code,
+			Entity:  failure.Entity,
+			Message: failure.Message,
+		}
+	}
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{"precheck-failures": docs}},
+		Assert: txn.DocExists,
+	}}
+	if err := mig.st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to set precheck failures")
+	}
+	mig.statusDoc.PrecheckFailures = docs
+	return nil
+}
+
+// SubmitMinionReport implements ModelMigration.
+func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
+	switch tag.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return errors.Errorf("unsupported agent tag: %s", tag)
+	}
+	docID := mig.minionReportId(phase, tag)
+	doc := modelMigMinionSyncDoc{
+		Id:          docID,
+		MigrationId: mig.Id(),
+		Phase:       phase.String(),
+		EntityKey:   tag.String(),
+		Time:        GetClock().Now().UnixNano(),
+		Success:     success,
+	}
+	ops := []txn.Op{{
+		C:      migrationsMinionSyncC,
+		Id:     docID,
+		Insert: &doc,
+		Assert: txn.DocMissing,
+	}}
+	err := mig.st.runTransaction(ops)
+	if errors.Cause(err) == txn.ErrAborted {
+		// A report has already been made for this agent and
+		// phase. That's fine as long as it agrees with this one.
+		coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+		defer closer()
+		var existingDoc modelMigMinionSyncDoc
+		err := coll.FindId(docID).Select(bson.M{"success": 1}).One(&existingDoc)
+		if err != nil {
+			return errors.Annotate(err, "checking existing report")
+		}
+		if existingDoc.Success != success {
+			return errors.Errorf("conflicting reports received for %s/%s/%s",
+				mig.Id(), phase.String(), tag)
+		}
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "failed to record minion report")
+	}
+	return nil
+}
+
+// MinionReports implements ModelMigration.
+func (mig *modelMigration) MinionReports() (*MinionReports, error) {
+	all, err := mig.getAllAgents()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving phase")
+	}
+
+	coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+	defer closer()
+	query := coll.Find(bson.M{"_id": bson.M{
+		"$regex": "^" + regexp.QuoteMeta(mig.minionReportId(phase, nil)),
+	}})
+	query = query.Select(bson.M{
+		"entity-key": 1,
+		"success":    1,
+	})
+	var docs []modelMigMinionSyncDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "retrieving minion reports")
+	}
+
+	succeeded := set.NewStrings()
+	failed := set.NewStrings()
+	for _, doc := range docs {
+		if !all.Contains(doc.EntityKey) {
+			// An agent which no longer exists.
+			continue
+		}
+		if doc.Success {
+			succeeded.Add(doc.EntityKey)
+		} else {
+			failed.Add(doc.EntityKey)
+		}
+	}
+	unknown := all.Difference(succeeded).Difference(failed)
+
+	var reports MinionReports
+	if reports.Succeeded, err = tagsFromStrings(succeeded); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if reports.Failed, err = tagsFromStrings(failed); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if reports.Unknown, err = tagsFromStrings(unknown); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &reports, nil
+}
+
+// WatchMinionReports implements ModelMigration.
+func (mig *modelMigration) WatchMinionReports() (NotifyWatcher, error) {
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving phase")
+	}
+	prefix := mig.minionReportId(phase, nil)
+	filter := func(rawId interface{}) bool {
+		id, ok := rawId.(string)
+		if !ok {
+			return false
+		}
+		return strings.HasPrefix(id, prefix)
+	}
+	return newNotifyCollWatcher(mig.st, migrationsMinionSyncC, filter), nil
+}
+
+// minionReportId returns the id of the minion report document for
+// the given phase and agent. If tag is nil, the prefix shared by all
+// reports for the phase is returned.
+func (mig *modelMigration) minionReportId(phase migration.Phase, tag names.Tag) string {
+	prefix := fmt.Sprintf("%s:%s:", mig.Id(), phase.String())
+	if tag == nil {
+		return prefix
+	}
+	return prefix + tag.String()
+}
+
+// getAllAgents returns the tags of all the machine and unit agents
+// in the migration's model.
+func (mig *modelMigration) getAllAgents() (set.Strings, error) {
+	agentTags := set.NewStrings()
+
+	machineColl, closer := mig.st.getCollection(machinesC)
+	defer closer()
+	var machineDocs []struct {
+		Id string `bson:"machineid"`
+	}
+	err := machineColl.Find(nil).Select(bson.M{"machineid": 1}).All(&machineDocs)
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving machines")
+	}
+	for _, doc := range machineDocs {
+		agentTags.Add(names.NewMachineTag(doc.Id).String())
+	}
+
+	unitColl, closer := mig.st.getCollection(unitsC)
+	defer closer()
+	var unitDocs []struct {
+		Name string `bson:"name"`
+	}
+	err = unitColl.Find(nil).Select(bson.M{"name": 1}).All(&unitDocs)
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving units")
+	}
+	for _, doc := range unitDocs {
+		agentTags.Add(names.NewUnitTag(doc.Name).String())
+	}
+
+	return agentTags, nil
+}
+
+func tagsFromStrings(tagStrs set.Strings) ([]names.Tag, error) {
+	var tags []names.Tag
+	for _, tagStr := range tagStrs.SortedValues() {
+		tag, err := names.ParseTag(tagStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
 // Refresh implements ModelMigration.
 func (mig *modelMigration) Refresh() error {
 	// Only the status document is updated. The modelMigDoc is static
@@ -438,6 +725,18 @@ func (st *State) GetModelMigration() (ModelMigration, error) {
 
 	query := migColl.Find(bson.M{"model-uuid": st.ModelUUID()})
 	query = query.Sort("-_id").Limit(1)
+	return st.migrationFromQuery(query)
+}
+
+// Migration retrieves a specific ModelMigration by its id. See also
+// GetModelMigration.
+func (st *State) Migration(id string) (ModelMigration, error) {
+	migColl, closer := st.getCollection(migrationsC)
+	defer closer()
+	return st.migrationFromQuery(migColl.FindId(id))
+}
+
+func (st *State) migrationFromQuery(query mongo.Query) (ModelMigration, error) {
 	var doc modelMigDoc
 	err := query.One(&doc)
 	if err == mgo.ErrNotFound {
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type ModelMigrationSuite struct {
@@ -245,6 +246,21 @@ func (s *ModelMigrationSuite) TestGetsLatestAttempt(c *gc.C) {
 	}
 }
 
+func (s *ModelMigrationSuite) TestGetById(c *gc.C) {
+	mig1, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig2, err := s.State2.Migration(mig1.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.Id(), gc.Equals, mig1.Id())
+}
+
+func (s *ModelMigrationSuite) TestGetByIdNotExist(c *gc.C) {
+	mig, err := s.State2.Migration("does-not-exist")
+	c.Check(mig, gc.IsNil)
+	c.Check(errors.IsNotFound(err), jc.IsTrue)
+}
+
 func (s *ModelMigrationSuite) TestRefresh(c *gc.C) {
 	mig1, err := s.State2.CreateModelMigration(s.stdSpec)
 	c.Assert(err, jc.ErrorIsNil)
@@ -320,6 +336,28 @@ func (s *ModelMigrationSuite) TestSuccessfulPhaseTransitions(c *gc.C) {
 	s.assertMigrationCleanedUp(c, mig)
 }
 
+func (s *ModelMigrationSuite) TestSUCCESSMarksModelExporting(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []migration.Phase{
+		migration.READONLY,
+		migration.PRECHECK,
+		migration.IMPORT,
+		migration.VALIDATION,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+		c.Assert(model.Refresh(), jc.ErrorIsNil)
+		c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeActive)
+	}
+
+	c.Assert(mig.SetPhase(migration.SUCCESS), jc.ErrorIsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
+}
+
 func (s *ModelMigrationSuite) TestABORTCleanup(c *gc.C) {
 	mig, err := s.State2.CreateModelMigration(s.stdSpec)
 	c.Assert(err, jc.ErrorIsNil)
@@ -409,6 +447,28 @@ func (s *ModelMigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *ModelMigrationSuite) TestPrecheckFailures(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.PrecheckFailures(), gc.HasLen, 0)
+
+	failures := []migration.PrecheckFailure{{
+		Code:    migration.PrecheckMachineError,
+		Entity:  "machine-0",
+		Message: "machine 0 is in error state",
+	}, {
+		Code:    migration.PrecheckModelNameTaken,
+		Message: `model "foo" already exists for admin on target`,
+	}}
+	err = mig.SetPrecheckFailures(failures)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.PrecheckFailures(), jc.DeepEquals, failures)
+
+	mig2, err := s.State2.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.PrecheckFailures(), jc.DeepEquals, failures)
+}
+
 func (s *ModelMigrationSuite) TestWatchForModelMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createWatcher(c, s.State2)
@@ -545,6 +605,120 @@ func (s *ModelMigrationSuite) TestWatchMigrationStatusMultiModel(c *gc.C) {
 	wc3.AssertNoChange()
 }
 
+func (s *ModelMigrationSuite) TestMinionReports(c *gc.C) {
+	// Create some machines and units to report with.
+	factory2 := factory.NewFactory(s.State2)
+	m0 := factory2.MakeMachine(c, nil)
+	u0 := factory2.MakeUnit(c, &factory.UnitParams{Machine: m0})
+	m1 := factory2.MakeMachine(c, nil)
+	m2 := factory2.MakeMachine(c, nil)
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	const phase = migration.QUIESCE
+	c.Assert(mig.SubmitMinionReport(m0.Tag(), phase, true), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(m1.Tag(), phase, false), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(u0.Tag(), phase, true), jc.ErrorIsNil)
+
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.SameContents, []names.Tag{m0.Tag(), u0.Tag()})
+	c.Check(reports.Failed, jc.SameContents, []names.Tag{m1.Tag()})
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{m2.Tag()})
+}
+
+func (s *ModelMigrationSuite) TestDuplicateMinionReportsSameSuccess(c *gc.C) {
+	// It should be OK for a minion report to arrive more than once
+	// for the same migration, agent and phase as long as the value
+	// of "success" is the same.
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewMachineTag("42")
+	c.Check(mig.SubmitMinionReport(tag, migration.QUIESCE, true), jc.ErrorIsNil)
+	c.Check(mig.SubmitMinionReport(tag, migration.QUIESCE, true), jc.ErrorIsNil)
+}
+
+func (s *ModelMigrationSuite) TestDuplicateMinionReportsDifferingSuccess(c *gc.C) {
+	// It is not OK for a minion report to arrive more than once for
+	// the same migration, agent and phase when the "success" value
+	// changes.
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewMachineTag("42")
+	c.Check(mig.SubmitMinionReport(tag, migration.QUIESCE, true), jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(tag, migration.QUIESCE, false)
+	c.Check(err, gc.ErrorMatches,
+		fmt.Sprintf("conflicting reports received for %s/QUIESCE/machine-42", mig.Id()))
+}
+
+func (s *ModelMigrationSuite) TestMinionReportWithUnsupportedTag(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(names.NewUserTag("bob"), migration.QUIESCE, true)
+	c.Check(err, gc.ErrorMatches, "unsupported agent tag: user-bob")
+}
+
+func (s *ModelMigrationSuite) TestMinionReportsIgnoresOtherPhases(c *gc.C) {
+	factory2 := factory.NewFactory(s.State2)
+	m0 := factory2.MakeMachine(c, nil)
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(m0.Tag(), migration.QUIESCE, true), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.READONLY), jc.ErrorIsNil)
+
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, gc.HasLen, 0)
+	c.Check(reports.Failed, gc.HasLen, 0)
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{m0.Tag()})
+}
+
+func (s *ModelMigrationSuite) TestWatchMinionReports(c *gc.C) {
+	mig, wc := s.createMigAndWatchReports(c, s.State2)
+	wc.AssertOneChange() // initial event
+
+	// A report should trigger the watcher.
+	c.Assert(mig.SubmitMinionReport(names.NewMachineTag("0"), migration.QUIESCE, true), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// A report for a different phase shouldn't trigger the watcher.
+	c.Assert(mig.SubmitMinionReport(names.NewMachineTag("1"), migration.IMPORT, true), jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *ModelMigrationSuite) TestWatchMinionReportsMultiModel(c *gc.C) {
+	mig, wc := s.createMigAndWatchReports(c, s.State2)
+	wc.AssertOneChange() // initial event
+
+	State3 := s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { State3.Close() })
+	mig3, wc3 := s.createMigAndWatchReports(c, State3)
+	wc3.AssertOneChange() // initial event
+
+	// Ensure the correct watchers are triggered.
+	c.Assert(mig.SubmitMinionReport(names.NewMachineTag("0"), migration.QUIESCE, true), jc.ErrorIsNil)
+	wc.AssertOneChange()
+	wc3.AssertNoChange()
+
+	c.Assert(mig3.SubmitMinionReport(names.NewMachineTag("0"), migration.QUIESCE, true), jc.ErrorIsNil)
+	wc.AssertNoChange()
+	wc3.AssertOneChange()
+}
+
+func (s *ModelMigrationSuite) createMigAndWatchReports(c *gc.C, st *state.State) (
+	state.ModelMigration, statetesting.NotifyWatcherC,
+) {
+	mig, err := st.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := mig.WatchMinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { statetesting.AssertStop(c, w) })
+	return mig, statetesting.NewNotifyWatcherC(c, st, w)
+}
+
 func (s *ModelMigrationSuite) createStatusWatcher(c *gc.C, st *state.State) (
 	state.NotifyWatcher, statetesting.NotifyWatcherC,
 ) {
@@ -37,6 +37,12 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint holds the name of the unit's endpoint that the range
+	// was opened for, which decides the sources that may access it
+	// while the application is exposed. It is empty for ranges opened
+	// for all of the unit's endpoints.
+	Endpoint string `bson:"endpoint,omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -133,9 +139,19 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	if p.Endpoint != "" {
+		return fmt.Sprintf("%d-%d/%s (%q, endpoint %q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName, p.Endpoint)
+	}
 	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
 }
 
+// sameRangeForUnit reports whether the port ranges were opened by the
+// same unit for the same ports, regardless of their endpoints.
+func (prA PortRange) sameRangeForUnit(prB PortRange) bool {
+	prA.Endpoint = prB.Endpoint
+	return prA == prB
+}
+
 // portsDoc represents the state of ports opened on machines for networks
 type portsDoc struct {
 	DocID     string      `bson:"_id"`
@@ -293,7 +309,9 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			// Ranges are closed whichever endpoint they were
+			// opened for.
+			if existingPortsDef.sameRangeForUnit(portRange) {
 				found = true
 				continue
 			}
@@ -362,6 +380,22 @@ func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	return result
 }
 
+// AllPortRangeEndpoints returns a map with network.PortRange as keys
+// and the names of the endpoints they were opened for as values. Port
+// ranges opened for all of a unit's endpoints map to an empty name.
+func (p *Ports) AllPortRangeEndpoints() map[network.PortRange]string {
+	result := make(map[network.PortRange]string)
+	for _, portRange := range p.doc.Ports {
+		rawRange := network.PortRange{
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+			Protocol: portRange.Protocol,
+		}
+		result[rawRange] = portRange.Endpoint
+	}
+	return result
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
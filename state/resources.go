@@ -37,6 +37,10 @@ type Resources interface {
 	// UpdatePendingResource adds the resource to blob storage and updates the metadata.
 	UpdatePendingResource(applicationID, pendingID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error)
 
+	// StoreResourceData adds the content of an existing resource to
+	// blob storage, leaving the resource metadata unchanged.
+	StoreResourceData(applicationID, name string, r io.Reader) (resource.Resource, error)
+
 	// OpenResource returns the metadata for a resource and a reader for the resource.
 	OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error)
 
@@ -40,6 +40,7 @@ import (
 	"github.com/juju/juju/state/cloudimagemetadata"
 	stateaudit "github.com/juju/juju/state/internal/audit"
 	statelease "github.com/juju/juju/state/lease"
+	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/state/workers"
 	"github.com/juju/juju/status"
 	jujuversion "github.com/juju/juju/version"
@@ -156,6 +157,61 @@ func (st *State) RemoveImportingModelDocs() error {
 	return st.removeAllModelDocs(bson.D{{"migration-mode", MigrationModeImporting}})
 }
 
+// RemoveExportingModelDocs removes all documents from multi-model
+// collections for the current model, along with its logs and the
+// binaries stored for it. It is used to remove a model from the
+// source controller once it has been migrated. This method asserts
+// that the model's migration mode is "exporting".
+func (st *State) RemoveExportingModelDocs() error {
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.MigrationMode() != MigrationModeExporting {
+		return errors.Errorf("model %q is not being exported", model.Name())
+	}
+	if err := st.removeModelBinaries(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.removeAllModelDocs(bson.D{{"migration-mode", MigrationModeExporting}}); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(removeModelLogs(st, st.ModelUUID()))
+}
+
+// removeModelBinaries removes the charm archives and agent binaries
+// stored for the current model, releasing the model's references to
+// the underlying blobs.
+func (st *State) removeModelBinaries() error {
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	for _, source := range []struct {
+		collection string
+		field      string
+	}{
+		{charmsC, "storagepath"},
+		{toolsmetadataC, "path"},
+	} {
+		coll, closer := st.getCollection(source.collection)
+		var docs []bson.M
+		err := coll.Find(nil).Select(bson.D{{source.field, 1}}).All(&docs)
+		closer()
+		if err != nil {
+			return errors.Annotatef(err, "reading binary paths from %q", source.collection)
+		}
+		for _, doc := range docs {
+			path, _ := doc[source.field].(string)
+			if path == "" {
+				// Placeholder or pending documents have no binary.
+				continue
+			}
+			if err := stor.Remove(path); err != nil && !errors.IsNotFound(err) {
+				return errors.Annotatef(err, "removing binary %q from %q", path, source.collection)
+			}
+		}
+	}
+	return nil
+}
+
 func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	env, err := st.Model()
 	if err != nil {
@@ -188,7 +244,7 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 		}
 		if info.rawAccess {
 			if err := st.removeAllInCollectionRaw(name); err != nil {
-				return errors.Trace(err)
+				return errors.Annotatef(err, "removing documents from %q", name)
 			}
 			continue
 		}
@@ -199,7 +255,7 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 		var err error
 		ops, err = st.appendRemoveAllInCollectionOps(ops, name)
 		if err != nil {
-			return errors.Trace(err)
+			return errors.Annotatef(err, "reading documents from %q", name)
 		}
 	}
 	return st.runTransaction(ops)
@@ -1936,6 +1992,23 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntries returns the audit entries which match the given filter,
+// most recent first.
+func (st *State) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	find := func(collectionName string, query bson.D, limit int, docs interface{}) error {
+		collection, closeCollection := st.getCollection(collectionName)
+		defer closeCollection()
+
+		q := collection.Find(query).Sort("-timestamp")
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		return errors.Trace(q.All(docs))
+	}
+	entries, err := stateaudit.GetAuditEntriesFn(auditingC, find)(filter)
+	return entries, errors.Annotate(err, "cannot read audit entries")
+}
+
 var tagPrefix = map[byte]string{
 	'm': names.MachineTagKind + "-",
 	'a': names.ApplicationTagKind + "-",
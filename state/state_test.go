@@ -2493,6 +2493,34 @@ func (s *StateSuite) TestRemoveImportingModelDocsImporting(c *gc.C) {
 	c.Assert(state.HostedModelCount(c, s.State), gc.Equals, 0)
 }
 
+func (s *StateSuite) TestRemoveExportingModelDocsFailsActive(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := st.RemoveExportingModelDocs()
+	c.Assert(err, gc.ErrorMatches, `model ".+" is not being exported`)
+}
+
+func (s *StateSuite) TestRemoveExportingModelDocsExporting(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	userModelKey := s.insertFakeModelDocs(c, st)
+	c.Assert(state.HostedModelCount(c, s.State), gc.Equals, 1)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeExporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = st.RemoveExportingModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// test that we can not find the user:envName unique index
+	s.checkUserModelNameExists(c, checkUserModelNameArgs{st: st, id: userModelKey, exists: false})
+	s.AssertModelDeleted(c, st)
+	c.Assert(state.HostedModelCount(c, s.State), gc.Equals, 0)
+}
+
 type attrs map[string]interface{}
 
 func (s *StateSuite) TestWatchForModelConfigChanges(c *gc.C) {
@@ -245,74 +245,86 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	return results, nil
 }
 
-// PruneStatusHistory removes status history entries until
-// only logs newer than <maxLogTime> remain and also ensures
-// that the collection is smaller than <maxLogsMB> after the
-// deletion.
-func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+// PruneStatusHistory removes status history entries of the model until
+// only the ones newer than <maxHistoryTime> remain and also ensures that
+// the model's share of the collection is smaller than <maxHistoryMB>
+// after the deletion. It returns the number of entries removed.
+func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) (int, error) {
 	if maxHistoryMB < 0 {
-		return errors.NotValidf("non-positive maxHistoryMB")
+		return 0, errors.NotValidf("non-positive maxHistoryMB")
 	}
 	if maxHistoryTime < 0 {
-		return errors.NotValidf("non-positive maxHistoryTime")
+		return 0, errors.NotValidf("non-positive maxHistoryTime")
 	}
 	if maxHistoryMB == 0 && maxHistoryTime == 0 {
-		return errors.NotValidf("backlog size and time constraints are both 0")
+		return 0, errors.NotValidf("backlog size and time constraints are both 0")
 	}
 	history, closer := st.getRawCollection(statusesHistoryC)
 	defer closer()
+	modelUUID := st.ModelUUID()
+	removed := 0
 
 	// Status Record Age
 	// TODO(perrito666): 2016-04-26 lp:1558657
 	if maxHistoryTime > 0 {
 		t := time.Now().Add(-maxHistoryTime)
-		_, err := history.RemoveAll(bson.D{
+		info, err := history.RemoveAll(bson.D{
+			{"model-uuid", modelUUID},
 			{"updated", bson.M{"$lt": t.UnixNano()}},
 		})
 		if err != nil {
-			return errors.Trace(err)
+			return removed, errors.Trace(err)
 		}
+		removed += info.Removed
 	}
 	if maxHistoryMB == 0 {
-		return nil
+		return removed, nil
 	}
 	// Collection Size
 	collMB, err := getCollectionMB(history)
 	if err != nil {
-		return errors.Annotate(err, "retrieving status history collection size")
+		return removed, errors.Annotate(err, "retrieving status history collection size")
 	}
 	if collMB <= maxHistoryMB {
-		return nil
+		// The model's share cannot be larger than the whole collection.
+		return removed, nil
 	}
-	// TODO(perrito666) explore if there would be any beneffit from having the
-	// size limit be per model
-	count, err := history.Count()
-	if err == mgo.ErrNotFound || count <= 0 {
-		return nil
+	total, err := history.Count()
+	if err == mgo.ErrNotFound || total <= 0 {
+		return removed, nil
 	}
 	if err != nil {
-		return errors.Annotate(err, "counting status history records")
+		return removed, errors.Annotate(err, "counting status history records")
+	}
+	modelQuery := bson.D{{"model-uuid", modelUUID}}
+	count, err := history.Find(modelQuery).Count()
+	if err != nil {
+		return removed, errors.Annotate(err, "counting model status history records")
 	}
 	// We are making the assumption that status sizes can be averaged for
 	// large numbers and we will get a reasonable approach on the size.
 	// Note: Capped collections are not used for this because they, currently
 	// at least, lack a way to be resized and the size is expected to change
 	// as real life data of the history usage is gathered.
-	sizePerStatus := float64(collMB) / float64(count)
+	sizePerStatus := float64(collMB) / float64(total)
 	if sizePerStatus == 0 {
-		return errors.New("unexpected result calculating status history entry size")
+		return removed, errors.New("unexpected result calculating status history entry size")
+	}
+	if float64(count)*sizePerStatus <= float64(maxHistoryMB) {
+		return removed, nil
 	}
-	deleteStatuses := count - int(float64(collMB-maxHistoryMB)/sizePerStatus)
+	keepStatuses := int(float64(maxHistoryMB) / sizePerStatus)
 	result := historicalStatusDoc{}
-	err = history.Find(nil).Sort("-updated").Skip(deleteStatuses).One(&result)
+	err = history.Find(modelQuery).Sort("-updated").Skip(keepStatuses).One(&result)
 	if err != nil {
-		return errors.Trace(err)
+		return removed, errors.Trace(err)
 	}
-	_, err = history.RemoveAll(bson.D{
-		{"updated", bson.M{"$lt": result.Updated}},
+	info, err := history.RemoveAll(bson.D{
+		{"model-uuid", modelUUID},
+		{"updated", bson.M{"$lte": result.Updated}},
 	})
 	if err != nil {
-		return errors.Trace(err)
+		return removed, errors.Trace(err)
 	}
-	return nil
+	return removed + info.Removed, nil
 }
@@ -31,8 +31,9 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryBySize(c *gc.C) {
 	c.Logf("%d\n", len(history))
 	c.Assert(history, gc.HasLen, 20001)
 
-	err = state.PruneStatusHistory(s.State, 0, 1)
+	removed, err := state.PruneStatusHistory(s.State, 0, 1)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, jc.GreaterThan, 10000)
 
 	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
 	c.Assert(err, jc.ErrorIsNil)
@@ -88,8 +89,9 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 		checkPrimedUnitStatus(c, statusInfo, 9-i, 24*time.Hour)
 	}
 
-	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024)
+	removed, err := state.PruneStatusHistory(s.State, 10*time.Hour, 1024)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.Equals, 320)
 
 	history, err = units[0].StatusHistory(status.StatusHistoryFilter{Size: 50})
 	c.Assert(err, jc.ErrorIsNil)
@@ -136,6 +138,31 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 	}
 }
 
+func (s *StatusHistorySuite) TestPruneStatusHistoryOnlyPrunesModel(c *gc.C) {
+	service := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: service})
+	primeUnitStatusHistory(c, unit, 10, 24*time.Hour)
+
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	otherFactory := factory.NewFactory(otherState)
+	otherService := otherFactory.MakeApplication(c, nil)
+	otherUnit := otherFactory.MakeUnit(c, &factory.UnitParams{Application: otherService})
+	primeUnitStatusHistory(c, otherUnit, 10, 24*time.Hour)
+
+	removed, err := state.PruneStatusHistory(s.State, 10*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.Equals, 10)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+
+	history, err = otherUnit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 11)
+}
+
 func (s *StatusHistorySuite) TestStatusHistoryFiltersByDateAndDelta(c *gc.C) {
 	// TODO(perrito666) setup should be extracted into a fixture and the
 	// 6 or 7 test cases each get their own method.
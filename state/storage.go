@@ -34,9 +34,12 @@ type StorageInstance interface {
 	// Kind returns the storage instance kind.
 	Kind() StorageKind
 
-	// Owner returns the tag of the service or unit that owns this storage
-	// instance.
-	Owner() names.Tag
+	// Owner returns the tag of the application or unit that owns this
+	// storage instance, and a boolean reporting whether or not there is
+	// an owner. Storage that has been detached from its unit has no
+	// owner, and is kept alive until it is attached to another unit or
+	// destroyed.
+	Owner() (names.Tag, bool)
 
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
@@ -76,6 +79,33 @@ const (
 	StorageKindFilesystem
 )
 
+// String returns a human readable string representing the type.
+func (k StorageKind) String() string {
+	switch k {
+	case StorageKindBlock:
+		return "block"
+	case StorageKindFilesystem:
+		return "filesystem"
+	default:
+		return "unknown"
+	}
+}
+
+// parseStorageKind is used by the migration code to go from the
+// string representation back to the enum.
+func parseStorageKind(value string) (StorageKind, error) {
+	switch value {
+	case "block":
+		return StorageKindBlock, nil
+	case "filesystem":
+		return StorageKindFilesystem, nil
+	case "unknown":
+		return StorageKindUnknown, nil
+	default:
+		return StorageKindUnknown, errors.NotValidf("storage kind %q", value)
+	}
+}
+
 type storageInstance struct {
 	st  *State
 	doc storageInstanceDoc
@@ -93,14 +123,17 @@ func (s *storageInstance) Kind() StorageKind {
 	return s.doc.Kind
 }
 
-func (s *storageInstance) Owner() names.Tag {
+func (s *storageInstance) Owner() (names.Tag, bool) {
+	if s.doc.Owner == "" {
+		return nil, false
+	}
 	tag, err := names.ParseTag(s.doc.Owner)
 	if err != nil {
-		// This should be impossible; we do not expose
-		// a means of modifying the owner tag.
+		// This should be impossible; the owner tag is
+		// only ever set to a valid tag, or cleared.
 		panic(err)
 	}
-	return tag
+	return tag, true
 }
 
 func (s *storageInstance) StorageName() string {
@@ -621,6 +654,392 @@ func destroyStorageAttachmentOps(storage names.StorageTag, unit names.UnitTag) [
 	return ops
 }
 
+// DetachStorage ensures that the storage instance will be detached from
+// the unit at some point, without destroying the storage instance. Once
+// detached, the storage instance has no owner, and is kept alive until
+// it is attached to another unit with AttachStorage, or destroyed.
+//
+// Only storage owned by the unit may be detached; the unit must retain
+// at least as many instances of the storage as the charm requires.
+func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %s from unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageAttachment(storage, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		if si.doc.Owner != unit.String() {
+			return nil, errors.NotSupportedf("detaching storage not owned by the unit")
+		}
+		if err := st.validateStorageDetach(si, unit); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := destroyStorageAttachmentOps(storage, unit)
+		ops = append(ops, txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: bson.D{{"life", Alive}, {"owner", si.doc.Owner}},
+			Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+		})
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateStorageDetach checks that the storage instance may be detached
+// from the unit: the unit's charm must not require the storage, and the
+// storage must not be confined to the unit's machine.
+func (st *State) validateStorageDetach(si *storageInstance, unit names.UnitTag) error {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charmStorage := ch.Meta().Storage[si.doc.StorageName]
+	count, err := st.countEntityStorageInstancesForName(unit, si.doc.StorageName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count <= uint64(charmStorage.CountMin) {
+		return errors.Errorf(
+			"charm requires at least %d %q storage instance(s); "+
+				"remove the unit with its storage detached instead",
+			charmStorage.CountMin, si.doc.StorageName,
+		)
+	}
+	return st.validateStorageDetachable(si)
+}
+
+// validateStorageDetachable checks that the storage instance can outlive
+// the unit it is attached to: the storage must not be confined to the
+// unit's machine. A machine-scoped filesystem may be detached only if it
+// is backed by a volume that is not itself machine-scoped; the volume is
+// moved to the new machine along with the filesystem.
+func (st *State) validateStorageDetachable(si *storageInstance) error {
+	if v, err := st.storageInstanceVolume(si.StorageTag()); err == nil {
+		if _, ok := names.VolumeMachine(v.VolumeTag()); ok {
+			return errors.NotSupportedf("detaching machine-scoped volume %s", v.VolumeTag().Id())
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if f, err := st.storageInstanceFilesystem(si.StorageTag()); err == nil {
+		if _, ok := names.FilesystemMachine(f.FilesystemTag()); ok {
+			// The backing volume, if any, was checked above.
+			if _, err := f.Volume(); err == ErrNoBackingVolume {
+				return errors.NotSupportedf("detaching machine-scoped filesystem %s", f.FilesystemTag().Id())
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// detachUnitStorageOps returns txn ops that detach the storage instances
+// owned by the unit from it, so that they are kept when the unit is
+// removed. Storage that cannot be detached causes an error.
+func (st *State) detachUnitStorageOps(unit names.UnitTag) ([]txn.Op, error) {
+	storageCollection, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	err := storageCollection.Find(bson.D{{"owner", unit.String()}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get unit storage instances")
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		si := &storageInstance{st, doc}
+		if si.doc.Life != Alive {
+			continue
+		}
+		if err := st.validateStorageDetachable(si); err != nil {
+			return nil, errors.Annotatef(err, "cannot detach storage %s", si.StorageTag().Id())
+		}
+		ops = append(ops, txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: bson.D{{"life", Alive}, {"owner", si.doc.Owner}},
+			Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+		})
+	}
+	return ops, nil
+}
+
+// AttachStorage attaches storage that has previously been detached, and
+// so has no owner, to the specified unit. The unit's charm must declare
+// non-shared storage with the same name and kind, with room for another
+// instance. If the unit is assigned to a machine, the storage's volume
+// or filesystem will be attached to the machine.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach storage %s to unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, unitNotAliveErr
+		}
+		app, err := u.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		count, err := st.countEntityStorageInstancesForName(unit, si.doc.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.validateStorageAttach(si, ch.Meta(), count); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := attachStorageOps(si, unit)
+
+		// Take a copy of the storage instance, with the new owner,
+		// for determining the machine storage parameters.
+		attached := &storageInstance{st, si.doc}
+		attached.doc.Owner = unit.String()
+		machineOps, err := st.attachStorageToUnitMachineOps(ch.Meta(), u, attached)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, machineOps...)
+		return append(ops, txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+		}), nil
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeStorage requests that the specified storage instance be grown
+// to the given size, in MiB. The storage instance's volume is resized
+// as described by ResizeVolume. Filesystem storage may be resized only
+// if it is backed by a volume; once the volume has been resized, the
+// filesystem is grown to fill it.
+func (st *State) ResizeStorage(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %s", tag.Id())
+	si, err := st.storageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch si.Kind() {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return st.ResizeVolume(v.VolumeTag(), size)
+	case StorageKindFilesystem:
+		return st.resizeFilesystemStorage(tag, size)
+	}
+	return errors.NotSupportedf("resizing %s storage", si.Kind())
+}
+
+// resizeFilesystemStorage requests that the volume backing the
+// filesystem of the specified storage instance, and then the
+// filesystem itself, be grown to the given size, in MiB.
+func (st *State) resizeFilesystemStorage(tag names.StorageTag, size uint64) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		f, err := st.storageInstanceFilesystem(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeTag, err := f.Volume()
+		if errors.Cause(err) == ErrNoBackingVolume {
+			return nil, errors.NotSupportedf("resizing filesystem storage not backed by a volume")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if f.Life() != Alive {
+			return nil, errors.New("filesystem is not alive")
+		}
+		info, err := f.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		v, err := st.volumeByTag(volumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := st.resizeVolumeOps(v, size)
+		if err != nil && err != jujutxn.ErrNoOperations {
+			return nil, errors.Annotatef(err, "resizing volume %q", volumeTag.Id())
+		}
+		if f.PendingSize() != size {
+			ops = append(ops, txn.Op{
+				C:  filesystemsC,
+				Id: f.FilesystemTag().Id(),
+				Assert: bson.D{
+					{"life", Alive},
+					{"info.size", info.Size},
+				},
+				Update: bson.D{{"$set", bson.D{{"pendingsize", size}}}},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateStorageAttach checks that the storage instance is detached, and
+// may be attached to a unit running a charm with the given metadata, which
+// already has count instances of the storage.
+func (st *State) validateStorageAttach(si *storageInstance, charmMeta *charm.Meta, count uint64) error {
+	if si.doc.Life != Alive {
+		return errors.New("storage is not alive")
+	}
+	if si.doc.Owner != "" || si.doc.AttachmentCount != 0 {
+		return errors.New("storage is attached to another unit")
+	}
+	charmStorage, ok := charmMeta.Storage[si.doc.StorageName]
+	if !ok {
+		return errors.NotFoundf("charm storage %q", si.doc.StorageName)
+	}
+	if charmStorage.Shared {
+		return errors.NotSupportedf("attaching shared storage")
+	}
+	var kind StorageKind
+	switch charmStorage.Type {
+	case charm.StorageBlock:
+		kind = StorageKindBlock
+	case charm.StorageFilesystem:
+		kind = StorageKindFilesystem
+	}
+	if kind != si.doc.Kind {
+		return errors.Errorf(
+			"storage kind mismatch: charm storage %q is %s, storage is %s",
+			si.doc.StorageName, kind, si.doc.Kind,
+		)
+	}
+	if charmStorage.CountMax >= 0 && count+1 > uint64(charmStorage.CountMax) {
+		return errors.Errorf(
+			"charm allows at most %d %q storage instance(s)",
+			charmStorage.CountMax, si.doc.StorageName,
+		)
+	}
+	return st.validateStorageAttachmentsDetached(si)
+}
+
+// attachStorageOps returns txn.Ops for attaching the detached storage
+// instance to the unit. The caller is responsible for attaching the
+// storage to the unit's machine, if any, and for incrementing the
+// storage attachment count of the unit.
+func attachStorageOps(si *storageInstance, unit names.UnitTag) []txn.Op {
+	return []txn.Op{{
+		C:  storageInstancesC,
+		Id: si.doc.Id,
+		Assert: bson.D{
+			{"life", Alive},
+			{"owner", ""},
+			{"attachmentcount", 0},
+		},
+		Update: bson.D{{"$set", bson.D{
+			{"owner", unit.String()},
+			{"attachmentcount", 1},
+		}}},
+	}, createStorageAttachmentOp(si.StorageTag(), unit)}
+}
+
+// attachStorageToUnitMachineOps returns txn.Ops for attaching the existing
+// volume or filesystem of a storage instance to the machine that the unit
+// is assigned to, if any.
+func (st *State) attachStorageToUnitMachineOps(
+	charmMeta *charm.Meta, u *Unit, si *storageInstance,
+) ([]txn.Op, error) {
+	m, err := u.machine()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := u.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageParams, err := machineStorageParamsForStorageInstance(
+		st, charmMeta, u.UnitTag(), u.Series(), cons, si,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateDynamicMachineStorageParams(m, storageParams); err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageOps, volumeAttachments, filesystemAttachments, err := st.machineStorageOps(
+		&m.doc, storageParams,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachmentOps, err := addMachineStorageAttachmentsOps(
+		m, volumeAttachments, filesystemAttachments,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(storageOps, attachmentOps...), nil
+}
+
+// validateStorageAttachmentsDetached checks that the volume and filesystem
+// of a detached storage instance, if any, are alive and no longer attached
+// to the machine of the unit it was detached from.
+func (st *State) validateStorageAttachmentsDetached(si *storageInstance) error {
+	if v, err := st.storageInstanceVolume(si.StorageTag()); err == nil {
+		if v.doc.Life != Alive {
+			return errors.Errorf("volume %s is not alive", v.doc.Name)
+		}
+		if v.doc.AttachmentCount > 0 {
+			return errors.Errorf("volume %s is still attached to a machine", v.doc.Name)
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if f, err := st.storageInstanceFilesystem(si.StorageTag()); err == nil {
+		if f.doc.Life != Alive {
+			return errors.Errorf("filesystem %s is not alive", f.doc.FilesystemId)
+		}
+		if f.doc.AttachmentCount > 0 {
+			return errors.Errorf("filesystem %s is still attached to a machine", f.doc.FilesystemId)
+		}
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Remove removes the storage attachment from state, and may remove its storage
 // instance as well, if the storage instance is Dying and no other references to
 // it exist. It will fail if the storage attachment is not Dying.
@@ -674,7 +1093,7 @@ func removeStorageAttachmentOps(
 		if si.doc.Life == Dying {
 			hasLastRef = bson.D{{"life", Dying}, {"attachmentcount", 1}}
 		} else if si.doc.Owner == names.NewUnitTag(s.doc.Unit).String() {
-			hasLastRef = bson.D{{"attachmentcount", 1}}
+			hasLastRef = bson.D{{"owner", si.doc.Owner}, {"attachmentcount", 1}}
 		}
 		if len(hasLastRef) > 0 {
 			// Either the storage instance is dying, or its owner
@@ -712,9 +1131,84 @@ func removeStorageAttachmentOps(
 		}
 	}
 	ops = append(ops, decrefOp)
+	if si.doc.Owner == "" {
+		// The storage instance has been detached from the unit,
+		// and will outlive the attachment; detach its volume or
+		// filesystem from the unit's machine, so that it can be
+		// attached to another.
+		detachOps, err := detachStorageFromUnitMachineOps(st, si, names.NewUnitTag(s.doc.Unit))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	}
 	return ops, nil
 }
 
+// detachStorageFromUnitMachineOps returns txn.Ops to detach the volume
+// or filesystem of the specified storage instance from the machine that
+// the unit is assigned to, if any.
+func detachStorageFromUnitMachineOps(st *State, si *storageInstance, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine := names.NewMachineTag(machineId)
+
+	switch si.doc.Kind {
+	case StorageKindBlock:
+		v, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		va, err := st.VolumeAttachment(machine, v.VolumeTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if va.Life() != Alive {
+			return nil, nil
+		}
+		return detachVolumeOps(machine, v.VolumeTag()), nil
+	case StorageKindFilesystem:
+		// The backing volume of a volume-backed filesystem is
+		// detached from the machine once the filesystem has
+		// been unmounted and its attachment removed; see
+		// RemoveFilesystemAttachment. Both must be removed
+		// before the storage can be attached to another unit,
+		// at which point they are recreated on that unit's
+		// machine.
+		f, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fa, err := st.FilesystemAttachment(machine, f.FilesystemTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fa.Life() != Alive {
+			return nil, nil
+		}
+		return detachFilesystemOps(machine, f.FilesystemTag()), nil
+	}
+	return nil, nil
+}
+
 // removeStorageInstancesOps returns the transaction operations to remove all
 // storage instances owned by the specified entity.
 func removeStorageInstancesOps(st *State, owner names.Tag) ([]txn.Op, error) {
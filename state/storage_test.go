@@ -579,7 +579,9 @@ func (s *StorageStateSuite) TestAllStorageInstances(c *gc.C) {
 	for _, one := range all {
 		c.Assert(one.Kind(), gc.DeepEquals, state.StorageKindBlock)
 		c.Assert(nameSet.Contains(one.StorageName()), jc.IsTrue)
-		c.Assert(ownerSet.Contains(one.Owner().String()), jc.IsTrue)
+		owner, ok := one.Owner()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(ownerSet.Contains(owner.String()), jc.IsTrue)
 	}
 }
 
@@ -828,6 +830,367 @@ func (s *StorageStateSuite) TestDestroyUnitStorageAttachments(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// setupDetachableStorage adds a unit of the storage-block charm, with
+// storage that may be detached from it. The unit's detachable storage
+// instance is returned.
+func (s *StorageStateSuite) setupDetachableStorage(c *gc.C) (*state.Application, *state.Unit, names.StorageTag) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop-pool", 1024, 1),
+		"allecto": makeStorageCons("persistent-block", 1024, 1),
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	return service, u, names.NewStorageTag("allecto/0")
+}
+
+func (s *StorageStateSuite) detachStorage(c *gc.C, storageTag names.StorageTag, u *state.Unit) {
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageStateSuite) TestDetachStorage(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c)
+
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	sa, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sa.Life(), gc.Equals, state.Dying)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+
+	// The storage instance outlives its last attachment.
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	si, err = s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	attachments, err := s.State.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 0)
+}
+
+func (s *StorageStateSuite) TestDetachStorageRequiredByCharm(c *gc.C) {
+	_, u, _ := s.setupDetachableStorage(c)
+	err := s.State.DetachStorage(names.NewStorageTag("data/1"), u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/1 from unit storage-block/0: charm requires at least 1 "data" storage instance\(s\); remove the unit with its storage detached instead`)
+}
+
+func (s *StorageStateSuite) TestDetachStorageNotAttached(c *gc.C) {
+	_, u, _ := s.setupDetachableStorage(c)
+	err := s.State.DetachStorage(names.NewStorageTag("allecto/123"), u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage allecto/123 from unit storage-block/0: storage attachment allecto/123:storage-block/0 not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageStateSuite) TestDetachStorageDetachesVolume(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c)
+	err := u.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	s.detachStorage(c, storageTag, u)
+	attachment := s.volumeAttachment(c, names.NewMachineTag(machineId), volume.VolumeTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageStateSuite) TestAttachStorage(c *gc.C) {
+	service, u, storageTag := s.setupDetachableStorage(c)
+	err := u.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	s.detachStorage(c, storageTag, u)
+	err = s.State.RemoveVolumeAttachment(names.NewMachineTag(machineId), volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId2, err := u2.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+	sa, err := s.State.StorageAttachment(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sa.Life(), gc.Equals, state.Alive)
+
+	// The existing volume is attached to the new unit's machine.
+	machineTag2 := names.NewMachineTag(machineId2)
+	attachment := s.volumeAttachment(c, machineTag2, volume.VolumeTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+	assertMachineStorageRefs(c, s.State, machineTag2)
+}
+
+func (s *StorageStateSuite) TestAttachStorageVolumeStillAttached(c *gc.C) {
+	service, u, storageTag := s.setupDetachableStorage(c)
+	err := u.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.detachStorage(c, storageTag, u)
+
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage allecto/0 to unit storage-block/1: volume .* is still attached to a machine`)
+}
+
+func (s *StorageStateSuite) TestAttachStorageAttachedToAnotherUnit(c *gc.C) {
+	service, _, storageTag := s.setupDetachableStorage(c)
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage allecto/0 to unit storage-block/1: storage is attached to another unit`)
+}
+
+func (s *StorageStateSuite) TestAddUnitAttachStorage(c *gc.C) {
+	service, u, storageTag := s.setupDetachableStorage(c)
+	s.detachStorage(c, storageTag, u)
+
+	u2, err := service.AddUnitWithParams(state.AddUnitParams{
+		AttachStorage: []names.StorageTag{storageTag},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The attached storage takes the place of the "allecto"
+	// storage that would otherwise have been created.
+	attachments, err := s.State.UnitStorageAttachments(u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	storageTags := make([]names.StorageTag, len(attachments))
+	for i, a := range attachments {
+		storageTags[i] = a.StorageInstance()
+	}
+	c.Assert(storageTags, jc.SameContents, []names.StorageTag{
+		storageTag, names.NewStorageTag("data/2"),
+	})
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+}
+
+func (s *StorageStateSuite) TestDestroyUnitDetachingStorage(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data":    makeStorageCons("persistent-block", 1024, 1),
+		"allecto": makeStorageCons("persistent-block", 1024, 1),
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	storageTags := []names.StorageTag{
+		names.NewStorageTag("allecto/0"),
+		names.NewStorageTag("data/1"),
+	}
+
+	err = u.DestroyDetachingStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Life(), gc.Equals, state.Dying)
+
+	// Cleaning up the dying unit destroys its storage attachments;
+	// removing them leaves the storage instances alive and unowned.
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, storageTag := range storageTags {
+		sa, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(sa.Life(), gc.Equals, state.Dying)
+		err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+		si, err := s.State.StorageInstance(storageTag)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(si.Life(), gc.Equals, state.Alive)
+		_, ok := si.Owner()
+		c.Assert(ok, jc.IsFalse)
+	}
+	err = u.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The kept storage can be attached to a new unit, even though
+	// the charm requires it.
+	u2, err := service.AddUnitWithParams(state.AddUnitParams{
+		AttachStorage: storageTags,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.State.UnitStorageAttachments(u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	attached := make([]names.StorageTag, len(attachments))
+	for i, a := range attachments {
+		attached[i] = a.StorageInstance()
+	}
+	c.Assert(attached, jc.SameContents, storageTags)
+}
+
+func (s *StorageStateSuite) TestDestroyUnitDetachingMachineScopedStorage(c *gc.C) {
+	_, u, _ := s.setupDetachableStorage(c)
+	err := u.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.DestroyDetachingStorage()
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/1: detaching machine-scoped volume .* not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Life(), gc.Equals, state.Alive)
+	si, err := s.State.StorageInstance(names.NewStorageTag("allecto/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u.Tag())
+}
+
+// setupDetachableFilesystemStorage adds a unit of the storage-filesystem
+// charm, assigned to a new machine, with two filesystem storage instances
+// in the given pool. One of the unit's storage instances is returned.
+func (s *StorageStateSuite) setupDetachableFilesystemStorage(c *gc.C, pool string) (*state.Application, *state.Unit, names.StorageTag) {
+	ch := s.AddTestingCharm(c, "storage-filesystem")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons(pool, 1024, 2),
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-filesystem", ch, storage)
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	return service, u, names.NewStorageTag("data/0")
+}
+
+func (s *StorageStateSuite) TestDetachStorageVolumeBackedFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupDetachableFilesystemStorage(c, "persistent-block")
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	_, ok := names.FilesystemMachine(filesystem.FilesystemTag())
+	c.Assert(ok, jc.IsTrue)
+	volume := s.filesystemVolume(c, filesystem.FilesystemTag())
+
+	s.detachStorage(c, storageTag, u)
+	filesystemAttachment := s.filesystemAttachment(c, machineTag, filesystem.FilesystemTag())
+	c.Assert(filesystemAttachment.Life(), gc.Equals, state.Dying)
+	volumeAttachment := s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Alive)
+
+	// Removing the filesystem attachment detaches the backing volume.
+	err = s.State.RemoveFilesystemAttachment(machineTag, filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	volumeAttachment = s.volumeAttachment(c, machineTag, volume.VolumeTag())
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Dying)
+	filesystem = s.filesystem(c, filesystem.FilesystemTag())
+	c.Assert(filesystem.Life(), gc.Equals, state.Alive)
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageStateSuite) TestDetachStorageMachineScopedFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupDetachableFilesystemStorage(c, "machinescoped")
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage data/0 from unit storage-filesystem/0: detaching machine-scoped filesystem .* not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageStateSuite) TestAttachStorageVolumeBackedFilesystem(c *gc.C) {
+	service, u, storageTag := s.setupDetachableFilesystemStorage(c, "persistent-block")
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	volume := s.filesystemVolume(c, filesystem.FilesystemTag())
+	s.detachStorage(c, storageTag, u)
+
+	u2, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId2, err := u2.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag2 := names.NewMachineTag(machineId2)
+
+	// The storage cannot be attached until both the filesystem
+	// and its backing volume have been detached.
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage data/0 to unit storage-filesystem/1: volume .* is still attached to a machine`)
+	err = s.State.RemoveFilesystemAttachment(machineTag, filesystem.FilesystemTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveVolumeAttachment(machineTag, volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+
+	// The existing filesystem and its backing volume are both
+	// attached to the new unit's machine.
+	filesystemAttachment := s.filesystemAttachment(c, machineTag2, filesystem.FilesystemTag())
+	c.Assert(filesystemAttachment.Life(), gc.Equals, state.Alive)
+	volumeAttachment := s.volumeAttachment(c, machineTag2, volume.VolumeTag())
+	c.Assert(volumeAttachment.Life(), gc.Equals, state.Alive)
+	assertMachineStorageRefs(c, s.State, machineTag2)
+
+	// The filesystem keeps the scope of the machine it was created
+	// on, but its attachment is reported for the new machine.
+	attachments, err := s.State.UnitStorageAttachments(u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	var attachmentIds []string
+	for _, a := range attachments {
+		f := s.storageInstanceFilesystem(c, a.StorageInstance())
+		attachmentIds = append(attachmentIds, machineId2+":"+f.FilesystemTag().Id())
+	}
+	c.Assert(attachmentIds, gc.HasLen, 3)
+	w := s.State.WatchMachineFilesystemAttachments(machineTag2)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(attachmentIds...)
+	wc.AssertNoChange()
+}
+
+func (s *StorageStateSuite) TestDestroyUnitDetachingVolumeBackedFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupDetachableFilesystemStorage(c, "persistent-block")
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+
+	err = u.DestroyDetachingStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+	attachment := s.filesystemAttachment(c, names.NewMachineTag(machineId), filesystem.FilesystemTag())
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+}
+
 func (s *StorageStateSuite) TestStorageLocationConflictIdentical(c *gc.C) {
 	s.testStorageLocationConflict(
 		c, "/srv", "/srv",
@@ -308,6 +308,20 @@ func (u *Unit) PasswordValid(password string) bool {
 // to a provisioned machine is Destroyed, it will be removed from state
 // directly.
 func (u *Unit) Destroy() (err error) {
+	return u.destroy(false)
+}
+
+// DestroyDetachingStorage is like Destroy, except that the storage
+// instances owned by the unit are detached from it rather than being
+// destroyed along with it. The detached storage may later be attached
+// to another unit. If any of the unit's storage cannot outlive it, such
+// as storage confined to the unit's machine, then the unit will not be
+// destroyed.
+func (u *Unit) DestroyDetachingStorage() (err error) {
+	return u.destroy(true)
+}
+
+func (u *Unit) destroy(detachStorage bool) (err error) {
 	defer func() {
 		if err == nil {
 			// This is a white lie; the document might actually be removed.
@@ -328,6 +342,13 @@ func (u *Unit) Destroy() (err error) {
 		case errAlreadyDying:
 			return nil, jujutxn.ErrNoOperations
 		case nil:
+			if detachStorage {
+				storageOps, err := unit.st.detachUnitStorageOps(unit.UnitTag())
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, storageOps...)
+			}
 			return ops, nil
 		default:
 			return nil, err
@@ -893,10 +914,24 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 // opening the requested range conflicts with another already opened range on
 // the same subnet and and the unit's assigned machine.
 func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int) (err error) {
+	return u.openPorts(subnetID, "", protocol, fromPort, toPort)
+}
+
+// OpenPortsForEndpoint opens the given port range and protocol for the
+// named endpoint of the unit, which must be one of the application's
+// endpoints. While the application is exposed, the range may only be
+// accessed from the sources the endpoint is exposed to. An empty
+// endpoint name opens the range for all of the unit's endpoints.
+func (u *Unit) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return u.openPorts("", endpoint, protocol, fromPort, toPort)
+}
+
+func (u *Unit) openPorts(subnetID, endpoint, protocol string, fromPort, toPort int) (err error) {
 	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	ports.Endpoint = endpoint
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
 	machineID, err := u.AssignedMachineId()
@@ -907,6 +942,9 @@ func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int
 	if err := u.checkSubnetAliveWhenSet(subnetID); err != nil {
 		return errors.Trace(err)
 	}
+	if err := u.checkEndpointWhenSet(endpoint); err != nil {
+		return errors.Trace(err)
+	}
 
 	machinePorts, err := getOrCreatePorts(u.st, machineID, subnetID)
 	if err != nil {
@@ -932,6 +970,26 @@ func (u *Unit) checkSubnetAliveWhenSet(subnetID string) error {
 	return nil
 }
 
+func (u *Unit) checkEndpointWhenSet(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	application, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	eps, err := application.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, ep := range eps {
+		if ep.Name == endpoint {
+			return nil
+		}
+	}
+	return errors.NotFoundf("endpoint %q", endpoint)
+}
+
 // ClosePortsOnSubnet closes the given port range and protocol for the unit on
 // the given subnet, which can be empty. When non-empty, subnetID must refer to
 // an existing, alive subnet, otherwise an error is returned.
@@ -1761,6 +1819,8 @@ func machineStorageParamsForStorageInstance(
 ) (*machineStorageParams, error) {
 
 	charmStorage := charmMeta.Storage[storage.StorageName()]
+	owner, _ := storage.Owner()
+	owned := owner == unit
 
 	var volumes []MachineVolumeParams
 	var filesystems []MachineFilesystemParams
@@ -1772,9 +1832,10 @@ func machineStorageParamsForStorageInstance(
 		volumeAttachmentParams := VolumeAttachmentParams{
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a volume.
+		volume, err := st.storageInstanceVolume(storage.StorageTag())
+		if errors.IsNotFound(err) && owned {
+			// The storage instance is owned by the unit, and has no
+			// volume yet, so we'll need to create one.
 			cons := allCons[storage.StorageName()]
 			volumeParams := VolumeParams{
 				storage: storage.StorageTag(),
@@ -1785,14 +1846,13 @@ func machineStorageParamsForStorageInstance(
 			volumes = append(volumes, MachineVolumeParams{
 				volumeParams, volumeAttachmentParams,
 			})
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) volume already, for which we will
-			// just add an attachment.
-			volume, err := st.StorageInstanceVolume(storage.StorageTag())
-			if err != nil {
-				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
-			}
+			// The storage instance is owned by the application, or
+			// was previously attached to another unit, so there
+			// should be a volume already, for which we will just
+			// add an attachment.
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		}
 	case StorageKindFilesystem:
@@ -1808,9 +1868,10 @@ func machineStorageParamsForStorageInstance(
 			location,
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a filesystem.
+		filesystem, err := st.storageInstanceFilesystem(storage.StorageTag())
+		if errors.IsNotFound(err) && owned {
+			// The storage instance is owned by the unit, and has no
+			// filesystem yet, so we'll need to create one.
 			cons := allCons[storage.StorageName()]
 			filesystemParams := FilesystemParams{
 				storage: storage.StorageTag(),
@@ -1821,14 +1882,13 @@ func machineStorageParamsForStorageInstance(
 			filesystems = append(filesystems, MachineFilesystemParams{
 				filesystemParams, filesystemAttachmentParams,
 			})
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) filesystem already, for which we will
-			// just add an attachment.
-			filesystem, err := st.StorageInstanceFilesystem(storage.StorageTag())
-			if err != nil {
-				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
-			}
+			// The storage instance is owned by the application, or
+			// was previously attached to another unit, so there
+			// should be a filesystem already, for which we will just
+			// add an attachment.
 			filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
 		}
 	default:
@@ -2123,6 +2183,11 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout is part of the ActionReceiver interface.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2148,7 +2213,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return u.st.enqueueAction(u.Tag(), name, payloadWithDefaults, timeout)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
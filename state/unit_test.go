@@ -1126,6 +1126,37 @@ func (s *UnitSuite) testOpenedPorts(c *gc.C, subnetID, expectedErrorCauseMatches
 	}
 }
 
+func (s *UnitSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsForEndpoint("missing", "tcp", 443, 443)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 443-443/tcp \("wordpress/0", endpoint "missing"\) for unit "wordpress/0" on subnet "": endpoint "missing" not found`)
+	// A range cannot be opened again for another endpoint.
+	err = s.unit.OpenPortsForEndpoint("", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-80/tcp \("wordpress/0"\) .*: port ranges 80-80/tcp \("wordpress/0", endpoint "url"\) and 80-80/tcp \("wordpress/0"\) conflict`)
+
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{80, 80, "tcp"}:     "url",
+		{8080, 8080, "tcp"}: "",
+	})
+
+	// Ranges are closed whichever endpoint they were opened for.
+	err = s.unit.ClosePort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.DeepEquals, []network.PortRange{{8080, 8080, "tcp"}})
+}
+
 func (s *UnitSuite) TestOpenClosePortWhenDying(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// unitStateDoc records the key-value state stored by a unit's charm
+// with the state-set hook tool. The keys are escaped so that they can
+// be stored as mongo field names.
+type unitStateDoc struct {
+	DocID     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	State     map[string]string `bson:"state"`
+}
+
+// State returns the key-value pairs stored by the unit's charm. If
+// nothing has been stored, it returns an empty map.
+func (u *Unit) State() (map[string]string, error) {
+	unitStates, closer := u.st.getCollection(unitStatesC)
+	defer closer()
+
+	var doc unitStateDoc
+	err := unitStates.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get state for unit %q", u)
+	}
+	return unescapeUnitState(doc.State), nil
+}
+
+// SetState replaces the key-value pairs stored by the unit's charm
+// with the supplied values. Setting an empty map removes all stored
+// values.
+func (u *Unit) SetState(state map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set state for unit %q", u)
+	for key := range state {
+		if key == "" {
+			return errors.NotValidf("empty key")
+		}
+	}
+	escaped := escapeUnitState(state)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if notDead, err := isNotDead(u.st, unitsC, u.doc.DocID); err != nil {
+				return nil, errors.Trace(err)
+			} else if !notDead {
+				return nil, errors.Errorf("unit is dead")
+			}
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		exists, err := u.hasStateDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch {
+		case exists && len(escaped) == 0:
+			ops = append(ops, removeUnitStateOp(u.st, u.globalKey()))
+		case exists:
+			ops = append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     u.st.docID(u.globalKey()),
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{{"state", escaped}}}},
+			})
+		case len(escaped) == 0:
+			return nil, jujutxn.ErrNoOperations
+		default:
+			ops = append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     u.st.docID(u.globalKey()),
+				Assert: txn.DocMissing,
+				Insert: &unitStateDoc{
+					ModelUUID: u.st.ModelUUID(),
+					State:     escaped,
+				},
+			})
+		}
+		return ops, nil
+	}
+	return u.st.run(buildTxn)
+}
+
+// hasStateDoc reports whether the unit has a state document.
+func (u *Unit) hasStateDoc() (bool, error) {
+	unitStates, closer := u.st.getCollection(unitStatesC)
+	defer closer()
+
+	n, err := unitStates.FindId(u.globalKey()).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return n > 0, nil
+}
+
+// removeUnitStateOp returns the operation needed to remove the unit
+// state document associated with the given globalKey.
+func removeUnitStateOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitStatesC,
+		Id:     st.docID(globalKey),
+		Remove: true,
+	}
+}
+
+func escapeUnitState(state map[string]string) map[string]string {
+	result := make(map[string]string, len(state))
+	for key, value := range state {
+		result[escapeReplacer.Replace(key)] = value
+	}
+	return result
+}
+
+func unescapeUnitState(state map[string]string) map[string]string {
+	result := make(map[string]string, len(state))
+	for key, value := range state {
+		result[unescapeReplacer.Replace(key)] = value
+	}
+	return result
+}
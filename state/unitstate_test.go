@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitStateSuite{})
+
+func (s *UnitStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *UnitStateSuite) TestStateEmpty(c *gc.C) {
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+}
+
+func (s *UnitStateSuite) TestSetState(c *gc.C) {
+	err := s.unit.SetState(map[string]string{
+		"db.host": "10.0.0.5",
+		"$secret": "sauce",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{
+		"db.host": "10.0.0.5",
+		"$secret": "sauce",
+	})
+}
+
+func (s *UnitStateSuite) TestSetStateReplaces(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"a": "1", "b": "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetState(map[string]string{"b": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"b": "3"})
+}
+
+func (s *UnitStateSuite) TestSetStateEmptyRemovesDoc(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"a": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetState(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertStateDocCount(c, 0)
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, gc.HasLen, 0)
+}
+
+func (s *UnitStateSuite) TestSetStateEmptyKey(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"": "1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set state for unit "mysql/0": empty key not valid`)
+}
+
+func (s *UnitStateSuite) TestSetStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.SetState(map[string]string{"a": "1"})
+	c.Assert(err, gc.ErrorMatches, `cannot set state for unit "mysql/0": unit is dead`)
+}
+
+func (s *UnitStateSuite) TestStateRemovedWithUnit(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"a": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStateDocCount(c, 1)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStateDocCount(c, 0)
+}
+
+func (s *UnitStateSuite) assertStateDocCount(c *gc.C, expected int) {
+	unitStates := s.MgoSuite.Session.DB("juju").C("unitstates")
+	var docs []bson.M
+	err := unitStates.Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, expected)
+}
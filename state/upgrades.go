@@ -249,3 +249,61 @@ func addDefaultBindingsToServices(st *State) error {
 func AddDefaultEndpointBindingsToServices(st *State) error {
 	return runForAllEnvStates(st, addDefaultBindingsToServices)
 }
+
+// ConvertAuditLog converts the audit log written by earlier versions of
+// Juju, whose entries record their timestamps as RFC3339 strings in an
+// uncapped collection. The timestamps are converted to native dates,
+// and the collection is capped and indexed as the audit log now is.
+func ConvertAuditLog(st *State) error {
+	coll, closer := st.getRawCollection(auditingC)
+	defer closer()
+
+	var doc struct {
+		Id        interface{} `bson:"_id"`
+		Timestamp string      `bson:"timestamp"`
+	}
+	// Select only the entries with string timestamps (BSON type 2),
+	// so that converting the collection is idempotent.
+	iter := coll.Find(bson.D{{"timestamp", bson.D{{"$type", 2}}}}).Select(bson.D{{"timestamp", 1}}).Iter()
+	converted := 0
+	for iter.Next(&doc) {
+		var t time.Time
+		if err := t.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+			return errors.Annotatef(err, "cannot parse timestamp of audit entry %v", doc.Id)
+		}
+		err := coll.UpdateId(doc.Id, bson.D{{"$set", bson.D{{"timestamp", t.UTC()}}}})
+		if err != nil {
+			return errors.Annotatef(err, "cannot convert audit entry %v", doc.Id)
+		}
+		converted++
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "cannot read audit entries")
+	}
+	upgradesLogger.Debugf("converted timestamps of %d audit entries", converted)
+
+	var stats struct {
+		Capped bool `bson:"capped"`
+	}
+	if err := coll.Database.Run(bson.D{{"collStats", coll.Name}}, &stats); err != nil {
+		return errors.Annotate(err, "cannot get audit log stats")
+	}
+	if !stats.Capped {
+		// Converting the collection drops its indexes, which are
+		// recreated below. The oldest entries are discarded if the
+		// audit log is larger than the capped collection.
+		err := coll.Database.Run(bson.D{
+			{"convertToCapped", coll.Name},
+			{"size", auditLogSize},
+		}, nil)
+		if err != nil {
+			return errors.Annotate(err, "cannot cap audit log")
+		}
+	}
+	for _, index := range allCollections()[auditingC].indexes {
+		if err := coll.EnsureIndex(index); err != nil {
+			return errors.Annotate(err, "cannot index audit log")
+		}
+	}
+	return nil
+}
@@ -586,3 +586,66 @@ func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServices(c *gc.C) {
 func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServicesIdempotent(c *gc.C) {
 	s.testAddDefaultEndpointBindingsToServices(c, true)
 }
+
+func (s *upgradesSuite) TestConvertAuditLog(c *gc.C) {
+	coll, closer := s.state.getRawCollection(auditingC)
+	defer closer()
+
+	// Replace the audit log with one as written by earlier versions.
+	err := coll.DropCollection()
+	c.Assert(err, jc.ErrorIsNil)
+	t0 := time.Date(2016, 7, 1, 12, 0, 0, 500, time.UTC)
+	for i := 0; i < 3; i++ {
+		timestamp, err := t0.Add(time.Duration(i) * time.Minute).MarshalText()
+		c.Assert(err, jc.ErrorIsNil)
+		err = coll.Insert(bson.M{
+			"model-uuid": s.state.ModelUUID(),
+			"timestamp":  string(timestamp),
+			"operation":  "deploy",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Converting the audit log twice is the same as doing it once.
+	for i := 0; i < 2; i++ {
+		err = ConvertAuditLog(s.state)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	var docs []struct {
+		Timestamp time.Time `bson:"timestamp"`
+		Operation string    `bson:"operation"`
+	}
+	err = coll.Find(nil).Sort("timestamp").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 3)
+	for i, doc := range docs {
+		// Mongo stores dates with millisecond precision.
+		expected := t0.Add(time.Duration(i) * time.Minute).Truncate(time.Millisecond)
+		c.Check(doc.Timestamp.UTC(), gc.Equals, expected)
+		c.Check(doc.Operation, gc.Equals, "deploy")
+	}
+	n, err := coll.Find(bson.D{{"timestamp", bson.D{{"$type", 2}}}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+
+	var stats struct {
+		Capped bool `bson:"capped"`
+	}
+	err = coll.Database.Run(bson.D{{"collStats", coll.Name}}, &stats)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats.Capped, jc.IsTrue)
+
+	indexes, err := coll.Indexes()
+	c.Assert(err, jc.ErrorIsNil)
+	var keys [][]string
+	for _, index := range indexes {
+		keys = append(keys, index.Key)
+	}
+	c.Assert(keys, jc.SameContents, [][]string{
+		{"_id"},
+		{"-timestamp"},
+		{"model-uuid", "-timestamp"},
+		{"origin-name", "-timestamp"},
+	})
+}
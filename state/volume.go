@@ -46,6 +46,10 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// PendingSize returns the size, in MiB, that the volume has been
+	// requested to grow to, or zero if there is no pending resize.
+	PendingSize() uint64
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +95,7 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+	PendingSize     uint64        `bson:"pendingsize,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -236,6 +241,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// PendingSize is required to implement Volume.
+func (v *volume) PendingSize() uint64 {
+	return v.doc.PendingSize
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -697,7 +707,7 @@ func (st *State) RemoveVolume(tag names.VolumeTag) (err error) {
 		if volume.Life() != Dead {
 			return nil, errors.New("volume is not dead")
 		}
-		return []txn.Op{
+		ops := []txn.Op{
 			{
 				C:      volumesC,
 				Id:     tag.Id(),
@@ -705,7 +715,16 @@ func (st *State) RemoveVolume(tag names.VolumeTag) (err error) {
 				Remove: true,
 			},
 			removeStatusOp(st, volumeGlobalKey(tag.Id())),
-		}, nil
+		}
+		if info, err := volume.Info(); err == nil {
+			// Release the provider ID, if the volume was imported.
+			ops = append(ops, txn.Op{
+				C:      providerIDsC,
+				Id:     st.volumeProviderIdKey(info.VolumeId),
+				Remove: true,
+			})
+		}
+		return ops, nil
 	}
 	return st.run(buildTxn)
 }
@@ -769,6 +788,139 @@ func (st *State) addVolumeOps(params VolumeParams, machineId string) ([]txn.Op,
 	return ops, names.NewVolumeTag(name), nil
 }
 
+// ValidateExistingVolume checks that the volume with the given provider
+// ID may be imported into the model from the named storage pool, as
+// storage with the given name, without modifying the model. It should
+// be called before the volume is prepared for import in the cloud, so
+// that an import which AddExistingVolume would refuse leaves the
+// volume untouched.
+func (st *State) ValidateExistingVolume(poolName, volumeId, storageName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing volume")
+	if volumeId == "" {
+		return errors.NotValidf("empty volume ID")
+	}
+	if !names.IsValidStorageName(storageName) {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if err := validateExistingVolumePool(st, poolName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(checkVolumeIdUnused(st, volumeId))
+}
+
+// AddExistingVolume imports an existing, already-provisioned volume
+// into the model. The volume is assigned to a new, detached block
+// storage instance with the given storage name, which may then be
+// attached to a unit. The tag of the storage instance is returned.
+func (st *State) AddExistingVolume(info VolumeInfo, storageName string) (_ names.StorageTag, err error) {
+	if err := st.ValidateExistingVolume(info.Pool, info.VolumeId, storageName); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot add existing volume")
+
+	storageId, err := newStorageInstanceId(st, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate storage instance name")
+	}
+	storageTag := names.NewStorageTag(storageId)
+	name, err := newVolumeName(st, "")
+	if err != nil {
+		return names.StorageTag{}, errors.Annotate(err, "cannot generate volume name")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := checkVolumeIdUnused(st, info.VolumeId); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{
+			// The provider ID is reserved so that concurrent
+			// imports of the same volume cannot both succeed.
+			{
+				C:      providerIDsC,
+				Id:     st.volumeProviderIdKey(info.VolumeId),
+				Assert: txn.DocMissing,
+				Insert: providerIdDoc{ID: st.volumeProviderIdKey(info.VolumeId)},
+			},
+			{
+				C:      storageInstancesC,
+				Id:     storageId,
+				Assert: txn.DocMissing,
+				Insert: &storageInstanceDoc{
+					Id:          storageId,
+					Kind:        StorageKindBlock,
+					StorageName: storageName,
+				},
+			},
+			createStatusOp(st, volumeGlobalKey(name), statusDoc{
+				Status: status.StatusDetached,
+				// TODO(fwereade): 2016-03-17 lp:1558657
+				Updated: time.Now().UnixNano(),
+			}),
+			{
+				C:      volumesC,
+				Id:     name,
+				Assert: txn.DocMissing,
+				Insert: &volumeDoc{
+					Name:      name,
+					StorageId: storageId,
+					Binding:   storageTag.String(),
+					Info:      &info,
+				},
+			},
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// checkVolumeIdUnused returns an error satisfying errors.IsAlreadyExists
+// if the model already has a volume with the given provider ID, or if
+// the ID has been reserved by an import.
+func checkVolumeIdUnused(st *State, volumeId string) error {
+	existing, err := st.volumes(bson.D{{"info.volumeid", volumeId}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(existing) == 0 {
+		coll, closer := st.getCollection(providerIDsC)
+		defer closer()
+		n, err := coll.FindId(st.volumeProviderIdKey(volumeId)).Count()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if n == 0 {
+			return nil
+		}
+	}
+	return errors.AlreadyExistsf("volume with provider ID %q", volumeId)
+}
+
+// volumeProviderIdKey returns the key of the providerIDsC document
+// which reserves the provider ID of an imported volume.
+func (st *State) volumeProviderIdKey(volumeId string) string {
+	return st.docID("volume:" + volumeId)
+}
+
+// validateExistingVolumePool validates that volumes from the named
+// storage pool may be imported into the model.
+func validateExistingVolumePool(st *State, poolName string) error {
+	if err := validateStoragePool(st, poolName, storage.StorageKindBlock, nil); err != nil {
+		return errors.Trace(err)
+	}
+	_, provider, err := poolStorageProvider(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return errors.NotSupportedf("importing machine-scoped volumes")
+	}
+	return nil
+}
+
 func (st *State) volumeParamsWithDefaults(params VolumeParams) (VolumeParams, error) {
 	if params.Pool != "" {
 		return params, nil
@@ -952,7 +1104,7 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 	// TODO(axw) we should reject info without VolumeId set; can't do this
 	// until the providers all set it correctly.
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		v, err := st.Volume(tag)
+		v, err := st.volumeByTag(tag)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -975,6 +1127,112 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if pendingSize := v.PendingSize(); pendingSize != 0 && info.Size >= pendingSize {
+			// The volume has been resized, so the
+			// pending resize request is complete.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"pendingsize", pendingSize}},
+				Update: bson.D{{"$unset", bson.D{{"pendingsize", nil}}}},
+			})
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeVolume requests that the specified volume be grown to the
+// given size, in MiB. The volume must be alive and provisioned, the
+// volume's storage provider must support resizing volumes, and the
+// new size must be greater than the volume's current size. The volume
+// is resized asynchronously by the storage provisioner, which records
+// the new size with SetVolumeInfo.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return st.resizeVolumeOps(v, size)
+	}
+	return st.run(buildTxn)
+}
+
+// resizeVolumeOps returns the operations required to request that the
+// given volume be grown to the given size, in MiB, or
+// jujutxn.ErrNoOperations if that size has already been requested.
+func (st *State) resizeVolumeOps(v *volume, size uint64) ([]txn.Op, error) {
+	if v.Life() != Alive {
+		return nil, errors.New("volume is not alive")
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	providerType, provider, err := poolStorageProvider(st, info.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !storage.SupportsResize(provider) {
+		return nil, errors.NotSupportedf("resizing %q volumes", providerType)
+	}
+	if size <= info.Size {
+		return nil, errors.Errorf(
+			"new size %dM must be greater than current size %dM",
+			size, info.Size,
+		)
+	}
+	if v.PendingSize() == size {
+		return nil, jujutxn.ErrNoOperations
+	}
+	return []txn.Op{{
+		C:  volumesC,
+		Id: v.VolumeTag().Id(),
+		Assert: bson.D{
+			{"life", Alive},
+			{"info.size", info.Size},
+		},
+		Update: bson.D{{"$set", bson.D{{"pendingsize", size}}}},
+	}}, nil
+}
+
+// CancelVolumeResize cancels the pending resize of the specified volume
+// to the given size, in MiB, along with the pending resize of the
+// filesystem that the volume backs, if any. The storage provisioner
+// cancels resizes that the volume's storage provider cannot carry out,
+// so that they do not remain pending indefinitely. If a different size
+// has since been requested, CancelVolumeResize does nothing.
+func (st *State) CancelVolumeResize(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot cancel resize of volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.PendingSize() != size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"pendingsize", size}},
+			Update: bson.D{{"$unset", bson.D{{"pendingsize", nil}}}},
+		}}
+		f, err := st.volumeFilesystem(tag)
+		if err == nil && f.PendingSize() != 0 {
+			ops = append(ops, txn.Op{
+				C:      filesystemsC,
+				Id:     f.FilesystemTag().Id(),
+				Assert: bson.D{{"pendingsize", f.PendingSize()}},
+				Update: bson.D{{"$unset", bson.D{{"pendingsize", nil}}}},
+			})
+		} else if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
 )
@@ -236,6 +237,228 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) TestResizeVolume(c *gc.C) {
+	volume, _ := s.setupVolumeAttachment(c)
+	volumeTag := volume.VolumeTag()
+	err := s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	c.Assert(volume.PendingSize(), gc.Equals, uint64(2048))
+
+	// Recording info with a smaller size leaves the resize pending.
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume", Pool: "loop-pool"})
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	c.Assert(volume.PendingSize(), gc.Equals, uint64(2048))
+
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 2048, VolumeId: "vol-ume", Pool: "loop-pool"})
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	c.Assert(volume.PendingSize(), gc.Equals, uint64(0))
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2048))
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeNotGrowing(c *gc.C) {
+	volume, _ := s.setupVolumeAttachment(c)
+	err := s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeVolume(volume.VolumeTag(), 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": new size 1024M must be greater than current size 1024M`)
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeNotProvisioned(c *gc.C) {
+	volume, _ := s.setupVolumeAttachment(c)
+	err := s.State.ResizeVolume(volume.VolumeTag(), 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": volume "0/0" not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeStateSuite) TestResizeStorage(c *gc.C) {
+	volume, _ := s.setupVolumeAttachment(c)
+	err := s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeStorage(names.NewStorageTag("data/0"), 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volume.VolumeTag())
+	c.Assert(volume.PendingSize(), gc.Equals, uint64(4096))
+}
+
+func (s *VolumeStateSuite) TestResizeStorageFilesystemNotVolumeBacked(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.ResizeStorage(storageTag, 4096)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: resizing filesystem storage not backed by a volume not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeProviderNotSupported(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "block", "environscoped")
+	volume := s.storageInstanceVolume(c, storageTag)
+	err := s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeVolume(volume.VolumeTag(), 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0": resizing "environscoped" volumes not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.volume(c, volume.VolumeTag()).PendingSize(), gc.Equals, uint64(0))
+}
+
+func (s *VolumeStateSuite) TestCancelVolumeResize(c *gc.C) {
+	volume, _ := s.setupVolumeAttachment(c)
+	volumeTag := volume.VolumeTag()
+	err := s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Cancelling a resize to a size other than the one
+	// pending leaves the pending resize alone.
+	err = s.State.CancelVolumeResize(volumeTag, 4096)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).PendingSize(), gc.Equals, uint64(2048))
+
+	err = s.State.CancelVolumeResize(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volume(c, volumeTag).PendingSize(), gc.Equals, uint64(0))
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolume(c *gc.C) {
+	volumeInfo := state.VolumeInfo{
+		Pool:       "persistent-block",
+		Size:       123,
+		VolumeId:   "vol-ume",
+		Persistent: true,
+	}
+	storageTag, err := s.State.AddExistingVolume(volumeInfo, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("allecto/0"))
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Kind(), gc.Equals, state.StorageKindBlock)
+	c.Assert(si.StorageName(), gc.Equals, "allecto")
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+
+	volume := s.storageInstanceVolume(c, storageTag)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, volumeInfo)
+	c.Assert(volume.LifeBinding(), gc.Equals, storageTag)
+	_, ok = volume.Params()
+	c.Assert(ok, jc.IsFalse)
+	volumeStatus, err := volume.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeStatus.Status, gc.Equals, status.StatusDetached)
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeAttachStorage(c *gc.C) {
+	storageTag, err := s.State.AddExistingVolume(state.VolumeInfo{
+		Pool:     "persistent-block",
+		Size:     1024,
+		VolumeId: "vol-ume",
+	}, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop-pool", 1024, 1),
+	})
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.VolumeAttachment(names.NewMachineTag(machineId), volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	assertMachineStorageRefs(c, s.State, names.NewMachineTag(machineId))
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeDuplicate(c *gc.C) {
+	volumeInfo := state.VolumeInfo{
+		Pool:     "persistent-block",
+		Size:     123,
+		VolumeId: "vol-ume",
+	}
+	_, err := s.State.AddExistingVolume(volumeInfo, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddExistingVolume(volumeInfo, "allecto")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing volume: volume with provider ID "vol-ume" already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeConcurrent(c *gc.C) {
+	volumeInfo := state.VolumeInfo{
+		Pool:     "persistent-block",
+		Size:     123,
+		VolumeId: "vol-ume",
+	}
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddExistingVolume(volumeInfo, "allecto")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+	_, err := s.State.AddExistingVolume(volumeInfo, "allecto")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing volume: volume with provider ID "vol-ume" already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeAfterRemoval(c *gc.C) {
+	volumeInfo := state.VolumeInfo{
+		Pool:     "persistent-block",
+		Size:     123,
+		VolumeId: "vol-ume",
+	}
+	storageTag, err := s.State.AddExistingVolume(volumeInfo, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.State.DestroyVolume(volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveVolume(volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddExistingVolume(volumeInfo, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeStateSuite) TestValidateExistingVolume(c *gc.C) {
+	err := s.State.ValidateExistingVolume("persistent-block", "vol-ume", "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddExistingVolume(state.VolumeInfo{
+		Pool: "persistent-block", Size: 123, VolumeId: "vol-ume",
+	}, "allecto")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ValidateExistingVolume("persistent-block", "vol-ume", "allecto")
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+	err = s.State.ValidateExistingVolume("loop-pool", "vol-other", "allecto")
+	c.Assert(err, gc.ErrorMatches, "cannot add existing volume: importing machine-scoped volumes not supported")
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeInvalid(c *gc.C) {
+	_, err := s.State.AddExistingVolume(state.VolumeInfo{Pool: "persistent-block", Size: 123}, "allecto")
+	c.Assert(err, gc.ErrorMatches, "cannot add existing volume: empty volume ID not valid")
+	_, err = s.State.AddExistingVolume(state.VolumeInfo{
+		Pool: "persistent-block", Size: 123, VolumeId: "vol-ume",
+	}, "0allecto")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing volume: storage name "0allecto" not valid`)
+	_, err = s.State.AddExistingVolume(state.VolumeInfo{
+		Pool: "loop-pool", Size: 123, VolumeId: "vol-ume",
+	}, "allecto")
+	c.Assert(err, gc.ErrorMatches, "cannot add existing volume: importing machine-scoped volumes not supported")
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
@@ -374,6 +597,27 @@ func (s *VolumeStateSuite) TestWatchMachineVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *VolumeStateSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	volume, machine := s.setupVolumeAttachment(c)
+	volumeTag := volume.VolumeTag()
+
+	w := s.State.WatchMachineVolumeResizes(machine.MachineTag())
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(volumeTag.Id()) // initial
+	wc.AssertNoChange()
+
+	err := s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(volumeTag.Id())
+	wc.AssertNoChange()
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(volumeTag.Id())
+	wc.AssertNoChange()
+}
+
 func (s *VolumeStateSuite) TestWatchMachineVolumeAttachments(c *gc.C) {
 	service := s.setupMixedScopeStorageService(c, "block")
 	addUnit := func(to *state.Machine) (u *state.Unit, m *state.Machine) {
@@ -253,6 +253,65 @@ func (st *State) watchMachineStorage(m names.MachineTag, collection string) Stri
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to any model-scoped volume, so that pending resizes may be
+// carried out. Unlike WatchModelVolumes, changes other than lifecycle
+// changes are reported.
+func (st *State) WatchModelVolumeResizes() StringsWatcher {
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newcollectionWatcher(st, colWCfg{col: volumesC, filter: filter})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to any volume scoped to the specified machine, so that
+// pending resizes may be carried out.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newcollectionWatcher(st, colWCfg{col: volumesC, filter: filter})
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies
+// of changes to any model-scoped filesystem, so that pending resizes
+// may be carried out.
+func (st *State) WatchModelFilesystemResizes() StringsWatcher {
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newcollectionWatcher(st, colWCfg{col: filesystemsC, filter: filter})
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies
+// of changes to any filesystem scoped to the specified machine, so that
+// pending resizes may be carried out.
+func (st *State) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newcollectionWatcher(st, colWCfg{col: filesystemsC, filter: filter})
+}
+
 // WatchEnvironVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to environ-
 // scoped volumes.
@@ -293,9 +352,21 @@ func (st *State) WatchMachineVolumeAttachments(m names.MachineTag) StringsWatche
 
 // WatchMachineFilesystemAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all filesystem attachments related to the specified
-// machine, for filesystems scoped to the machine.
+// machine, for machine-scoped filesystems. A volume-backed filesystem keeps the
+// scope of the machine it was created on when its storage is moved to another
+// machine, so attachments of filesystems scoped to other machines are included.
 func (st *State) WatchMachineFilesystemAttachments(m names.MachineTag) StringsWatcher {
-	return st.watchMachineStorageAttachments(m, filesystemAttachmentsC)
+	pattern := fmt.Sprintf("^%s:.+/.*", st.docID(m.Id()))
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	prefix := m.Id() + ":"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix) && strings.Contains(k[len(prefix):], "/")
+	}
+	return newLifecycleWatcher(st, filesystemAttachmentsC, members, filter, nil)
 }
 
 func (st *State) watchMachineStorageAttachments(m names.MachineTag, collection string) StringsWatcher {
@@ -1333,6 +1404,12 @@ func (st *State) WatchVolumeAttachment(m names.MachineTag, v names.VolumeTag) No
 	return newEntityWatcher(st, volumeAttachmentsC, st.docID(id))
 }
 
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem.
+func (st *State) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(st, filesystemsC, st.docID(f.Id()))
+}
+
 // WatchFilesystemAttachment returns a watcher for observing changes
 // to a filesystem attachment.
 func (st *State) WatchFilesystemAttachment(m names.MachineTag, f names.FilesystemTag) NotifyWatcher {
@@ -1739,6 +1816,61 @@ func (w *cleanupWatcher) loop() (err error) {
 	}
 }
 
+// notifyCollWatcher implements NotifyWatcher, triggering when a
+// change is seen in a specific collection matching the provided
+// filter function.
+type notifyCollWatcher struct {
+	commonWatcher
+	collName string
+	filter   func(interface{}) bool
+	out      chan struct{}
+}
+
+var _ Watcher = (*notifyCollWatcher)(nil)
+
+func newNotifyCollWatcher(st *State, collName string, filter func(interface{}) bool) NotifyWatcher {
+	w := &notifyCollWatcher{
+		commonWatcher: newCommonWatcher(st),
+		collName:      collName,
+		filter:        filter,
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *notifyCollWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *notifyCollWatcher) loop() error {
+	in := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(w.collName, in, w.filter)
+	defer w.watcher.UnwatchCollection(w.collName, in)
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // actionStatusWatcher is a StringsWatcher that filters notifications
 // to Action Id's that match the ActionReceiver and ActionStatus set
 // provided.
@@ -2234,6 +2366,13 @@ type openedPortsWatcher struct {
 
 var _ Watcher = (*openedPortsWatcher)(nil)
 
+// WatchSubnets returns a NotifyWatcher that notifies when the subnets
+// of the model change. As subnets record the space they belong to, this
+// includes spaces gaining or losing subnets.
+func (st *State) WatchSubnets() NotifyWatcher {
+	return newNotifyCollWatcher(st, subnetsC, isLocalID(st))
+}
+
 // WatchOpenedPorts starts and returns a StringsWatcher notifying of changes to
 // the openedPorts collection. Reported changes have the following format:
 // "<machine-id>:[<subnet-CIDR>]", i.e. "0:10.20.0.0/16" or "1:" (empty subnet
@@ -0,0 +1,25 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+)
+
+// ValidateImportOwner checks that a volume which the storage provider
+// records as belonging to the model with UUID owner may be imported
+// into the model with UUID modelUUID. An empty owner means that the
+// volume is not known to belong to any model.
+//
+// Unless force is true, importing a volume that belongs to another
+// model is refused, as the volume would then be managed by both.
+func ValidateImportOwner(volumeId, owner, modelUUID string, force bool) error {
+	if owner == "" || owner == modelUUID || force {
+		return nil
+	}
+	return errors.Errorf(
+		"volume %q belongs to model %q, not %q (use force to import it anyway)",
+		volumeId, owner, modelUUID,
+	)
+}
@@ -0,0 +1,33 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+)
+
+type ImportSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ImportSuite{})
+
+func (*ImportSuite) TestValidateImportOwner(c *gc.C) {
+	for _, owner := range []string{"", "model-a"} {
+		err := storage.ValidateImportOwner("vol-0", owner, "model-a", false)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (*ImportSuite) TestValidateImportOwnerOtherModel(c *gc.C) {
+	err := storage.ValidateImportOwner("vol-0", "model-b", "model-a", false)
+	c.Check(err, gc.ErrorMatches, `volume "vol-0" belongs to model "model-b", not "model-a" \(use force to import it anyway\)`)
+
+	err = storage.ValidateImportOwner("vol-0", "model-b", "model-a", true)
+	c.Check(err, jc.ErrorIsNil)
+}
@@ -106,6 +106,49 @@ type VolumeSource interface {
 	// are detachable, and reject attempts to attach/detach on
 	// that basis.
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
+
+	// ResizeVolumes grows the volumes with the specified parameters to
+	// at least the requested sizes, and returns information about the
+	// resized volumes. Volumes are never shrunk.
+	//
+	// ResizeVolumes must be idempotent; it may be called even if the
+	// volume has already been resized, in which case the volume is
+	// left unchanged.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeImporter provides an interface for importing volumes that were
+// not created by Juju into the model. A VolumeSource may optionally
+// implement VolumeImporter.
+type VolumeImporter interface {
+	// ImportVolume prepares the volume with the specified provider
+	// volume ID for management by Juju, tagging it with the given
+	// resource tags if the storage provider supports tags, and
+	// returns information about the volume to record in the model.
+	//
+	// ImportVolume must return an error if the volume is attached to
+	// a machine, or is otherwise unavailable for import. Unless force
+	// is true, ImportVolume must also return an error, without
+	// modifying the volume, if the volume belongs to another model
+	// (see ValidateImportOwner).
+	ImportVolume(volumeId string, resourceTags map[string]string, force bool) (VolumeInfo, error)
+}
+
+// ResizeSupporter may optionally be implemented by a Provider to
+// report whether or not the volumes created by its volume sources can
+// be resized. Volumes from providers that do not implement
+// ResizeSupporter cannot be resized.
+type ResizeSupporter interface {
+	// SupportsResize reports whether or not the provider's volume
+	// sources can grow volumes with ResizeVolumes.
+	SupportsResize() bool
+}
+
+// SupportsResize reports whether or not volumes created by the given
+// provider can be resized.
+func SupportsResize(p Provider) bool {
+	r, ok := p.(ResizeSupporter)
+	return ok && r.SupportsResize()
 }
 
 // FilesystemSource provides an interface for creating, destroying and
@@ -141,6 +184,20 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemResizer provides an interface for growing volume-backed
+// filesystems to fill their volumes, once the volumes have been
+// resized. A FilesystemSource may optionally implement
+// FilesystemResizer.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to at least the requested sizes, and returns
+	// information about the resized filesystems.
+	//
+	// ResizeFilesystems must be idempotent; it may be called even if
+	// the filesystem has already been grown.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -191,6 +248,25 @@ type VolumeAttachmentParams struct {
 	VolumeId string
 }
 
+// VolumeResizeParams is a set of parameters for resizing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume that
+	// should be resized.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// should be resized.
+	VolumeId string
+
+	// Size is the minimum size that the volume should be resized to,
+	// in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that is to be used
+	// to resize the volume.
+	Provider ProviderType
+}
+
 // AttachmentParams describes the parameters for attaching a volume or
 // filesystem to a machine.
 type AttachmentParams struct {
@@ -239,6 +315,25 @@ type FilesystemParams struct {
 	ResourceTags map[string]string
 }
 
+// FilesystemResizeParams is a set of parameters for growing a
+// volume-backed filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the filesystem that
+	// should be resized.
+	Tag names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the
+	// filesystem that should be resized.
+	FilesystemId string
+
+	// Volume is the tag of the volume that backs the filesystem.
+	Volume names.VolumeTag
+
+	// Size is the minimum size that the filesystem should be grown
+	// to, in MiB.
+	Size uint64
+}
+
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
 // or detachment.
 type FilesystemAttachmentParams struct {
@@ -280,6 +375,21 @@ type AttachVolumesResult struct {
 	Error            error
 }
 
+// ResizeVolumesResult contains the result of a VolumeSource.ResizeVolumes
+// call for one volume. Volume should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	Volume *Volume
+	Error  error
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// Filesystem should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	Filesystem *Filesystem
+	Error      error
+}
+
 // CreateFilesystemsResult contains the result of a FilesystemSource.CreateFilesystems call
 // for one filesystem. Filesystem should only be used if Error is nil.
 type CreateFilesystemsResult struct {
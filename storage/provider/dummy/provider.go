@@ -12,6 +12,7 @@ import (
 )
 
 var _ storage.Provider = (*StorageProvider)(nil)
+var _ storage.ResizeSupporter = (*StorageProvider)(nil)
 
 // StorageProvider is an implementation of storage.Provider, suitable for testing.
 // Each method's default behaviour may be overridden by setting the corresponding
@@ -26,6 +27,10 @@ type StorageProvider struct {
 	// dynamic provisioning.
 	IsDynamic bool
 
+	// IsResizable defines whether or not the provider reports that
+	// its volumes may be resized.
+	IsResizable bool
+
 	// VolumeSourceFunc will be called by VolumeSource, if non-nil;
 	// otherwise VolumeSource will return a NotSupported error.
 	VolumeSourceFunc func(*config.Config, *storage.Config) (storage.VolumeSource, error)
@@ -90,3 +95,9 @@ func (p *StorageProvider) Dynamic() bool {
 	p.MethodCall(p, "Dynamic")
 	return p.IsDynamic
 }
+
+// SupportsResize is defined on storage.ResizeSupporter.
+func (p *StorageProvider) SupportsResize() bool {
+	p.MethodCall(p, "SupportsResize")
+	return p.IsResizable
+}
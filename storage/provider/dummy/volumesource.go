@@ -23,6 +23,8 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]error, error)
+	ResizeVolumesFunc        func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	ImportVolumeFunc         func(string, map[string]string, bool) (storage.VolumeInfo, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -87,3 +89,21 @@ func (s *VolumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// ResizeVolumes is defined on storage.VolumeSource.
+func (s *VolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
+
+// ImportVolume is defined on storage.VolumeImporter.
+func (s *VolumeSource) ImportVolume(volumeId string, resourceTags map[string]string, force bool) (storage.VolumeInfo, error) {
+	s.MethodCall(s, "ImportVolume", volumeId, resourceTags, force)
+	if s.ImportVolumeFunc != nil {
+		return s.ImportVolumeFunc(volumeId, resourceTags, force)
+	}
+	return storage.VolumeInfo{}, errors.NotImplementedf("ImportVolume")
+}
@@ -30,6 +30,7 @@ type loopProvider struct {
 }
 
 var _ storage.Provider = (*loopProvider)(nil)
+var _ storage.ResizeSupporter = (*loopProvider)(nil)
 
 // ValidateConfig is defined on the Provider interface.
 func (*loopProvider) ValidateConfig(*storage.Config) error {
@@ -91,6 +92,11 @@ func (*loopProvider) Dynamic() bool {
 	return true
 }
 
+// SupportsResize is defined on the ResizeSupporter interface.
+func (*loopProvider) SupportsResize() bool {
+	return true
+}
+
 // loopVolumeSource provides common functionality to handle
 // loop devices for rootfs and host loop volume sources.
 type loopVolumeSource struct {
@@ -239,6 +245,54 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeSource interface.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := lvs.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.Tag.Id())
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (*storage.Volume, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	fileInfo, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting loop backing file size")
+	}
+	size := uint64(fileInfo.Size()) / (1024 * 1024)
+	if size < arg.Size {
+		// fallocate will extend the file, preserving its contents.
+		if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+			return nil, errors.Annotate(err, "could not extend block file")
+		}
+		size = arg.Size
+	}
+	// Refresh the capacity of any loop devices associated with the
+	// file, in case a previous attempt was interrupted before doing so.
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if err := refreshLoopDevice(lvs.run, deviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &storage.Volume{
+		arg.Tag,
+		storage.VolumeInfo{
+			VolumeId: arg.Tag.String(),
+			Size:     size,
+		},
+	}, nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -289,6 +343,16 @@ func detachLoopDevice(run runCommandFunc, deviceName string) error {
 	return err
 }
 
+// refreshLoopDevice updates the capacity of the loop device with the
+// specified name to match the size of its backing file.
+func refreshLoopDevice(run runCommandFunc, deviceName string) error {
+	_, err := run("losetup", "-c", path.Join("/dev", deviceName))
+	if err != nil {
+		return errors.Annotatef(err, "refreshing loop device %q", deviceName)
+	}
+	return nil
+}
+
 // associatedLoopDevices returns the device names of the loop devices
 // associated with the specified file path.
 func associatedLoopDevices(run runCommandFunc, filePath string) ([]string, error) {
@@ -101,6 +101,67 @@ func (s *managedfsSuite) TestCreateFilesystems(c *gc.C) {
 	}})
 }
 
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// The partition on sda is grown before the filesystem.
+	s.commands.expect("sgdisk", "-e", "-d", "1", "-n", "1:0:-1", "/dev/sda")
+	s.commands.expect("partx", "-u", "/dev/sda")
+	s.commands.expect("resize2fs", "/dev/sda1")
+	// xvdf1 is assumed to not be partitioned.
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       6,
+	}
+	c.Assert(source, gc.Implements, new(storage.FilesystemResizer))
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		Volume:       names.NewVolumeTag("0"),
+		Size:         4,
+	}, {
+		Tag:          names.NewFilesystemTag("0/1"),
+		FilesystemId: "filesystem-0-1",
+		Volume:       names.NewVolumeTag("1"),
+		Size:         5,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/0"),
+			names.NewVolumeTag("0"),
+			storage.FilesystemInfo{"filesystem-0-0", 4},
+		},
+	}, {
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/1"),
+			names.NewVolumeTag("1"),
+			storage.FilesystemInfo{"filesystem-0-1", 6},
+		},
+	}})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsVolumeNotResized(c *gc.C) {
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       2,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		Volume:       names.NewVolumeTag("0"),
+		Size:         4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is not yet resized")
+}
 func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	source := s.initSource(c)
 	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
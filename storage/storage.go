@@ -47,4 +47,9 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the storage attachment's block device in
+	// MiB, as observed on the machine. Size is only reported for
+	// block-kind storage attachments.
+	Size uint64
 }
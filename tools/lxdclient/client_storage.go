@@ -0,0 +1,305 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/juju/errors"
+	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared"
+)
+
+// StoragePool describes an LXD storage pool.
+type StoragePool struct {
+	Name   string            `json:"name"`
+	Driver string            `json:"driver"`
+	Config map[string]string `json:"config"`
+}
+
+// StorageVolume describes a volume in an LXD storage pool.
+type StorageVolume struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	UsedBy []string          `json:"used_by"`
+}
+
+// DiskDevice describes a custom storage volume attached to a
+// container as a disk device.
+type DiskDevice struct {
+	// Pool is the name of the storage pool containing the volume.
+	Pool string
+
+	// Source is the name of the custom storage volume.
+	Source string
+
+	// Path is the path in the container at which the volume
+	// is mounted.
+	Path string
+
+	// ReadOnly indicates that the volume is mounted read-only.
+	ReadOnly bool
+}
+
+func (d DiskDevice) props() []string {
+	props := []string{
+		"pool=" + d.Pool,
+		"source=" + d.Source,
+		"path=" + d.Path,
+	}
+	if d.ReadOnly {
+		props = append(props, "readonly=true")
+	}
+	return props
+}
+
+func (d DiskDevice) matches(device shared.Device) bool {
+	readOnly := device["readonly"] == "true"
+	return device["type"] == "disk" &&
+		device["pool"] == d.Pool &&
+		device["source"] == d.Source &&
+		device["path"] == d.Path &&
+		readOnly == d.ReadOnly
+}
+
+// customVolumeType is the type of storage volumes created by users,
+// as opposed to those created by LXD for containers and images.
+const customVolumeType = "custom"
+
+type rawStorageClient interface {
+	StoragePoolGet(name string) (StoragePool, error)
+	StoragePoolCreate(name, driver string, config map[string]string) error
+	StoragePoolVolumeTypeGet(pool, volume, volumeType string) (StorageVolume, error)
+	StoragePoolVolumeTypeCreate(pool, volume, volumeType string, config map[string]string) error
+	StoragePoolVolumeTypeDelete(pool, volume, volumeType string) error
+
+	ContainerInfo(name string) (*shared.ContainerInfo, error)
+	ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error)
+	ContainerDeviceDelete(container, devname string) (*lxd.Response, error)
+	WaitForSuccess(waitURL string) error
+}
+
+type storageClient struct {
+	raw rawStorageClient
+}
+
+// EnsureStoragePool creates the named storage pool with the given
+// driver and config, if it does not already exist. No check is made
+// that an existing pool has the same driver and config. If the LXD
+// server does not support storage pools, an error satisfying
+// errors.IsNotSupported is returned.
+func (c storageClient) EnsureStoragePool(name, driver string, config map[string]string) error {
+	_, err := c.raw.StoragePoolGet(name)
+	if err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err := c.raw.StoragePoolCreate(name, driver, config); err != nil {
+		return errors.Annotatef(err, "creating storage pool %q", name)
+	}
+	return nil
+}
+
+// StorageVolume returns the custom volume with the given name in the
+// given storage pool.
+func (c storageClient) StorageVolume(pool, volume string) (StorageVolume, error) {
+	v, err := c.raw.StoragePoolVolumeTypeGet(pool, volume, customVolumeType)
+	return v, errors.Trace(err)
+}
+
+// CreateStorageVolume creates a custom volume with the given name and
+// config in the given storage pool.
+func (c storageClient) CreateStorageVolume(pool, volume string, config map[string]string) error {
+	err := c.raw.StoragePoolVolumeTypeCreate(pool, volume, customVolumeType, config)
+	return errors.Trace(err)
+}
+
+// DestroyStorageVolume destroys the custom volume with the given name
+// in the given storage pool.
+func (c storageClient) DestroyStorageVolume(pool, volume string) error {
+	err := c.raw.StoragePoolVolumeTypeDelete(pool, volume, customVolumeType)
+	return errors.Trace(err)
+}
+
+// AttachDisk attaches a custom volume to the named container as a
+// disk device with the given name. If the container already has a
+// matching device, AttachDisk does nothing.
+func (c storageClient) AttachDisk(container, device string, disk DiskDevice) error {
+	info, err := c.raw.ContainerInfo(container)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if existing, ok := info.Devices[device]; ok {
+		if disk.matches(existing) {
+			return nil
+		}
+		return errors.Errorf("container %q already has a different device %q", container, device)
+	}
+	resp, err := c.raw.ContainerDeviceAdd(container, device, "disk", disk.props())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// DetachDisk removes the named disk device from the named container.
+// If the container has no such device, DetachDisk does nothing.
+func (c storageClient) DetachDisk(container, device string) error {
+	info, err := c.raw.ContainerInfo(container)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := info.Devices[device]; !ok {
+		return nil
+	}
+	resp, err := c.raw.ContainerDeviceDelete(container, device)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// storageAPI extends the raw LXD client with the storage pool and
+// volume API, which the LXD client library does not yet provide.
+type storageAPI struct {
+	*lxd.Client
+}
+
+// StoragePoolGet returns the named storage pool.
+func (c storageAPI) StoragePoolGet(name string) (StoragePool, error) {
+	var pool StoragePool
+	resp, err := c.do("GET", nil, "storage-pools", name)
+	if err != nil {
+		return StoragePool{}, errors.Trace(err)
+	}
+	if err := json.Unmarshal(resp.Metadata, &pool); err != nil {
+		return StoragePool{}, errors.Annotate(err, "decoding storage pool")
+	}
+	return pool, nil
+}
+
+// StoragePoolCreate creates a storage pool with the given name,
+// driver and config.
+func (c storageAPI) StoragePoolCreate(name, driver string, config map[string]string) error {
+	_, err := c.do("POST", StoragePool{
+		Name:   name,
+		Driver: driver,
+		Config: config,
+	}, "storage-pools")
+	return errors.Trace(err)
+}
+
+// StoragePoolVolumeTypeGet returns the named volume of the given type
+// in the given storage pool.
+func (c storageAPI) StoragePoolVolumeTypeGet(pool, volume, volumeType string) (StorageVolume, error) {
+	var v StorageVolume
+	resp, err := c.do("GET", nil, "storage-pools", pool, "volumes", volumeType, volume)
+	if err != nil {
+		return StorageVolume{}, errors.Trace(err)
+	}
+	if err := json.Unmarshal(resp.Metadata, &v); err != nil {
+		return StorageVolume{}, errors.Annotate(err, "decoding storage volume")
+	}
+	return v, nil
+}
+
+// StoragePoolVolumeTypeCreate creates a volume of the given type in
+// the given storage pool.
+func (c storageAPI) StoragePoolVolumeTypeCreate(pool, volume, volumeType string, config map[string]string) error {
+	_, err := c.do("POST", StorageVolume{
+		Name:   volume,
+		Type:   volumeType,
+		Config: config,
+	}, "storage-pools", pool, "volumes", volumeType)
+	return errors.Trace(err)
+}
+
+// StoragePoolVolumeTypeDelete deletes the named volume of the given
+// type from the given storage pool.
+func (c storageAPI) StoragePoolVolumeTypeDelete(pool, volume, volumeType string) error {
+	_, err := c.do("DELETE", nil, "storage-pools", pool, "volumes", volumeType, volume)
+	return errors.Trace(err)
+}
+
+// storageExtension is the LXD API extension that provides the storage
+// pool and volume API.
+const storageExtension = "storage"
+
+// do makes a synchronous request to the LXD API, returning an error
+// satisfying errors.IsNotFound if the requested entity does not exist,
+// or errors.IsNotSupported if the server has no storage API.
+func (c storageAPI) do(method string, body interface{}, elem ...string) (*lxd.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	url := c.BaseURL + path.Join("/", shared.APIVersion, path.Join(elem...))
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.Http.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		// Servers without the storage API respond with 404 to
+		// every storage request; that must not be mistaken for
+		// a missing pool or volume.
+		if err := c.checkStorageSupported(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.NotFoundf("%s", path.Join(elem...))
+	}
+	return lxd.HoistResponse(resp, lxd.Sync)
+}
+
+// checkStorageSupported returns an error satisfying
+// errors.IsNotSupported if the server does not advertise the
+// storage API extension.
+func (c storageAPI) checkStorageSupported() error {
+	resp, err := c.Http.Get(c.BaseURL + path.Join("/", shared.APIVersion))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	lxdResp, err := lxd.HoistResponse(resp, lxd.Sync)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var server struct {
+		APIExtensions []string `json:"api_extensions"`
+	}
+	if err := json.Unmarshal(lxdResp.Metadata, &server); err != nil {
+		return errors.Annotate(err, "decoding server status")
+	}
+	for _, extension := range server.APIExtensions {
+		if extension == storageExtension {
+			return nil
+		}
+	}
+	return errors.NotSupportedf("LXD server without the %q API extension", storageExtension)
+}
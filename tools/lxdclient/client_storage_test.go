@@ -0,0 +1,235 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared"
+	gc "gopkg.in/check.v1"
+)
+
+type storageSuite struct {
+	BaseSuite
+	storage storageClient
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+var _ rawStorageClient = (*stubClient)(nil)
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.Client.Response = &lxd.Response{Operation: "/1.0/operations/foo"}
+	s.storage = storageClient{s.Client}
+}
+
+func (s *storageSuite) TestEnsureStoragePoolExists(c *gc.C) {
+	err := s.storage.EnsureStoragePool("juju", "zfs", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCall(c, 0, "StoragePoolGet", "juju")
+	s.Stub.CheckCallNames(c, "StoragePoolGet")
+}
+
+func (s *storageSuite) TestEnsureStoragePoolCreates(c *gc.C) {
+	s.Stub.SetErrors(errors.NotFoundf("storage-pools/juju"))
+	config := map[string]string{"zfs.pool_name": "juju-lxd"}
+	err := s.storage.EnsureStoragePool("juju", "zfs", config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "StoragePoolGet", "StoragePoolCreate")
+	s.Stub.CheckCall(c, 1, "StoragePoolCreate", "juju", "zfs", config)
+}
+
+func (s *storageSuite) TestEnsureStoragePoolError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	err := s.storage.EnsureStoragePool("juju", "zfs", nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.Stub.CheckCallNames(c, "StoragePoolGet")
+}
+
+func (s *storageSuite) TestEnsureStoragePoolNotSupported(c *gc.C) {
+	s.Stub.SetErrors(errors.NotSupportedf("LXD server without the %q API extension", "storage"))
+	err := s.storage.EnsureStoragePool("juju", "zfs", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.Stub.CheckCallNames(c, "StoragePoolGet")
+}
+
+func (s *storageSuite) TestCreateStorageVolume(c *gc.C) {
+	config := map[string]string{"size": "1024MiB"}
+	err := s.storage.CreateStorageVolume("juju", "vol", config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCall(c, 0, "StoragePoolVolumeTypeCreate", "juju", "vol", "custom", config)
+}
+
+func (s *storageSuite) TestDestroyStorageVolume(c *gc.C) {
+	err := s.storage.DestroyStorageVolume("juju", "vol")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCall(c, 0, "StoragePoolVolumeTypeDelete", "juju", "vol", "custom")
+}
+
+func (s *storageSuite) TestAttachDisk(c *gc.C) {
+	s.Client.Container = &shared.ContainerInfo{}
+	err := s.storage.AttachDisk("juju-machine-0", "vol", DiskDevice{
+		Pool:     "juju",
+		Source:   "vol",
+		Path:     "/srv",
+		ReadOnly: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "ContainerInfo", "ContainerDeviceAdd", "WaitForSuccess")
+	s.Stub.CheckCall(c, 1, "ContainerDeviceAdd", "juju-machine-0", "vol", "disk", []string{
+		"pool=juju", "source=vol", "path=/srv", "readonly=true",
+	})
+	s.Stub.CheckCall(c, 2, "WaitForSuccess", "/1.0/operations/foo")
+}
+
+func (s *storageSuite) TestAttachDiskAlreadyAttached(c *gc.C) {
+	s.Client.Container = &shared.ContainerInfo{
+		Devices: shared.Devices{
+			"vol": shared.Device{
+				"type":   "disk",
+				"pool":   "juju",
+				"source": "vol",
+				"path":   "/srv",
+			},
+		},
+	}
+	err := s.storage.AttachDisk("juju-machine-0", "vol", DiskDevice{
+		Pool:   "juju",
+		Source: "vol",
+		Path:   "/srv",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "ContainerInfo")
+}
+
+func (s *storageSuite) TestAttachDiskConflict(c *gc.C) {
+	s.Client.Container = &shared.ContainerInfo{
+		Devices: shared.Devices{
+			"vol": shared.Device{"type": "nic"},
+		},
+	}
+	err := s.storage.AttachDisk("juju-machine-0", "vol", DiskDevice{
+		Pool:   "juju",
+		Source: "vol",
+		Path:   "/srv",
+	})
+	c.Assert(err, gc.ErrorMatches, `container "juju-machine-0" already has a different device "vol"`)
+}
+
+func (s *storageSuite) TestDetachDisk(c *gc.C) {
+	s.Client.Container = &shared.ContainerInfo{
+		Devices: shared.Devices{
+			"vol": shared.Device{"type": "disk"},
+		},
+	}
+	err := s.storage.DetachDisk("juju-machine-0", "vol")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "ContainerInfo", "ContainerDeviceDelete", "WaitForSuccess")
+	s.Stub.CheckCall(c, 1, "ContainerDeviceDelete", "juju-machine-0", "vol")
+}
+
+func (s *storageSuite) TestDetachDiskNotAttached(c *gc.C) {
+	s.Client.Container = &shared.ContainerInfo{}
+	err := s.storage.DetachDisk("juju-machine-0", "vol")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Stub.CheckCallNames(c, "ContainerInfo")
+}
+
+type storageAPISuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&storageAPISuite{})
+
+func (s *storageAPISuite) serve(c *gc.C, handler http.HandlerFunc) storageAPI {
+	server := httptest.NewServer(handler)
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	return storageAPI{&lxd.Client{BaseURL: server.URL}}
+}
+
+func (s *storageAPISuite) TestStoragePoolGet(c *gc.C) {
+	api := s.serve(c, func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "GET")
+		c.Check(req.URL.Path, gc.Equals, "/1.0/storage-pools/juju")
+		w.Write([]byte(`{"type":"sync","status_code":200,"metadata":{"name":"juju","driver":"zfs","config":{"size":"10GB"}}}`))
+	})
+	pool, err := api.StoragePoolGet("juju")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool, jc.DeepEquals, StoragePool{
+		Name:   "juju",
+		Driver: "zfs",
+		Config: map[string]string{"size": "10GB"},
+	})
+}
+
+// serveStorage returns a storageAPI for a server that responds to
+// storage requests with 404, and that advertises the given API
+// extensions.
+func (s *storageAPISuite) serveStorage(c *gc.C, extensions ...string) storageAPI {
+	return s.serve(c, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/1.0" {
+			data, err := json.Marshal(map[string]interface{}{
+				"type":        "sync",
+				"status_code": 200,
+				"metadata":    map[string]interface{}{"api_extensions": extensions},
+			})
+			c.Check(err, jc.ErrorIsNil)
+			w.Write(data)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type":"error","error":"not found","error_code":404}`))
+	})
+}
+
+func (s *storageAPISuite) TestStoragePoolGetNotFound(c *gc.C) {
+	api := s.serveStorage(c, "storage")
+	_, err := api.StoragePoolGet("juju")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageAPISuite) TestStoragePoolGetNotSupported(c *gc.C) {
+	api := s.serveStorage(c, "network")
+	_, err := api.StoragePoolGet("juju")
+	c.Assert(err, gc.ErrorMatches, `LXD server without the "storage" API extension not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageAPISuite) TestStoragePoolVolumeTypeCreate(c *gc.C) {
+	api := s.serve(c, func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/1.0/storage-pools/juju/volumes/custom")
+		data, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		var volume StorageVolume
+		c.Check(json.Unmarshal(data, &volume), jc.ErrorIsNil)
+		c.Check(volume, jc.DeepEquals, StorageVolume{
+			Name:   "vol",
+			Type:   "custom",
+			Config: map[string]string{"size": "1024MiB"},
+		})
+		w.Write([]byte(`{"type":"sync","status_code":200,"metadata":{}}`))
+	})
+	err := api.StoragePoolVolumeTypeCreate("juju", "vol", "custom", map[string]string{"size": "1024MiB"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageAPISuite) TestStoragePoolVolumeTypeDelete(c *gc.C) {
+	api := s.serve(c, func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "DELETE")
+		c.Check(req.URL.Path, gc.Equals, "/1.0/storage-pools/juju/volumes/custom/vol")
+		w.Write([]byte(`{"type":"sync","status_code":200,"metadata":{}}`))
+	})
+	err := api.StoragePoolVolumeTypeDelete("juju", "vol", "custom")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -40,6 +40,9 @@ type stubClient struct {
 
 	Instance   *shared.ContainerState
 	Instances  []shared.ContainerInfo
+	Container  *shared.ContainerInfo
+	Pool       StoragePool
+	Volume     StorageVolume
 	ReturnCode int
 	Response   *lxd.Response
 	Aliases    map[string]string
@@ -133,3 +136,75 @@ func (s *stubClient) SetContainerConfig(name, key, value string) error {
 
 	return nil
 }
+
+func (s *stubClient) ContainerInfo(name string) (*shared.ContainerInfo, error) {
+	s.stub.AddCall("ContainerInfo", name)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.Container, nil
+}
+
+func (s *stubClient) ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error) {
+	s.stub.AddCall("ContainerDeviceAdd", container, devname, devtype, props)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.Response, nil
+}
+
+func (s *stubClient) ContainerDeviceDelete(container, devname string) (*lxd.Response, error) {
+	s.stub.AddCall("ContainerDeviceDelete", container, devname)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.Response, nil
+}
+
+func (s *stubClient) StoragePoolGet(name string) (StoragePool, error) {
+	s.stub.AddCall("StoragePoolGet", name)
+	if err := s.stub.NextErr(); err != nil {
+		return StoragePool{}, errors.Trace(err)
+	}
+
+	return s.Pool, nil
+}
+
+func (s *stubClient) StoragePoolCreate(name, driver string, config map[string]string) error {
+	s.stub.AddCall("StoragePoolCreate", name, driver, config)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubClient) StoragePoolVolumeTypeGet(pool, volume, volumeType string) (StorageVolume, error) {
+	s.stub.AddCall("StoragePoolVolumeTypeGet", pool, volume, volumeType)
+	if err := s.stub.NextErr(); err != nil {
+		return StorageVolume{}, errors.Trace(err)
+	}
+
+	return s.Volume, nil
+}
+
+func (s *stubClient) StoragePoolVolumeTypeCreate(pool, volume, volumeType string, config map[string]string) error {
+	s.stub.AddCall("StoragePoolVolumeTypeCreate", pool, volume, volumeType, config)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubClient) StoragePoolVolumeTypeDelete(pool, volume, volumeType string) error {
+	s.stub.AddCall("StoragePoolVolumeTypeDelete", pool, volume, volumeType)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
@@ -18,10 +18,9 @@ import (
 // (below).
 var stateUpgradeOperations = func() []Operation {
 	steps := []Operation{
-		// Replace when we have upgrades to do
 		upgradeToVersion{
-			version.MustParse("1.26-placeholder1"),
-			[]Step{},
+			version.MustParse("2.0.0"),
+			stateStepsFor20(),
 		},
 	}
 	return steps
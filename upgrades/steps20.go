@@ -0,0 +1,21 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import (
+	"github.com/juju/juju/state"
+)
+
+// stateStepsFor20 returns upgrade steps for Juju 2.0 that manipulate state directly.
+func stateStepsFor20() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "convert audit log to capped collection with dated entries",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return state.ConvertAuditLog(context.State())
+			},
+		},
+	}
+}
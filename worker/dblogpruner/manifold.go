@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dblogpruner
+
+import (
+	"github.com/juju/errors"
+
+	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a
+// Manifold will depend.
+type ManifoldConfig struct {
+	AgentName string
+	StateName string
+
+	// PruneParams holds the pruning interval and the limit on the
+	// size of the logs of all models together.
+	PruneParams *LogPruneParams
+}
+
+// Manifold returns a dependency manifold that runs a log pruning
+// worker on each controller machine. Logs are only pruned by the
+// worker on the machine hosting the MongoDB master.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.StateName,
+		},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			if config.PruneParams == nil {
+				return nil, errors.NotValidf("nil PruneParams")
+			}
+			var agent coreagent.Agent
+			if err := context.Get(config.AgentName, &agent); err != nil {
+				return nil, err
+			}
+			var stTracker workerstate.StateTracker
+			if err := context.Get(config.StateName, &stTracker); err != nil {
+				return nil, err
+			}
+			st, err := stTracker.Use()
+			if err != nil {
+				return nil, errors.Annotate(err, "acquiring state")
+			}
+			machine, err := st.Machine(agent.CurrentConfig().Tag().Id())
+			if err != nil {
+				stTracker.Done()
+				return nil, errors.Trace(err)
+			}
+			w := newWorker(st, config.PruneParams, func() (bool, error) {
+				return mongo.IsMaster(st.MongoSession(), machine)
+			})
+
+			// When the worker is done, indicate that we no longer
+			// need the State.
+			go func() {
+				w.Wait()
+				stTracker.Done()
+			}()
+			return w, nil
+		},
+	}
+}
@@ -4,50 +4,70 @@
 package dblogpruner
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"launchpad.net/tomb"
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
 )
 
-// LogPruneParams specifies how logs should be pruned.
+var logger = loggo.GetLogger("juju.worker.dblogpruner")
+
+// LogPruneParams specifies how logs should be pruned. The retention
+// limits of each model are read from the model's config; the logs of
+// all models together are kept within MaxCollectionMB.
 type LogPruneParams struct {
-	MaxLogAge       time.Duration
 	MaxCollectionMB int
 	PruneInterval   time.Duration
 }
 
-const DefaultMaxLogAge = 3 * 24 * time.Hour // 3 days
-const DefaultMaxCollectionMB = 4 * 1024     // 4 GB
+const DefaultMaxCollectionMB = 4 * 1024 // 4 GB
 const DefaultPruneInterval = 5 * time.Minute
 
 // NewLogPruneParams returns a LogPruneParams initialised with default
 // values.
 func NewLogPruneParams() *LogPruneParams {
 	return &LogPruneParams{
-		MaxLogAge:       DefaultMaxLogAge,
 		MaxCollectionMB: DefaultMaxCollectionMB,
 		PruneInterval:   DefaultPruneInterval,
 	}
 }
 
 // New returns a worker which periodically wakes up to remove old log
-// entries stored in MongoDB. This worker is intended to run just
-// once, on the MongoDB master.
+// entries stored in MongoDB. The logs of each model are pruned
+// according to that model's retention settings, which are read anew on
+// every run. This worker is intended to run just once, on the MongoDB
+// master; see Manifold.
 func New(st *state.State, params *LogPruneParams) worker.Worker {
+	return newWorker(st, params, nil)
+}
+
+// newWorker returns a log pruning worker which, if isMaster is not
+// nil, only prunes the logs while it reports true.
+func newWorker(st *state.State, params *LogPruneParams, isMaster func() (bool, error)) worker.Worker {
 	w := &pruneWorker{
-		st:     st,
-		params: params,
+		st:       st,
+		params:   params,
+		isMaster: isMaster,
+		removed:  make(map[string]int),
 	}
-	return worker.NewSimpleWorker(w.loop)
+	w.Worker = worker.NewSimpleWorker(w.loop)
+	return w
 }
 
 type pruneWorker struct {
-	st     *state.State
-	params *LogPruneParams
+	worker.Worker
+	st       *state.State
+	params   *LogPruneParams
+	isMaster func() (bool, error)
+
+	mu        sync.Mutex
+	lastPrune time.Time
+	removed   map[string]int
 }
 
 func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
@@ -57,12 +77,95 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 		case <-stopCh:
 			return tomb.ErrDying
 		case <-time.After(p.PruneInterval):
-			// TODO(fwereade): 2016-03-17 lp:1558657
-			minLogTime := time.Now().Add(-p.MaxLogAge)
-			err := state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB)
-			if err != nil {
+			if err := w.prune(); err != nil {
 				return errors.Trace(err)
 			}
 		}
 	}
 }
+
+// prune removes the old log entries of every model in the controller,
+// and then the oldest entries of the models with the most logs while
+// the logs collection is larger than allowed.
+func (w *pruneWorker) prune() error {
+	if w.isMaster != nil {
+		isMaster, err := w.isMaster()
+		if err != nil {
+			return errors.Annotate(err, "cannot check for MongoDB master")
+		}
+		if !isMaster {
+			// The worker on the master's machine prunes the logs.
+			return nil
+		}
+	}
+	models, err := w.st.AllModels()
+	if err != nil {
+		return errors.Annotate(err, "cannot list models")
+	}
+	for _, m := range models {
+		cfg, err := m.Config()
+		if errors.IsNotFound(err) {
+			// The model has been removed since it was listed.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "cannot read config of model %q", m.UUID())
+		}
+		maxAge, err := cfg.MaxLogsAge()
+		if err != nil {
+			logger.Errorf("not pruning logs of model %q: %v", m.UUID(), err)
+			continue
+		}
+		maxSizeMB, err := cfg.MaxLogSizeMB()
+		if err != nil {
+			logger.Errorf("not pruning logs of model %q: %v", m.UUID(), err)
+			continue
+		}
+		// TODO(fwereade): 2016-03-17 lp:1558657
+		minLogTime := time.Now().Add(-maxAge)
+		removed, err := state.PruneLogs(w.st, m.UUID(), minLogTime, int(maxSizeMB))
+		if err != nil {
+			return errors.Annotatef(err, "cannot prune logs of model %q", m.UUID())
+		}
+		if removed > 0 {
+			logger.Debugf("pruned %d log entries of model %q", removed, m.UUID())
+		}
+		w.record(m.UUID(), removed)
+	}
+	if w.params.MaxCollectionMB > 0 {
+		removed, err := state.PruneLogsToSize(w.st, w.params.MaxCollectionMB)
+		if err != nil {
+			return errors.Annotate(err, "cannot prune logs to size")
+		}
+		for modelUUID, count := range removed {
+			logger.Debugf("pruned %d log entries of model %q to bound the logs size", count, modelUUID)
+			w.record(modelUUID, count)
+		}
+	}
+	w.mu.Lock()
+	w.lastPrune = time.Now()
+	w.mu.Unlock()
+	return nil
+}
+
+func (w *pruneWorker) record(modelUUID string, removed int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.removed[modelUUID] += removed
+}
+
+// Report is part of the dependency.Reporter interface.
+func (w *pruneWorker) Report() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	removed := make(map[string]interface{}, len(w.removed))
+	for modelUUID, count := range w.removed {
+		removed[modelUUID] = count
+	}
+	report := map[string]interface{}{
+		"total-removed": removed,
+	}
+	if !w.lastPrune.IsZero() {
+		report["last-prune"] = w.lastPrune.Format(time.RFC3339)
+	}
+	return report
+}
@@ -4,6 +4,7 @@
 package dblogpruner_test
 
 import (
+	"fmt"
 	stdtesting "testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dblogpruner"
+	"github.com/juju/juju/worker/dependency"
 )
 
 func TestPackage(t *stdtesting.T) {
@@ -40,11 +42,17 @@ func (s *suite) SetUpTest(c *gc.C) {
 }
 
 func (s *suite) StartWorker(c *gc.C, maxLogAge time.Duration, maxCollectionMB int) {
-	params := &dblogpruner.LogPruneParams{
-		MaxLogAge:       maxLogAge,
-		MaxCollectionMB: maxCollectionMB,
-		PruneInterval:   time.Millisecond, // Speed up pruning interval for testing
-	}
+	s.StartWorkerWithParams(c, maxLogAge, maxCollectionMB, &dblogpruner.LogPruneParams{
+		PruneInterval: time.Millisecond, // Speed up pruning interval for testing
+	})
+}
+
+func (s *suite) StartWorkerWithParams(c *gc.C, maxLogAge time.Duration, maxCollectionMB int, params *dblogpruner.LogPruneParams) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"max-logs-age":  maxLogAge.String(),
+		"max-logs-size": fmt.Sprintf("%dM", maxCollectionMB),
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
 	s.pruner = dblogpruner.New(s.State, params)
 	s.AddCleanup(func(*gc.C) {
 		s.pruner.Kill()
@@ -107,8 +115,110 @@ func (s *suite) TestPrunesLogsBySize(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesPerModel(c *gc.C) {
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	err := otherState.UpdateModelConfig(map[string]interface{}{
+		"max-logs-age": "999h",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	old := time.Now().Add(-48 * time.Hour)
+	s.addLogs(c, old, "prune", 10)
+	s.addModelLogs(c, otherState, old, "keep", 10)
+	s.StartWorker(c, 24*time.Hour, int(1e9))
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining == 0 {
+			// The other model's logs are within its own limits.
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 10)
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
+func (s *suite) TestPrunesControllerLogsBySize(c *gc.C) {
+	startingLogCount := 25000
+	s.addLogs(c, time.Now(), "stuff", startingLogCount)
+
+	// The model's own limits would keep all of its logs.
+	s.StartWorkerWithParams(c, 999*time.Hour, int(1e9), &dblogpruner.LogPruneParams{
+		MaxCollectionMB: 2,
+		PruneInterval:   time.Millisecond,
+	})
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		count, err := s.logsColl.Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if count < startingLogCount {
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
+func (s *suite) TestPrunesOnlyOnMaster(c *gc.C) {
+	s.addLogs(c, time.Now().Add(-48*time.Hour), "prune", 5)
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"max-logs-age": "24h",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	checked := make(chan bool)
+	isMaster := func() (bool, error) {
+		master := <-checked
+		return master, nil
+	}
+	s.pruner = dblogpruner.NewWorker(s.State, &dblogpruner.LogPruneParams{
+		PruneInterval: time.Millisecond,
+	}, isMaster)
+	defer func() {
+		s.pruner.Kill()
+		close(checked)
+		c.Assert(s.pruner.Wait(), jc.ErrorIsNil)
+	}()
+
+	// Each check is only answered once the previous prune is done.
+	checked <- false
+	checked <- false
+	count, err := s.logsColl.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 5)
+
+	checked <- true
+	checked <- false
+	count, err = s.logsColl.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
+func (s *suite) TestReport(c *gc.C) {
+	s.addLogs(c, time.Now().Add(-48*time.Hour), "prune", 5)
+	s.StartWorker(c, 24*time.Hour, int(1e9))
+
+	reporter, ok := s.pruner.(dependency.Reporter)
+	c.Assert(ok, jc.IsTrue)
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		report := reporter.Report()
+		removed := report["total-removed"].(map[string]interface{})
+		if removed[s.State.ModelUUID()] == 5 && report["last-prune"] != nil {
+			return
+		}
+	}
+	c.Fatal("pruning wasn't reported as expected")
+}
+
 func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
-	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("0"), version.Current)
+	s.addModelLogs(c, s.State, t0, text, count)
+}
+
+func (s *suite) addModelLogs(c *gc.C, st *state.State, t0 time.Time, text string, count int) {
+	dbLogger := state.NewDbLogger(st, names.NewMachineTag("0"), version.Current)
 	defer dbLogger.Close()
 
 	for offset := 0; offset < count; offset++ {
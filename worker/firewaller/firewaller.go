@@ -4,6 +4,7 @@
 package firewaller
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/juju/errors"
@@ -33,13 +34,14 @@ type Firewaller struct {
 	modelWatcher    watcher.NotifyWatcher
 	machinesWatcher watcher.StringsWatcher
 	portsWatcher    watcher.StringsWatcher
+	subnetsWatcher  watcher.NotifyWatcher
 	machineds       map[names.MachineTag]*machineData
 	unitsChange     chan *unitsChange
 	unitds          map[names.UnitTag]*unitData
 	applicationids  map[names.ApplicationTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalRuleRef   map[ingressSource]int
 	machinePorts    map[names.MachineTag]machineRanges
 }
 
@@ -89,7 +91,7 @@ func (fw *Firewaller) setUp() error {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[ingressSource]int)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -114,6 +116,16 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	// Subnets record the spaces they belong to, so changes to them may
+	// change the ingress CIDRs of services exposed to spaces.
+	fw.subnetsWatcher, err = fw.st.WatchSubnets()
+	if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	}
+	if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	logger.Debugf("started watching opened port ranges for the environment")
 	return nil
 }
@@ -176,23 +188,65 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-fw.subnetsWatcher.Changes():
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			if err := fw.subnetsChanged(); err != nil {
+				return errors.Trace(err)
+			}
 		case change := <-fw.unitsChange:
 			if err := fw.unitsChanged(change); err != nil {
 				return errors.Trace(err)
 			}
 		case change := <-fw.exposedChange:
-			change.serviced.exposed = change.exposed
-			unitds := []*unitData{}
-			for _, unitd := range change.serviced.unitds {
-				unitds = append(unitds, unitd)
-			}
-			if err := fw.flushUnits(unitds); err != nil {
-				return errors.Annotate(err, "cannot change firewall ports")
+			if err := fw.exposedChanged(change); err != nil {
+				return errors.Trace(err)
 			}
 		}
 	}
 }
 
+// exposedChanged updates the firewall of the units of the changed
+// service, if its exposed flag or ingress CIDRs have changed.
+func (fw *Firewaller) exposedChanged(change *exposedChange) error {
+	serviced := change.serviced
+	if change.exposed == serviced.exposed && reflect.DeepEqual(change.ingressCIDRs, serviced.ingressCIDRs) {
+		return nil
+	}
+	serviced.exposed = change.exposed
+	serviced.ingressCIDRs = change.ingressCIDRs
+	unitds := []*unitData{}
+	for _, unitd := range serviced.unitds {
+		unitds = append(unitds, unitd)
+	}
+	if err := fw.flushUnits(unitds); err != nil {
+		return errors.Annotate(err, "cannot change firewall ports")
+	}
+	return nil
+}
+
+// subnetsChanged refreshes the ingress CIDRs of the exposed services,
+// which depend on the subnets of the spaces they are exposed to.
+func (fw *Firewaller) subnetsChanged() error {
+	for _, serviced := range fw.applicationids {
+		if !serviced.exposed {
+			continue
+		}
+		ingressCIDRs, err := serviced.application.EndpointIngressCIDRs()
+		if params.IsCodeNotFound(err) {
+			// The service's watchLoop will notice.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := fw.exposedChanged(&exposedChange{serviced, true, ingressCIDRs}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // startMachine creates a new data value for tracking details of the
 // machine and starts watching the machine for units added or removed.
 func (fw *Firewaller) startMachine(tag names.MachineTag) error {
@@ -200,8 +254,8 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
-		definedPorts: make(map[network.PortRange]names.UnitTag),
+		openedRules:  make([]network.IngressRule, 0),
+		definedPorts: make(map[network.PortRange]firewaller.PortOwner),
 	}
 	m, err := machined.machine()
 	if params.IsCodeNotFound(err) {
@@ -313,17 +367,20 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	ingressCIDRs, err := service.EndpointIngressCIDRs()
+	if err != nil {
+		return err
+	}
 	serviced := &serviceData{
-		fw:          fw,
-		application: service,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:           fw,
+		application:  service,
+		exposed:      exposed,
+		ingressCIDRs: ingressCIDRs,
+		unitds:       make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
-		Work: func() error {
-			return serviced.watchLoop(exposed)
-		},
+		Work: serviced.watchLoop,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -339,43 +396,44 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 // units and services with the opened and closed ports globally and
 // opens and closes the appropriate ports for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	initialRules, err := fw.environIngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	collector := make(map[ingressSource]bool)
 	for _, machined := range fw.machineds {
-		for portRange, unitTag := range machined.definedPorts {
-			unitd, known := machined.unitds[unitTag]
+		for portRange, owner := range machined.definedPorts {
+			unitd, known := machined.unitds[owner.UnitTag]
 			if !known {
-				delete(machined.unitds, unitTag)
+				delete(machined.unitds, owner.UnitTag)
 				continue
 			}
 			if unitd.serviced.exposed {
-				collector[portRange] = true
+				for _, cidr := range unitd.serviced.endpointCIDRs(owner.Endpoint) {
+					collector[ingressSource{portRange, cidr}] = true
+				}
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+	var sources []ingressSource
+	for source := range collector {
+		sources = append(sources, source)
 	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	wantedRules := sourcesToRules(sources)
+	// Check which rules to open or to close.
+	toOpen := diffRules(wantedRules, initialRules)
+	toClose := diffRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := fw.openEnvironIngressRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
 	}
 	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := fw.closeEnvironIngressRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
 	}
 	return nil
 }
@@ -411,31 +469,29 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instanceIngressRules(instances[0], machineId)
 		if err != nil {
 			return err
 		}
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		// Check which rules to open or to close.
+		toOpen := diffRules(machined.openedRules, initialRules)
+		toClose := diffRules(initialRules, machined.openedRules)
 		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
+			logger.Infof("opening instance ingress rules %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := openInstanceIngressRules(instances[0], machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
 		}
 		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
+			logger.Infof("closing instance ingress rules %v for %q",
 				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+			if err := closeInstanceIngressRules(instances[0], machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
 		}
 	}
 	return nil
@@ -504,17 +560,16 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	newPortRanges := make(map[network.PortRange]names.UnitTag)
-	for portRange, unitTag := range ports {
-		unitd, ok := machined.unitds[unitTag]
-		if !ok {
+	newPortRanges := make(map[network.PortRange]firewaller.PortOwner)
+	for portRange, owner := range ports {
+		if _, ok := machined.unitds[owner.UnitTag]; !ok {
 			// It is common to receive port change notification before
 			// registering a unit. Skip handling the port change - it will
 			// be handled when the unit is registered.
-			logger.Errorf("failed to lookup %q, skipping port change", unitTag)
+			logger.Errorf("failed to lookup %q, skipping port change", owner.UnitTag)
 			return nil
 		}
-		newPortRanges[portRange] = unitd.tag
+		newPortRanges[portRange] = owner
 	}
 
 	if !portMapsEqual(machined.definedPorts, newPortRanges) {
@@ -524,7 +579,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 	return nil
 }
 
-func portMapsEqual(a, b map[network.PortRange]names.UnitTag) bool {
+func portMapsEqual(a, b map[network.PortRange]firewaller.PortOwner) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -556,68 +611,72 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
-	for portRange, unitTag := range machined.definedPorts {
-		unitd, known := machined.unitds[unitTag]
+	// Gather ingress rules to open and close.
+	var sources []ingressSource
+	for portRange, owner := range machined.definedPorts {
+		unitd, known := machined.unitds[owner.UnitTag]
 		if !known {
-			delete(machined.unitds, unitTag)
+			delete(machined.unitds, owner.UnitTag)
 			continue
 		}
 		if unitd.serviced.exposed {
-			want = append(want, portRange)
+			for _, cidr := range unitd.serviced.endpointCIDRs(owner.Endpoint) {
+				sources = append(sources, ingressSource{portRange, cidr})
+			}
 		}
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	want := sourcesToRules(sources)
+	toOpen := diffRules(want, machined.openedRules)
+	toClose := diffRules(machined.openedRules, want)
+	machined.openedRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		return fw.flushGlobalRules(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.flushInstanceRules(machined, toOpen, toClose)
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+// flushGlobalRules opens and closes global ingress rules in the
+// environment. It keeps a reference count for each port range and
+// source CIDR so that only 0-to-1 and 1-to-0 events modify the
+// environment.
+func (fw *Firewaller) flushGlobalRules(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which sources are really to open or close.
+	var toOpen, toClose []ingressSource
+	for _, source := range rulesToSources(rawOpen) {
+		if fw.globalRuleRef[source] == 0 {
+			toOpen = append(toOpen, source)
 		}
-		fw.globalPortRef[portRange]++
+		fw.globalRuleRef[source]++
 	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+	for _, source := range rulesToSources(rawClose) {
+		fw.globalRuleRef[source]--
+		if fw.globalRuleRef[source] == 0 {
+			toClose = append(toClose, source)
+			delete(fw.globalRuleRef, source)
 		}
 	}
-	// Open and close the ports.
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		rules := sourcesToRules(toOpen)
+		if err := fw.openEnvironIngressRules(rules); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		logger.Infof("opened ingress rules %v in environment", rules)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		rules := sourcesToRules(toClose)
+		if err := fw.closeEnvironIngressRules(rules); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		logger.Infof("closed ingress rules %v in environment", rules)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushInstanceRules opens and closes ingress rules on the machine.
+func (fw *Firewaller) flushInstanceRules(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -641,22 +700,20 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		if err := openInstanceIngressRules(instances[0], machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if err := closeInstanceIngressRules(instances[0], machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
 	return nil
 }
@@ -755,9 +812,10 @@ type machineData struct {
 	fw          *Firewaller
 	tag         names.MachineTag
 	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
-	// ports defined by units on this machine
-	definedPorts map[network.PortRange]names.UnitTag
+	openedRules []network.IngressRule
+	// ports defined by units on this machine, and the endpoints
+	// they were opened for
+	definedPorts map[network.PortRange]firewaller.PortOwner
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -805,10 +863,12 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and ingress CIDRs
+// for one specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced     *serviceData
+	exposed      bool
+	ingressCIDRs map[string][]string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -818,10 +878,28 @@ type serviceData struct {
 	application *firewaller.Application
 	exposed     bool
 	unitds      map[names.UnitTag]*unitData
+
+	// ingressCIDRs holds the source CIDRs from which the ports opened
+	// by the service's units may be accessed while it is exposed, keyed
+	// by the endpoint the ports were opened for. An empty list allows
+	// access from nowhere.
+	ingressCIDRs map[string][]string
+}
+
+// endpointCIDRs returns the source CIDRs from which the ports opened for
+// the named endpoint may be accessed. Endpoints without their own
+// sources use those for all endpoints, if any.
+func (sd *serviceData) endpointCIDRs(endpoint string) []string {
+	if cidrs, ok := sd.ingressCIDRs[endpoint]; ok {
+		return cidrs
+	}
+	return sd.ingressCIDRs[""]
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag and ingress CIDRs for
+// changes. The main loop compares them against the current ones, as
+// the ingress CIDRs may also change with the subnets of spaces.
+func (sd *serviceData) watchLoop() error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -847,13 +925,12 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
-				continue
+			cidrsChange, err := sd.application.EndpointIngressCIDRs()
+			if err != nil {
+				return errors.Trace(err)
 			}
-
-			exposed = change
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, cidrsChange}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -871,20 +948,6 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
-next:
-	for _, a := range A {
-		for _, b := range B {
-			if a == b {
-				continue next
-			}
-		}
-		missing = append(missing, a)
-	}
-	return
-}
-
 // parsePortsKey parses a ports document global key coming from the ports
 // watcher (e.g. "42:0.1.2.0/24") and returns the machine and subnet tags from
 // its components (in the last example "machine-42" and "subnet-0.1.2.0/24").
@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewaller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// ingressSource is a single port range and source CIDR pair. Ingress
+// rules are broken down into sources so that rules opened for
+// different services can be reference counted and compared, even when
+// their source CIDRs overlap.
+type ingressSource struct {
+	portRange network.PortRange
+	cidr      string
+}
+
+// rulesToSources breaks the given ingress rules down into sources.
+func rulesToSources(rules []network.IngressRule) []ingressSource {
+	var sources []ingressSource
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			sources = append(sources, ingressSource{rule.PortRange, cidr})
+		}
+	}
+	return sources
+}
+
+// sourcesToRules combines the given sources into sorted ingress rules,
+// one per port range.
+func sourcesToRules(sources []ingressSource) []network.IngressRule {
+	cidrs := make(map[network.PortRange][]string)
+	for _, source := range sources {
+		cidrs[source.portRange] = append(cidrs[source.portRange], source.cidr)
+	}
+	rules := make([]network.IngressRule, 0, len(cidrs))
+	for portRange, sourceCIDRs := range cidrs {
+		rules = append(rules, network.IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: set.NewStrings(sourceCIDRs...).SortedValues(),
+		})
+	}
+	network.SortIngressRules(rules)
+	return rules
+}
+
+// diffRules returns the ingress rules made of all the sources that
+// exist in A but not B.
+func diffRules(A, B []network.IngressRule) []network.IngressRule {
+	existing := make(map[ingressSource]bool)
+	for _, source := range rulesToSources(B) {
+		existing[source] = true
+	}
+	var missing []ingressSource
+	for _, source := range rulesToSources(A) {
+		if !existing[source] {
+			missing = append(missing, source)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return sourcesToRules(missing)
+}
+
+// openToWorldPortRanges returns the port ranges of the given rules, for
+// firewalls which cannot restrict the sources of ingress. Rules which
+// do not allow access from anywhere are left out, as opening them to
+// the world would defeat their purpose.
+func openToWorldPortRanges(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	for _, rule := range rules {
+		if !rule.IsOpenToWorld() {
+			logger.Errorf("cannot restrict ingress to %v: not supported by the provider", rule)
+			continue
+		}
+		ports = append(ports, rule.PortRange)
+	}
+	network.SortPortRanges(ports)
+	return ports
+}
+
+// environIngressRules returns the ingress rules opened for the whole
+// environment.
+func (fw *Firewaller) environIngressRules() ([]network.IngressRule, error) {
+	if ingress, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		rules, err := ingress.IngressRules()
+		return rules, errors.Trace(err)
+	}
+	ports, err := fw.environ.Ports()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.PortRangesToIngressRules(ports), nil
+}
+
+// openEnvironIngressRules opens the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) openEnvironIngressRules(rules []network.IngressRule) error {
+	if ingress, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		return ingress.OpenIngressRules(rules)
+	}
+	ports := openToWorldPortRanges(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return fw.environ.OpenPorts(ports)
+}
+
+// closeEnvironIngressRules closes the given ingress rules for the
+// whole environment.
+func (fw *Firewaller) closeEnvironIngressRules(rules []network.IngressRule) error {
+	if ingress, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		return ingress.CloseIngressRules(rules)
+	}
+	ports := openToWorldPortRanges(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return fw.environ.ClosePorts(ports)
+}
+
+// instanceIngressRules returns the ingress rules opened on the given
+// instance.
+func instanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if ingress, ok := inst.(instance.IngressRuleInstance); ok {
+		rules, err := ingress.IngressRules(machineId)
+		return rules, errors.Trace(err)
+	}
+	ports, err := inst.Ports(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.PortRangesToIngressRules(ports), nil
+}
+
+// openInstanceIngressRules opens the given ingress rules on the given
+// instance.
+func openInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if ingress, ok := inst.(instance.IngressRuleInstance); ok {
+		return ingress.OpenIngressRules(machineId, rules)
+	}
+	ports := openToWorldPortRanges(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return inst.OpenPorts(machineId, ports)
+}
+
+// closeInstanceIngressRules closes the given ingress rules on the
+// given instance.
+func closeInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if ingress, ok := inst.(instance.IngressRuleInstance); ok {
+		return ingress.CloseIngressRules(machineId, rules)
+	}
+	ports := openToWorldPortRanges(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return inst.ClosePorts(machineId, ports)
+}
@@ -39,6 +39,18 @@ type sender interface {
 	Send(logfwd.Record) error
 }
 
+// BatchSender is implemented by senders which can send several log
+// records to their log sink at once.
+type BatchSender interface {
+	// SendBatch sends the records, oldest first, to the log sink.
+	SendBatch([]logfwd.Record) error
+}
+
+// maxBatchSize is the most log records sent to the sink at once.
+// Records are read from the stream while the previous batch is being
+// sent, so batches grow with the rate at which records are logged.
+const maxBatchSize = 100
+
 // TODO(ericsnow) It is likely that eventually we will want to support
 // multiplexing to multiple senders, each in its own goroutine (or worker).
 
@@ -93,7 +105,9 @@ func openLogForwarder(args OpenLogForwarderArgs) (*LogForwarder, error) {
 		return nil, errors.Trace(err)
 	}
 
-	lf, err := NewLogForwarder(stream, sink)
+	// The tracking sender is passed directly, since the LogSink
+	// wrapping it does not expose its SendBatch method.
+	lf, err := NewLogForwarder(stream, sink.SendCloser)
 	return lf, errors.Trace(err)
 }
 
@@ -127,7 +141,7 @@ func (lf *LogForwarder) loop() error {
 		return nil
 	}
 
-	records := make(chan logfwd.Record)
+	records := make(chan logfwd.Record, maxBatchSize)
 	go func() {
 		for {
 			rec, err := lf.stream.Next()
@@ -150,13 +164,37 @@ func (lf *LogForwarder) loop() error {
 		case <-lf.catacomb.Dying():
 			return lf.catacomb.ErrDying()
 		case rec := <-records:
-			if err := lf.sender.Send(rec); err != nil {
+			batch := []logfwd.Record{rec}
+		collect:
+			for len(batch) < maxBatchSize {
+				select {
+				case rec := <-records:
+					batch = append(batch, rec)
+				default:
+					break collect
+				}
+			}
+			if err := lf.send(batch); err != nil {
 				return errors.Trace(err)
 			}
 		}
 	}
 }
 
+// send sends the records to the sender, in a single batch if the
+// sender supports it.
+func (lf *LogForwarder) send(batch []logfwd.Record) error {
+	if sender, ok := lf.sender.(BatchSender); ok {
+		return errors.Trace(sender.SendBatch(batch))
+	}
+	for _, rec := range batch {
+		if err := lf.sender.Send(rec); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // Kill implements Worker.Kill()
 func (lf *LogForwarder) Kill() {
 	lf.catacomb.Kill(nil)
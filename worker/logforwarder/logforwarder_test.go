@@ -138,6 +138,91 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 	s.checkClose(c, lf, failure)
 }
 
+func (s *LogForwarderSuite) TestBatches(c *gc.C) {
+	stream := &chanStream{
+		recs:  make(chan logfwd.Record, 10),
+		nexts: make(chan struct{}),
+	}
+	sender := &stubBatchSender{
+		batches: make(chan []logfwd.Record),
+		release: make(chan struct{}),
+	}
+	lf, err := logforwarder.NewLogForwarder(stream, sender)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, lf)
+
+	recs := make([]logfwd.Record, 5)
+	for i := range recs {
+		recs[i] = s.rec
+		recs[i].ID = int64(i)
+	}
+	stream.waitNext(c)
+	stream.recs <- recs[0]
+	c.Check(sender.waitBatch(c), jc.DeepEquals, recs[:1])
+
+	// The records logged while the first batch is being sent are
+	// sent together in the next batch.
+	for _, rec := range recs[1:] {
+		stream.recs <- rec
+		stream.waitNext(c)
+	}
+	stream.waitNext(c)
+	sender.release <- struct{}{}
+	c.Check(sender.waitBatch(c), jc.DeepEquals, recs[1:])
+	sender.release <- struct{}{}
+}
+
+// chanStream is a LogStream which returns the records sent on its
+// channel, and notifies each call to Next.
+type chanStream struct {
+	recs  chan logfwd.Record
+	nexts chan struct{}
+}
+
+func (s *chanStream) waitNext(c *gc.C) {
+	select {
+	case <-s.nexts:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for Next call")
+	}
+}
+
+func (s *chanStream) Next() (logfwd.Record, error) {
+	s.nexts <- struct{}{}
+	return <-s.recs, nil
+}
+
+// stubBatchSender is a BatchSender which passes each batch on its
+// channel, and returns once released.
+type stubBatchSender struct {
+	batches chan []logfwd.Record
+	release chan struct{}
+}
+
+func (s *stubBatchSender) waitBatch(c *gc.C) []logfwd.Record {
+	select {
+	case batch := <-s.batches:
+		return batch
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for SendBatch call")
+	}
+	return nil
+}
+
+func (s *stubBatchSender) Send(logfwd.Record) error {
+	return errors.New("unexpected Send call")
+}
+
+func (s *stubBatchSender) SendBatch(recs []logfwd.Record) error {
+	s.batches <- recs
+	<-s.release
+	return nil
+}
+
+func (s *stubBatchSender) Close() error {
+	return nil
+}
+
 type stubStream struct {
 	stub *testing.Stub
 
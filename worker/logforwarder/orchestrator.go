@@ -9,10 +9,15 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
 )
 
+// orchestrator runs a log forwarder for each enabled log sink. Each
+// forwarder tracks the records sent to its own sink, so they resume
+// independently of one another.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -42,21 +47,55 @@ func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error)
 	}
 	controllerUUID := args.Config.UUID() // This won't work for per-model forwarding.
 
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
-	if len(args.SinkOpeners) == 0 {
-		return nil, nil
+	var forwarders []worker.Worker
+	for _, openSink := range args.SinkOpeners {
+		lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
+			AllModels:      true,
+			ControllerUUID: controllerUUID,
+			Config:         args.Config,
+			Caller:         args.Caller,
+			OpenSink:       openSink,
+			OpenLogStream:  args.OpenLogStream,
+		})
+		if errors.Cause(err) == ErrSinkNotEnabled {
+			continue
+		}
+		if err != nil {
+			for _, lf := range forwarders {
+				worker.Stop(lf)
+			}
+			return nil, errors.Annotate(err, "opening log forwarder")
+		}
+		forwarders = append(forwarders, lf)
 	}
-	if len(args.SinkOpeners) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
+	if len(forwarders) == 0 {
+		logger.Debugf("log forwarding not enabled")
 	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		AllModels:      true,
-		ControllerUUID: controllerUUID,
-		Config:         args.Config,
-		Caller:         args.Caller,
-		OpenSink:       args.SinkOpeners[0],
-		OpenLogStream:  args.OpenLogStream,
+
+	o := &orchestrator{}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: forwarders,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+func (o *orchestrator) loop() error {
+	// TODO(ericsnow) restart upon config changed
+	<-o.catacomb.Dying()
+	return o.catacomb.ErrDying()
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
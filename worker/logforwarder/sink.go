@@ -4,22 +4,43 @@
 package logforwarder
 
 import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
+// ErrSinkNotEnabled is returned by a LogSinkFn when the logging config
+// does not enable forwarding to its log sink.
+var ErrSinkNotEnabled = errors.New("log sink not enabled")
+
 // LoggingConfig is the logging config for a model (or controller).
 type LoggingConfig interface {
 	// LogFwdSyslog returns the syslog forwarding config.
 	LogFwdSyslog() (*syslog.RawConfig, bool)
+
+	// LogFwdHTTP returns the HTTP forwarding config.
+	LogFwdHTTP() (*httpjson.RawConfig, bool)
+
+	// LogFwdGELF returns the GELF (Graylog) forwarding config.
+	LogFwdGELF() (*gelf.RawConfig, bool)
+
+	// LogFwdFile returns the local file forwarding config.
+	LogFwdFile() (*logfile.RawConfig, bool)
 }
 
-// LogSinkFn is a function that opens a log sink.
+// LogSinkFn is a function that opens a log sink. It returns
+// ErrSinkNotEnabled if the config does not enable the sink.
 type LogSinkFn func(LoggingConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
 	SendCloser
 
-	// Name is the name of the sink.
+	// Name is the name of the sink. Forwarding to the sink resumes
+	// from the last record sent to the sink with the same name, so
+	// it must be stable across restarts.
 	Name string
 }
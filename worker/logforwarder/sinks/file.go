@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// NewFileOpener returns a function which opens a log sink writing
+// records to the local file in the logging config. The file is kept
+// within the forwarded log directory under the given agent log
+// directory.
+func NewFileOpener(logDir string) logforwarder.LogSinkFn {
+	dir := logfile.Dir(logDir)
+	return func(cfg logforwarder.LoggingConfig) (*logforwarder.LogSink, error) {
+		return OpenFileSink(cfg, dir, logfile.Open)
+	}
+}
+
+// OpenFileSink opens a file log sink in the given directory, using the
+// given function to open the client. The sink is named after the
+// file's path.
+func OpenFileSink(cfg logforwarder.LoggingConfig, dir string, open func(string, logfile.RawConfig) (*logfile.Client, error)) (*logforwarder.LogSink, error) {
+	fileCfg, ok := cfg.LogFwdFile()
+	if !ok {
+		return nil, logforwarder.ErrSinkNotEnabled
+	}
+	client, err := open(dir, *fileCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sink := &logforwarder.LogSink{
+		SendCloser: client,
+		Name:       "file://" + filepath.Join(dir, fileCfg.Path),
+	}
+	return sink, nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenGELF opens a log sink which sends records to the Graylog server
+// in the logging config.
+func OpenGELF(cfg logforwarder.LoggingConfig) (*logforwarder.LogSink, error) {
+	return OpenGELFSink(cfg, gelf.Open)
+}
+
+// OpenGELFSink opens a GELF log sink, using the given function to open
+// the client. The sink is named after the protocol and address of the
+// server.
+func OpenGELFSink(cfg logforwarder.LoggingConfig, open func(gelf.RawConfig) (*gelf.Client, error)) (*logforwarder.LogSink, error) {
+	gelfCfg, ok := cfg.LogFwdGELF()
+	if !ok {
+		return nil, logforwarder.ErrSinkNotEnabled
+	}
+	client, err := open(*gelfCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sink := &logforwarder.LogSink{
+		SendCloser: client,
+		Name:       "gelf+" + client.Protocol + "://" + gelfCfg.Address(),
+	}
+	return sink, nil
+}
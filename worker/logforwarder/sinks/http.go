@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP opens a log sink which POSTs records as JSON to the HTTP
+// endpoint in the logging config.
+func OpenHTTP(cfg logforwarder.LoggingConfig) (*logforwarder.LogSink, error) {
+	return OpenHTTPSink(cfg, httpjson.Open)
+}
+
+// OpenHTTPSink opens an HTTP log sink, using the given function to
+// open the client. The sink is named after the endpoint's URL.
+func OpenHTTPSink(cfg logforwarder.LoggingConfig, open func(httpjson.RawConfig) (*httpjson.Client, error)) (*logforwarder.LogSink, error) {
+	httpCfg, ok := cfg.LogFwdHTTP()
+	if !ok {
+		return nil, logforwarder.ErrSinkNotEnabled
+	}
+	client, err := open(*httpCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sink := &logforwarder.LogSink{
+		SendCloser: client,
+		Name:       httpCfg.URL,
+	}
+	return sink, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	cfg  *fakeLoggingConfig
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+func (s *SinksSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.cfg = &fakeLoggingConfig{}
+}
+
+func (s *SinksSuite) TestOpenSyslogNotEnabled(c *gc.C) {
+	_, err := sinks.OpenSyslog(s.cfg)
+
+	c.Check(err, gc.Equals, logforwarder.ErrSinkNotEnabled)
+}
+
+func (s *SinksSuite) TestOpenHTTPSink(c *gc.C) {
+	s.cfg.http = &httpjson.RawConfig{URL: "https://logs.example.com/juju"}
+	client := &httpjson.Client{}
+
+	sink, err := sinks.OpenHTTPSink(s.cfg, func(cfg httpjson.RawConfig) (*httpjson.Client, error) {
+		s.stub.AddCall("Open", cfg)
+		return client, s.stub.NextErr()
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCall(c, 0, "Open", *s.cfg.http)
+	c.Check(sink.Name, gc.Equals, "https://logs.example.com/juju")
+	c.Check(sink.SendCloser, gc.Equals, client)
+}
+
+func (s *SinksSuite) TestOpenHTTPSinkNotEnabled(c *gc.C) {
+	_, err := sinks.OpenHTTPSink(s.cfg, func(cfg httpjson.RawConfig) (*httpjson.Client, error) {
+		s.stub.AddCall("Open", cfg)
+		return nil, s.stub.NextErr()
+	})
+
+	c.Check(err, gc.Equals, logforwarder.ErrSinkNotEnabled)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *SinksSuite) TestOpenHTTPSinkError(c *gc.C) {
+	s.cfg.http = &httpjson.RawConfig{URL: "https://logs.example.com/juju"}
+	s.stub.SetErrors(errors.New("boom"))
+
+	_, err := sinks.OpenHTTPSink(s.cfg, func(cfg httpjson.RawConfig) (*httpjson.Client, error) {
+		s.stub.AddCall("Open", cfg)
+		return nil, s.stub.NextErr()
+	})
+
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SinksSuite) TestOpenGELFSink(c *gc.C) {
+	s.cfg.gelf = &gelf.RawConfig{Host: "graylog.example.com", Protocol: "tcp"}
+	client := &gelf.Client{Protocol: "tcp"}
+
+	sink, err := sinks.OpenGELFSink(s.cfg, func(cfg gelf.RawConfig) (*gelf.Client, error) {
+		s.stub.AddCall("Open", cfg)
+		return client, s.stub.NextErr()
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCall(c, 0, "Open", *s.cfg.gelf)
+	c.Check(sink.Name, gc.Equals, "gelf+tcp://graylog.example.com:12201")
+	c.Check(sink.SendCloser, gc.Equals, client)
+}
+
+func (s *SinksSuite) TestOpenGELFSinkNotEnabled(c *gc.C) {
+	_, err := sinks.OpenGELFSink(s.cfg, func(cfg gelf.RawConfig) (*gelf.Client, error) {
+		s.stub.AddCall("Open", cfg)
+		return nil, s.stub.NextErr()
+	})
+
+	c.Check(err, gc.Equals, logforwarder.ErrSinkNotEnabled)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *SinksSuite) TestOpenFileSink(c *gc.C) {
+	s.cfg.file = &logfile.RawConfig{Path: "forwarded.log"}
+	client := &logfile.Client{}
+
+	sink, err := sinks.OpenFileSink(s.cfg, "/var/log/juju/forwarded", func(dir string, cfg logfile.RawConfig) (*logfile.Client, error) {
+		s.stub.AddCall("Open", dir, cfg)
+		return client, s.stub.NextErr()
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCall(c, 0, "Open", "/var/log/juju/forwarded", *s.cfg.file)
+	c.Check(sink.Name, gc.Equals, "file:///var/log/juju/forwarded/forwarded.log")
+	c.Check(sink.SendCloser, gc.Equals, client)
+}
+
+func (s *SinksSuite) TestNewFileOpenerConfinesToLogDir(c *gc.C) {
+	logDir := c.MkDir()
+	s.cfg.file = &logfile.RawConfig{Path: "forwarded.log"}
+
+	sink, err := sinks.NewFileOpener(logDir)(s.cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	path := filepath.Join(logDir, "forwarded", "forwarded.log")
+	c.Check(sink.Name, gc.Equals, "file://"+path)
+	_, err = os.Stat(path)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *SinksSuite) TestOpenFileSinkNotEnabled(c *gc.C) {
+	_, err := sinks.OpenFileSink(s.cfg, "/var/log/juju/forwarded", func(dir string, cfg logfile.RawConfig) (*logfile.Client, error) {
+		s.stub.AddCall("Open", dir, cfg)
+		return nil, s.stub.NextErr()
+	})
+
+	c.Check(err, gc.Equals, logforwarder.ErrSinkNotEnabled)
+	s.stub.CheckNoCalls(c)
+}
+
+type fakeLoggingConfig struct {
+	syslog *syslog.RawConfig
+	http   *httpjson.RawConfig
+	gelf   *gelf.RawConfig
+	file   *logfile.RawConfig
+}
+
+func (cfg *fakeLoggingConfig) LogFwdSyslog() (*syslog.RawConfig, bool) {
+	return cfg.syslog, cfg.syslog != nil
+}
+
+func (cfg *fakeLoggingConfig) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	return cfg.http, cfg.http != nil
+}
+
+func (cfg *fakeLoggingConfig) LogFwdGELF() (*gelf.RawConfig, bool) {
+	return cfg.gelf, cfg.gelf != nil
+}
+
+func (cfg *fakeLoggingConfig) LogFwdFile() (*logfile.RawConfig, bool) {
+	return cfg.file, cfg.file != nil
+}
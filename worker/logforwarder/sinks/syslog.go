@@ -6,23 +6,19 @@ package sinks
 import (
 	"github.com/juju/errors"
 
-	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/worker/logforwarder"
 )
 
+// OpenSyslog opens a log sink which forwards records to the syslog
+// host in the logging config.
 func OpenSyslog(cfg logforwarder.LoggingConfig) (*logforwarder.LogSink, error) {
 	client, name, err := OpenSyslogSender(cfg, syslog.Open)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	if client == nil {
-		// TODO(axw) we should be returning an error
-		// which we interpret up the stack.
-		return &logforwarder.LogSink{
-			SendCloser: emptySendCloser{},
-			Name:       name,
-		}, nil
+		return nil, logforwarder.ErrSinkNotEnabled
 	}
 	sink := &logforwarder.LogSink{
 		SendCloser: client,
@@ -31,16 +27,6 @@ func OpenSyslog(cfg logforwarder.LoggingConfig) (*logforwarder.LogSink, error) {
 	return sink, nil
 }
 
-type emptySendCloser struct{}
-
-func (emptySendCloser) Send(logfwd.Record) error {
-	return nil
-}
-
-func (emptySendCloser) Close() error {
-	return nil
-}
-
 func OpenSyslogSender(cfg logforwarder.LoggingConfig, open func(syslog.RawConfig) (*syslog.Client, error)) (*syslog.Client, string, error) {
 	syslogCfg, ok := cfg.LogFwdSyslog()
 	if !ok {
@@ -48,6 +34,6 @@ func OpenSyslogSender(cfg logforwarder.LoggingConfig, open func(syslog.RawConfig
 	}
 	sink := syslogCfg.Host
 
-	client, err := syslog.Open(*syslogCfg)
+	client, err := open(*syslogCfg)
 	return client, sink, errors.Trace(err)
 }
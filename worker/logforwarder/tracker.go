@@ -38,7 +38,7 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 
 	return &LogSink{
 		&trackingSender{
-			SendCloser: sink,
+			SendCloser: sink.SendCloser,
 			tracker:    newLastSentTracker(sink.Name, args.Caller),
 		},
 		sink.Name,
@@ -53,14 +53,38 @@ type trackingSender struct {
 
 // Send implements Sender.
 func (s *trackingSender) Send(rec logfwd.Record) error {
-	if err := s.SendCloser.Send(rec); err != nil {
-		return errors.Trace(err)
+	return errors.Trace(s.SendBatch([]logfwd.Record{rec}))
+}
+
+// SendBatch implements BatchSender. The records are sent together if
+// the underlying sink supports it, and the last record sent is then
+// recorded once for the whole batch.
+func (s *trackingSender) SendBatch(recs []logfwd.Record) error {
+	if sender, ok := s.SendCloser.(BatchSender); ok {
+		if err := sender.SendBatch(recs); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		for _, rec := range recs {
+			if err := s.SendCloser.Send(rec); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	}
-	model := rec.Origin.ModelUUID
-	if s.allModels {
-		model = ""
+
+	lastSent := make(map[string]int64)
+	var models []string
+	for _, rec := range recs {
+		model := rec.Origin.ModelUUID
+		if s.allModels {
+			model = ""
+		}
+		if _, ok := lastSent[model]; !ok {
+			models = append(models, model)
+		}
+		lastSent[model] = rec.ID
 	}
-	if err := s.tracker.setOne(model, rec.ID); err != nil {
+	if err := s.tracker.set(models, lastSent); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
@@ -81,29 +105,35 @@ func newLastSentTracker(sink string, caller base.APICaller) *lastSentTracker {
 	}
 }
 
-// TODO(ericsnow) We need to leverage the bulk API here.
-
-func (lst lastSentTracker) setOne(model string, recID int64) error {
-	var modelTag names.ModelTag
-	if model != "" {
-		if !names.IsValidModel(model) {
-			return errors.Errorf("bad model UUID %q", model)
+// set records the last record sent for each of the models, in a
+// single call to the controller.
+func (lst lastSentTracker) set(models []string, recIDs map[string]int64) error {
+	reqs := make([]logfwdapi.LastSentInfo, len(models))
+	for i, model := range models {
+		var modelTag names.ModelTag
+		if model != "" {
+			if !names.IsValidModel(model) {
+				return errors.Errorf("bad model UUID %q", model)
+			}
+			modelTag = names.NewModelTag(model)
+		}
+		reqs[i] = logfwdapi.LastSentInfo{
+			LastSentID: logfwdapi.LastSentID{
+				Model: modelTag,
+				Sink:  lst.sink,
+			},
+			RecordID: recIDs[model],
 		}
-		modelTag = names.NewModelTag(model)
 	}
 
-	results, err := lst.client.SetList([]logfwdapi.LastSentInfo{{
-		LastSentID: logfwdapi.LastSentID{
-			Model: modelTag,
-			Sink:  lst.sink,
-		},
-		RecordID: recID,
-	}})
+	results, err := lst.client.SetList(reqs)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := results[0].Error; err != nil {
-		return errors.Trace(err)
+	for _, result := range results {
+		if err := result.Error; err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
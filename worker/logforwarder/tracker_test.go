@@ -0,0 +1,92 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+type TrackingSinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TrackingSinkSuite{})
+
+type recordingBatchSender struct {
+	batches [][]logfwd.Record
+}
+
+func (s *recordingBatchSender) Send(rec logfwd.Record) error {
+	return s.SendBatch([]logfwd.Record{rec})
+}
+
+func (s *recordingBatchSender) SendBatch(recs []logfwd.Record) error {
+	s.batches = append(s.batches, recs)
+	return nil
+}
+
+func (s *recordingBatchSender) Close() error {
+	return nil
+}
+
+func (s *TrackingSinkSuite) TestSendBatchSetsLastSentOnce(c *gc.C) {
+	const (
+		model1 = "deadbeef-2f18-4fd2-967d-db9663db7bea"
+		model2 = "feedface-2f18-4fd2-967d-db9663db7bea"
+	)
+	sender := &recordingBatchSender{}
+	var calls []params.LogForwardingSetLastSentParams
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LogForwarding")
+		c.Check(request, gc.Equals, "SetLastSent")
+		args := arg.(params.LogForwardingSetLastSentParams)
+		calls = append(calls, args)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: make([]params.ErrorResult, len(args.Params)),
+		}
+		return nil
+	})
+	sink, err := logforwarder.OpenTrackingSink(logforwarder.TrackingSinkArgs{
+		Caller: caller,
+		OpenSink: func(logforwarder.LoggingConfig) (*logforwarder.LogSink, error) {
+			return &logforwarder.LogSink{SendCloser: sender, Name: "sink"}, nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var recs []logfwd.Record
+	for i, model := range []string{model1, model2, model1} {
+		recs = append(recs, logfwd.Record{
+			ID:     int64(i + 1),
+			Origin: logfwd.Origin{ModelUUID: model},
+		})
+	}
+	batchSender, ok := sink.SendCloser.(logforwarder.BatchSender)
+	c.Assert(ok, jc.IsTrue)
+	err = batchSender.SendBatch(recs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(sender.batches, jc.DeepEquals, [][]logfwd.Record{recs})
+	c.Assert(calls, gc.HasLen, 1)
+	c.Check(calls[0].Params, jc.DeepEquals, []params.LogForwardingSetLastSentParam{{
+		LogForwardingID: params.LogForwardingID{
+			ModelTag: "model-" + model1,
+			Sink:     "sink",
+		},
+		RecordID: 3,
+	}, {
+		LogForwardingID: params.LogForwardingID{
+			ModelTag: "model-" + model2,
+			Sink:     "sink",
+		},
+		RecordID: 2,
+	}})
+}
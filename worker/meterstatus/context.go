@@ -69,6 +69,11 @@ func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// TimeOutAction implements runner.Context.
+func (ctx *limitedContext) TimeOutAction() error {
+	return jujuc.ErrRestrictedContext
+}
+
 // Flush implementes runner.Context.
 func (ctx *limitedContext) Flush(_ string, err error) error {
 	return err
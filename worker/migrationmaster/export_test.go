@@ -5,3 +5,4 @@ package migrationmaster
 
 var ApiOpen = &apiOpen
 var TempSuccessSleep = &tempSuccessSleep
+var LogTransferBatchSize = &logTransferBatchSize
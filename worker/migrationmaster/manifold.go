@@ -4,18 +4,31 @@
 package migrationmaster
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/fortress"
 )
 
+// DefaultMinionReportTimeout is the usual time to wait for the agents
+// in the model to report back for each migration phase.
+const DefaultMinionReportTimeout = 15 * time.Minute
+
 // ManifoldConfig defines the names of the manifolds on which a
 // Worker manifold will depend.
 type ManifoldConfig struct {
 	APICallerName string
 	FortressName  string
+	ClockName     string
+
+	// MinionReportTimeout is how long the worker will wait for the
+	// agents in the model to report back for each migration phase.
+	MinionReportTimeout time.Duration
 
 	NewFacade func(base.APICaller) (Facade, error)
 	NewWorker func(Config) (worker.Worker, error)
@@ -29,6 +42,9 @@ func (config ManifoldConfig) validate() error {
 	if config.FortressName == "" {
 		return errors.NotValidf("empty FortressName")
 	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
 	if config.NewFacade == nil {
 		return errors.NotValidf("nil NewFacade")
 	}
@@ -51,13 +67,19 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.FortressName, &guard); err != nil {
 		return nil, errors.Trace(err)
 	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
 	facade, err := config.NewFacade(apiCaller)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	worker, err := config.NewWorker(Config{
-		Facade: facade,
-		Guard:  guard,
+		Facade:              facade,
+		Guard:               guard,
+		Clock:               clock,
+		MinionReportTimeout: config.MinionReportTimeout,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -68,7 +90,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 // Manifold packages a Worker for use in a dependency.Engine.
 func Manifold(config ManifoldConfig) dependency.Manifold {
 	return dependency.Manifold{
-		Inputs: []string{config.APICallerName, config.FortressName},
+		Inputs: []string{config.APICallerName, config.FortressName, config.ClockName},
 		Start:  config.start,
 	}
 }
@@ -4,11 +4,14 @@
 package migrationmaster_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	gc "gopkg.in/check.v1"
 )
 
@@ -29,6 +32,18 @@ func (*ValidateSuite) TestMissingGuard(c *gc.C) {
 	checkNotValid(c, config, "nil Guard not valid")
 }
 
+func (*ValidateSuite) TestMissingClock(c *gc.C) {
+	config := validConfig()
+	config.Clock = nil
+	checkNotValid(c, config, "nil Clock not valid")
+}
+
+func (*ValidateSuite) TestZeroMinionReportTimeout(c *gc.C) {
+	config := validConfig()
+	config.MinionReportTimeout = 0
+	checkNotValid(c, config, "non-positive MinionReportTimeout not valid")
+}
+
 func (*ValidateSuite) TestMissingFacade(c *gc.C) {
 	config := validConfig()
 	config.Facade = nil
@@ -37,8 +52,10 @@ func (*ValidateSuite) TestMissingFacade(c *gc.C) {
 
 func validConfig() migrationmaster.Config {
 	return migrationmaster.Config{
-		Guard:  struct{ fortress.Guard }{},
-		Facade: struct{ migrationmaster.Facade }{},
+		Guard:               struct{ fortress.Guard }{},
+		Facade:              struct{ migrationmaster.Facade }{},
+		Clock:               struct{ clock.Clock }{},
+		MinionReportTimeout: time.Minute,
 	}
 }
 
@@ -4,10 +4,14 @@
 package migrationmaster
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/migrationmaster"
@@ -25,6 +29,10 @@ var (
 	apiOpen          = api.Open
 	tempSuccessSleep = 10 * time.Second
 
+	// logTransferBatchSize is the maximum number of log records
+	// sent to the target controller in a single request.
+	logTransferBatchSize = 1000
+
 	// ErrDoneForNow indicates a temporary issue was encountered and
 	// that the worker should restart and retry.
 	ErrDoneForNow = errors.New("done for now")
@@ -45,15 +53,58 @@ type Facade interface {
 	// migration.
 	SetPhase(migration.Phase) error
 
+	// SetStatusMessage sets a human readable message about the
+	// progress of the currently active model migration.
+	SetStatusMessage(string) error
+
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() ([]byte, error)
+
+	// ModelLogs returns at most maxCount of the log records for the
+	// model associated with the API connection which follow the
+	// record with the time and document ID given, oldest first. If
+	// afterID is empty, records written at the time given are
+	// included.
+	ModelLogs(after time.Time, afterID string, maxCount int) ([]params.LogStreamRecord, error)
+
+	// WatchMinionReports returns a watcher which reports when a
+	// migration minion has made a report for the current migration
+	// phase.
+	WatchMinionReports() (watcher.NotifyWatcher, error)
+
+	// MinionReports returns details of the reports made by migration
+	// minions to the controller for the current migration phase.
+	MinionReports() (migrationmaster.MinionReports, error)
+
+	// ModelInfo returns the details of the model associated with the
+	// API connection which are needed by the target controller's
+	// prechecks.
+	ModelInfo() (migration.ModelInfo, error)
+
+	// Prechecks returns the problems found which would prevent the
+	// model associated with the API connection from being migrated.
+	Prechecks() ([]migration.PrecheckFailure, error)
+
+	// SetPrecheckFailures records the problems found by the
+	// prechecks for the currently active model migration.
+	SetPrecheckFailures([]migration.PrecheckFailure) error
+
+	// Reap removes all documents of the model associated with the
+	// API connection from the source controller.
+	Reap() error
 }
 
 // Config defines the operation of a Worker.
 type Config struct {
 	Facade Facade
 	Guard  fortress.Guard
+	Clock  clock.Clock
+
+	// MinionReportTimeout is how long to wait for the agents in
+	// the model to report that they have completed the actions
+	// required for a migration phase before aborting.
+	MinionReportTimeout time.Duration
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -64,6 +115,12 @@ func (config Config) Validate() error {
 	if config.Guard == nil {
 		return errors.NotValidf("nil Guard")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.MinionReportTimeout <= 0 {
+		return errors.NotValidf("non-positive MinionReportTimeout")
+	}
 	return nil
 }
 
@@ -127,7 +184,7 @@ func (w *Worker) run() error {
 		case migration.READONLY:
 			phase, err = w.doREADONLY()
 		case migration.PRECHECK:
-			phase, err = w.doPRECHECK()
+			phase, err = w.doPRECHECK(status.TargetInfo)
 		case migration.IMPORT:
 			phase, err = w.doIMPORT(status.TargetInfo)
 		case migration.VALIDATION:
@@ -135,7 +192,7 @@ func (w *Worker) run() error {
 		case migration.SUCCESS:
 			phase, err = w.doSUCCESS()
 		case migration.LOGTRANSFER:
-			phase, err = w.doLOGTRANSFER()
+			phase, err = w.doLOGTRANSFER(status.TargetInfo, status.ModelUUID)
 		case migration.REAP:
 			phase, err = w.doREAP()
 		case migration.ABORT:
@@ -183,7 +240,15 @@ func (w *Worker) killed() bool {
 }
 
 func (w *Worker) doQUIESCE() (migration.Phase, error) {
-	// TODO(mjs) - Wait for all agents to report back.
+	// Wait for all the agents in the model to report that they
+	// have stopped their workers.
+	ok, err := w.waitForMinions(migration.QUIESCE)
+	if err != nil {
+		return migration.UNKNOWN, errors.Trace(err)
+	}
+	if !ok {
+		return migration.ABORT, nil
+	}
 	return migration.READONLY, nil
 }
 
@@ -192,11 +257,57 @@ func (w *Worker) doREADONLY() (migration.Phase, error) {
 	return migration.PRECHECK, nil
 }
 
-func (w *Worker) doPRECHECK() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+func (w *Worker) doPRECHECK(targetInfo migration.TargetInfo) (migration.Phase, error) {
+	logger.Infof("running source prechecks")
+	failures, err := w.config.Facade.Prechecks()
+	if err != nil {
+		logger.Errorf("source prechecks failed: %v", err)
+		return migration.ABORT, nil
+	}
+
+	model, err := w.config.Facade.ModelInfo()
+	if err != nil {
+		logger.Errorf("failed to retrieve model details: %v", err)
+		return migration.ABORT, nil
+	}
+
+	logger.Infof("running target prechecks")
+	targetFailures, err := targetPrechecks(targetInfo, model)
+	if err != nil {
+		logger.Errorf("target prechecks failed: %v", err)
+		return migration.ABORT, nil
+	}
+	failures = append(failures, targetFailures...)
+
+	if len(failures) > 0 {
+		if err := w.config.Facade.SetPrecheckFailures(failures); err != nil {
+			return migration.UNKNOWN, errors.Annotate(err, "failed to record precheck failures")
+		}
+		messages := make([]string, len(failures))
+		for i, failure := range failures {
+			messages[i] = failure.Message
+		}
+		w.setStatusMessage(fmt.Sprintf(
+			"precheck failed: %d problem(s) found: %s",
+			len(failures), strings.Join(messages, "; "),
+		))
+		return migration.ABORT, nil
+	}
 	return migration.IMPORT, nil
 }
 
+func targetPrechecks(targetInfo migration.TargetInfo, model migration.ModelInfo) ([]migration.PrecheckFailure, error) {
+	conn, err := openAPIConn(targetInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to connect to target controller")
+	}
+	defer conn.Close()
+
+	targetClient := migrationtarget.NewClient(conn)
+	failures, err := targetClient.Prechecks(model)
+	return failures, errors.Trace(err)
+}
+
 func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, error) {
 	logger.Infof("exporting model")
 	bytes, err := w.config.Facade.Export()
@@ -255,13 +366,84 @@ func (w *Worker) doSUCCESS() (migration.Phase, error) {
 	return migration.LOGTRANSFER, nil
 }
 
-func (w *Worker) doLOGTRANSFER() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+func (w *Worker) doLOGTRANSFER(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
+	err := w.transferLogs(targetInfo, modelUUID)
+	if w.killed() {
+		return migration.UNKNOWN, w.catacomb.ErrDying()
+	} else if err != nil {
+		// The model has already been migrated so there's no going
+		// back. Exit and retry; the transfer will resume from the
+		// last record received by the target controller.
+		logger.Errorf("log transfer failed: %v", err)
+		return migration.UNKNOWN, ErrDoneForNow
+	}
 	return migration.REAP, nil
 }
 
+func (w *Worker) transferLogs(targetInfo migration.TargetInfo, modelUUID string) error {
+	conn, err := openAPIConn(targetInfo)
+	if err != nil {
+		return errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+	targetClient := migrationtarget.NewClient(conn)
+
+	latest, err := targetClient.LatestLogTime(modelUUID)
+	if err != nil {
+		return errors.Annotate(err, "retrieving latest transferred log time")
+	}
+	if !latest.IsZero() {
+		logger.Infof("resuming log transfer after %s", latest)
+	}
+
+	// The target controller only records the time of the last
+	// record received, so when resuming we start from records
+	// written at that time; the target ignores any records it
+	// already has. Subsequent batches follow the last record sent.
+	var latestID string
+	sent := 0
+	for {
+		if w.killed() {
+			return w.catacomb.ErrDying()
+		}
+		records, err := w.config.Facade.ModelLogs(latest, latestID, logTransferBatchSize)
+		if err != nil {
+			return errors.Annotate(err, "retrieving model logs")
+		}
+		if len(records) == 0 {
+			break
+		}
+		if err := targetClient.AddLogs(modelUUID, records); err != nil {
+			return errors.Annotate(err, "sending logs to target controller")
+		}
+		sent += len(records)
+		latest = records[len(records)-1].Timestamp
+		latestID = records[len(records)-1].DocID
+		w.setStatusMessage(fmt.Sprintf(
+			"transferring logs: %d records sent (up to %s)",
+			sent, latest.Format(time.RFC3339),
+		))
+	}
+	w.setStatusMessage(fmt.Sprintf("log transfer complete: %d records sent", sent))
+	return nil
+}
+
+// setStatusMessage reports migration progress. Failing to set the
+// message isn't reason enough to stop the migration.
+func (w *Worker) setStatusMessage(message string) {
+	logger.Infof(message)
+	if err := w.config.Facade.SetStatusMessage(message); err != nil {
+		logger.Errorf("failed to set migration status message: %v", err)
+	}
+}
+
 func (w *Worker) doREAP() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+	logger.Infof("removing model from source controller")
+	err := w.config.Facade.Reap()
+	if err != nil {
+		w.setStatusMessage(fmt.Sprintf("model removal failed: %v", err))
+		return migration.REAPFAILED, nil
+	}
 	return migration.DONE, nil
 }
 
@@ -323,6 +505,93 @@ func (w *Worker) waitForActiveMigration() (migrationmaster.MigrationStatus, erro
 	}
 }
 
+// waitForMinions waits for all the agents in the model to report
+// back for the given migration phase. It returns true if all agents
+// reported success, and false if any agent reported failure or the
+// configured timeout passed before all agents had reported. An error
+// is only returned if the worker should exit.
+func (w *Worker) waitForMinions(phase migration.Phase) (bool, error) {
+	timeout := w.config.Clock.After(w.config.MinionReportTimeout)
+
+	watcher, err := w.config.Facade.WatchMinionReports()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return false, errors.Trace(err)
+	}
+	defer watcher.Kill()
+
+	logger.Infof("waiting for agents to report back for %s", phase)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return false, w.catacomb.ErrDying()
+
+		case <-timeout:
+			// Report the agents still missing at the timeout, rather
+			// than those missing when the reports last changed.
+			reports, err := w.config.Facade.MinionReports()
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			if reports.Phase != phase {
+				w.setStatusMessage(fmt.Sprintf(
+					"timed out waiting for agents to report back for %s", phase,
+				))
+				return false, nil
+			}
+			w.setStatusMessage(fmt.Sprintf(
+				"timed out waiting for %d agent(s) to report back for %s: %s",
+				len(reports.UnknownAgents), phase, formatTags(reports.UnknownAgents),
+			))
+			return false, nil
+
+		case <-watcher.Changes():
+			reports, err := w.config.Facade.MinionReports()
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			if reports.Phase != phase {
+				// Reports for some other phase; wait for more.
+				logger.Warningf("ignoring minion reports for %s (waiting for %s)",
+					reports.Phase, phase)
+				continue
+			}
+
+			if failures := len(reports.FailedAgents); failures > 0 {
+				w.setStatusMessage(fmt.Sprintf(
+					"%d agent(s) failed %s: %s",
+					failures, phase, formatTags(reports.FailedAgents),
+				))
+				return false, nil
+			}
+
+			if reports.UnknownCount == 0 {
+				logger.Infof("all agents have reported back for %s (%d succeeded)",
+					phase, reports.SuccessCount)
+				return true, nil
+			}
+			logger.Debugf("%d agent(s) still to report back for %s",
+				reports.UnknownCount, phase)
+		}
+	}
+}
+
+func formatTags(tags []names.Tag) string {
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = tag.Id()
+		switch tag.(type) {
+		case names.MachineTag:
+			out[i] = "machine " + out[i]
+		case names.UnitTag:
+			out[i] = "unit " + out[i]
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
 func openAPIConn(targetInfo migration.TargetInfo) (api.Connection, error) {
 	apiInfo := &api.Info{
 		Addrs:    targetInfo.Addrs,
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -27,6 +28,7 @@ import (
 
 type Suite struct {
 	coretesting.BaseSuite
+	clock         *coretesting.Clock
 	stub          *jujutesting.Stub
 	connection    *stubConnection
 	connectionErr error
@@ -63,6 +65,18 @@ var (
 			params.ModelArgs{ModelTag: modelTagString},
 		},
 	}
+	targetPrechecksCall = jujutesting.StubCall{
+		"APICall:MigrationTarget.Prechecks",
+		[]interface{}{
+			params.MigrationModelInfo{
+				UUID:         "model-uuid",
+				Name:         "model-name",
+				OwnerTag:     names.NewUserTag("owner").String(),
+				AgentVersion: version.MustParse("1.2.3"),
+				CloudName:    "cloud",
+			},
+		},
+	}
 	connCloseCall = jujutesting.StubCall{"Connection.Close", nil}
 	abortCall     = jujutesting.StubCall{
 		"APICall:MigrationTarget.Abort",
@@ -70,16 +84,31 @@ var (
 			params.ModelArgs{ModelTag: modelTagString},
 		},
 	}
+	latestLogTimeCall = jujutesting.StubCall{
+		"APICall:MigrationTarget.LatestLogTime",
+		[]interface{}{
+			params.ModelArgs{ModelTag: modelTagString},
+		},
+	}
+	noLogsTransferredCalls = []jujutesting.StubCall{
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.ModelLogs", []interface{}{time.Time{}, "", 2}},
+		{"masterClient.SetStatusMessage", []interface{}{"log transfer complete: 0 records sent"}},
+		connCloseCall,
+	}
 )
 
 func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
+	s.clock = coretesting.NewClock(time.Now())
 	s.stub = new(jujutesting.Stub)
 	s.connection = &stubConnection{stub: s.stub}
 	s.connectionErr = nil
 	s.PatchValue(migrationmaster.ApiOpen, s.apiOpen)
 	s.PatchValue(migrationmaster.TempSuccessSleep, time.Millisecond)
+	s.PatchValue(migrationmaster.LogTransferBatchSize, 2)
 }
 
 func (s *Suite) apiOpen(info *api.Info, dialOpts api.DialOpts) (api.Connection, error) {
@@ -90,16 +119,22 @@ func (s *Suite) apiOpen(info *api.Info, dialOpts api.DialOpts) (api.Connection,
 	return s.connection, nil
 }
 
+func (s *Suite) makeConfig(masterClient *stubMasterClient, guard fortress.Guard) migrationmaster.Config {
+	return migrationmaster.Config{
+		Facade:              masterClient,
+		Guard:               guard,
+		Clock:               s.clock,
+		MinionReportTimeout: time.Minute,
+	}
+}
+
 func (s *Suite) triggerMigration(masterClient *stubMasterClient) {
 	masterClient.watcherChanges <- struct{}{}
 }
 
 func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -109,12 +144,19 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 	// Observe that the migration was seen, the model exported, an API
 	// connection to the target controller was made, the model was
 	// imported and then the migration completed.
-	s.stub.CheckCalls(c, []jujutesting.StubCall{
+	s.stub.CheckCalls(c, joinCalls([]jujutesting.StubCall{
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		targetPrechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		apiOpenCall,
@@ -126,19 +168,18 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.SUCCESS}},
 		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
+	}, noLogsTransferredCalls, []jujutesting.StubCall{
 		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
 		{"masterClient.SetPhase", []interface{}{migration.DONE}},
-	})
+	}))
 }
 
 func (s *Suite) TestMigrationResume(c *gc.C) {
 	// Test that a partially complete migration can be resumed.
 
 	masterClient := newStubMasterClient(s.stub)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	masterClient.status.Phase = migration.SUCCESS
 	s.triggerMigration(masterClient)
@@ -146,24 +187,117 @@ func (s *Suite) TestMigrationResume(c *gc.C) {
 	err = workertest.CheckKilled(c, worker)
 	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
 
-	s.stub.CheckCalls(c, []jujutesting.StubCall{
+	s.stub.CheckCalls(c, joinCalls([]jujutesting.StubCall{
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
 		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
+	}, noLogsTransferredCalls, []jujutesting.StubCall{
+		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
+		{"masterClient.SetPhase", []interface{}{migration.DONE}},
+	}))
+}
+
+func (s *Suite) TestREAPFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.REAP
+	masterClient.reapErr = errors.New("boom")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.Reap", nil},
+		{"masterClient.SetStatusMessage", []interface{}{"model removal failed: boom"}},
+		{"masterClient.SetPhase", []interface{}{migration.REAPFAILED}},
+	})
+}
+
+func (s *Suite) TestLogTransfer(c *gc.C) {
+	t0 := time.Date(2016, 8, 1, 10, 0, 0, 0, time.UTC)
+	records := []params.LogStreamRecord{
+		{Timestamp: t0.Add(time.Second), Message: "one", DocID: "id-1"},
+		{Timestamp: t0.Add(2 * time.Second), Message: "two", DocID: "id-2"},
+		{Timestamp: t0.Add(3 * time.Second), Message: "three", DocID: "id-3"},
+	}
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.LOGTRANSFER
+	masterClient.logBatches = [][]params.LogStreamRecord{records[:2], records[2:]}
+	s.connection.latestLogTime = t0
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+
+	// The transfer resumes from the time of the latest record known
+	// to the target, then follows the last record of each batch.
+	// Progress is reported after each batch.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.ModelLogs", []interface{}{t0, "", 2}},
+		addLogsCall(records[:2]),
+		{"masterClient.SetStatusMessage", []interface{}{
+			"transferring logs: 2 records sent (up to 2016-08-01T10:00:02Z)",
+		}},
+		{"masterClient.ModelLogs", []interface{}{records[1].Timestamp, "id-2", 2}},
+		addLogsCall(records[2:]),
+		{"masterClient.SetStatusMessage", []interface{}{
+			"transferring logs: 3 records sent (up to 2016-08-01T10:00:03Z)",
+		}},
+		{"masterClient.ModelLogs", []interface{}{records[2].Timestamp, "id-3", 2}},
+		{"masterClient.SetStatusMessage", []interface{}{"log transfer complete: 3 records sent"}},
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
 		{"masterClient.SetPhase", []interface{}{migration.DONE}},
 	})
 }
 
+func (s *Suite) TestLogTransferFailure(c *gc.C) {
+	records := []params.LogStreamRecord{{Message: "one"}}
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.LOGTRANSFER
+	masterClient.logBatches = [][]params.LogStreamRecord{records}
+	s.connection.addLogsErr = errors.New("boom")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	// The worker exits so that the transfer can be retried. The
+	// phase isn't changed.
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		apiOpenCall,
+		latestLogTimeCall,
+		{"masterClient.ModelLogs", []interface{}{time.Time{}, "", 2}},
+		addLogsCall(records),
+		connCloseCall,
+	})
+}
+
 func (s *Suite) TestPreviouslyAbortedMigration(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.status.Phase = migration.ABORTDONE
 	s.triggerMigration(masterClient)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	workertest.CheckAlive(c, worker)
 	workertest.CleanKill(c, worker)
@@ -175,10 +309,7 @@ func (s *Suite) TestPreviouslyCompletedMigration(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.status.Phase = migration.DONE
 	s.triggerMigration(masterClient)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 
 	err = workertest.CheckKilled(c, worker)
@@ -193,10 +324,7 @@ func (s *Suite) TestPreviouslyCompletedMigration(c *gc.C) {
 func (s *Suite) TestWatchFailure(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.watchErr = errors.New("boom")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, worker)
 	c.Assert(err, gc.ErrorMatches, "watching for migration: boom")
@@ -205,10 +333,7 @@ func (s *Suite) TestWatchFailure(c *gc.C) {
 func (s *Suite) TestStatusError(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.statusErr = errors.New("splat")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -223,10 +348,7 @@ func (s *Suite) TestStatusError(c *gc.C) {
 func (s *Suite) TestStatusNotFound(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.statusErr = &params.Error{Code: params.CodeNotFound}
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -245,10 +367,7 @@ func (s *Suite) TestUnlockError(c *gc.C) {
 	masterClient.statusErr = &params.Error{Code: params.CodeNotFound}
 	guard := newStubGuard(s.stub)
 	guard.unlockErr = errors.New("pow")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  guard,
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, guard))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -266,10 +385,7 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	guard := newStubGuard(s.stub)
 	guard.lockdownErr = errors.New("biff")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  guard,
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, guard))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -286,10 +402,7 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 func (s *Suite) TestExportFailure(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.exportErr = errors.New("boom")
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.triggerMigration(masterClient)
 
@@ -300,8 +413,15 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		targetPrechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
@@ -314,10 +434,7 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 
 func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.connectionErr = errors.New("boom")
 	s.triggerMigration(masterClient)
@@ -329,10 +446,12 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
-		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
-		{"masterClient.Export", nil},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
 		apiOpenCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
 		apiOpenCall,
@@ -342,10 +461,7 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 
 func (s *Suite) TestImportFailure(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
-	worker, err := migrationmaster.New(migrationmaster.Config{
-		Facade: masterClient,
-		Guard:  newStubGuard(s.stub),
-	})
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
 	c.Assert(err, jc.ErrorIsNil)
 	s.connection.importErr = errors.New("boom")
 	s.triggerMigration(masterClient)
@@ -357,8 +473,15 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 		{"masterClient.Watch", nil},
 		{"masterClient.GetMigrationStatus", nil},
 		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		targetPrechecksCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		apiOpenCall,
@@ -372,6 +495,247 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestQUIESCEMinionFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReports.UnknownCount = 1
+	masterClient.minionReports.UnknownAgents = []names.Tag{names.NewMachineTag("2")}
+	masterClient.minionReports.FailedAgents = []names.Tag{
+		names.NewMachineTag("1"),
+		names.NewUnitTag("foo/0"),
+	}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetStatusMessage", []interface{}{
+			"2 agent(s) failed QUIESCE: machine 1, unit foo/0",
+		}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestQUIESCETimeout(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReports.SuccessCount = 1
+	masterClient.minionReports.UnknownCount = 2
+	masterClient.minionReports.UnknownAgents = []names.Tag{
+		names.NewMachineTag("2"),
+		names.NewUnitTag("bar/1"),
+	}
+	masterClient.minionReportsCalled = make(chan struct{}, 1)
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	// Wait for the timeout to be set up and the initial reports
+	// to be seen before letting the timeout pass.
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for clock.After call")
+	}
+	select {
+	case <-masterClient.minionReportsCalled:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for MinionReports call")
+	}
+	// The machine reports back without the reports watcher firing
+	// before the timeout; the agents still missing at the timeout
+	// are reported.
+	masterClient.minionReports.SuccessCount = 2
+	masterClient.minionReports.UnknownCount = 1
+	masterClient.minionReports.UnknownAgents = []names.Tag{
+		names.NewUnitTag("bar/1"),
+	}
+	s.clock.Advance(time.Minute)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetStatusMessage", []interface{}{
+			"timed out waiting for 1 agent(s) to report back for QUIESCE: unit bar/1",
+		}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestQUIESCEWatchMinionReportsError(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.watchMinionReportsErr = errors.New("boom")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestQUIESCEMinionReportsError(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.minionReportsErr = errors.New("splat")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches, "splat")
+}
+
+func (s *Suite) TestPRECHECKFailures(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.precheckFailures = []migration.PrecheckFailure{{
+		Code:    migration.PrecheckMachineError,
+		Entity:  "machine-1",
+		Message: "machine 1 is in error state: boom",
+	}}
+	s.connection.precheckFailures = []params.PrecheckFailure{{
+		Code:    migration.PrecheckModelExists,
+		Entity:  "model-model-uuid",
+		Message: "model with same UUID already exists (model-uuid)",
+	}}
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		targetPrechecksCall,
+		connCloseCall,
+		{"masterClient.SetPrecheckFailures", []interface{}{[]migration.PrecheckFailure{{
+			Code:    migration.PrecheckMachineError,
+			Entity:  "machine-1",
+			Message: "machine 1 is in error state: boom",
+		}, {
+			Code:    migration.PrecheckModelExists,
+			Entity:  "model-model-uuid",
+			Message: "model with same UUID already exists (model-uuid)",
+		}}}},
+		{"masterClient.SetStatusMessage", []interface{}{
+			"precheck failed: 2 problem(s) found: machine 1 is in error state: boom; " +
+				"model with same UUID already exists (model-uuid)",
+		}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestPRECHECKSourceError(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.prechecksErr = errors.New("boom")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestPRECHECKTargetError(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	s.connection.prechecksErr = errors.New("boom")
+	worker, err := migrationmaster.New(s.makeConfig(masterClient, newStubGuard(s.stub)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.WatchMinionReports", nil},
+		{"masterClient.MinionReports", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Prechecks", nil},
+		{"masterClient.ModelInfo", nil},
+		apiOpenCall,
+		targetPrechecksCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func addLogsCall(records []params.LogStreamRecord) jujutesting.StubCall {
+	return jujutesting.StubCall{
+		"APICall:MigrationTarget.AddLogs",
+		[]interface{}{
+			params.AddModelLogsArgs{
+				ModelTag: modelTagString,
+				Records:  records,
+			},
+		},
+	}
+}
+
+func joinCalls(allCalls ...[]jujutesting.StubCall) []jujutesting.StubCall {
+	var out []jujutesting.StubCall
+	for _, calls := range allCalls {
+		out = append(out, calls...)
+	}
+	return out
+}
+
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
 	return &stubGuard{stub: stub}
 }
@@ -408,6 +772,11 @@ func newStubMasterClient(stub *jujutesting.Stub) *stubMasterClient {
 				Password:      "secret",
 			},
 		},
+		minionReports: masterapi.MinionReports{
+			MigrationId:  "model-uuid:2",
+			Phase:        migration.QUIESCE,
+			SuccessCount: 3,
+		},
 	}
 }
 
@@ -419,6 +788,17 @@ type stubMasterClient struct {
 	status         masterapi.MigrationStatus
 	statusErr      error
 	exportErr      error
+	logBatches     [][]params.LogStreamRecord
+
+	watchMinionReportsErr error
+	minionReports         masterapi.MinionReports
+	minionReportsErr      error
+	minionReportsCalled   chan struct{}
+
+	modelInfoErr     error
+	prechecksErr     error
+	precheckFailures []migration.PrecheckFailure
+	reapErr          error
 }
 
 func (c *stubMasterClient) Watch() (watcher.NotifyWatcher, error) {
@@ -451,6 +831,74 @@ func (c *stubMasterClient) SetPhase(phase migration.Phase) error {
 	return nil
 }
 
+func (c *stubMasterClient) SetStatusMessage(message string) error {
+	c.stub.AddCall("masterClient.SetStatusMessage", message)
+	return nil
+}
+
+func (c *stubMasterClient) ModelLogs(after time.Time, afterID string, maxCount int) ([]params.LogStreamRecord, error) {
+	c.stub.AddCall("masterClient.ModelLogs", after, afterID, maxCount)
+	if len(c.logBatches) == 0 {
+		return nil, nil
+	}
+	batch := c.logBatches[0]
+	c.logBatches = c.logBatches[1:]
+	return batch, nil
+}
+
+func (c *stubMasterClient) WatchMinionReports() (watcher.NotifyWatcher, error) {
+	c.stub.AddCall("masterClient.WatchMinionReports")
+	if c.watchMinionReportsErr != nil {
+		return nil, c.watchMinionReportsErr
+	}
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	return newMockWatcher(changes), nil
+}
+
+func (c *stubMasterClient) MinionReports() (masterapi.MinionReports, error) {
+	c.stub.AddCall("masterClient.MinionReports")
+	if c.minionReportsCalled != nil {
+		c.minionReportsCalled <- struct{}{}
+	}
+	if c.minionReportsErr != nil {
+		return masterapi.MinionReports{}, c.minionReportsErr
+	}
+	return c.minionReports, nil
+}
+
+func (c *stubMasterClient) ModelInfo() (migration.ModelInfo, error) {
+	c.stub.AddCall("masterClient.ModelInfo")
+	if c.modelInfoErr != nil {
+		return migration.ModelInfo{}, c.modelInfoErr
+	}
+	return migration.ModelInfo{
+		UUID:         "model-uuid",
+		Name:         "model-name",
+		Owner:        names.NewUserTag("owner"),
+		AgentVersion: version.MustParse("1.2.3"),
+		CloudName:    "cloud",
+	}, nil
+}
+
+func (c *stubMasterClient) Prechecks() ([]migration.PrecheckFailure, error) {
+	c.stub.AddCall("masterClient.Prechecks")
+	if c.prechecksErr != nil {
+		return nil, c.prechecksErr
+	}
+	return c.precheckFailures, nil
+}
+
+func (c *stubMasterClient) Reap() error {
+	c.stub.AddCall("masterClient.Reap")
+	return c.reapErr
+}
+
+func (c *stubMasterClient) SetPrecheckFailures(failures []migration.PrecheckFailure) error {
+	c.stub.AddCall("masterClient.SetPrecheckFailures", failures)
+	return nil
+}
+
 func newMockWatcher(changes chan struct{}) *mockWatcher {
 	return &mockWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -469,8 +917,13 @@ func (w *mockWatcher) Changes() watcher.NotifyChannel {
 
 type stubConnection struct {
 	api.Connection
-	stub      *jujutesting.Stub
-	importErr error
+	stub          *jujutesting.Stub
+	importErr     error
+	latestLogTime time.Time
+	addLogsErr    error
+
+	prechecksErr     error
+	precheckFailures []params.PrecheckFailure
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
@@ -482,10 +935,23 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 
 	if objType == "MigrationTarget" {
 		switch request {
+		case "Prechecks":
+			if c.prechecksErr != nil {
+				return c.prechecksErr
+			}
+			*(response.(*params.PrecheckResult)) = params.PrecheckResult{
+				Failures: c.precheckFailures,
+			}
+			return nil
 		case "Import":
 			return c.importErr
 		case "Activate":
 			return nil
+		case "LatestLogTime":
+			*(response.(*time.Time)) = c.latestLogTime
+			return nil
+		case "AddLogs":
+			return c.addLogsErr
 		}
 	}
 	return errors.New("unexpected API call")
@@ -25,6 +25,10 @@ type Facade interface {
 	// for the migration for the model associated with the API
 	// connection.
 	Watch() (watcher.MigrationStatusWatcher, error)
+
+	// Report allows a migration minion to report if it successfully
+	// completed its activities for a given migration phase.
+	Report(migrationId string, phase migration.Phase, success bool) error
 }
 
 // Config defines the operation of a Worker.
@@ -121,11 +125,13 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 
 	switch status.Phase {
 	case migration.QUIESCE:
-		// TODO(mjs) - once Will's stable mode work comes
-		// together this worker will only start up when a
-		// migration is active. Here the minion should report
-		// to the controller that it is running so that the
-		// migration can progress to READONLY.
+		// The fortress is now locked down so the workers it
+		// guards have stopped. Let the controller know so that
+		// the migration can progress to READONLY.
+		err := w.report(status, true)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	case migration.VALIDATION:
 		// TODO(mjs) - check connection to the target
 		// controller here and report success/failure.
@@ -158,6 +164,12 @@ func (w *Worker) doSUCCESS(targetAddrs []string, caCert string) error {
 	return errors.Annotate(err, "setting agent config")
 }
 
+func (w *Worker) report(status watcher.MigrationStatus, success bool) error {
+	logger.Debugf("reporting back for phase %s: %v", status.Phase, success)
+	err := w.config.Facade.Report(status.MigrationId, status.Phase, success)
+	return errors.Annotate(err, "failed to report phase progress")
+}
+
 func apiAddrsToHostPorts(addrs []string) ([][]network.HostPort, error) {
 	hps, err := network.ParseHostPorts(addrs...)
 	if err != nil {
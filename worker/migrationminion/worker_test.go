@@ -126,6 +126,45 @@ func (s *Suite) TestNONE(c *gc.C) {
 	s.stub.CheckCallNames(c, "Watch", "Unlock")
 }
 
+func (s *Suite) TestQUIESCE(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       migration.QUIESCE,
+	}
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade: s.client,
+		Guard:  s.guard,
+		Agent:  s.agent,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitForStubCalls(c, []string{
+		"Watch",
+		"Lockdown",
+		"Report",
+	})
+	s.stub.CheckCall(c, 2, "Report", "id", migration.QUIESCE, true)
+}
+
+func (s *Suite) TestQUIESCEReportFailure(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       migration.QUIESCE,
+	}
+	s.client.reportErr = errors.New("splat")
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade: s.client,
+		Guard:  s.guard,
+		Agent:  s.agent,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "failed to report phase progress: splat")
+	s.stub.CheckCallNames(c, "Watch", "Lockdown", "Report")
+}
+
 func (s *Suite) TestSUCCESS(c *gc.C) {
 	addrs := []string{"1.1.1.1:1", "9.9.9.9:9"}
 	s.client.watcher.changes <- watcher.MigrationStatus{
@@ -151,6 +190,25 @@ func (s *Suite) TestSUCCESS(c *gc.C) {
 	s.stub.CheckCallNames(c, "Watch", "Lockdown")
 }
 
+func (s *Suite) waitForStubCalls(c *gc.C, expectedCallNames []string) {
+	var callNames []string
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		callNames = stubCallNames(s.stub)
+		if len(callNames) >= len(expectedCallNames) {
+			break
+		}
+	}
+	c.Check(callNames, jc.DeepEquals, expectedCallNames)
+}
+
+func stubCallNames(stub *jujutesting.Stub) []string {
+	var out []string
+	for _, call := range stub.Calls() {
+		out = append(out, call.FuncName)
+	}
+	return out
+}
+
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
 	return &stubGuard{stub: stub}
 }
@@ -179,9 +237,10 @@ func newStubMinionClient(stub *jujutesting.Stub) *stubMinionClient {
 }
 
 type stubMinionClient struct {
-	stub     *jujutesting.Stub
-	watcher  *stubWatcher
-	watchErr error
+	stub      *jujutesting.Stub
+	watcher   *stubWatcher
+	watchErr  error
+	reportErr error
 }
 
 func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
@@ -192,6 +251,11 @@ func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
 	return c.watcher, nil
 }
 
+func (c *stubMinionClient) Report(id string, phase migration.Phase, success bool) error {
+	c.stub.MethodCall(c, "Report", id, phase, success)
+	return c.reportErr
+}
+
 func newStubWatcher() *stubWatcher {
 	return &stubWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -17,10 +17,8 @@ import (
 // ManifoldConfig describes the resources and configuration on which the
 // statushistorypruner worker depends.
 type ManifoldConfig struct {
-	APICallerName  string
-	MaxHistoryTime time.Duration
-	MaxHistoryMB   uint
-	PruneInterval  time.Duration
+	APICallerName string
+	PruneInterval time.Duration
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	NewTimer worker.NewTimerFunc
 }
@@ -37,11 +35,9 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 
 			facade := statushistory.NewFacade(apiCaller)
 			prunerConfig := Config{
-				Facade:         facade,
-				MaxHistoryTime: config.MaxHistoryTime,
-				MaxHistoryMB:   config.MaxHistoryMB,
-				PruneInterval:  config.PruneInterval,
-				NewTimer:       config.NewTimer,
+				Facade:        facade,
+				PruneInterval: config.PruneInterval,
+				NewTimer:      config.NewTimer,
 			}
 			w, err := New(prunerConfig)
 			if err != nil {
@@ -4,24 +4,28 @@
 package statushistorypruner
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker"
 )
 
+var logger = loggo.GetLogger("juju.worker.statushistorypruner")
+
 // Facade represents an API that implements status history pruning.
 type Facade interface {
-	Prune(time.Duration, int) error
+	Prune(time.Duration, int) (int, error)
+	ModelConfig() (*config.Config, error)
 }
 
 // Config holds all necessary attributes to start a pruner worker.
 type Config struct {
-	Facade         Facade
-	MaxHistoryTime time.Duration
-	MaxHistoryMB   uint
-	PruneInterval  time.Duration
+	Facade        Facade
+	PruneInterval time.Duration
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	NewTimer worker.NewTimerFunc
 }
@@ -35,27 +39,81 @@ func (c *Config) Validate() error {
 	if c.NewTimer == nil {
 		return errors.New("missing Timer")
 	}
-	// TODO(perrito666) this assumes out of band knowledge of how filter
-	// values are treated, expand config to support the "dont use this filter"
-	// case as an explicit statement.
-	if c.MaxHistoryMB <= 0 && c.MaxHistoryTime <= 0 {
-		return errors.New("missing prune criteria, no size or date limit provided")
-	}
 	return nil
 }
 
-// New returns a worker.Worker for history Pruner.
+// New returns a worker.Worker for history Pruner. The retention limits
+// are read from the model config on every run, so that changes to them
+// take effect without restarting the worker.
 func New(conf Config) (worker.Worker, error) {
 	if err := conf.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	w := &prunerWorker{}
 	doPruning := func(stop <-chan struct{}) error {
-		err := conf.Facade.Prune(conf.MaxHistoryTime, int(conf.MaxHistoryMB))
+		cfg, err := conf.Facade.ModelConfig()
+		if err != nil {
+			return errors.Annotate(err, "cannot read model config")
+		}
+		maxHistoryTime, err := cfg.MaxStatusHistoryAge()
+		if err != nil {
+			// Failing would only restart the worker with the
+			// same config; wait for the config to be fixed.
+			logger.Errorf("not pruning status history: %v", err)
+			return nil
+		}
+		maxHistorySizeMB, err := cfg.MaxStatusHistorySizeMB()
+		if err != nil {
+			logger.Errorf("not pruning status history: %v", err)
+			return nil
+		}
+		maxHistoryMB := int(maxHistorySizeMB)
+		removed, err := conf.Facade.Prune(maxHistoryTime, maxHistoryMB)
 		if err != nil {
 			return errors.Trace(err)
 		}
+		w.record(maxHistoryTime, maxHistoryMB, removed)
 		return nil
 	}
+	w.Worker = worker.NewPeriodicWorker(doPruning, conf.PruneInterval, conf.NewTimer)
+	return w, nil
+}
+
+// prunerWorker wraps the periodic pruning worker so that the outcome of
+// the pruning runs can be reported by the dependency engine.
+type prunerWorker struct {
+	worker.Worker
+
+	mu           sync.Mutex
+	maxAge       time.Duration
+	maxSizeMB    int
+	lastPrune    time.Time
+	lastRemoved  int
+	totalRemoved int
+}
 
-	return worker.NewPeriodicWorker(doPruning, conf.PruneInterval, conf.NewTimer), nil
+func (w *prunerWorker) record(maxAge time.Duration, maxSizeMB, removed int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.maxAge = maxAge
+	w.maxSizeMB = maxSizeMB
+	w.lastPrune = time.Now()
+	w.lastRemoved = removed
+	w.totalRemoved += removed
+}
+
+// Report is part of the dependency.Reporter interface.
+func (w *prunerWorker) Report() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	report := map[string]interface{}{
+		"max-age":       w.maxAge.String(),
+		"max-size-mb":   w.maxSizeMB,
+		"last-removed":  w.lastRemoved,
+		"total-removed": w.totalRemoved,
+	}
+	if !w.lastPrune.IsZero() {
+		report["last-prune"] = w.lastPrune.Format(time.RFC3339)
+	}
+	return report
 }
@@ -7,11 +7,14 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs/config"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/statushistorypruner"
 )
 
@@ -32,11 +35,9 @@ func (s *statusHistoryPrunerSuite) TestWorkerCallsPrune(c *gc.C) {
 	}
 	facade := newFakeFacade()
 	conf := statushistorypruner.Config{
-		Facade:         facade,
-		MaxHistoryTime: 1 * time.Second,
-		MaxHistoryMB:   3,
-		PruneInterval:  coretesting.ShortWait,
-		NewTimer:       fakeTimerFunc,
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
 	}
 
 	pruner, err := statushistorypruner.New(conf)
@@ -55,6 +56,10 @@ func (s *statusHistoryPrunerSuite) TestWorkerCallsPrune(c *gc.C) {
 		c.Fatal("timed out waiting for passed logs to pruner")
 	}
 	c.Assert(passedMB, gc.Equals, 3)
+	facade.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelConfig", nil},
+		{"Prune", []interface{}{time.Second, 3}},
+	})
 
 	// Reset will have been called with the actual PruneInterval
 	var period time.Duration
@@ -66,6 +71,48 @@ func (s *statusHistoryPrunerSuite) TestWorkerCallsPrune(c *gc.C) {
 	c.Assert(period, gc.Equals, coretesting.ShortWait)
 }
 
+func (s *statusHistoryPrunerSuite) TestWorkerReportsPruning(c *gc.C) {
+	fakeTimer := newMockTimer(coretesting.LongWait)
+	fakeTimerFunc := func(d time.Duration) worker.PeriodicTimer {
+		return fakeTimer
+	}
+	facade := newFakeFacade()
+	facade.removed = 42
+	conf := statushistorypruner.Config{
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
+	}
+
+	pruner, err := statushistorypruner.New(conf)
+	c.Check(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		c.Assert(worker.Stop(pruner), jc.ErrorIsNil)
+	})
+
+	err = fakeTimer.fire()
+	c.Check(err, jc.ErrorIsNil)
+	select {
+	case <-facade.passedMaxHistoryMB:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for passed logs to pruner")
+	}
+	select {
+	case <-fakeTimer.period:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for period reset by pruner")
+	}
+
+	reporter, ok := pruner.(dependency.Reporter)
+	c.Assert(ok, jc.IsTrue)
+	report := reporter.Report()
+	c.Assert(report["max-age"], gc.Equals, "1s")
+	c.Assert(report["max-size-mb"], gc.Equals, 3)
+	c.Assert(report["last-removed"], gc.Equals, 42)
+	c.Assert(report["total-removed"], gc.Equals, 42)
+	c.Assert(report["last-prune"], gc.NotNil)
+}
+
 func (s *statusHistoryPrunerSuite) TestWorkerWontCallPruneBeforeFiringTimer(c *gc.C) {
 	fakeTimer := newMockTimer(coretesting.LongWait)
 
@@ -77,11 +124,9 @@ func (s *statusHistoryPrunerSuite) TestWorkerWontCallPruneBeforeFiringTimer(c *g
 	}
 	facade := newFakeFacade()
 	conf := statushistorypruner.Config{
-		Facade:         facade,
-		MaxHistoryTime: 1 * time.Second,
-		MaxHistoryMB:   3,
-		PruneInterval:  coretesting.ShortWait,
-		NewTimer:       fakeTimerFunc,
+		Facade:        facade,
+		PruneInterval: coretesting.ShortWait,
+		NewTimer:      fakeTimerFunc,
 	}
 
 	pruner, err := statushistorypruner.New(conf)
@@ -131,7 +176,10 @@ func newMockTimer(d time.Duration) *mockTimer {
 }
 
 type fakeFacade struct {
+	gitjujutesting.Stub
 	passedMaxHistoryMB chan int
+	config             *config.Config
+	removed            int
 }
 
 func newFakeFacade() *fakeFacade {
@@ -140,14 +188,31 @@ func newFakeFacade() *fakeFacade {
 	}
 }
 
+// ModelConfig implements Facade
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.MethodCall(f, "ModelConfig")
+	if f.config != nil {
+		return f.config, f.NextErr()
+	}
+	cfg, err := config.New(config.NoDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"max-status-history-age":  "1s",
+		"max-status-history-size": "3M",
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return cfg, f.NextErr()
+}
+
 // Prune implements Facade
-func (f *fakeFacade) Prune(_ time.Duration, maxHistoryMB int) error {
+func (f *fakeFacade) Prune(maxHistoryTime time.Duration, maxHistoryMB int) (int, error) {
+	f.MethodCall(f, "Prune", maxHistoryTime, maxHistoryMB)
 	// TODO(perrito666) either make this send its actual args, or just use
 	// a stub and drop the unnecessary channel malarkey entirely
 	select {
 	case f.passedMaxHistoryMB <- maxHistoryMB:
 	case <-time.After(coretesting.LongWait):
-		return errors.New("timed out waiting for facade call Prune to run")
+		return 0, errors.New("timed out waiting for facade call Prune to run")
 	}
-	return nil
+	return f.removed, f.NextErr()
 }
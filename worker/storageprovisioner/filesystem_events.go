@@ -65,6 +65,47 @@ func filesystemsChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// filesystemResizesChanged is called when the filesystems with the
+// provided IDs have been seen to have changed, and may need to be
+// resized to fill their backing volumes.
+func filesystemResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.FilesystemTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewFilesystemTag(change)
+	}
+	results, err := ctx.config.Filesystems.FilesystemResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem resize parameters")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		// Remove any previously scheduled resize; it
+		// will be replaced with the latest parameters.
+		ctx.schedule.Remove(resizeFilesystemKey{tags[i]})
+		if result.Error != nil {
+			if params.IsCodeNotFoundOrCodeUnauthorized(result.Error) {
+				// The filesystem has been removed.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		if result.Result == nil {
+			// There is no pending resize.
+			continue
+		}
+		args, err := filesystemResizeParamsFromParams(*result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, &resizeFilesystemOp{args: args})
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // filesystemAttachmentsChanged is called when the lifecycle states of the filesystem
 // attachments with the provided IDs have been seen to have changed.
 func filesystemAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -372,12 +413,52 @@ func processAliveFilesystemAttachments(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := refreshAttachmentFilesystems(ctx, params); err != nil {
+		return errors.Trace(err)
+	}
 	for i, params := range params {
 		updatePendingFilesystemAttachment(ctx, pending[i], params)
 	}
 	return nil
 }
 
+// refreshAttachmentFilesystems records the info of any already-provisioned
+// filesystems for the given attachments that have not been seen in this
+// session. A filesystem detached from one machine and attached to another
+// may have been provisioned before the filesystem watcher reported it.
+func refreshAttachmentFilesystems(ctx *context, attachmentParams []storage.FilesystemAttachmentParams) error {
+	var tags []names.FilesystemTag
+	for _, params := range attachmentParams {
+		if _, ok := ctx.filesystems[params.Filesystem]; !ok {
+			tags = append(tags, params.Filesystem)
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	filesystemResults, err := ctx.config.Filesystems.Filesystems(tags)
+	if err != nil {
+		return errors.Annotatef(err, "getting filesystem information")
+	}
+	for i, result := range filesystemResults {
+		if result.Error != nil {
+			if params.IsCodeNotProvisioned(result.Error) {
+				// The filesystem will be recorded once provisioned.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting filesystem information for filesystem %q", tags[i].Id(),
+			)
+		}
+		filesystem, err := filesystemFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "getting filesystem info")
+		}
+		updateFilesystem(ctx, filesystem)
+	}
+	return nil
+}
+
 // filesystemAttachmentParams obtains the specified attachments' parameters.
 func filesystemAttachmentParams(
 	ctx *context, ids []params.MachineStorageId,
@@ -465,6 +546,23 @@ func filesystemParamsFromParams(in params.FilesystemParams) (storage.FilesystemP
 	}, nil
 }
 
+func filesystemResizeParamsFromParams(in params.FilesystemResizeParams) (storage.FilesystemResizeParams, error) {
+	filesystemTag, err := names.ParseFilesystemTag(in.FilesystemTag)
+	if err != nil {
+		return storage.FilesystemResizeParams{}, errors.Trace(err)
+	}
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.FilesystemResizeParams{}, errors.Trace(err)
+	}
+	return storage.FilesystemResizeParams{
+		Tag:          filesystemTag,
+		FilesystemId: in.FilesystemId,
+		Volume:       volumeTag,
+		Size:         in.Size,
+	}, nil
+}
+
 func filesystemAttachmentParamsFromParams(in params.FilesystemAttachmentParams) (storage.FilesystemAttachmentParams, error) {
 	machineTag, err := names.ParseMachineTag(in.MachineTag)
 	if err != nil {
@@ -113,6 +113,68 @@ func createFilesystems(ctx *context, ops map[names.FilesystemTag]*createFilesyst
 	return nil
 }
 
+// resizeFilesystems grows volume-backed filesystems to fill their
+// resized backing volumes.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	resizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		return errors.NotSupportedf("resizing managed filesystems")
+	}
+	resizeParams := make([]storage.FilesystemResizeParams, 0, len(ops))
+	volumeTags := make([]names.VolumeTag, 0, len(ops))
+	for _, op := range ops {
+		resizeParams = append(resizeParams, op.args)
+		volumeTags = append(volumeTags, op.args.Volume)
+	}
+	// The block devices of the backing volumes must be refreshed,
+	// so that we observe their new sizes.
+	if err := refreshVolumeBlockDevices(ctx, volumeTags); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("resizing filesystems: %+v", resizeParams)
+	results, err := resizer.ResizeFilesystems(resizeParams)
+	if err != nil {
+		return errors.Annotate(err, "resizing filesystems")
+	}
+	var reschedule []scheduleOp
+	var filesystems []storage.Filesystem
+	for i, result := range results {
+		p := resizeParams[i]
+		if result.Error != nil {
+			// The backing volume may not have been resized
+			// yet, so we reschedule the filesystem resize.
+			reschedule = append(reschedule, ops[p.Tag])
+			logger.Debugf(
+				"failed to resize %s: %v",
+				names.ReadableString(p.Tag), result.Error,
+			)
+			continue
+		}
+		filesystems = append(filesystems, *result.Filesystem)
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(filesystems) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystemsFromStorage(filesystems))
+	if err != nil {
+		return errors.Annotate(err, "publishing resized filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing resized filesystem %s to state: %v",
+				filesystems[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, f := range filesystems {
+		updateFilesystem(ctx, f)
+	}
+	return nil
+}
+
 // attachFilesystems creates filesystem attachments with the specified parameters.
 func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFilesystemOp) error {
 	filesystemAttachmentParams := make([]storage.FilesystemAttachmentParams, 0, len(ops))
@@ -532,3 +594,20 @@ func (op *detachFilesystemOp) key() interface{} {
 		AttachmentTag: op.args.Filesystem.String(),
 	}
 }
+
+// resizeFilesystemKey is the schedule key for a resizeFilesystemOp.
+// Filesystem resizes are keyed separately from the filesystem's other
+// operations, as a provisioned filesystem may be resized and destroyed
+// concurrently.
+type resizeFilesystemKey struct {
+	tag names.FilesystemTag
+}
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	args storage.FilesystemResizeParams
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	return resizeFilesystemKey{op.args.Tag}
+}
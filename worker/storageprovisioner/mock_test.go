@@ -123,15 +123,18 @@ func newMockModelAccessor(c *gc.C) *mockModelAccessor {
 
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
+	resizesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	pendingResizes         map[string]params.VolumeResizeParams
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	cancelVolumeResizes     func([]params.VolumeResizeParams) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -149,6 +152,10 @@ func (w *mockVolumeAccessor) WatchVolumes() (watcher.StringsWatcher, error) {
 	return w.volumesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error) {
 	return w.attachmentsWatcher, nil
 }
@@ -244,6 +251,18 @@ func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageI
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range volumes {
+		var r params.VolumeResizeParamsResult
+		if resizeParams, ok := v.pendingResizes[tag.String()]; ok {
+			r.Result = &resizeParams
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	if v.setVolumeInfo != nil {
 		return v.setVolumeInfo(volumes)
@@ -258,24 +277,35 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return make([]params.ErrorResult, len(volumeAttachments)), nil
 }
 
+func (v *mockVolumeAccessor) CancelVolumeResizes(resizes []params.VolumeResizeParams) ([]params.ErrorResult, error) {
+	if v.cancelVolumeResizes != nil {
+		return v.cancelVolumeResizes(resizes)
+	}
+	return make([]params.ErrorResult, len(resizes)), nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		pendingResizes:         make(map[string]params.VolumeResizeParams),
 	}
 }
 
 type mockFilesystemAccessor struct {
 	filesystemsWatcher     *mockStringsWatcher
+	resizesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedFilesystems map[string]params.Filesystem
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
+	pendingResizes         map[string]params.FilesystemResizeParams
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
@@ -300,6 +330,10 @@ func (w *mockFilesystemAccessor) WatchFilesystemAttachments() (watcher.MachineSt
 	return w.attachmentsWatcher, nil
 }
 
+func (w *mockFilesystemAccessor) WatchFilesystemResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (v *mockFilesystemAccessor) Filesystems(filesystems []names.FilesystemTag) ([]params.FilesystemResult, error) {
 	var result []params.FilesystemResult
 	for _, tag := range filesystems {
@@ -368,6 +402,18 @@ func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.Machine
 	return result, nil
 }
 
+func (f *mockFilesystemAccessor) FilesystemResizeParams(filesystems []names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error) {
+	var result []params.FilesystemResizeParamsResult
+	for _, tag := range filesystems {
+		var r params.FilesystemResizeParamsResult
+		if resizeParams, ok := f.pendingResizes[tag.String()]; ok {
+			r.Result = &resizeParams
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
 func (f *mockFilesystemAccessor) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	if f.setFilesystemInfo != nil {
 		return f.setFilesystemInfo(filesystems)
@@ -385,10 +431,12 @@ func (f *mockFilesystemAccessor) SetFilesystemAttachmentInfo(filesystemAttachmen
 func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
+		pendingResizes:         make(map[string]params.FilesystemResizeParams),
 	}
 }
 
@@ -463,6 +511,7 @@ type dummyProvider struct {
 	detachVolumesFunc            func([]storage.VolumeAttachmentParams) ([]error, error)
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
@@ -539,6 +588,24 @@ func (s *dummyVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error)
 	return make([]error, len(volumeIds)), nil
 }
 
+// ResizeVolumes resizes volumes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider != nil && s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Volume = &storage.Volume{
+			p.Tag,
+			storage.VolumeInfo{
+				Size:     p.Size,
+				VolumeId: p.VolumeId,
+			},
+		}
+	}
+	return results, nil
+}
+
 // AttachVolumes attaches volumes to machines.
 func (s *dummyVolumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	if s.provider != nil && s.provider.attachVolumesFunc != nil {
@@ -707,6 +774,30 @@ func (s *mockManagedFilesystemSource) AttachFilesystems(args []storage.Filesyste
 	return results, nil
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		blockDevice, ok := s.blockDevices[arg.Volume]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v's backing-volume is not attached", arg.Tag.Id())
+			continue
+		}
+		if blockDevice.Size < arg.Size {
+			results[i].Error = errors.Errorf("filesystem %v's backing-volume is not yet resized", arg.Tag.Id())
+			continue
+		}
+		results[i].Filesystem = &storage.Filesystem{
+			Tag:    arg.Tag,
+			Volume: arg.Volume,
+			FilesystemInfo: storage.FilesystemInfo{
+				Size:         blockDevice.Size,
+				FilesystemId: arg.FilesystemId,
+			},
+		}
+	}
+	return results, nil
+}
+
 func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.FilesystemAttachmentParams) ([]error, error) {
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
@@ -61,6 +61,11 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, that may require the
+	// volumes to be resized.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -80,9 +85,17 @@ type VolumeAccessor interface {
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
 
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
 	// SetVolumeInfo records the details of newly provisioned volumes.
 	SetVolumeInfo([]params.Volume) ([]params.ErrorResult, error)
 
+	// CancelVolumeResizes cancels the specified pending volume resizes,
+	// which the volumes' storage providers cannot carry out.
+	CancelVolumeResizes([]params.VolumeResizeParams) ([]params.ErrorResult, error)
+
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
@@ -99,6 +112,11 @@ type FilesystemAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchFilesystemAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchFilesystemResizes watches for changes to filesystems that
+	// this storage provisioner is responsible for, that may require
+	// the filesystems to be resized.
+	WatchFilesystemResizes() (watcher.StringsWatcher, error)
+
 	// Filesystems returns details of filesystems with the specified tags.
 	Filesystems([]names.FilesystemTag) ([]params.FilesystemResult, error)
 
@@ -114,6 +132,10 @@ type FilesystemAccessor interface {
 	// filesystem attachments with the specified tags.
 	FilesystemAttachmentParams([]params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error)
 
+	// FilesystemResizeParams returns the parameters for resizing the
+	// filesystems with the specified tags.
+	FilesystemResizeParams([]names.FilesystemTag) ([]params.FilesystemResizeParamsResult, error)
+
 	// SetFilesystemInfo records the details of newly provisioned filesystems.
 	SetFilesystemInfo([]params.Filesystem) ([]params.ErrorResult, error)
 
@@ -211,6 +233,8 @@ func (w *storageProvisioner) Wait() error {
 func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		filesystemResizesChanges     watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
@@ -258,6 +282,29 @@ func (w *storageProvisioner) loop() error {
 		}
 		filesystemsChanges = filesystemsWatcher.Changes()
 
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+		if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		}
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+
+		// Only machine-scoped provisioners resize filesystems, as
+		// only volume-backed filesystems, which are managed by the
+		// machine, can be resized.
+		if _, ok := w.config.Scope.(names.MachineTag); ok {
+			filesystemResizesWatcher, err := w.config.Filesystems.WatchFilesystemResizes()
+			if err != nil {
+				return errors.Annotate(err, "watching filesystem resizes")
+			}
+			if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			filesystemResizesChanges = filesystemResizesWatcher.Changes()
+		}
+
 		volumeAttachmentsWatcher, err := w.config.Volumes.WatchVolumeAttachments()
 		if err != nil {
 			return errors.Annotate(err, "watching volume attachments")
@@ -332,6 +379,13 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
@@ -346,6 +400,13 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-filesystemAttachmentsChanges:
 			if !ok {
 				return errors.New("filesystem attachments watcher closed")
@@ -380,10 +441,12 @@ func processSchedule(ctx *context) error {
 	destroyVolumeOps := make(map[names.VolumeTag]*destroyVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	destroyFilesystemOps := make(map[names.FilesystemTag]*destroyFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -396,6 +459,8 @@ func processSchedule(ctx *context) error {
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
 			detachVolumeOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
 		case *createFilesystemOp:
 			createFilesystemOps[key.(names.FilesystemTag)] = op
 		case *destroyFilesystemOp:
@@ -404,6 +469,8 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[op.args.Tag] = op
 		}
 	}
 	if len(destroyVolumeOps) > 0 {
@@ -426,6 +493,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
 	if len(destroyFilesystemOps) > 0 {
 		if err := destroyFilesystems(ctx, destroyFilesystemOps); err != nil {
 			return errors.Annotate(err, "destroying filesystems")
@@ -446,6 +518,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
 	return nil
 }
 
@@ -271,6 +271,203 @@ func (s *storageProvisionerSuite) TestCreateVolumeRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestResizeVolume(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedVolumes["volume-1"] = params.Volume{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId:   "vol-1",
+			HardwareId: "serial-1",
+			Size:       1024,
+			Persistent: true,
+		},
+	}
+	volumeAccessor.pendingResizes["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		// Only the size of the volume is changed.
+		c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+			VolumeTag: "volume-1",
+			Info: params.VolumeInfo{
+				VolumeId:   "vol-1",
+				HardwareId: "serial-1",
+				Size:       2048,
+				Persistent: true,
+			},
+		}})
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeRetry(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.pendingResizes["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	clock := &mockClock{}
+	var resizeVolumeTimes []time.Time
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeVolumeTimes = append(resizeVolumeTimes, clock.Now())
+		if len(resizeVolumeTimes) < 3 {
+			return []storage.ResizeVolumesResult{{Error: errors.New("badness")}}, nil
+		}
+		return []storage.ResizeVolumesResult{{
+			Volume: &storage.Volume{Tag: args[0].Tag, VolumeInfo: storage.VolumeInfo{Size: args[0].Size}},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(resizeVolumeTimes, jc.DeepEquals, []time.Time{
+		time.Time{},
+		time.Time{}.Add(30 * time.Second),
+		time.Time{}.Add(90 * time.Second),
+	})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeNotSupported(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.pendingResizes["volume-1"] = params.VolumeResizeParams{
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Size:      2048,
+		Provider:  "dummy",
+	}
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+	cancelled := make(chan interface{}, 1)
+	volumeAccessor.cancelVolumeResizes = func(resizes []params.VolumeResizeParams) ([]params.ErrorResult, error) {
+		cancelled <- resizes
+		return make([]params.ErrorResult, len(resizes)), nil
+	}
+
+	resized := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resized <- nil
+		return []storage.ResizeVolumesResult{{
+			Error: errors.NotSupportedf("resizing volumes"),
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, resized, "waiting for volume to be resized")
+
+	// The resize is cancelled rather than retried, and no volume
+	// info is set.
+	resizes := waitChannel(c, cancelled, "waiting for volume resize to be cancelled")
+	c.Assert(resizes, jc.DeepEquals, []params.VolumeResizeParams{
+		volumeAccessor.pendingResizes["volume-1"],
+	})
+	assertNoEvent(c, resized, "volume resize retried")
+	assertNoEvent(c, volumeInfoSet, "volume info set")
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-1",
+		Status: "error",
+		Info:   "resizing volumes not supported",
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeFilesystem(c *gc.C) {
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.pendingResizes["filesystem-0-0"] = params.FilesystemResizeParams{
+		FilesystemTag: "filesystem-0-0",
+		FilesystemId:  "xvdf1",
+		VolumeTag:     "volume-0-0",
+		Size:          2048,
+	}
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		defer close(filesystemInfoSet)
+		c.Assert(filesystems, jc.DeepEquals, []params.Filesystem{{
+			FilesystemTag: "filesystem-0-0",
+			VolumeTag:     "volume-0-0",
+			Info: params.FilesystemInfo{
+				FilesystemId: "xvdf1",
+				Size:         2048,
+			},
+		}})
+		return make([]params.ErrorResult, len(filesystems)), nil
+	}
+
+	// The backing volume's block device is initially the old
+	// size, so the filesystem resize must be retried.
+	volumeAccessor := newMockVolumeAccessor()
+	blockDeviceId := params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}
+	volumeAccessor.blockDevices[blockDeviceId] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       1024,
+	}
+
+	clock := &mockClock{}
+	clock.onAfter = func(d time.Duration) <-chan time.Time {
+		volumeAccessor.blockDevices[blockDeviceId] = storage.BlockDevice{
+			DeviceName: "xvdf1",
+			Size:       2048,
+		}
+		clock.now = clock.now.Add(d)
+		ch := make(chan time.Time, 1)
+		ch <- clock.now
+		return ch
+	}
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		volumes:     volumeAccessor,
+		filesystems: filesystemAccessor,
+		clock:       clock,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.resizesWatcher.changes <- []string{"0/0"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
+}
+
 func (s *storageProvisionerSuite) TestCreateFilesystemRetry(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
@@ -954,6 +1151,48 @@ func (s *storageProvisionerSuite) TestFilesystemAttachmentAdded(c *gc.C) {
 	assertNoEvent(c, filesystemAttachmentInfoSet, "filesystem attachment info set")
 }
 
+func (s *storageProvisionerSuite) TestFilesystemAttachmentAddedFilesystemNotWatched(c *gc.C) {
+	// A filesystem which was detached from one machine may be attached
+	// to another without the filesystem watcher reporting a change. The
+	// filesystem's info must be fetched so that the attachment can be
+	// made.
+	filesystemAttachmentInfoSet := make(chan []params.FilesystemAttachment)
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(filesystemAttachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		filesystemAttachmentInfoSet <- filesystemAttachments
+		return make([]params.ErrorResult, len(filesystemAttachments)), nil
+	}
+	filesystemAccessor.provisionedFilesystems["filesystem-1"] = params.Filesystem{
+		FilesystemTag: "filesystem-1",
+		Info: params.FilesystemInfo{
+			FilesystemId: "fs-123",
+		},
+	}
+	filesystemAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+
+	args := &workerArgs{filesystems: filesystemAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	args.environ.watcher.changes <- struct{}{}
+	filesystemAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "filesystem-1",
+	}}
+	select {
+	case attachments := <-filesystemAttachmentInfoSet:
+		c.Assert(attachments, jc.DeepEquals, []params.FilesystemAttachment{{
+			FilesystemTag: "filesystem-1",
+			MachineTag:    "machine-1",
+			Info: params.FilesystemAttachmentInfo{
+				MountPoint: "/srv/fs-123",
+			},
+		}})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for filesystem attachment info to be set")
+	}
+}
+
 func (s *storageProvisionerSuite) TestCreateVolumeBackedFilesystem(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
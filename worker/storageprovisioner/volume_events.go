@@ -54,6 +54,46 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, and may need to be resized.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize parameters")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		// Remove any previously scheduled resize; it
+		// will be replaced with the latest parameters.
+		ctx.schedule.Remove(resizeVolumeKey{tags[i]})
+		if result.Error != nil {
+			if params.IsCodeNotFoundOrCodeUnauthorized(result.Error) {
+				// The volume has been removed.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		if result.Result == nil {
+			// There is no pending resize.
+			continue
+		}
+		args, err := volumeResizeParamsFromParams(*result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, &resizeVolumeOp{args: args})
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // volumeAttachmentsChanged is called when the lifecycle states of the volume
 // attachments with the provided IDs have been seen to have changed.
 func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -431,6 +471,28 @@ func volumeFromParams(in params.Volume) (storage.Volume, error) {
 	}, nil
 }
 
+func volumeResizeParamsFromParams(in params.VolumeResizeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Tag:      volumeTag,
+		VolumeId: in.VolumeId,
+		Size:     in.Size,
+		Provider: storage.ProviderType(in.Provider),
+	}, nil
+}
+
+func volumeResizeParamsToParams(in storage.VolumeResizeParams) params.VolumeResizeParams {
+	return params.VolumeResizeParams{
+		VolumeTag: in.Tag.String(),
+		VolumeId:  in.VolumeId,
+		Size:      in.Size,
+		Provider:  string(in.Provider),
+	}
+}
+
 func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error) {
 	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
 	if err != nil {
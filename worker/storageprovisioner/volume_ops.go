@@ -323,6 +323,132 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes resizes volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	resizeParams := make([]storage.VolumeResizeParams, 0, len(ops))
+	for _, op := range ops {
+		resizeParams = append(resizeParams, op.args)
+	}
+	paramsBySource, volumeSources, err := volumeResizeParamsBySource(
+		ctx.modelConfig, ctx.config.StorageDir, resizeParams,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var resized []storage.Volume
+	var cancelled []params.VolumeResizeParams
+	var statuses []params.EntityStatusArgs
+	for sourceName, resizeParams := range paramsBySource {
+		logger.Debugf("resizing volumes: %+v", resizeParams)
+		volumeSource := volumeSources[sourceName]
+		results, err := volumeSource.ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			p := resizeParams[i]
+			if result.Error == nil {
+				resized = append(resized, *result.Volume)
+				continue
+			}
+			if errors.IsNotSupported(result.Error) {
+				// There's no point retrying, so we cancel
+				// the resize and report the failure in the
+				// volume's status.
+				logger.Errorf(
+					"failed to resize %s: %v",
+					names.ReadableString(p.Tag), result.Error,
+				)
+				cancelled = append(cancelled, volumeResizeParamsToParams(p))
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.StatusError.String(),
+					Info:   result.Error.Error(),
+				})
+				continue
+			}
+			reschedule = append(reschedule, ops[p.Tag])
+			logger.Debugf(
+				"failed to resize %s: %v",
+				names.ReadableString(p.Tag), result.Error,
+			)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if err := cancelVolumeResizes(ctx, cancelled); err != nil {
+		return errors.Trace(err)
+	}
+	setStatus(ctx, statuses)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Only the size of a volume changes when it is resized, so we
+	// update the existing volume information with the new sizes.
+	tags := make([]names.VolumeTag, len(resized))
+	for i, v := range resized {
+		tags[i] = v.Tag
+	}
+	volumeResults, err := ctx.config.Volumes.Volumes(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]storage.Volume, 0, len(resized))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting information for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		volume, err := volumeFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volume.Size = resized[i].Size
+		volumes = append(volumes, volume)
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing resized volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing resized volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, v := range volumes {
+		updateVolume(ctx, v)
+	}
+	return nil
+}
+
+// cancelVolumeResizes cancels the pending resizes of volumes that
+// cannot be resized by their storage providers.
+func cancelVolumeResizes(ctx *context, resizes []params.VolumeResizeParams) error {
+	if len(resizes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.CancelVolumeResizes(resizes)
+	if err != nil {
+		return errors.Annotate(err, "cancelling volume resizes")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"cancelling resize of volume %s: %v",
+				resizes[i].VolumeTag, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	environConfig *config.Config,
@@ -363,6 +489,46 @@ func volumeParamsBySource(
 	return paramsBySource, volumeSources, nil
 }
 
+// volumeResizeParamsBySource separates the volume resize parameters by
+// volume source.
+func volumeResizeParamsBySource(
+	environConfig *config.Config,
+	baseStorageDir string,
+	params []storage.VolumeResizeParams,
+) (map[string][]storage.VolumeResizeParams, map[string]storage.VolumeSource, error) {
+	volumeSources := make(map[string]storage.VolumeSource)
+	for _, params := range params {
+		sourceName := string(params.Provider)
+		if _, ok := volumeSources[sourceName]; ok {
+			continue
+		}
+		volumeSource, err := volumeSource(
+			environConfig, baseStorageDir, sourceName, params.Provider,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			volumeSource = nil
+		} else if err != nil {
+			return nil, nil, errors.Annotate(err, "getting volume source")
+		}
+		volumeSources[sourceName] = volumeSource
+	}
+	paramsBySource := make(map[string][]storage.VolumeResizeParams)
+	for _, params := range params {
+		sourceName := string(params.Provider)
+		if volumeSources[sourceName] == nil {
+			// Volumes from non-dynamic sources
+			// cannot be managed after creation.
+			logger.Errorf(
+				"cannot resize %s: %q storage provider is not dynamic",
+				names.ReadableString(params.Tag), sourceName,
+			)
+			continue
+		}
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], params)
+	}
+	return paramsBySource, volumeSources, nil
+}
+
 // validateVolumeParams validates a collection of volume parameters.
 func validateVolumeParams(
 	volumeSource storage.VolumeSource, volumeParams []storage.VolumeParams,
@@ -482,3 +648,19 @@ func (op *detachVolumeOp) key() interface{} {
 		AttachmentTag: op.args.Volume.String(),
 	}
 }
+
+// resizeVolumeKey is the schedule key for a resizeVolumeOp. Volume
+// resizes are keyed separately from the volume's other operations, as
+// a provisioned volume may be resized and destroyed concurrently.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args storage.VolumeResizeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -20,6 +21,7 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+	Clock          clock.Clock
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
@@ -102,6 +104,7 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		actionId:      actionId,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		clock:         f.config.Clock,
 	}, nil
 }
 
@@ -5,8 +5,10 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/worker/uniter/runner"
 )
@@ -16,9 +18,11 @@ type runAction struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	clock         clock.Clock
 
-	name   string
-	runner runner.Runner
+	name    string
+	timeout time.Duration
+	runner  runner.Runner
 
 	RequiresMachineLock
 }
@@ -54,6 +58,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 		return nil, errors.Trace(err)
 	}
 	ra.name = actionData.Name
+	ra.timeout = actionData.Timeout
 	ra.runner = rnr
 	return stateChange{
 		Kind:     RunAction,
@@ -72,6 +77,11 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	if ra.timeout > 0 {
+		done := make(chan struct{})
+		defer close(done)
+		go ra.enforceTimeout(done)
+	}
 	err := ra.runner.RunAction(ra.name)
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
@@ -86,6 +96,20 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// enforceTimeout kills the action's process, failing the action, if it
+// is still running when its timeout expires. It returns early if done
+// is closed.
+func (ra *runAction) enforceTimeout(done <-chan struct{}) {
+	select {
+	case <-ra.clock.After(ra.timeout):
+		logger.Infof("action %s timed out after %v", ra.actionId, ra.timeout)
+		if err := ra.runner.Context().TimeOutAction(); err != nil {
+			logger.Errorf("cannot stop timed out action %s: %v", ra.actionId, err)
+		}
+	case <-done:
+	}
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
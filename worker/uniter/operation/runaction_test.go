@@ -4,12 +4,15 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -227,6 +230,66 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteTimeout(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	mockRunner := runnerFactory.MockNewActionRunner.runner
+	mockContext := mockRunner.context.(*MockContext)
+	mockContext.actionData.Timeout = time.Minute
+	mockContext.timedOut = make(chan struct{})
+	// The action only finishes once its process has been killed.
+	mockRunner.MockRunAction.block = mockContext.timedOut
+
+	clock := coretesting.NewClock(time.Time{})
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
+		Clock:         clock,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	done := make(chan error)
+	go func() {
+		_, err := op.Execute(*midState)
+		done <- err
+	}()
+	select {
+	case <-clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for timeout alarm")
+	}
+	clock.Advance(time.Minute)
+
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for action to be stopped")
+	}
+	mockContext.CheckCallNames(c, "Prepare", "TimeOutAction")
+}
+
+func (s *RunActionSuite) TestExecuteWithinTimeout(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	mockContext := runnerFactory.MockNewActionRunner.runner.context.(*MockContext)
+	mockContext.actionData.Timeout = time.Minute
+
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
+		Clock:         coretesting.NewClock(time.Time{}),
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	mockContext.CheckCallNames(c, "Prepare")
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -290,6 +290,7 @@ type MockContext struct {
 	actionData      *context.ActionData
 	setStatusCalled bool
 	status          jujuc.StatusInfo
+	timedOut        chan struct{}
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -326,13 +327,25 @@ func (mock *MockContext) Prepare() error {
 	return mock.NextErr()
 }
 
+func (mock *MockContext) TimeOutAction() error {
+	mock.MethodCall(mock, "TimeOutAction")
+	if mock.timedOut != nil {
+		close(mock.timedOut)
+	}
+	return mock.NextErr()
+}
+
 type MockRunAction struct {
 	gotName *string
 	err     error
+	block   <-chan struct{}
 }
 
 func (mock *MockRunAction) Call(actionName string) error {
 	mock.gotName = &actionName
+	if mock.block != nil {
+		<-mock.block
+	}
 	return mock.err
 }
 
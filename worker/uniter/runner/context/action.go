@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 )
 
@@ -12,7 +14,9 @@ type ActionData struct {
 	Name           string
 	Tag            names.ActionTag
 	Params         map[string]interface{}
+	Timeout        time.Duration
 	Failed         bool
+	TimedOut       bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
 }
@@ -202,6 +202,16 @@ type HookContext struct {
 	// hook run, so the actual add will happen in a flush.
 	storageAddConstraints map[string][]params.StorageConstraints
 
+	// unitState holds the key-value state stored by the unit's charm,
+	// including any changes made during the hook. It is read from the
+	// controller when first needed.
+	unitState map[string]string
+
+	// unitStateChanged is true if the unit state has been changed
+	// during the hook, in which case it is written on a successful
+	// hook run.
+	unitStateChanged bool
+
 	// clock is used for any time operations.
 	clock clock.Clock
 
@@ -384,6 +394,21 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// UnitWorkloadVersion is part of the jujuc.ContextVersion interface.
+func (ctx *HookContext) UnitWorkloadVersion() (string, error) {
+	version, err := ctx.unit.WorkloadVersion()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return version, nil
+}
+
+// SetUnitWorkloadVersion is part of the jujuc.ContextVersion interface.
+func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
+	err := ctx.unit.SetWorkloadVersion(version)
+	return errors.Trace(err)
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -434,8 +459,12 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	return ctx.OpenPortsForEndpoint("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -475,6 +504,69 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// GoalState is part of the jujuc.ContextUnit interface. The goal state
+// is not cached, so that it reflects units arriving during the hook.
+func (ctx *HookContext) GoalState() (params.GoalState, error) {
+	goalState, err := ctx.unit.GoalState()
+	if err != nil {
+		return params.GoalState{}, errors.Trace(err)
+	}
+	return goalState, nil
+}
+
+// UnitState is part of the jujuc.ContextUnitState interface.
+func (ctx *HookContext) UnitState() (map[string]string, error) {
+	if err := ctx.ensureUnitState(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(ctx.unitState))
+	for key, value := range ctx.unitState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// SetUnitStateValue is part of the jujuc.ContextUnitState interface.
+func (ctx *HookContext) SetUnitStateValue(key, value string) error {
+	if err := ctx.ensureUnitState(); err != nil {
+		return errors.Trace(err)
+	}
+	if current, ok := ctx.unitState[key]; ok && current == value {
+		return nil
+	}
+	ctx.unitState[key] = value
+	ctx.unitStateChanged = true
+	return nil
+}
+
+// DeleteUnitStateValue is part of the jujuc.ContextUnitState interface.
+func (ctx *HookContext) DeleteUnitStateValue(key string) error {
+	if err := ctx.ensureUnitState(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := ctx.unitState[key]; !ok {
+		return nil
+	}
+	delete(ctx.unitState, key)
+	ctx.unitStateChanged = true
+	return nil
+}
+
+func (ctx *HookContext) ensureUnitState() error {
+	if ctx.unitState != nil {
+		return nil
+	}
+	state, err := ctx.unit.State()
+	if err != nil {
+		return errors.Annotate(err, "cannot read unit state")
+	}
+	if state == nil {
+		state = make(map[string]string)
+	}
+	ctx.unitState = state
+	return nil
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
@@ -509,6 +601,34 @@ func (ctx *HookContext) SetActionFailed() error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the running action.
+// Unlike results, the message is sent to the controller immediately.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	err := ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+	return errors.Trace(err)
+}
+
+// TimeOutAction marks the running action as failed because it ran for
+// longer than its timeout, and kills the process running it.
+func (ctx *HookContext) TimeOutAction() error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	mutex.Lock()
+	ctx.actionData.TimedOut = true
+	mutex.Unlock()
+	return ctx.killCharmHook()
+}
+
+func (ctx *HookContext) actionTimedOut() bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return ctx.actionData.TimedOut
+}
+
 // UpdateActionResults inserts new values for use with action-set and
 // action-fail.  The results struct will be delivered to the controller
 // upon completion of the Action.  It returns an error if not called on an
@@ -677,7 +797,8 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 			var e error
 			var op string
 			if rangeInfo.ShouldOpen {
-				e = ctx.unit.OpenPorts(
+				e = ctx.unit.OpenPortsForEndpoint(
+					rangeInfo.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
@@ -713,6 +834,17 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	if ctx.unitStateChanged && writeChanges {
+		err := ctx.unit.SetState(ctx.unitState)
+		if err != nil {
+			err = errors.Annotatef(err, "cannot write unit state")
+			logger.Errorf("%v", err)
+			if ctxErr == nil {
+				ctxErr = err
+			}
+		}
+	}
+
 	// TODO (tasdomas) 2014 09 03: context finalization needs to modified to apply all
 	//                             changes in one api call to minimize the risk
 	//                             of partial failures.
@@ -746,6 +878,12 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		}
 		status = params.ActionFailed
 	}
+	// An action killed for running too long fails however the process
+	// running it exited.
+	if ctx.actionTimedOut() {
+		message = "timed out"
+		status = params.ActionFailed
+	}
 
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
@@ -135,6 +135,19 @@ func (s *InterfaceSuite) TestSetUnitStatusUpdatesFlag(c *gc.C) {
 	c.Assert(ctx.(runner.Context).HasExecutionSetUnitStatus(), jc.IsTrue)
 }
 
+func (s *InterfaceSuite) TestSetUnitWorkloadVersion(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	version, err := ctx.UnitWorkloadVersion()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "")
+
+	err = ctx.SetUnitWorkloadVersion("4.5.3")
+	c.Check(err, jc.ErrorIsNil)
+	version, err = ctx.UnitWorkloadVersion()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, "4.5.3")
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -216,6 +229,10 @@ func (s *InterfaceSuite) TestNonActionCallsToActionMethodsFail(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "not running an action")
 	err = ctx.UpdateActionResults([]string{"1", "2", "3"}, "value")
 	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.TimeOutAction()
+	c.Check(err, gc.ErrorMatches, "not running an action")
+	err = ctx.LogActionMessage("foo")
+	c.Check(err, gc.ErrorMatches, "not running an action")
 }
 
 // TestUpdateActionResults demonstrates that UpdateActionResults functions
@@ -278,6 +295,17 @@ func (s *InterfaceSuite) TestSetActionFailed(c *gc.C) {
 	c.Check(actionData.Failed, jc.IsTrue)
 }
 
+// TestTimeOutAction ensures TimeOutAction marks the action as timed
+// out even when there is no process to kill.
+func (s *InterfaceSuite) TestTimeOutAction(c *gc.C) {
+	hctx := context.GetStubActionContext(nil)
+	err := hctx.TimeOutAction()
+	c.Assert(err, gc.Equals, context.ErrNoProcess)
+	actionData, err := hctx.ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actionData.TimedOut, jc.IsTrue)
+}
+
 // TestSetActionMessage ensures SetActionMessage works properly.
 func (s *InterfaceSuite) TestSetActionMessage(c *gc.C) {
 	hctx := context.GetStubActionContext(nil)
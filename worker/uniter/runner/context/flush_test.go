@@ -207,6 +207,49 @@ func (s *FlushContextSuite) TestRunHookAddUnitStorageOnSuccess(c *gc.C) {
 	c.Assert(all, gc.HasLen, 0)
 }
 
+func (s *FlushContextSuite) TestRunHookUnitStateOnFailure(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"a": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetUnitStateValue("b", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteUnitStateValue("a")
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err := ctx.UnitState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"b": "2"})
+
+	// Flush the context with an error.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	// Check that the changes have not been written to state.
+	unitState, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"a": "1"})
+}
+
+func (s *FlushContextSuite) TestRunHookUnitStateOnSuccess(c *gc.C) {
+	err := s.unit.SetState(map[string]string{"a": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetUnitStateValue("b", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteUnitStateValue("a")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with a success.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check that the changes have been written to state.
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, map[string]string{"b": "2"})
+}
+
 func (s *HookContextSuite) context(c *gc.C) *context.HookContext {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
@@ -135,6 +135,7 @@ func makePendingPorts(
 
 type portsTest struct {
 	about         string
+	endpoint      string
 	proto         string
 	ports         []int
 	machinePorts  map[network.PortRange]params.RelationUnit
@@ -192,6 +193,13 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:         "open a range conflicting with the same unit (ignored)",
 		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
 		expectPending: map[context.PortRange]context.PortRangeInfo{},
+	}, {
+		about:    "open a new range for an endpoint",
+		endpoint: "website",
+		expectPending: map[context.PortRange]context.PortRangeInfo{{
+			Ports:      network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"},
+			RelationId: -1,
+		}: {ShouldOpen: true, Endpoint: "website"}},
 	}, {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
@@ -202,6 +210,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryOpenPorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
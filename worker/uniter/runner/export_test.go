@@ -4,6 +4,8 @@
 package runner
 
 import (
+	"os/exec"
+
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
 
@@ -12,8 +14,13 @@ var (
 	SearchHook              = searchHook
 	HookCommand             = hookCommand
 	LookPath                = lookPath
+	SetProcessGroup         = setProcessGroup
 )
 
+func NewHookProcess(cmd *exec.Cmd) context.HookProcess {
+	return hookProcess{cmd.Process}
+}
+
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
@@ -44,6 +44,8 @@ type Context interface {
 // common to all charm hooks.
 type HookContext interface {
 	ContextUnit
+	ContextUnitState
+	ContextVersion
 	ContextStatus
 	ContextInstance
 	ContextNetworking
@@ -96,6 +98,11 @@ type actionHookContext interface {
 
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
+
+	// LogActionMessage records a progress message for the Action. The
+	// message is sent to the controller immediately, so that it can
+	// be seen while the Action is still running.
+	LogActionMessage(string) error
 }
 
 // ContextUnit is the part of a hook context related to the unit.
@@ -105,6 +112,37 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// GoalState returns the units the executing unit's application is
+	// expected to have, and the units expected on each of its relations.
+	GoalState() (params.GoalState, error)
+}
+
+// ContextUnitState is the part of a hook context related to the
+// key-value state stored by the unit's charm.
+type ContextUnitState interface {
+	// UnitState returns the unit's stored key-value state, including
+	// any changes made in this context.
+	UnitState() (map[string]string, error)
+
+	// SetUnitStateValue sets the value for a key in the unit's state.
+	// The change is written to the controller when the hook commits.
+	SetUnitStateValue(key, value string) error
+
+	// DeleteUnitStateValue removes a key from the unit's state. The
+	// change is written to the controller when the hook commits.
+	DeleteUnitStateValue(key string) error
+}
+
+// ContextVersion is the part of a hook context related to the
+// version of the workload run by the unit.
+type ContextVersion interface {
+	// UnitWorkloadVersion returns the currently set workload version for
+	// the unit.
+	UnitWorkloadVersion() (string, error)
+
+	// SetUnitWorkloadVersion updates the workload version for the unit.
+	SetUnitWorkloadVersion(string) error
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -148,6 +186,11 @@ type ContextNetworking interface {
 	// executing unit's service is exposed.
 	OpenPorts(protocol string, fromPort, toPort int) error
 
+	// OpenPortsForEndpoint marks the supplied port range for opening
+	// for the named endpoint when the executing unit's service is
+	// exposed. The endpoint's expose settings then apply to the range.
+	OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error
+
 	// ClosePorts ensures the supplied port range is closed even when
 	// the executing unit's service is exposed (unless it is opened
 	// separately by a co- located unit).
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// GoalStateCommand implements the goal-state command.
+type GoalStateCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewGoalStateCommand makes a goal-state command.
+func NewGoalStateCommand(ctx Context) (cmd.Command, error) {
+	return &GoalStateCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *GoalStateCommand) Info() *cmd.Info {
+	doc := `
+goal-state prints the units this unit's application is expected to
+have, and the units expected on each of its relations, keyed by the
+relation's endpoint name. Each unit is reported with one of the
+following statuses:
+
+    waiting  the unit has not yet been deployed
+    joining  the unit is running but has not yet joined the relation
+    active   the unit is running and, for related units, has joined
+             the relation
+    dying    the unit is going away
+
+Charms can use this to defer work, such as forming a cluster, until
+all of the expected units have arrived.
+`
+	return &cmd.Info{
+		Name:    "goal-state",
+		Purpose: "print the status of the charm's peers and related units",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *GoalStateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"json": cmd.FormatJson,
+		"yaml": cmd.FormatYaml,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *GoalStateCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *GoalStateCommand) Run(ctx *cmd.Context) error {
+	goalState, err := c.ctx.GoalState()
+	if err != nil {
+		return errors.Annotate(err, "cannot get goal state")
+	}
+	return c.out.Write(ctx, formatGoalState(goalState))
+}
+
+// goalStateStatus is the formatted status of a unit in the goal state.
+type goalStateStatus struct {
+	Status string `json:"status" yaml:"status"`
+}
+
+// formattedGoalState is the goal state as written by goal-state.
+type formattedGoalState struct {
+	Units     map[string]goalStateStatus            `json:"units" yaml:"units"`
+	Relations map[string]map[string]goalStateStatus `json:"relations" yaml:"relations"`
+}
+
+func formatGoalState(goalState params.GoalState) formattedGoalState {
+	result := formattedGoalState{
+		Units:     formatUnitsGoalState(goalState.Units),
+		Relations: make(map[string]map[string]goalStateStatus),
+	}
+	for endpoint, units := range goalState.Relations {
+		result.Relations[endpoint] = formatUnitsGoalState(units)
+	}
+	return result
+}
+
+func formatUnitsGoalState(units params.UnitsGoalState) map[string]goalStateStatus {
+	result := make(map[string]goalStateStatus)
+	for name, unit := range units {
+		result[name] = goalStateStatus{Status: unit.Status}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type goalStateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&goalStateSuite{})
+
+func (s *goalStateSuite) run(c *gc.C, args ...string) (int, *cmd.Context) {
+	hctx := s.newHookContext(c)
+	hctx.info.Unit.GoalState = params.GoalState{
+		Units: params.UnitsGoalState{
+			"mysql/0": {Status: "active"},
+			"mysql/1": {Status: "waiting"},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"server": {
+				"wordpress/0": {Status: "joining"},
+			},
+		},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return code, ctx
+}
+
+func (s *goalStateSuite) TestGoalStateYAML(c *gc.C) {
+	code, ctx := s.run(c)
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `
+units:
+  mysql/0:
+    status: active
+  mysql/1:
+    status: waiting
+relations:
+  server:
+    wordpress/0:
+      status: joining
+`[1:])
+}
+
+func (s *goalStateSuite) TestGoalStateJSON(c *gc.C) {
+	code, ctx := s.run(c, "--format", "json")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), jc.JSONEquals, map[string]interface{}{
+		"units": map[string]interface{}{
+			"mysql/0": map[string]interface{}{"status": "active"},
+			"mysql/1": map[string]interface{}{"status": "waiting"},
+		},
+		"relations": map[string]interface{}{
+			"server": map[string]interface{}{
+				"wordpress/0": map[string]interface{}{"status": "joining"},
+			},
+		},
+	})
+}
+
+func (s *goalStateSuite) TestGoalStateExtraArgs(c *gc.C) {
+	code, ctx := s.run(c, "foo")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `error: unrecognized args: ["foo"]`+"\n")
+}
+
+func (s *goalStateSuite) TestGoalStateError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	code, ctx := s.run(c)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot get goal state: boom\n")
+}
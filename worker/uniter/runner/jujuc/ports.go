@@ -110,7 +110,11 @@ type portCommand struct {
 	Protocol   string
 	FromPort   int
 	ToPort     int
+	Endpoint   string
 	formatFlag string // deprecated
+
+	// withEndpoint is true for commands accepting --endpoint.
+	withEndpoint bool
 }
 
 func (c *portCommand) Info() *cmd.Info {
@@ -119,6 +123,9 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	if c.withEndpoint {
+		f.StringVar(&c.Endpoint, "endpoint", "", "open the port range for the named endpoint only")
+	}
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -148,13 +155,22 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the service is exposed.",
+	Doc: `
+The port range will only be open while the service is exposed.
+
+When --endpoint is given, the port range is opened for that endpoint
+and is only reachable from the sources the endpoint is exposed to.
+`,
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
-		info: openPortInfo,
+		info:         openPortInfo,
+		withEndpoint: true,
 		action: func(c *portCommand) error {
+			if c.Endpoint != "" {
+				return ctx.OpenPortsForEndpoint(c.Endpoint, c.Protocol, c.FromPort, c.ToPort)
+			}
 			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
 		},
 	}, nil
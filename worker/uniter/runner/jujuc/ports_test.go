@@ -126,6 +126,9 @@ register a port or range to open
 
 Details:
 The port range will only be open while the service is exposed.
+
+When --endpoint is given, the port range is opened for that endpoint
+and is only reachable from the sources the endpoint is exposed to.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -138,6 +141,26 @@ ensure a port or range is always closed
 `[1:])
 }
 
+func (s *PortsSuite) TestOpenPortForEndpoint(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("open-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--endpoint", "website", "80/tcp"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	hctx.info.CheckPorts(c, makeRanges("80/tcp"))
+	s.Stub.CheckCall(c, 0, "OpenPortsForEndpoint", "website", "tcp", 80, 80)
+}
+
+func (s *PortsSuite) TestClosePortRejectsEndpoint(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("close-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = testing.InitCommand(com, []string{"--endpoint", "website", "80"})
+	c.Assert(err, gc.ErrorMatches, "flag provided but not defined: --endpoint")
+}
+
 // Since the deprecation warning gets output during Run, we really need
 // some valid commands to run
 var portsFormatDeprectaionTests = []struct {
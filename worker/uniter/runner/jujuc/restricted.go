@@ -25,6 +25,26 @@ type RestrictedContext struct{}
 // ConfigSettings implements jujuc.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// GoalState implements jujuc.Context.
+func (*RestrictedContext) GoalState() (params.GoalState, error) {
+	return params.GoalState{}, ErrRestrictedContext
+}
+
+// UnitState implements jujuc.Context.
+func (*RestrictedContext) UnitState() (map[string]string, error) { return nil, ErrRestrictedContext }
+
+// SetUnitStateValue implements jujuc.Context.
+func (*RestrictedContext) SetUnitStateValue(string, string) error { return ErrRestrictedContext }
+
+// DeleteUnitStateValue implements jujuc.Context.
+func (*RestrictedContext) DeleteUnitStateValue(string) error { return ErrRestrictedContext }
+
+// UnitWorkloadVersion implements jujuc.Context.
+func (*RestrictedContext) UnitWorkloadVersion() (string, error) { return "", ErrRestrictedContext }
+
+// SetUnitWorkloadVersion implements jujuc.Context.
+func (*RestrictedContext) SetUnitWorkloadVersion(string) error { return ErrRestrictedContext }
+
 // UnitStatus implements jujuc.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) { return nil, ErrRestrictedContext }
 
@@ -56,6 +76,11 @@ func (*RestrictedContext) OpenPorts(protocol string, fromPort, toPort int) error
 	return ErrRestrictedContext
 }
 
+// OpenPortsForEndpoint implements jujuc.Context.
+func (*RestrictedContext) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
 // ClosePorts implements jujuc.Context.
 func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) error {
 	return ErrRestrictedContext
@@ -133,6 +158,9 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
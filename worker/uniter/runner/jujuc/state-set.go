@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// stateSetCommand implements the state-set command.
+type stateSetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	settings map[string]string
+}
+
+// NewStateSetCommand returns a new stateSetCommand with the given context.
+func NewStateSetCommand(ctx Context) (cmd.Command, error) {
+	return &stateSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateSetCommand) Info() *cmd.Info {
+	doc := `
+state-set stores the supplied key/value pairs in the unit's state. The
+values are written to the controller when the hook completes
+successfully, along with the hook's other changes; if the hook fails,
+they are discarded. Setting a key to an empty value removes it.
+`
+	return &cmd.Info{
+		Name:    "state-set",
+		Args:    "<key>=<value> [...]",
+		Purpose: "set unit state",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateSetCommand) Init(args []string) (err error) {
+	c.settings, err = keyvalues.Parse(args, true)
+	if err == nil && len(c.settings) == 0 {
+		err = errors.New("no key/value pairs specified")
+	}
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateSetCommand) Run(_ *cmd.Context) error {
+	keys := make([]string, 0, len(c.settings))
+	for key := range c.settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var err error
+		if value := c.settings[key]; value == "" {
+			err = c.ctx.DeleteUnitStateValue(key)
+		} else {
+			err = c.ctx.SetUnitStateValue(key, value)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "cannot set unit state")
+		}
+	}
+	return nil
+}
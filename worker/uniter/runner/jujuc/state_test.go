@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateCommandsSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateCommandsSuite{})
+
+func (s *stateCommandsSuite) newContext(c *gc.C) *Context {
+	hctx := s.newHookContext(c)
+	hctx.info.UnitState.State = map[string]string{
+		"db-host": "10.0.0.5",
+		"db-port": "5432",
+	}
+	return hctx
+}
+
+func (s *stateCommandsSuite) run(c *gc.C, hctx *Context, name string, args ...string) (int, *cmd.Context) {
+	com, err := jujuc.NewCommand(hctx, cmdString(name))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return code, ctx
+}
+
+func (s *stateCommandsSuite) TestStateGetKey(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "state-get", "db-host")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "10.0.0.5\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *stateCommandsSuite) TestStateGetMissingKey(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "state-get", "unknown")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *stateCommandsSuite) TestStateGetAll(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "state-get", "--format", "json")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), jc.JSONEquals, map[string]string{
+		"db-host": "10.0.0.5",
+		"db-port": "5432",
+	})
+}
+
+func (s *stateCommandsSuite) TestStateGetInvalidKey(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "state-get", "x=y")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `error: invalid key "x=y"`+"\n")
+}
+
+func (s *stateCommandsSuite) TestStateGetError(c *gc.C) {
+	hctx := s.newContext(c)
+	s.Stub.SetErrors(errors.New("zap"))
+	code, ctx := s.run(c, hctx, "state-get")
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read unit state: zap\n")
+}
+
+func (s *stateCommandsSuite) TestStateSet(c *gc.C) {
+	hctx := s.newContext(c)
+	code, ctx := s.run(c, hctx, "state-set", "db-host=10.0.0.6", "db-port=", "db-name=wiki")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.UnitState.State, jc.DeepEquals, map[string]string{
+		"db-host": "10.0.0.6",
+		"db-name": "wiki",
+	})
+	s.Stub.CheckCallNames(c, "SetUnitStateValue", "SetUnitStateValue", "DeleteUnitStateValue")
+}
+
+func (s *stateCommandsSuite) TestStateSetNoArgs(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "state-set")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: no key/value pairs specified\n")
+}
+
+func (s *stateCommandsSuite) TestStateSetBadArg(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "state-set", "nonsense")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `error: expected "key=value", got "nonsense"`+"\n")
+}
+
+func (s *stateCommandsSuite) TestStateSetError(c *gc.C) {
+	hctx := s.newContext(c)
+	s.Stub.SetErrors(errors.New("splat"))
+	code, ctx := s.run(c, hctx, "state-set", "a=b")
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot set unit state: splat\n")
+}
+
+func (s *stateCommandsSuite) TestStateDelete(c *gc.C) {
+	hctx := s.newContext(c)
+	code, ctx := s.run(c, hctx, "state-delete", "db-port", "unknown")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.UnitState.State, jc.DeepEquals, map[string]string{
+		"db-host": "10.0.0.5",
+	})
+	s.Stub.CheckCalls(c, []jujutesting.StubCall{
+		{"DeleteUnitStateValue", []interface{}{"db-port"}},
+		{"DeleteUnitStateValue", []interface{}{"unknown"}},
+	})
+}
+
+func (s *stateCommandsSuite) TestStateDeleteNoArgs(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "state-delete")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: no keys specified\n")
+}
@@ -9,7 +9,8 @@ import (
 
 // ActionHook holds the values for the hook context.
 type ActionHook struct {
-	ActionParams map[string]interface{}
+	ActionParams   map[string]interface{}
+	ActionMessages []string
 }
 
 // ContextActionHook is a test double for jujuc.ActionHookContext.
@@ -69,3 +70,17 @@ func (c *ContextActionHook) SetActionFailed() error {
 	}
 	return nil
 }
+
+// LogActionMessage implements jujuc.ActionHookContext.
+func (c *ContextActionHook) LogActionMessage(message string) error {
+	c.stub.AddCall("LogActionMessage", message)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.ActionParams == nil {
+		return errors.Errorf("not running an action")
+	}
+	c.info.ActionMessages = append(c.info.ActionMessages, message)
+	return nil
+}
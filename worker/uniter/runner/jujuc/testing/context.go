@@ -12,6 +12,8 @@ import (
 // ContextInfo holds the values for the hook context.
 type ContextInfo struct {
 	Unit
+	UnitState
+	Version
 	Status
 	Instance
 	NetworkInterface
@@ -51,6 +53,8 @@ type contextBase struct {
 // Context is a test double for jujuc.Context.
 type Context struct {
 	ContextUnit
+	ContextUnitState
+	ContextVersion
 	ContextStatus
 	ContextInstance
 	ContextNetworking
@@ -68,6 +72,10 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	var ctx Context
 	ctx.ContextUnit.stub = stub
 	ctx.ContextUnit.info = &info.Unit
+	ctx.ContextUnitState.stub = stub
+	ctx.ContextUnitState.info = &info.UnitState
+	ctx.ContextVersion.stub = stub
+	ctx.ContextVersion.info = &info.Version
 	ctx.ContextStatus.stub = stub
 	ctx.ContextStatus.info = &info.Status
 	ctx.ContextInstance.stub = stub
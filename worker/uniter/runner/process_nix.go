@@ -0,0 +1,27 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup arranges for the command to be started in a new
+// process group, so that any processes it starts can be killed with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// Kill kills the process, along with every process in its process
+// group if it leads one. Killing only the process would leave behind
+// any processes it started, such as the commands run by a hook script.
+func (p hookProcess) Kill() error {
+	if pgid, err := syscall.Getpgid(p.Pid()); err == nil && pgid == p.Pid() {
+		return syscall.Kill(-pgid, syscall.SIGKILL)
+	}
+	return p.Process.Kill()
+}
@@ -7,7 +7,20 @@
 package runner_test
 
 import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner"
 )
 
 func processExists(pid int) bool {
@@ -17,3 +30,39 @@ func processExists(pid int) bool {
 	}
 	return true
 }
+
+type HookProcessSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&HookProcessSuite{})
+
+func (s *HookProcessSuite) TestKillKillsProcessGroup(c *gc.C) {
+	pidFile := filepath.Join(c.MkDir(), "pid")
+	ps := exec.Command("/bin/sh", "-c", "sleep 100 & echo $! > "+pidFile+"; wait")
+	runner.SetProcessGroup(ps)
+	err := ps.Start()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var childPid int
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		content, err := ioutil.ReadFile(pidFile)
+		if err == nil && strings.HasSuffix(string(content), "\n") {
+			childPid, err = strconv.Atoi(strings.TrimSpace(string(content)))
+			c.Assert(err, jc.ErrorIsNil)
+			break
+		}
+	}
+	c.Assert(childPid, gc.Not(gc.Equals), 0)
+
+	err = runner.NewHookProcess(ps).Kill()
+	c.Assert(err, jc.ErrorIsNil)
+	err = ps.Wait()
+	c.Assert(err, gc.ErrorMatches, "signal: killed")
+
+	// The process started by the hook is killed too.
+	attempt := utils.AttemptStrategy{Total: testing.LongWait, Delay: 10 * time.Millisecond}
+	for a := attempt.Start(); processExists(childPid) && a.Next(); {
+	}
+	c.Assert(processExists(childPid), jc.IsFalse)
+}
@@ -0,0 +1,12 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing on Windows, where the hook process is
+// killed on its own.
+func setProcessGroup(cmd *exec.Cmd) {}
@@ -47,7 +47,7 @@ type StorageAccessor interface {
 
 type storageAttachment struct {
 	*stateFile
-	jujuc.ContextStorageAttachment
+	*contextStorage
 }
 
 // Attachments generates storage hooks in response to changes to
@@ -137,6 +137,7 @@ func (a *Attachments) init() error {
 				tag:      storageTag,
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
+				size:     attachment.Size,
 			},
 		}
 	}
@@ -210,10 +211,11 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := storageState.CommitHook(hi); err != nil {
+	storageTag := names.NewStorageTag(hi.StorageId)
+	size := a.storageAttachments[storageTag].size
+	if err := storageState.commitHook(hi, size); err != nil {
 		return err
 	}
-	storageTag := names.NewStorageTag(hi.StorageId)
 	switch hi.Kind {
 	case hooks.StorageAttached:
 		a.pending.Remove(storageTag)
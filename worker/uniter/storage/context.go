@@ -14,6 +14,7 @@ type contextStorage struct {
 	tag      names.StorageTag
 	kind     storage.StorageKind
 	location string
+	size     uint64
 }
 
 func (ctx *contextStorage) Tag() names.StorageTag {
@@ -21,7 +21,7 @@ func StateAttached(s State) bool {
 }
 
 func ValidateHook(tag names.StorageTag, attached bool, hi hook.Info) error {
-	st := &state{tag, attached}
+	st := &state{storage: tag, attached: attached}
 	return st.ValidateHook(hi)
 }
 
@@ -136,8 +136,24 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes, and the storage growing.
+			if storageAttachment.attachedSize == 0 {
+				// The storage was attached before its size was
+				// recorded (e.g. by an older agent), so record
+				// the size now to detect the storage growing.
+				if err := storageAttachment.setAttachedSize(snap.Size); err != nil {
+					return nil, errors.Trace(err)
+				}
+				return nil, resolver.ErrNoOperation
+			}
+			if snap.Size <= storageAttachment.attachedSize {
+				return nil, resolver.ErrNoOperation
+			}
+			// The storage has grown since the "storage-attached"
+			// hook was last run. Run it again, so the charm can
+			// make use of the additional space.
+			hookInfo.Kind = hooks.StorageAttached
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -175,6 +191,7 @@ func (s *storageResolver) nextHookOp(
 			tag:      tag,
 			kind:     storage.StorageKind(snap.Kind),
 			location: snap.Location,
+			size:     snap.Size,
 		},
 	}
 
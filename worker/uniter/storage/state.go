@@ -25,6 +25,11 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// attachedSize records the size of the storage attachment's
+	// block device, in MiB, when the storage-attached hook was
+	// last committed. It is zero if the size is not known.
+	attachedSize uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -38,9 +43,9 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 	}
 	switch hi.Kind {
 	case hooks.StorageAttached:
-		if s.attached {
-			return errors.New("storage already attached")
-		}
+		// storage-attached may be run again for attached
+		// storage, to inform the charm that the storage
+		// has grown.
 	case hooks.StorageDetaching:
 		if !s.attached {
 			return errors.New("storage not attached")
@@ -85,6 +90,9 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	if info.Size != nil {
+		d.state.attachedSize = *info.Size
+	}
 	return d, nil
 }
 
@@ -125,17 +133,44 @@ func readAllStateFiles(dirPath string) (files map[names.StorageTag]*stateFile, e
 // CommitHook doesn't validate hi but guarantees that successive writes
 // of the same hi are idempotent.
 func (d *stateFile) CommitHook(hi hook.Info) (err error) {
+	return d.commitHook(hi, d.state.attachedSize)
+}
+
+// commitHook is like CommitHook, but additionally records the size
+// of the storage attachment's block device reported to a
+// storage-attached hook.
+func (d *stateFile) commitHook(hi hook.Info, size uint64) (err error) {
 	defer errors.DeferredAnnotatef(&err, "failed to write %q hook info for %q on state directory", hi.Kind, hi.StorageId)
 	if hi.Kind == hooks.StorageDetaching {
 		return d.Remove()
 	}
 	attached := true
-	di := diskInfo{&attached}
+	di := diskInfo{Attached: &attached}
+	if size != 0 {
+		di.Size = &size
+	}
 	if err := utils.WriteYaml(d.path, &di); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
 	d.state.attached = true
+	d.state.attachedSize = size
+	return nil
+}
+
+// setAttachedSize records the size of the attached storage's block
+// device, for storage that was attached without its size recorded.
+func (d *stateFile) setAttachedSize(size uint64) (err error) {
+	if size == 0 || size == d.state.attachedSize {
+		return nil
+	}
+	defer errors.DeferredAnnotatef(&err, "failed to record size of %q on state directory", d.state.storage.Id())
+	attached := true
+	di := diskInfo{Attached: &attached, Size: &size}
+	if err := utils.WriteYaml(d.path, &di); err != nil {
+		return err
+	}
+	d.state.attachedSize = size
 	return nil
 }
 
@@ -146,10 +181,12 @@ func (d *stateFile) Remove() error {
 	}
 	// If atomic delete succeeded, update own state.
 	d.state.attached = false
+	d.state.attachedSize = 0
 	return nil
 }
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool   `yaml:"attached,omitempty"`
+	Size     *uint64 `yaml:"size,omitempty"`
 }
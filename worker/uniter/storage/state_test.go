@@ -198,5 +198,5 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 	assertValidates(false, hooks.StorageAttached)
 	assertValidates(true, hooks.StorageDetaching)
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
-	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+	assertValidates(true, hooks.StorageAttached)
 }